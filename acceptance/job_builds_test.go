@@ -28,14 +28,14 @@ var _ = Describe("Job Builds", func() {
 		dbConn = db.Wrap(postgresRunner.Open())
 		dbListener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
 		bus := db.NewNotificationsBus(dbListener, dbConn)
-		sqlDB = db.NewSQL(dbConn, bus)
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus)
+		sqlDB = db.NewSQL(dbConn, bus, nil)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, nil)
 
 		atcCommand = NewATCCommand(atcBin, 1, postgresRunner.DataSourceName(), []string{}, BASIC_AUTH)
 		err := atcCommand.Start()
 		Expect(err).NotTo(HaveOccurred())
 
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, nil)
 	})
 
 	AfterEach(func() {
@@ -82,7 +82,7 @@ var _ = Describe("Job Builds", func() {
 					Jobs: atc.JobConfigs{
 						{Name: "job-name"},
 					},
-				}, db.ConfigVersion(1), db.PipelineUnpaused)
+				}, db.ConfigVersion(1), db.PipelineUnpaused, "")
 				Expect(err).NotTo(HaveOccurred())
 
 				savedPipeline, found, err := teamDB.GetPipelineByName(pipelineName)
@@ -28,7 +28,7 @@ var _ = Describe("Multiple ATCs", func() {
 		dbConn = db.Wrap(postgresRunner.Open())
 		dbListener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
 		bus := db.NewNotificationsBus(dbListener, dbConn)
-		sqlDB = db.NewSQL(dbConn, bus)
+		sqlDB = db.NewSQL(dbConn, bus, nil)
 
 		atcOneCommand = NewATCCommand(atcBin, 1, postgresRunner.DataSourceName(), []string{}, NO_AUTH)
 		err := atcOneCommand.Start()
@@ -28,13 +28,13 @@ var _ = Describe("Resource Pagination", func() {
 		dbListener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
 		bus := db.NewNotificationsBus(dbListener, dbConn)
 
-		sqlDB = db.NewSQL(dbConn, bus)
+		sqlDB = db.NewSQL(dbConn, bus, nil)
 
 		atcCommand = NewATCCommand(atcBin, 1, postgresRunner.DataSourceName(), []string{}, BASIC_AUTH)
 		err := atcCommand.Start()
 		Expect(err).NotTo(HaveOccurred())
 
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, nil)
 		teamDB := teamDBFactory.GetTeamDB(atc.DefaultTeamName)
 		team, found, err := teamDB.GetTeam()
 		Expect(err).NotTo(HaveOccurred())
@@ -56,14 +56,14 @@ var _ = Describe("Resource Pagination", func() {
 			Resources: atc.ResourceConfigs{
 				{Name: "resource-name"},
 			},
-		}, db.ConfigVersion(1), db.PipelineUnpaused)
+		}, db.ConfigVersion(1), db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		savedPipeline, found, err := teamDB.GetPipelineByName(atc.DefaultPipelineName)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(found).To(BeTrue())
 
-		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus)
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, nil)
 		pipelineDB = pipelineDBFactory.Build(savedPipeline)
 	})
 
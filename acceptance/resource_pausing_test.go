@@ -27,13 +27,13 @@ var _ = Describe("Resource Pausing", func() {
 		dbListener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
 		bus := db.NewNotificationsBus(dbListener, dbConn)
 
-		sqlDB = db.NewSQL(dbConn, bus)
+		sqlDB = db.NewSQL(dbConn, bus, nil)
 
 		atcCommand = NewATCCommand(atcBin, 1, postgresRunner.DataSourceName(), []string{}, BASIC_AUTH)
 		err := atcCommand.Start()
 		Expect(err).NotTo(HaveOccurred())
 
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, nil)
 		teamDB := teamDBFactory.GetTeamDB(atc.DefaultTeamName)
 		// job build data
 		_, _, err = teamDB.SaveConfig("some-pipeline", atc.Config{
@@ -50,14 +50,14 @@ var _ = Describe("Resource Pausing", func() {
 			Resources: atc.ResourceConfigs{
 				{Name: "resource-name"},
 			},
-		}, db.ConfigVersion(1), db.PipelineUnpaused)
+		}, db.ConfigVersion(1), db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		savedPipeline, found, err := teamDB.GetPipelineByName("some-pipeline")
 		Expect(err).NotTo(HaveOccurred())
 		Expect(found).To(BeTrue())
 
-		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus)
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, nil)
 		pipelineDB = pipelineDBFactory.Build(savedPipeline)
 	})
 
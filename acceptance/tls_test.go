@@ -29,7 +29,7 @@ var _ = Describe("TLS", func() {
 
 		dbListener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
 		bus := db.NewNotificationsBus(dbListener, dbConn)
-		sqlDB = db.NewSQL(dbConn, bus)
+		sqlDB = db.NewSQL(dbConn, bus, nil)
 	})
 
 	AfterEach(func() {
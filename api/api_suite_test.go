@@ -16,6 +16,7 @@ import (
 	"github.com/concourse/atc/api"
 	"github.com/concourse/atc/auth"
 
+	"github.com/concourse/atc/api/auditserver/auditserverfakes"
 	"github.com/concourse/atc/api/buildserver/buildserverfakes"
 	"github.com/concourse/atc/api/containerserver/containerserverfakes"
 	"github.com/concourse/atc/api/jobserver/jobserverfakes"
@@ -43,6 +44,7 @@ var (
 	userContextReader             *authfakes.FakeUserContextReader
 	fakeTokenGenerator            *authfakes.FakeTokenGenerator
 	providerFactory               *authfakes.FakeProviderFactory
+	ldapFactory                   *authfakes.FakeLDAPProviderFactory
 	fakeEngine                    *enginefakes.FakeEngine
 	fakeWorkerClient              *workerfakes.FakeClient
 	teamServerDB                  *teamserverfakes.FakeTeamsDB
@@ -54,6 +56,7 @@ var (
 	teamDBFactory                 *dbfakes.FakeTeamDBFactory
 	teamDB                        *dbfakes.FakeTeamDB
 	pipelinesDB                   *dbfakes.FakePipelinesDB
+	auditDB                       *auditserverfakes.FakeAuditDB
 	buildsDB                      *authfakes.FakeBuildsDB
 	buildServerDB                 *buildserverfakes.FakeBuildsDB
 	build                         *dbfakes.FakeBuild
@@ -104,12 +107,14 @@ var _ = BeforeEach(func() {
 	volumesDB = new(volumeserverfakes.FakeVolumesDB)
 	pipeDB = new(pipesfakes.FakePipeDB)
 	pipelinesDB = new(dbfakes.FakePipelinesDB)
+	auditDB = new(auditserverfakes.FakeAuditDB)
 	buildsDB = new(authfakes.FakeBuildsDB)
 
 	authValidator = new(authfakes.FakeValidator)
 	userContextReader = new(authfakes.FakeUserContextReader)
 	fakeTokenGenerator = new(authfakes.FakeTokenGenerator)
 	providerFactory = new(authfakes.FakeProviderFactory)
+	ldapFactory = new(authfakes.FakeLDAPProviderFactory)
 
 	configValidationErrorMessages = []string{}
 	configValidationWarnings = []config.Warning{}
@@ -158,6 +163,7 @@ var _ = BeforeEach(func() {
 
 		fakeTokenGenerator,
 		providerFactory,
+		ldapFactory,
 		oAuthBaseURL,
 
 		pipelineDBFactory,
@@ -170,6 +176,7 @@ var _ = BeforeEach(func() {
 		volumesDB,
 		pipeDB,
 		pipelinesDB,
+		auditDB,
 
 		func(atc.Config) ([]config.Warning, []string) {
 			return configValidationWarnings, configValidationErrorMessages
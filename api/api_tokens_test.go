@@ -0,0 +1,336 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("API Tokens API", func() {
+	createdAt := time.Unix(1000, 0).UTC()
+
+	Describe("POST /api/v1/teams/:team_name/tokens", func() {
+		var body string
+		var response *http.Response
+
+		BeforeEach(func() {
+			body = `{"name":"deploy-bot","role":"operator"}`
+		})
+
+		JustBeforeEach(func() {
+			path := fmt.Sprintf("%s/api/v1/teams/a-team/tokens", server.URL)
+
+			request, err := http.NewRequest("POST", path, strings.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, false, true)
+			})
+
+			Context("when the token is created", func() {
+				BeforeEach(func() {
+					teamDB.CreateAPITokenReturns(db.APIToken{
+						ID:        1,
+						TeamID:    42,
+						Name:      "deploy-bot",
+						Role:      atc.RoleOperator,
+						CreatedAt: createdAt,
+					}, nil)
+				})
+
+				It("returns 201", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				})
+
+				It("saves only the hash of the token", func() {
+					Expect(teamDB.CreateAPITokenCallCount()).To(Equal(1))
+					name, role, tokenHash := teamDB.CreateAPITokenArgsForCall(0)
+					Expect(name).To(Equal("deploy-bot"))
+					Expect(role).To(Equal(atc.RoleOperator))
+
+					var token atc.APIToken
+					err := json.NewDecoder(response.Body).Decode(&token)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(token.Token).To(HavePrefix(auth.APITokenPrefix))
+					Expect(tokenHash).To(Equal(auth.HashAPIToken(token.Token)))
+					Expect(tokenHash).NotTo(Equal(token.Token))
+				})
+
+				It("returns the token", func() {
+					var token atc.APIToken
+					err := json.NewDecoder(response.Body).Decode(&token)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(token.Name).To(Equal("deploy-bot"))
+					Expect(token.Role).To(Equal(atc.RoleOperator))
+					Expect(token.CreatedAt).To(Equal(createdAt.Unix()))
+				})
+			})
+
+			Context("when no role is given", func() {
+				BeforeEach(func() {
+					body = `{"name":"deploy-bot"}`
+				})
+
+				It("creates a member token", func() {
+					Expect(teamDB.CreateAPITokenCallCount()).To(Equal(1))
+					_, role, _ := teamDB.CreateAPITokenArgsForCall(0)
+					Expect(role).To(Equal(atc.RoleMember))
+				})
+			})
+
+			Context("when no name is given", func() {
+				BeforeEach(func() {
+					body = `{"role":"viewer"}`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(teamDB.CreateAPITokenCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the role is unknown", func() {
+				BeforeEach(func() {
+					body = `{"name":"deploy-bot","role":"overlord"}`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					responseBody, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(responseBody)).To(Equal("unknown role: overlord"))
+
+					Expect(teamDB.CreateAPITokenCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the body is malformed", func() {
+				BeforeEach(func() {
+					body = `{`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when a token with the name already exists", func() {
+				BeforeEach(func() {
+					teamDB.CreateAPITokenReturns(db.APIToken{}, db.ErrAPITokenAlreadyExists)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Context("when creating the token fails", func() {
+				BeforeEach(func() {
+					teamDB.CreateAPITokenReturns(db.APIToken{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated as a member of the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, false, true)
+				userContextReader.GetRoleReturns(atc.RoleMember, true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(teamDB.CreateAPITokenCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authenticated as another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("another-team", 43, false, true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/tokens", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(fmt.Sprintf("%s/api/v1/teams/a-team/tokens", server.URL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, false, true)
+			})
+
+			Context("when getting the tokens succeeds", func() {
+				BeforeEach(func() {
+					teamDB.GetAPITokensReturns([]db.APIToken{
+						{
+							ID:         1,
+							Name:       "deploy-bot",
+							Role:       atc.RoleOperator,
+							CreatedAt:  createdAt,
+							LastUsedAt: createdAt.Add(time.Minute),
+						},
+						{
+							ID:        2,
+							Name:      "dashboard",
+							Role:      atc.RoleViewer,
+							CreatedAt: createdAt,
+						},
+					}, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the tokens without their secrets", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"name": "deploy-bot",
+							"role": "operator",
+							"created_at": 1000,
+							"last_used_at": 1060
+						},
+						{
+							"name": "dashboard",
+							"role": "viewer",
+							"created_at": 1000
+						}
+					]`))
+				})
+			})
+
+			Context("when getting the tokens fails", func() {
+				BeforeEach(func() {
+					teamDB.GetAPITokensReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/tokens/:token_name", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			path := fmt.Sprintf("%s/api/v1/teams/a-team/tokens/deploy-bot", server.URL)
+
+			request, err := http.NewRequest("DELETE", path, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, false, true)
+			})
+
+			Context("when the token exists", func() {
+				BeforeEach(func() {
+					teamDB.DeleteAPITokenReturns(true, nil)
+				})
+
+				It("deletes the token", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+					Expect(teamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("a-team"))
+					Expect(teamDB.DeleteAPITokenCallCount()).To(Equal(1))
+					Expect(teamDB.DeleteAPITokenArgsForCall(0)).To(Equal("deploy-bot"))
+				})
+			})
+
+			Context("when the token does not exist", func() {
+				BeforeEach(func() {
+					teamDB.DeleteAPITokenReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when deleting the token fails", func() {
+				BeforeEach(func() {
+					teamDB.DeleteAPITokenReturns(false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
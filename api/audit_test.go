@@ -0,0 +1,213 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit API", func() {
+	Describe("GET /api/v1/audit", func() {
+		var (
+			path     string
+			header   http.Header
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			path = "/api/v1/audit"
+			header = http.Header{}
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", server.URL+path, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			req.Header = header
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("main", 1, true, true)
+			})
+
+			Context("when the events can be listed", func() {
+				BeforeEach(func() {
+					auditDB.GetAuditEventsReturns([]db.AuditEvent{
+						{
+							ID:       3,
+							Time:     time.Unix(1, 0),
+							TeamName: "some-team",
+							Username: "some-user",
+							Route:    atc.SaveConfig,
+							Method:   "PUT",
+							Path:     "/api/v1/teams/some-team/pipelines/some-pipeline/config",
+							Params:   map[string]string{"team_name": "some-team", "pipeline_name": "some-pipeline"},
+							Status:   200,
+						},
+					}, db.Pagination{
+						Previous: &db.Page{Until: 3, Limit: 1},
+						Next:     &db.Page{Since: 3, Limit: 1},
+					}, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the events", func() {
+					var events []atc.AuditEvent
+					err := json.NewDecoder(response.Body).Decode(&events)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(events).To(Equal([]atc.AuditEvent{
+						{
+							ID:       3,
+							Time:     1,
+							Team:     "some-team",
+							Username: "some-user",
+							Route:    atc.SaveConfig,
+							Method:   "PUT",
+							Path:     "/api/v1/teams/some-team/pipelines/some-pipeline/config",
+							Params:   map[string]string{"team_name": "some-team", "pipeline_name": "some-pipeline"},
+							Status:   200,
+						},
+					}))
+				})
+
+				It("asks for the first page by default", func() {
+					Expect(auditDB.GetAuditEventsCallCount()).To(Equal(1))
+
+					filter, page := auditDB.GetAuditEventsArgsForCall(0)
+					Expect(filter).To(Equal(db.AuditEventFilter{}))
+					Expect(page).To(Equal(db.Page{Limit: 100}))
+				})
+
+				Context("when filters and a page are given", func() {
+					BeforeEach(func() {
+						path = "/api/v1/audit?team=some-team&username=some-user&route=SaveConfig&since=5&limit=1"
+					})
+
+					It("passes them along", func() {
+						filter, page := auditDB.GetAuditEventsArgsForCall(0)
+						Expect(filter).To(Equal(db.AuditEventFilter{
+							TeamName: "some-team",
+							Username: "some-user",
+							Route:    "SaveConfig",
+						}))
+						Expect(page).To(Equal(db.Page{Since: 5, Limit: 1}))
+					})
+
+					It("links to the surrounding pages with the same filters", func() {
+						Expect(response.Header["Link"]).To(ConsistOf([]string{
+							`<https://example.com/api/v1/audit?limit=1&route=SaveConfig&team=some-team&until=3&username=some-user>; rel="previous"`,
+							`<https://example.com/api/v1/audit?limit=1&route=SaveConfig&since=3&team=some-team&username=some-user>; rel="next"`,
+						}))
+					})
+				})
+			})
+
+			Context("when listing the events fails", func() {
+				BeforeEach(func() {
+					auditDB.GetAuditEventsReturns(nil, db.Pagination{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated as a non-admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 5, false, true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not list the events", func() {
+				Expect(auditDB.GetAuditEventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authenticated with an API token of the admin team", func() {
+			var fakeAPITokenDB *authfakes.FakeAPITokenDB
+
+			BeforeEach(func() {
+				fakeAPITokenDB = new(authfakes.FakeAPITokenDB)
+
+				tokenValidator := auth.NewAPITokenValidator(lagertest.NewTestLogger("test"), fakeAPITokenDB)
+				authValidator.IsAuthenticatedStub = tokenValidator.IsAuthenticated
+				userContextReader.GetTeamStub = tokenValidator.GetTeam
+				userContextReader.GetRoleStub = tokenValidator.GetRole
+
+				token, err := auth.GenerateAPIToken()
+				Expect(err).NotTo(HaveOccurred())
+
+				header.Set("Authorization", "Bearer "+token)
+			})
+
+			Context("when the token has the viewer role", func() {
+				BeforeEach(func() {
+					fakeAPITokenDB.UseAPITokenReturns(db.APIToken{
+						TeamID:   1,
+						TeamName: "main",
+						IsAdmin:  true,
+						Name:     "some-bot",
+						Role:     atc.RoleViewer,
+					}, true, nil)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+
+				It("does not list the events", func() {
+					Expect(auditDB.GetAuditEventsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the token has the owner role", func() {
+				BeforeEach(func() {
+					fakeAPITokenDB.UseAPITokenReturns(db.APIToken{
+						TeamID:   1,
+						TeamName: "main",
+						IsAdmin:  true,
+						Name:     "some-bot",
+						Role:     atc.RoleOwner,
+					}, true, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
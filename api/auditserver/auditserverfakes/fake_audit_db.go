@@ -0,0 +1,83 @@
+// This file was generated by counterfeiter
+package auditserverfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/api/auditserver"
+	"github.com/concourse/atc/db"
+)
+
+type FakeAuditDB struct {
+	GetAuditEventsStub        func(filter db.AuditEventFilter, page db.Page) ([]db.AuditEvent, db.Pagination, error)
+	getAuditEventsMutex       sync.RWMutex
+	getAuditEventsArgsForCall []struct {
+		filter db.AuditEventFilter
+		page   db.Page
+	}
+	getAuditEventsReturns struct {
+		result1 []db.AuditEvent
+		result2 db.Pagination
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAuditDB) GetAuditEvents(filter db.AuditEventFilter, page db.Page) ([]db.AuditEvent, db.Pagination, error) {
+	fake.getAuditEventsMutex.Lock()
+	fake.getAuditEventsArgsForCall = append(fake.getAuditEventsArgsForCall, struct {
+		filter db.AuditEventFilter
+		page   db.Page
+	}{filter, page})
+	fake.recordInvocation("GetAuditEvents", []interface{}{filter, page})
+	fake.getAuditEventsMutex.Unlock()
+	if fake.GetAuditEventsStub != nil {
+		return fake.GetAuditEventsStub(filter, page)
+	} else {
+		return fake.getAuditEventsReturns.result1, fake.getAuditEventsReturns.result2, fake.getAuditEventsReturns.result3
+	}
+}
+
+func (fake *FakeAuditDB) GetAuditEventsCallCount() int {
+	fake.getAuditEventsMutex.RLock()
+	defer fake.getAuditEventsMutex.RUnlock()
+	return len(fake.getAuditEventsArgsForCall)
+}
+
+func (fake *FakeAuditDB) GetAuditEventsArgsForCall(i int) (db.AuditEventFilter, db.Page) {
+	fake.getAuditEventsMutex.RLock()
+	defer fake.getAuditEventsMutex.RUnlock()
+	return fake.getAuditEventsArgsForCall[i].filter, fake.getAuditEventsArgsForCall[i].page
+}
+
+func (fake *FakeAuditDB) GetAuditEventsReturns(result1 []db.AuditEvent, result2 db.Pagination, result3 error) {
+	fake.GetAuditEventsStub = nil
+	fake.getAuditEventsReturns = struct {
+		result1 []db.AuditEvent
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAuditDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getAuditEventsMutex.RLock()
+	defer fake.getAuditEventsMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeAuditDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ auditserver.AuditDB = new(FakeAuditDB)
@@ -0,0 +1,92 @@
+package auditserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-audit-events")
+
+	authTeam, authTeamFound := auth.GetTeam(r)
+	if !authTeamFound || !authTeam.IsAdmin() {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	until, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryUntil))
+	since, _ := strconv.Atoi(r.FormValue(atc.PaginationQuerySince))
+
+	limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+	if limit == 0 {
+		limit = atc.PaginationAPIDefaultLimit
+	}
+
+	filter := db.AuditEventFilter{
+		TeamName: r.FormValue("team"),
+		Username: r.FormValue("username"),
+		Route:    r.FormValue("route"),
+	}
+
+	events, pagination, err := s.db.GetAuditEvents(filter, db.Page{
+		Until: until,
+		Since: since,
+		Limit: limit,
+	})
+	if err != nil {
+		logger.Error("failed-to-get-audit-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if pagination.Next != nil {
+		s.addLink(w, filter, atc.PaginationQuerySince, pagination.Next.Since, pagination.Next.Limit, atc.LinkRelNext)
+	}
+
+	if pagination.Previous != nil {
+		s.addLink(w, filter, atc.PaginationQueryUntil, pagination.Previous.Until, pagination.Previous.Limit, atc.LinkRelPrevious)
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	presented := make([]atc.AuditEvent, len(events))
+	for i, event := range events {
+		presented[i] = present.AuditEvent(event)
+	}
+
+	json.NewEncoder(w).Encode(presented)
+}
+
+// addLink carries the filters over to the linked page.
+func (s *Server) addLink(w http.ResponseWriter, filter db.AuditEventFilter, pageParam string, id int, limit int, rel string) {
+	query := url.Values{}
+	query.Set(pageParam, strconv.Itoa(id))
+	query.Set(atc.PaginationQueryLimit, strconv.Itoa(limit))
+
+	if filter.TeamName != "" {
+		query.Set("team", filter.TeamName)
+	}
+
+	if filter.Username != "" {
+		query.Set("username", filter.Username)
+	}
+
+	if filter.Route != "" {
+		query.Set("route", filter.Route)
+	}
+
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s/api/v1/audit?%s>; rel="%s"`,
+		s.externalURL,
+		query.Encode(),
+		rel,
+	))
+}
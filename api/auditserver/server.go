@@ -0,0 +1,32 @@
+package auditserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+type Server struct {
+	logger lager.Logger
+
+	externalURL string
+
+	db AuditDB
+}
+
+//go:generate counterfeiter . AuditDB
+
+type AuditDB interface {
+	GetAuditEvents(filter db.AuditEventFilter, page db.Page) ([]db.AuditEvent, db.Pagination, error)
+}
+
+func NewServer(
+	logger lager.Logger,
+	externalURL string,
+	db AuditDB,
+) *Server {
+	return &Server{
+		logger:      logger,
+		externalURL: externalURL,
+		db:          db,
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth/ldap"
+	"github.com/concourse/atc/auth/provider/providerfakes"
 	"github.com/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -96,11 +99,116 @@ var _ = Describe("Auth API", func() {
 
 						Expect(body).To(MatchJSON(`{"type":"some type","value":"some value"}`))
 
-						expiration, teamName, teamID, isAdmin := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						expiration, teamName, teamID, isAdmin, role, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
 						Expect(expiration).To(BeTemporally("~", time.Now().Add(24*time.Hour), time.Minute))
 						Expect(teamName).To(Equal(savedTeam.Name))
 						Expect(teamID).To(Equal(savedTeam.ID))
 						Expect(isAdmin).To(Equal(savedTeam.Admin))
+						Expect(role).To(Equal(atc.RoleOwner))
+					})
+				})
+
+				Context("when the team maps basic auth users to roles", func() {
+					BeforeEach(func() {
+						request.Header.Del("Authorization")
+						request.SetBasicAuth("some-operator", "some-password")
+
+						savedTeam.Roles = []atc.TeamRoleMapping{
+							{Role: atc.RoleOperator, BasicAuthUsers: []string{"some-operator"}},
+						}
+						teamDB.GetTeamReturns(savedTeam, true, nil)
+
+						fakeTokenGenerator.GenerateTokenReturns("some type", "some value", nil)
+					})
+
+					It("generates a token with the user's role", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						_, _, _, _, role, username := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						Expect(role).To(Equal(atc.RoleOperator))
+						Expect(username).To(Equal("some-operator"))
+					})
+				})
+
+				Context("when the team has LDAP auth", func() {
+					var fakeLDAPProvider *providerfakes.FakeLDAPProvider
+
+					BeforeEach(func() {
+						request.Header.Del("Authorization")
+						request.SetBasicAuth("some-ldap-user", "some-password")
+
+						savedTeam.LDAPAuth = &db.LDAPAuth{
+							Host: "ldap.example.com:636",
+						}
+						savedTeam.Roles = []atc.TeamRoleMapping{
+							{Role: atc.RoleMember, LDAPGroups: []string{"developers"}},
+						}
+						teamDB.GetTeamReturns(savedTeam, true, nil)
+
+						fakeLDAPProvider = new(providerfakes.FakeLDAPProvider)
+						ldapFactory.GetLDAPProviderReturns(fakeLDAPProvider, true)
+
+						fakeTokenGenerator.GenerateTokenReturns("some type", "some value", nil)
+					})
+
+					Context("when the directory accepts the user", func() {
+						BeforeEach(func() {
+							fakeLDAPProvider.AuthenticateReturns(ldap.User{
+								Username: "some-ldap-user",
+								Groups:   []string{"developers"},
+								Role:     atc.RoleMember,
+							}, true, nil)
+						})
+
+						It("authenticates the credentials against the directory", func() {
+							Expect(fakeLDAPProvider.AuthenticateCallCount()).To(Equal(1))
+							_, username, password := fakeLDAPProvider.AuthenticateArgsForCall(0)
+							Expect(username).To(Equal("some-ldap-user"))
+							Expect(password).To(Equal("some-password"))
+						})
+
+						It("generates a token with the user's role", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+							_, _, _, _, role, username := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+							Expect(role).To(Equal(atc.RoleMember))
+							Expect(username).To(Equal("some-ldap-user"))
+						})
+
+						Context("when none of the user's groups are mapped to a role", func() {
+							BeforeEach(func() {
+								fakeLDAPProvider.AuthenticateReturns(ldap.User{
+									Username: "some-ldap-user",
+									Groups:   []string{"testers"},
+								}, true, nil)
+							})
+
+							It("generates a token with the team's default role", func() {
+								_, _, _, _, role, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+								Expect(role).To(Equal(atc.RoleViewer))
+							})
+						})
+					})
+
+					Context("when the directory rejects the user", func() {
+						BeforeEach(func() {
+							fakeLDAPProvider.AuthenticateReturns(ldap.User{}, false, nil)
+						})
+
+						It("returns unauthorized", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+							Expect(fakeTokenGenerator.GenerateTokenCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the directory fails", func() {
+						BeforeEach(func() {
+							fakeLDAPProvider.AuthenticateReturns(ldap.User{}, false, errors.New("nope"))
+						})
+
+						It("returns Internal Server Error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
 					})
 				})
 
@@ -171,6 +279,12 @@ var _ = Describe("Auth API", func() {
 							ClientSecret: "client-secret",
 							DisplayName:  "custom secure auth",
 						},
+						OIDCAuth: &db.OIDCAuth{
+							Issuer:       "https://sso.example.com",
+							ClientID:     "client-id",
+							ClientSecret: "client-secret",
+							DisplayName:  "Corp SSO",
+						},
 					},
 				}
 
@@ -205,6 +319,11 @@ var _ = Describe("Auth API", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(body).To(MatchJSON(`[
+					{
+						"type": "oauth",
+						"display_name": "Corp SSO",
+						"auth_url": "https://oauth.example.com/auth/oidc?team_name=some-team"
+					},
 					{
 						"type": "oauth",
 						"display_name": "GitHub",
@@ -229,6 +348,41 @@ var _ = Describe("Auth API", func() {
 			})
 		})
 
+		Context("when only LDAP is present", func() {
+			var response *http.Response
+
+			BeforeEach(func() {
+				teamDB.GetTeamReturns(db.SavedTeam{
+					Team: db.Team{
+						Name: "some-team",
+						LDAPAuth: &db.LDAPAuth{
+							Host:        "ldap.example.com:636",
+							DisplayName: "Corp Directory",
+						},
+					},
+				}, true, nil)
+			})
+
+			JustBeforeEach(func() {
+				var err error
+				response, err = client.Get(server.URL + "/api/v1/teams/some-team/auth/methods")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the login form as the LDAP method", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"type": "basic",
+						"display_name": "Corp Directory",
+						"auth_url": "https://example.com/teams/some-team/login"
+					}
+				]`))
+			})
+		})
+
 		Context("when no providers are present", func() {
 			var request *http.Request
 			var response *http.Response
@@ -9,6 +9,8 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/ldap"
+	"github.com/concourse/atc/db"
 )
 
 const tokenDuration = 24 * time.Hour
@@ -41,7 +43,32 @@ func (s *Server) GetAuthToken(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		tokenType, tokenValue, err := s.tokenGenerator.GenerateToken(time.Now().Add(tokenDuration), team.Name, team.ID, team.Admin)
+		role := team.DefaultRole()
+		username, password, ok := r.BasicAuth()
+		if ok {
+			if team.LDAPAuth != nil && (team.BasicAuth == nil || !auth.NewBasicAuthValidator(team).IsAuthenticated(r)) {
+				user, found, err := s.authenticateLDAPUser(logger, team, username, password)
+				if err != nil {
+					logger.Error("authenticate-ldap-user", err)
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				if !found {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				username = user.Username
+				if user.Role != "" {
+					role = user.Role
+				}
+			} else {
+				role = team.BasicAuthUserRole(username)
+			}
+		}
+
+		tokenType, tokenValue, err := s.tokenGenerator.GenerateToken(time.Now().Add(tokenDuration), team.Name, team.ID, team.Admin, role, username)
 		if err != nil {
 			logger.Error("generate-token", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -55,3 +82,12 @@ func (s *Server) GetAuthToken(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(token)
 }
+
+func (s *Server) authenticateLDAPUser(logger lager.Logger, team db.SavedTeam, username string, password string) (ldap.User, bool, error) {
+	ldapProvider, found := s.ldapFactory.GetLDAPProvider(team)
+	if !found {
+		return ldap.User{}, false, nil
+	}
+
+	return ldapProvider.Authenticate(logger.Session("ldap"), username, password)
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/genericoauth"
 	"github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/auth/ldap"
+	"github.com/concourse/atc/auth/oidc"
 	"github.com/concourse/atc/auth/uaa"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/web"
@@ -116,7 +118,30 @@ func (s *Server) authMethods(team db.SavedTeam) ([]atc.AuthMethod, error) {
 		})
 	}
 
-	if team.BasicAuth != nil {
+	if team.OIDCAuth != nil {
+		path, err := auth.OAuthRoutes.CreatePathForRoute(
+			auth.OAuthBegin,
+			rata.Params{"provider": oidc.ProviderName},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		displayName := team.OIDCAuth.DisplayName
+		if displayName == "" {
+			displayName = oidc.DisplayName
+		}
+
+		path = path + fmt.Sprintf("?team_name=%s", team.Name)
+		methods = append(methods, atc.AuthMethod{
+			Type:        atc.AuthTypeOAuth,
+			DisplayName: displayName,
+			AuthURL:     s.oAuthBaseURL + path,
+		})
+	}
+
+	// LDAP users log in with the same form as the basic auth user
+	if team.BasicAuth != nil || team.LDAPAuth != nil {
 		path, err := web.Routes.CreatePathForRoute(
 			web.TeamLogIn,
 			rata.Params{"team_name": team.Name},
@@ -125,9 +150,17 @@ func (s *Server) authMethods(team db.SavedTeam) ([]atc.AuthMethod, error) {
 			return nil, err
 		}
 
+		displayName := BasicAuthDisplayName
+		if team.BasicAuth == nil {
+			displayName = team.LDAPAuth.DisplayName
+			if displayName == "" {
+				displayName = ldap.DisplayName
+			}
+		}
+
 		methods = append(methods, atc.AuthMethod{
 			Type:        atc.AuthTypeBasic,
-			DisplayName: BasicAuthDisplayName,
+			DisplayName: displayName,
 			AuthURL:     s.externalURL + path,
 		})
 	}
@@ -12,6 +12,7 @@ type Server struct {
 	oAuthBaseURL    string
 	tokenGenerator  auth.TokenGenerator
 	providerFactory auth.ProviderFactory
+	ldapFactory     auth.LDAPProviderFactory
 	teamDBFactory   db.TeamDBFactory
 }
 
@@ -21,6 +22,7 @@ func NewServer(
 	oAuthBaseURL string,
 	tokenGenerator auth.TokenGenerator,
 	providerFactory auth.ProviderFactory,
+	ldapFactory auth.LDAPProviderFactory,
 	teamDBFactory db.TeamDBFactory,
 ) *Server {
 	return &Server{
@@ -29,6 +31,7 @@ func NewServer(
 		oAuthBaseURL:    oAuthBaseURL,
 		tokenGenerator:  tokenGenerator,
 		providerFactory: providerFactory,
+		ldapFactory:     ldapFactory,
 		teamDBFactory:   teamDBFactory,
 	}
 }
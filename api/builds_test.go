@@ -17,6 +17,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine/enginefakes"
 )
@@ -1148,6 +1149,249 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("POST /api/v1/builds/:build_id/rerun", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("POST", server.URL+"/api/v1/builds/128/rerun", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the build can be found", func() {
+				BeforeEach(func() {
+					build.IDReturns(128)
+					build.TeamNameReturns("some-team")
+					buildsDB.GetBuildByIDReturns(build, true, nil)
+				})
+
+				Context("when accessing same team's build", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-team", 2, true, true)
+					})
+
+					Context("when the rerun is created", func() {
+						BeforeEach(func() {
+							rerun := new(dbfakes.FakeBuild)
+							rerun.IDReturns(129)
+							rerun.NameReturns("2")
+							rerun.JobNameReturns("some-job")
+							rerun.PipelineNameReturns("some-pipeline")
+							rerun.TeamNameReturns("some-team")
+							rerun.StatusReturns(db.StatusPending)
+							rerun.RerunOfReturns(128)
+
+							build.RerunReturns(rerun, nil)
+						})
+
+						It("reruns the build", func() {
+							Expect(build.RerunCallCount()).To(Equal(1))
+						})
+
+						It("returns 201", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusCreated))
+						})
+
+						It("returns the rerun, linked to the original build", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"id": 129,
+								"name": "2",
+								"job_name": "some-job",
+								"pipeline_name": "some-pipeline",
+								"team_name": "some-team",
+								"status": "pending",
+								"url": "/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/2",
+								"api_url": "/api/v1/builds/129",
+								"rerun_of": 128
+							}`))
+						})
+					})
+
+					Context("when the build is a one-off", func() {
+						BeforeEach(func() {
+							build.RerunReturns(nil, db.ErrCannotRerunOneOffBuild)
+						})
+
+						It("returns 400 with the reason", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("one-off builds cannot be rerun")))
+						})
+					})
+
+					Context("when rerunning the build fails", func() {
+						BeforeEach(func() {
+							build.RerunReturns(nil, errors.New("oh no!"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when accessing other team's build", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-other-team", 2, true, true)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+
+					It("does not rerun the build", func() {
+						Expect(build.RerunCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					buildsDB.GetBuildByIDReturns(nil, false, nil)
+				})
+
+				It("returns Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not rerun the build", func() {
+				Expect(build.RerunCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/usage", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/usage")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				buildsDB.GetBuildByIDReturns(build, true, nil)
+				build.JobNameReturns("job1")
+				build.TeamNameReturns("some-team")
+				build.GetResourceUsageReturns([]atc.StepResourceUsage{
+					{
+						PlanID:   "some-plan-id",
+						StepName: "some-task",
+						StepType: "task",
+						Usage: atc.ResourceUsage{
+							PeakCPU:       2,
+							AverageCPU:    1.5,
+							PeakMemory:    2048,
+							AverageMemory: 1024,
+							PeakDisk:      4096,
+							AverageDisk:   512,
+							Samples:       3,
+						},
+					},
+				}, nil)
+			})
+
+			Context("when not authenticated", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(false)
+				})
+
+				Context("and the pipeline is public but the job is private", func() {
+					BeforeEach(func() {
+						build.GetPipelineReturns(db.SavedPipeline{Public: true}, nil)
+						build.GetConfigReturns(atc.Config{
+							Jobs: atc.JobConfigs{
+								{Name: "job1", Public: false},
+							},
+						}, 1, nil)
+					})
+
+					It("returns 401", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					})
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("some-team", 5, false, true)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns the usage of each step", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"plan_id": "some-plan-id",
+							"step_name": "some-task",
+							"step_type": "task",
+							"usage": {
+								"peak_cpu": 2,
+								"average_cpu": 1.5,
+								"peak_memory": 2048,
+								"average_memory": 1024,
+								"peak_disk": 4096,
+								"average_disk": 512,
+								"samples": 3
+							}
+						}
+					]`))
+				})
+
+				Context("when looking up the usage fails", func() {
+					BeforeEach(func() {
+						build.GetResourceUsageReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when build is not found", func() {
+			BeforeEach(func() {
+				buildsDB.GetBuildByIDReturns(nil, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/preparation", func() {
 		var response *http.Response
 
@@ -1172,6 +1416,12 @@ var _ = Describe("Builds API", func() {
 					},
 					InputsSatisfied:     db.BuildPreparationStatusBlocking,
 					MissingInputReasons: db.MissingInputReasons{"some-input": "some-reason"},
+					SchedulingExplanation: algorithm.Explanation{
+						"some-input": {
+							Reason: algorithm.ExplanationPassedConstraint,
+							Passed: []string{"some-job"},
+						},
+					},
 				}
 				buildsDB.GetBuildByIDReturns(build, true, nil)
 				build.JobNameReturns("job1")
@@ -1280,6 +1530,12 @@ var _ = Describe("Builds API", func() {
 					"inputs_satisfied": "blocking",
 					"missing_input_reasons": {
 						"some-input": "some-reason"
+					},
+					"scheduling_explanation": {
+						"some-input": {
+							"reason": "passed_constraint",
+							"passed": ["some-job"]
+						}
 					}
 				}`))
 				})
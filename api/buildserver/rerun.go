@@ -0,0 +1,36 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) RerunBuild(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("rerun-build", lager.Data{
+			"build": build.ID(),
+		})
+
+		rerun, err := build.Rerun()
+		switch err {
+		case nil:
+		case db.ErrCannotRerunOneOffBuild, db.ErrCannotRerunUnscheduledBuild:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "%s", err)
+			return
+		default:
+			logger.Error("failed-to-rerun-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+
+		json.NewEncoder(w).Encode(present.Build(rerun))
+	})
+}
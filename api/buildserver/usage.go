@@ -0,0 +1,27 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) GetBuildResourceUsage(build db.Build) http.Handler {
+	log := s.logger.Session("build-resource-usage", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		usages, err := build.GetResourceUsage()
+		if err != nil {
+			log.Error("cannot-get-build-resource-usage", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(usages)
+	})
+}
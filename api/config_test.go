@@ -8,6 +8,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
@@ -170,6 +171,40 @@ var _ = Describe("Config API", func() {
 				It("calls get config with the correct arguments", func() {
 					Expect(teamDB.GetConfigArgsForCall(0)).To(Equal("something-else"))
 				})
+
+				Context("when the pipeline was configured from a template", func() {
+					BeforeEach(func() {
+						teamDB.GetConfigTemplateReturns(db.ConfigTemplate{
+							Template: atc.RawConfig("jobs: [{name: ((job-name))}]"),
+							Vars:     atc.Vars{"job-name": "some-job"},
+						}, true, nil)
+					})
+
+					It("returns the template and vars along with the config", func() {
+						var actualConfigResponse atc.ConfigResponse
+						err := json.NewDecoder(response.Body).Decode(&actualConfigResponse)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(actualConfigResponse).To(Equal(atc.ConfigResponse{
+							Config:    &pipelineConfig,
+							RawConfig: atc.RawConfig("raw-config"),
+							Template:  atc.RawConfig("jobs: [{name: ((job-name))}]"),
+							Vars:      atc.Vars{"job-name": "some-job"},
+						}))
+
+						Expect(teamDB.GetConfigTemplateArgsForCall(0)).To(Equal("something-else"))
+					})
+				})
+
+				Context("when getting the config template fails", func() {
+					BeforeEach(func() {
+						teamDB.GetConfigTemplateReturns(db.ConfigTemplate{}, false, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
 			})
 
 			Context("when getting the config fails", func() {
@@ -319,11 +354,83 @@ var _ = Describe("Config API", func() {
 						It("saves it", func() {
 							Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := teamDB.SaveConfigArgsForCall(0)
+							name, savedConfig, id, pipelineState, savedBy := teamDB.SaveConfigArgsForCall(0)
 							Expect(name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
 							Expect(pipelineState).To(Equal(db.PipelineNoChange))
+							Expect(savedBy).To(Equal("a-team"))
+						})
+
+						Context("when the token names a user", func() {
+							BeforeEach(func() {
+								userContextReader.GetUsernameReturns("some-user", true)
+							})
+
+							It("records who saved it", func() {
+								_, _, _, _, savedBy := teamDB.SaveConfigArgsForCall(0)
+								Expect(savedBy).To(Equal("a-team/some-user"))
+							})
+						})
+
+						Context("when the config has passed constraints on jobs of other pipelines", func() {
+							BeforeEach(func() {
+								pipelineConfig.Jobs = append(pipelineConfig.Jobs, atc.JobConfig{
+									Name: "downstream-job",
+									Plan: atc.PlanSequence{
+										{
+											Get:    "some-resource",
+											Passed: []string{"upstream-pipeline/some-job", "missing-pipeline/some-job"},
+										},
+									},
+								})
+
+								payload, err := json.Marshal(pipelineConfig)
+								Expect(err).NotTo(HaveOccurred())
+
+								request.Body = gbytes.BufferWithBytes(payload)
+
+								teamDB.GetPipelinesReturns([]db.SavedPipeline{
+									{
+										Pipeline: db.Pipeline{
+											Name: "upstream-pipeline",
+											Config: atc.Config{
+												Jobs: atc.JobConfigs{{Name: "some-job"}},
+											},
+										},
+									},
+								}, nil)
+							})
+
+							It("saves it", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+								Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+							})
+
+							It("warns about the jobs which do not exist", func() {
+								Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+									"warnings": [
+										{
+											"type": "pipeline",
+											"message": "passed constraints reference 'missing-pipeline/some-job', but pipeline 'missing-pipeline' does not exist; they cannot be satisfied until it is configured"
+										}
+									]
+								}`))
+							})
+
+							Context("when the team's pipelines cannot be looked up", func() {
+								BeforeEach(func() {
+									teamDB.GetPipelinesReturns(nil, errors.New("oh no!"))
+								})
+
+								It("returns 500", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+								})
+
+								It("does not save it", func() {
+									Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+								})
+							})
 						})
 
 						Context("and saving it fails", func() {
@@ -401,7 +508,7 @@ var _ = Describe("Config API", func() {
 						It("saves it", func() {
 							Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := teamDB.SaveConfigArgsForCall(0)
+							name, savedConfig, id, pipelineState, _ := teamDB.SaveConfigArgsForCall(0)
 							Expect(name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -411,7 +518,7 @@ var _ = Describe("Config API", func() {
 						It("does not give the DB a map of empty interfaces to empty interfaces", func() {
 							Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
 
-							_, savedConfig, _, _ := teamDB.SaveConfigArgsForCall(0)
+							_, savedConfig, _, _, _ := teamDB.SaveConfigArgsForCall(0)
 							Expect(savedConfig).To(Equal(pipelineConfig))
 
 							_, err := json.Marshal(pipelineConfig)
@@ -448,7 +555,7 @@ jobs:
 							It("saves it", func() {
 								Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := teamDB.SaveConfigArgsForCall(0)
+								name, savedConfig, id, pipelineState, _ := teamDB.SaveConfigArgsForCall(0)
 								Expect(name).To(Equal("a-pipeline"))
 								Expect(savedConfig).To(Equal(atc.Config{
 									Resources: []atc.ResourceConfig{
@@ -586,7 +693,7 @@ jobs:
 							It("saves it", func() {
 								Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := teamDB.SaveConfigArgsForCall(0)
+								name, savedConfig, id, pipelineState, _ := teamDB.SaveConfigArgsForCall(0)
 								Expect(name).To(Equal("a-pipeline"))
 								Expect(savedConfig).To(Equal(pipelineConfig))
 								Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -697,6 +804,147 @@ jobs:
 							itSavesThePipeline()
 						})
 
+						Context("when vars are specified", func() {
+							template := `
+resources:
+- name: ((resource-name))
+  type: git
+  source: {uri: ((uri)), private_key: ((private-key))}
+jobs:
+- name: some-job
+  plan:
+  - get: ((resource-name))
+`
+
+							setTemplateRequest := func(varsPayload string) {
+								body := &bytes.Buffer{}
+								writer := multipart.NewWriter(body)
+
+								yamlWriter, err := writer.CreatePart(
+									textproto.MIMEHeader{
+										"Content-type": {"application/x-yaml"},
+									},
+								)
+								Expect(err).NotTo(HaveOccurred())
+
+								_, err = yamlWriter.Write([]byte(template))
+								Expect(err).NotTo(HaveOccurred())
+
+								varsWriter, err := writer.CreatePart(
+									textproto.MIMEHeader{
+										"Content-Disposition": {`form-data; name="vars"`},
+										"Content-type":        {"application/json"},
+									},
+								)
+								Expect(err).NotTo(HaveOccurred())
+
+								_, err = varsWriter.Write([]byte(varsPayload))
+								Expect(err).NotTo(HaveOccurred())
+
+								writer.Close()
+
+								request.Header.Set("Content-Type", writer.FormDataContentType())
+								request.Body = gbytes.BufferWithBytes(body.Bytes())
+							}
+
+							Context("when the vars are valid", func() {
+								BeforeEach(func() {
+									setTemplateRequest(`{"resource-name": "some-repo", "uri": "https://example.com/repo.git", "unused": "value"}`)
+								})
+
+								It("returns 200", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+								})
+
+								It("saves the rendered config along with the template and vars", func() {
+									Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+									Expect(teamDB.SaveConfigFromTemplateCallCount()).To(Equal(1))
+
+									name, savedConfig, savedTemplate, id, pipelineState, _ := teamDB.SaveConfigFromTemplateArgsForCall(0)
+									Expect(name).To(Equal("a-pipeline"))
+									Expect(id).To(Equal(db.ConfigVersion(42)))
+									Expect(pipelineState).To(Equal(db.PipelineNoChange))
+
+									Expect(savedConfig).To(Equal(atc.Config{
+										Resources: atc.ResourceConfigs{
+											{
+												Name: "some-repo",
+												Type: "git",
+												Source: atc.Source{
+													"uri":         "https://example.com/repo.git",
+													"private_key": "((private-key))",
+												},
+											},
+										},
+										Jobs: atc.JobConfigs{
+											{
+												Name: "some-job",
+												Plan: atc.PlanSequence{
+													{Get: "some-repo"},
+												},
+											},
+										},
+									}))
+
+									Expect(savedTemplate).To(Equal(db.ConfigTemplate{
+										Template: atc.RawConfig(template),
+										Vars: atc.Vars{
+											"resource-name": "some-repo",
+											"uri":           "https://example.com/repo.git",
+											"unused":        "value",
+										},
+									}))
+								})
+
+								It("returns warnings for unused vars", func() {
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+										"warnings": [
+											{"type": "vars", "message": "var 'unused' is not used by the config"}
+										]
+									}`))
+								})
+
+								Context("when validating the rendered config warns about missing vars", func() {
+									BeforeEach(func() {
+										configValidationWarnings = []config.Warning{
+											{Type: "vars", Message: "var 'private-key' is not defined; it will be looked up in the credential manager"},
+										}
+									})
+
+									It("returns those warnings too", func() {
+										Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+											"warnings": [
+												{"type": "vars", "message": "var 'unused' is not used by the config"},
+												{"type": "vars", "message": "var 'private-key' is not defined; it will be looked up in the credential manager"}
+											]
+										}`))
+									})
+								})
+							})
+
+							Context("when the vars are malformed", func() {
+								BeforeEach(func() {
+									setTemplateRequest(`{`)
+								})
+
+								It("returns 400", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+								})
+
+								It("returns error JSON", func() {
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+										"errors": [
+											"malformed vars"
+										]
+									}`))
+								})
+
+								It("does not save anything", func() {
+									Expect(teamDB.SaveConfigFromTemplateCallCount()).To(BeZero())
+								})
+							})
+						})
+
 						Context("when a strange paused value is specified", func() {
 							BeforeEach(func() {
 								body := &bytes.Buffer{}
@@ -933,4 +1181,347 @@ jobs:
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:name/config/revisions", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := requestGenerator.CreateRequest(atc.ListConfigRevisions, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when the pipeline has revisions", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigRevisionsReturns([]db.ConfigRevision{
+						{
+							ID:       2,
+							Version:  db.ConfigVersion(12),
+							Config:   pipelineConfig,
+							Template: &db.ConfigTemplate{Template: "some-template"},
+							SavedBy:  "a-team/some-user",
+							SavedAt:  time.Unix(2, 0),
+						},
+						{
+							ID:      1,
+							Version: db.ConfigVersion(11),
+							Config:  atc.Config{},
+							SavedBy: "a-team",
+							SavedAt: time.Unix(1, 0),
+						},
+					}, true, nil)
+				})
+
+				It("looks up the pipeline's revisions", func() {
+					Expect(teamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("a-team"))
+					Expect(teamDB.GetConfigRevisionsArgsForCall(0)).To(Equal("a-pipeline"))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the revisions", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+						{"id": 2, "version": 12, "templated": true, "saved_by": "a-team/some-user", "saved_at": 2},
+						{"id": 1, "version": 11, "templated": false, "saved_by": "a-team", "saved_at": 1}
+					]`))
+				})
+			})
+
+			Context("when the pipeline does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigRevisionsReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the revisions fails", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigRevisionsReturns(nil, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:name/config/revisions/:from_revision_id/diff/:to_revision_id", func() {
+		var (
+			toRevisionID string
+			response     *http.Response
+		)
+
+		BeforeEach(func() {
+			toRevisionID = "2"
+		})
+
+		JustBeforeEach(func() {
+			request, err := requestGenerator.CreateRequest(atc.DiffConfigRevisions, rata.Params{
+				"team_name":        "a-team",
+				"pipeline_name":    "a-pipeline",
+				"from_revision_id": "1",
+				"to_revision_id":   toRevisionID,
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when both revisions exist", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigRevisionStub = func(pipelineName string, revisionID int) (db.ConfigRevision, bool, error) {
+						if revisionID == 1 {
+							return db.ConfigRevision{ID: 1, Version: 11, Config: atc.Config{}, SavedAt: time.Unix(1, 0)}, true, nil
+						}
+
+						return db.ConfigRevision{ID: 2, Version: 12, Config: pipelineConfig, SavedAt: time.Unix(2, 0)}, true, nil
+					}
+				})
+
+				It("looks up both revisions of the pipeline", func() {
+					Expect(teamDB.GetConfigRevisionCallCount()).To(Equal(2))
+
+					pipelineName, revisionID := teamDB.GetConfigRevisionArgsForCall(0)
+					Expect(pipelineName).To(Equal("a-pipeline"))
+					Expect(revisionID).To(Equal(1))
+
+					pipelineName, revisionID = teamDB.GetConfigRevisionArgsForCall(1)
+					Expect(pipelineName).To(Equal("a-pipeline"))
+					Expect(revisionID).To(Equal(2))
+				})
+
+				It("returns the differences between them", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					var diff atc.ConfigRevisionDiff
+					err := json.NewDecoder(response.Body).Decode(&diff)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(diff).To(Equal(atc.ConfigRevisionDiff{
+						From: atc.ConfigRevision{ID: 1, Version: 11, SavedAt: 1},
+						To:   atc.ConfigRevision{ID: 2, Version: 12, SavedAt: 2},
+						Diff: config.Diff(atc.Config{}, pipelineConfig),
+					}))
+				})
+			})
+
+			Context("when a revision does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigRevisionReturns(db.ConfigRevision{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when a revision id is not a number", func() {
+				BeforeEach(func() {
+					toRevisionID = "latest"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:name/config/revisions/:revision_id/rollback", func() {
+		var (
+			request  *http.Request
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			var err error
+			request, err = requestGenerator.CreateRequest(atc.RollbackConfig, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+				"revision_id":   "1",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+
+				teamDB.GetConfigReturns(atc.Config{}, "", db.ConfigVersion(12), nil)
+			})
+
+			Context("when the revision exists", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigRevisionReturns(db.ConfigRevision{
+						ID:      1,
+						Version: 11,
+						Config:  pipelineConfig,
+					}, true, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("saves the revision's config over the current one", func() {
+					pipelineName, revisionID := teamDB.GetConfigRevisionArgsForCall(0)
+					Expect(pipelineName).To(Equal("a-pipeline"))
+					Expect(revisionID).To(Equal(1))
+
+					Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+
+					name, savedConfig, id, pipelineState, savedBy := teamDB.SaveConfigArgsForCall(0)
+					Expect(name).To(Equal("a-pipeline"))
+					Expect(savedConfig).To(Equal(pipelineConfig))
+					Expect(id).To(Equal(db.ConfigVersion(12)))
+					Expect(pipelineState).To(Equal(db.PipelineNoChange))
+					Expect(savedBy).To(Equal("a-team"))
+				})
+
+				Context("when a config version is specified", func() {
+					BeforeEach(func() {
+						request.Header.Set(atc.ConfigVersionHeader, "42")
+					})
+
+					It("replaces that version", func() {
+						_, _, id, _, _ := teamDB.SaveConfigArgsForCall(0)
+						Expect(id).To(Equal(db.ConfigVersion(42)))
+					})
+				})
+
+				Context("when the config no longer validates", func() {
+					BeforeEach(func() {
+						configValidationErrorMessages = []string{"totally invalid"}
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("returns error JSON", func() {
+						Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{"errors": ["totally invalid"]}`))
+					})
+
+					It("does not save it", func() {
+						Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when saving fails", func() {
+					BeforeEach(func() {
+						teamDB.SaveConfigReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the revision was rendered from a template", func() {
+				var template db.ConfigTemplate
+
+				BeforeEach(func() {
+					template = db.ConfigTemplate{
+						Template: "some-template",
+						Vars:     atc.Vars{"some": "var"},
+					}
+
+					teamDB.GetConfigRevisionReturns(db.ConfigRevision{
+						ID:       1,
+						Config:   pipelineConfig,
+						Template: &template,
+					}, true, nil)
+				})
+
+				It("saves the template along with the config", func() {
+					Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+					Expect(teamDB.SaveConfigFromTemplateCallCount()).To(Equal(1))
+
+					_, savedConfig, savedTemplate, _, _, _ := teamDB.SaveConfigFromTemplateArgsForCall(0)
+					Expect(savedConfig).To(Equal(pipelineConfig))
+					Expect(savedTemplate).To(Equal(template))
+				})
+			})
+
+			Context("when the revision does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigRevisionReturns(db.ConfigRevision{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+
+				It("does not save anything", func() {
+					Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not save anything", func() {
+				Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+			})
+		})
+	})
 })
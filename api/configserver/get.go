@@ -38,10 +38,25 @@ func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set(atc.ConfigVersionHeader, fmt.Sprintf("%d", id))
+	template, found, err := teamDB.GetConfigTemplate(pipelineName)
+	if err != nil {
+		logger.Error("failed-to-get-config-template", err)
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
 
-	json.NewEncoder(w).Encode(atc.ConfigResponse{
+	response := atc.ConfigResponse{
 		Config:    &config,
 		RawConfig: rawConfig,
-	})
+	}
+
+	if found {
+		response.Template = template.Template
+		response.Vars = template.Vars
+	}
+
+	w.Header().Set(atc.ConfigVersionHeader, fmt.Sprintf("%d", id))
+
+	json.NewEncoder(w).Encode(response)
 }
@@ -0,0 +1,156 @@
+package configserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+// ImportPipeline saves the config of an archive made by ExportPipeline, and
+// then adds its versions and builds to the pipeline, creating the pipeline if
+// it does not exist. Importing the same archive again changes nothing.
+func (s *Server) ImportPipeline(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("import-pipeline")
+
+	var archive atc.PipelineArchive
+	err := json.NewDecoder(r.Body).Decode(&archive)
+	if err != nil {
+		session.Error("malformed-request-payload", err)
+		s.handleBadRequest(w, []string{"malformed archive"}, session)
+		return
+	}
+
+	if archive.Version != atc.PipelineArchiveVersion {
+		s.handleBadRequest(w, []string{fmt.Sprintf("unsupported archive version: %d", archive.Version)}, session)
+		return
+	}
+
+	warnings, errorMessages := s.validate(archive.Config)
+	errorMessages = append(errorMessages, validateArchive(archive)...)
+	if len(errorMessages) > 0 {
+		session.Info("ignoring-invalid-archive", lager.Data{"errors": errorMessages})
+		s.handleBadRequest(w, errorMessages, session)
+		return
+	}
+
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	upstreamWarnings, err := s.validateUpstreamJobs(teamDB, pipelineName, archive.Config)
+	if err != nil {
+		session.Error("failed-to-validate-upstream-jobs", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	warnings = append(warnings, upstreamWarnings...)
+
+	currentConfig, _, version, err := teamDB.GetConfig(pipelineName)
+	if err != nil {
+		if _, ok := err.(atc.MalformedConfigError); !ok {
+			session.Error("failed-to-get-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	var savedPipeline db.SavedPipeline
+	var created bool
+
+	if version != 0 && reflect.DeepEqual(currentConfig, archive.Config) {
+		var found bool
+		savedPipeline, found, err = teamDB.GetPipelineByName(pipelineName)
+		if err != nil {
+			session.Error("failed-to-get-pipeline", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+	} else {
+		session.Info("saving-config")
+
+		savedPipeline, created, err = teamDB.SaveConfig(pipelineName, archive.Config, version, db.PipelineNoChange, savedBy(r))
+		if err != nil {
+			session.Error("failed-to-save-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "failed to save config: %s", err)
+			return
+		}
+	}
+
+	pipelineDB := s.pipelineDBFactory.Build(savedPipeline)
+
+	err = pipelineDB.Import(archive)
+	if err != nil {
+		session.Error("failed-to-import", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "failed to import: %s", err)
+		return
+	}
+
+	session.Info("imported")
+
+	if created {
+		w.WriteHeader(http.StatusCreated)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	s.writeSaveConfigResponse(w, SaveConfigResponse{Warnings: warnings}, session)
+}
+
+// finishedStatuses are the statuses a build may be imported with, as imported
+// builds are never run.
+var finishedStatuses = map[db.Status]bool{
+	db.StatusSucceeded: true,
+	db.StatusFailed:    true,
+	db.StatusErrored:   true,
+	db.StatusAborted:   true,
+}
+
+// validateArchive checks that everything the archive refers to is in its
+// config, and that its builds have finished.
+func validateArchive(archive atc.PipelineArchive) []string {
+	errorMessages := []string{}
+
+	for _, resource := range archive.Resources {
+		if _, found := archive.Config.Resources.Lookup(resource.Name); !found {
+			errorMessages = append(errorMessages, fmt.Sprintf("archive has versions of unknown resource '%s'", resource.Name))
+		}
+	}
+
+	for _, build := range archive.Builds {
+		if _, found := archive.Config.Jobs.Lookup(build.JobName); !found {
+			errorMessages = append(errorMessages, fmt.Sprintf("archive has build '%s' of unknown job '%s'", build.Name, build.JobName))
+			continue
+		}
+
+		if !finishedStatuses[db.Status(build.Status)] {
+			errorMessages = append(errorMessages, fmt.Sprintf("build '%s/%s' has unfinished status '%s'", build.JobName, build.Name, build.Status))
+		}
+
+		for _, input := range build.Inputs {
+			if _, found := archive.Config.Resources.Lookup(input.Resource); !found {
+				errorMessages = append(errorMessages, fmt.Sprintf("build '%s/%s' has input of unknown resource '%s'", build.JobName, build.Name, input.Resource))
+			}
+		}
+
+		for _, output := range build.Outputs {
+			if _, found := archive.Config.Resources.Lookup(output.Resource); !found {
+				errorMessages = append(errorMessages, fmt.Sprintf("build '%s/%s' has output of unknown resource '%s'", build.JobName, build.Name, output.Resource))
+			}
+		}
+	}
+
+	return errorMessages
+}
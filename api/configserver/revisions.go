@@ -0,0 +1,138 @@
+package configserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) ListConfigRevisions(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-config-revisions")
+
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	revisions, found, err := teamDB.GetConfigRevisions(pipelineName)
+	if err != nil {
+		logger.Error("failed-to-get-config-revisions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	presented := make([]atc.ConfigRevision, len(revisions))
+	for i, revision := range revisions {
+		presented[i] = present.ConfigRevision(revision)
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(presented)
+}
+
+func (s *Server) DiffConfigRevisions(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("diff-config-revisions")
+
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	from, found := s.getConfigRevision(logger, w, teamDB, pipelineName, r.FormValue(":from_revision_id"))
+	if !found {
+		return
+	}
+
+	to, found := s.getConfigRevision(logger, w, teamDB, pipelineName, r.FormValue(":to_revision_id"))
+	if !found {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(atc.ConfigRevisionDiff{
+		From: present.ConfigRevision(from),
+		To:   present.ConfigRevision(to),
+		Diff: config.Diff(from.Config, to.Config),
+	})
+}
+
+// RollbackConfig saves an earlier revision of the config as the pipeline's
+// current config. As with SaveConfig, the version to replace may be given in
+// the config version header; otherwise the current config is replaced.
+func (s *Server) RollbackConfig(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("rollback-config")
+
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamName := rata.Param(r, "team_name")
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	revision, found := s.getConfigRevision(session, w, teamDB, pipelineName, r.FormValue(":revision_id"))
+	if !found {
+		return
+	}
+
+	var version db.ConfigVersion
+	if configVersionStr := r.Header.Get(atc.ConfigVersionHeader); configVersionStr != "" {
+		_, err := fmt.Sscanf(configVersionStr, "%d", &version)
+		if err != nil {
+			session.Error("malformed-config-version", err)
+			s.handleBadRequest(w, []string{fmt.Sprintf("config version is malformed: %s", err)}, session)
+			return
+		}
+	} else {
+		_, _, currentVersion, err := teamDB.GetConfig(pipelineName)
+		if err != nil {
+			if _, ok := err.(atc.MalformedConfigError); !ok {
+				session.Error("failed-to-get-config", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		version = currentVersion
+	}
+
+	session.Info("rolling-back", lager.Data{"revision": revision.ID})
+
+	s.saveConfig(w, session, teamName, pipelineName, revision.Config, revision.Template, nil, version, db.PipelineNoChange, savedBy(r))
+}
+
+func (s *Server) getConfigRevision(
+	logger lager.Logger,
+	w http.ResponseWriter,
+	teamDB db.TeamDB,
+	pipelineName string,
+	revisionIDStr string,
+) (db.ConfigRevision, bool) {
+	revisionID, err := strconv.Atoi(revisionIDStr)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "invalid revision id: %s", revisionIDStr)
+		return db.ConfigRevision{}, false
+	}
+
+	revision, found, err := teamDB.GetConfigRevision(pipelineName, revisionID)
+	if err != nil {
+		logger.Error("failed-to-get-config-revision", err, lager.Data{"revision": revisionID})
+		w.WriteHeader(http.StatusInternalServerError)
+		return db.ConfigRevision{}, false
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return db.ConfigRevision{}, false
+	}
+
+	return revision, true
+}
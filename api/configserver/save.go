@@ -13,6 +13,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db"
 	"github.com/mitchellh/mapstructure"
@@ -27,6 +28,7 @@ var (
 	ErrFailedToConstructDecoder   = errors.New("decoder could not be constructed")
 	ErrCouldNotDecode             = errors.New("data could not be decoded into config structure")
 	ErrInvalidPausedValue         = errors.New("invalid paused value")
+	ErrMalformedVars              = errors.New("vars could not be decoded")
 )
 
 type ExtraKeysError struct {
@@ -65,7 +67,7 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	config, pausedState, err := saveConfigRequestUnmarshaler(r)
+	config, template, templateWarnings, pausedState, err := saveConfigRequestUnmarshaler(r)
 
 	switch err {
 	case ErrStatusUnsupportedMediaType:
@@ -90,6 +92,10 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		session.Error("invalid-paused-value", err)
 		s.handleBadRequest(w, []string{"invalid paused value"}, session)
 		return
+	case ErrMalformedVars:
+		session.Error("malformed-vars", err)
+		s.handleBadRequest(w, []string{"malformed vars"}, session)
+		return
 	default:
 		if err != nil {
 			if eke, ok := err.(ExtraKeysError); ok {
@@ -103,20 +109,53 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamName := rata.Param(r, "team_name")
+
+	s.saveConfig(w, session, teamName, pipelineName, config, template, templateWarnings, version, pausedState, savedBy(r))
+}
+
+// saveConfig validates and saves a config which was either sent by the user
+// or is being rolled back to.
+func (s *Server) saveConfig(
+	w http.ResponseWriter,
+	session lager.Logger,
+	teamName string,
+	pipelineName string,
+	config atc.Config,
+	template *db.ConfigTemplate,
+	templateWarnings []config.Warning,
+	version db.ConfigVersion,
+	pausedState db.PipelinePausedState,
+	savedBy string,
+) {
 	warnings, errorMessages := s.validate(config)
+	warnings = append(templateWarnings, warnings...)
 	if len(errorMessages) > 0 {
-		session.Error("ignoring-invalid-config", err)
+		session.Info("ignoring-invalid-config", lager.Data{"errors": errorMessages})
 		s.handleBadRequest(w, errorMessages, session)
 		return
 	}
 
-	session.Info("saving")
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
 
-	pipelineName := rata.Param(r, "pipeline_name")
-	teamName := rata.Param(r, "team_name")
+	upstreamWarnings, err := s.validateUpstreamJobs(teamDB, pipelineName, config)
+	if err != nil {
+		session.Error("failed-to-validate-upstream-jobs", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
 
-	teamDB := s.teamDBFactory.GetTeamDB(teamName)
-	_, created, err := teamDB.SaveConfig(pipelineName, config, version, pausedState)
+	warnings = append(warnings, upstreamWarnings...)
+
+	session.Info("saving")
+
+	var created bool
+	if template != nil {
+		_, created, err = teamDB.SaveConfigFromTemplate(pipelineName, config, *template, version, pausedState, savedBy)
+	} else {
+		_, created, err = teamDB.SaveConfig(pipelineName, config, version, pausedState, savedBy)
+	}
 	if err != nil {
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -135,6 +174,40 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	s.writeSaveConfigResponse(w, SaveConfigResponse{Warnings: warnings}, session)
 }
 
+// validateUpstreamJobs warns about passed constraints on jobs of the team's
+// other pipelines which do not exist.
+func (s *Server) validateUpstreamJobs(teamDB db.TeamDB, pipelineName string, pipelineConfig atc.Config) ([]config.Warning, error) {
+	pipelines, err := teamDB.GetPipelines()
+	if err != nil {
+		return nil, err
+	}
+
+	pipelineConfigs := map[string]atc.Config{}
+	for _, pipeline := range pipelines {
+		pipelineConfigs[pipeline.Name] = pipeline.Config
+	}
+
+	pipelineConfigs[pipelineName] = pipelineConfig
+
+	return config.ValidateUpstreamJobs(pipelineConfig, pipelineConfigs), nil
+}
+
+// savedBy describes who is saving a config: their team, and their username if
+// their token names one.
+func savedBy(r *http.Request) string {
+	authTeam, found := auth.GetTeam(r)
+	if !found {
+		return ""
+	}
+
+	username, found := auth.GetUsername(r)
+	if !found {
+		return authTeam.Name()
+	}
+
+	return authTeam.Name() + "/" + username
+}
+
 func (s *Server) handleBadRequest(w http.ResponseWriter, errorMessages []string, session lager.Logger) {
 	w.WriteHeader(http.StatusBadRequest)
 	s.writeSaveConfigResponse(w, SaveConfigResponse{
@@ -154,21 +227,31 @@ func (s *Server) writeSaveConfigResponse(w http.ResponseWriter, saveConfigRespon
 	w.Write(responseJSON)
 }
 
-func requestToConfig(contentType string, requestBody io.ReadCloser, configStructure interface{}) (db.PipelinePausedState, error) {
+// requestToConfig decodes the config document in the request body, returning
+// it as it was sent so that templates can be stored unrendered. Multipart
+// requests may also carry a "vars" part, which is decoded into varsStructure.
+func requestToConfig(contentType string, requestBody io.Reader, configStructure interface{}, varsStructure interface{}) (db.PipelinePausedState, []byte, error) {
 	pausedState := db.PipelineNoChange
+	var rawConfig []byte
 
 	mediaType, params, err := mime.ParseMediaType(contentType)
 	if err != nil {
-		return db.PipelineNoChange, ErrCannotParseContentType
+		return db.PipelineNoChange, nil, ErrCannotParseContentType
 	}
 
 	switch mediaType {
 	case "application/json":
-		err := json.NewDecoder(requestBody).Decode(configStructure)
+		body, err := ioutil.ReadAll(requestBody)
+		if err == nil {
+			err = json.NewDecoder(bytes.NewReader(body)).Decode(configStructure)
+		}
+
 		if err != nil {
-			return db.PipelineNoChange, ErrMalformedRequestPayload
+			return db.PipelineNoChange, nil, ErrMalformedRequestPayload
 		}
 
+		rawConfig = body
+
 	case "application/x-yaml":
 		body, err := ioutil.ReadAll(requestBody)
 		if err == nil {
@@ -176,9 +259,11 @@ func requestToConfig(contentType string, requestBody io.ReadCloser, configStruct
 		}
 
 		if err != nil {
-			return db.PipelineNoChange, ErrMalformedRequestPayload
+			return db.PipelineNoChange, nil, ErrMalformedRequestPayload
 		}
 
+		rawConfig = body
+
 	case "multipart/form-data":
 		multipartReader := multipart.NewReader(requestBody, params["boundary"])
 
@@ -190,13 +275,13 @@ func requestToConfig(contentType string, requestBody io.ReadCloser, configStruct
 			}
 
 			if err != nil {
-				return db.PipelineNoChange, err
+				return db.PipelineNoChange, nil, err
 			}
 
 			if part.FormName() == "paused" {
 				pausedValue, err := ioutil.ReadAll(part)
 				if err != nil {
-					return db.PipelineNoChange, err
+					return db.PipelineNoChange, nil, err
 				}
 
 				if string(pausedValue) == "true" {
@@ -204,35 +289,63 @@ func requestToConfig(contentType string, requestBody io.ReadCloser, configStruct
 				} else if string(pausedValue) == "false" {
 					pausedState = db.PipelineUnpaused
 				} else {
-					return db.PipelineNoChange, ErrInvalidPausedValue
+					return db.PipelineNoChange, nil, ErrInvalidPausedValue
+				}
+			} else if part.FormName() == "vars" && varsStructure != nil {
+				partContentType := part.Header.Get("Content-type")
+				_, _, err := requestToConfig(partContentType, part, varsStructure, nil)
+				if err != nil {
+					return db.PipelineNoChange, nil, ErrMalformedVars
 				}
 			} else {
 				partContentType := part.Header.Get("Content-type")
-				_, err := requestToConfig(partContentType, part, configStructure)
+				_, rawConfig, err = requestToConfig(partContentType, part, configStructure, nil)
 				if err != nil {
-					return db.PipelineNoChange, ErrMalformedRequestPayload
+					return db.PipelineNoChange, nil, ErrMalformedRequestPayload
 				}
 			}
 		}
 	default:
-		return db.PipelineNoChange, ErrStatusUnsupportedMediaType
+		return db.PipelineNoChange, nil, ErrStatusUnsupportedMediaType
 	}
 
-	return pausedState, nil
+	return pausedState, rawConfig, nil
 }
 
-func saveConfigRequestUnmarshaler(r *http.Request) (atc.Config, db.PipelinePausedState, error) {
+func saveConfigRequestUnmarshaler(r *http.Request) (atc.Config, *db.ConfigTemplate, []config.Warning, db.PipelinePausedState, error) {
 	var configStructure interface{}
-	pausedState, err := requestToConfig(r.Header.Get("Content-Type"), r.Body, &configStructure)
+	var varsStructure interface{}
+	pausedState, rawConfig, err := requestToConfig(r.Header.Get("Content-Type"), r.Body, &configStructure, &varsStructure)
 	if err != nil {
-		return atc.Config{}, db.PipelineNoChange, err
+		return atc.Config{}, nil, nil, db.PipelineNoChange, err
 	}
 
-	var config atc.Config
+	var template *db.ConfigTemplate
+	var warnings []config.Warning
+
+	if varsStructure != nil {
+		var vars atc.Vars
+		err := decodeVars(varsStructure, &vars)
+		if err != nil {
+			return atc.Config{}, nil, nil, db.PipelineNoChange, ErrMalformedVars
+		}
+
+		configStructure, warnings, err = config.RenderTemplate(configStructure, vars)
+		if err != nil {
+			return atc.Config{}, nil, nil, db.PipelineNoChange, err
+		}
+
+		template = &db.ConfigTemplate{
+			Template: atc.RawConfig(rawConfig),
+			Vars:     vars,
+		}
+	}
+
+	var pipelineConfig atc.Config
 	var md mapstructure.Metadata
 	msConfig := &mapstructure.DecoderConfig{
 		Metadata:         &md,
-		Result:           &config,
+		Result:           &pipelineConfig,
 		WeaklyTypedInput: true,
 		DecodeHook: mapstructure.ComposeDecodeHookFunc(
 			atc.SanitizeDecodeHook,
@@ -242,16 +355,31 @@ func saveConfigRequestUnmarshaler(r *http.Request) (atc.Config, db.PipelinePause
 
 	decoder, err := mapstructure.NewDecoder(msConfig)
 	if err != nil {
-		return atc.Config{}, db.PipelineNoChange, ErrFailedToConstructDecoder
+		return atc.Config{}, nil, nil, db.PipelineNoChange, ErrFailedToConstructDecoder
 	}
 
 	if err := decoder.Decode(configStructure); err != nil {
-		return atc.Config{}, db.PipelineNoChange, ErrCouldNotDecode
+		return atc.Config{}, nil, nil, db.PipelineNoChange, ErrCouldNotDecode
 	}
 
 	if len(md.Unused) != 0 {
-		return atc.Config{}, db.PipelineNoChange, ExtraKeysError{extraKeys: md.Unused}
+		return atc.Config{}, nil, nil, db.PipelineNoChange, ExtraKeysError{extraKeys: md.Unused}
+	}
+
+	return pipelineConfig, template, warnings, pausedState, nil
+}
+
+// decodeVars converts the vars document, which may contain the
+// map[interface{}]interface{} values produced by YAML, into atc.Vars that can
+// be stored as JSON.
+func decodeVars(varsStructure interface{}, vars *atc.Vars) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:     vars,
+		DecodeHook: atc.SanitizeDecodeHook,
+	})
+	if err != nil {
+		return err
 	}
 
-	return config, pausedState, nil
+	return decoder.Decode(varsStructure)
 }
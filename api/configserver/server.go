@@ -8,9 +8,10 @@ import (
 )
 
 type Server struct {
-	logger        lager.Logger
-	teamDBFactory db.TeamDBFactory
-	validate      ConfigValidator
+	logger            lager.Logger
+	teamDBFactory     db.TeamDBFactory
+	pipelineDBFactory db.PipelineDBFactory
+	validate          ConfigValidator
 }
 
 type ConfigValidator func(atc.Config) ([]config.Warning, []string)
@@ -18,11 +19,13 @@ type ConfigValidator func(atc.Config) ([]config.Warning, []string)
 func NewServer(
 	logger lager.Logger,
 	teamDBFactory db.TeamDBFactory,
+	pipelineDBFactory db.PipelineDBFactory,
 	validator ConfigValidator,
 ) *Server {
 	return &Server{
-		logger:        logger,
-		teamDBFactory: teamDBFactory,
-		validate:      validator,
+		logger:            logger,
+		teamDBFactory:     teamDBFactory,
+		pipelineDBFactory: pipelineDBFactory,
+		validate:          validator,
 	}
 }
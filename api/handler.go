@@ -8,6 +8,7 @@ import (
 	"github.com/tedsuo/rata"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/auditserver"
 	"github.com/concourse/atc/api/authserver"
 	"github.com/concourse/atc/api/buildserver"
 	"github.com/concourse/atc/api/cliserver"
@@ -39,6 +40,7 @@ func NewHandler(
 
 	tokenGenerator auth.TokenGenerator,
 	providerFactory auth.ProviderFactory,
+	ldapFactory auth.LDAPProviderFactory,
 	oAuthBaseURL string,
 
 	pipelineDBFactory db.PipelineDBFactory,
@@ -51,6 +53,7 @@ func NewHandler(
 	volumesDB volumeserver.VolumesDB,
 	pipeDB pipes.PipeDB,
 	pipelinesDB db.PipelinesDB,
+	auditDB auditserver.AuditDB,
 
 	configValidator configserver.ConfigValidator,
 	peerURL string,
@@ -83,6 +86,7 @@ func NewHandler(
 		oAuthBaseURL,
 		tokenGenerator,
 		providerFactory,
+		ldapFactory,
 		teamDBFactory,
 	)
 
@@ -97,14 +101,14 @@ func NewHandler(
 		drain,
 	)
 
-	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL)
+	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL, workerClient)
 	resourceServer := resourceserver.NewServer(logger, scannerFactory)
 	versionServer := versionserver.NewServer(logger, externalURL)
 	pipeServer := pipes.NewServer(logger, peerURL, externalURL, pipeDB)
 
 	pipelineServer := pipelineserver.NewServer(logger, teamDBFactory, pipelinesDB)
 
-	configServer := configserver.NewServer(logger, teamDBFactory, configValidator)
+	configServer := configserver.NewServer(logger, teamDBFactory, pipelineDBFactory, configValidator)
 
 	workerServer := workerserver.NewServer(logger, workerDB, teamDBFactory)
 
@@ -120,31 +124,42 @@ func NewHandler(
 
 	infoServer := infoserver.NewServer(logger, version)
 
+	auditServer := auditserver.NewServer(logger, externalURL, auditDB)
+
 	handlers := map[string]http.Handler{
 		atc.ListAuthMethods: http.HandlerFunc(authServer.ListAuthMethods),
 		atc.GetAuthToken:    http.HandlerFunc(authServer.GetAuthToken),
 
-		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
-		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
-
-		atc.GetBuild:            buildHandlerFactory.HandlerFor(buildServer.GetBuild),
-		atc.ListBuilds:          http.HandlerFunc(buildServer.ListBuilds),
-		atc.CreateBuild:         teamHandlerFactory.HandlerFor(buildServer.CreateBuild),
-		atc.BuildResources:      buildHandlerFactory.HandlerFor(buildServer.BuildResources),
-		atc.AbortBuild:          buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
-		atc.GetBuildPlan:        buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
-		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
-		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
-
-		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
-		atc.GetJob:         pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
-		atc.ListJobBuilds:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
-		atc.ListJobInputs:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
-		atc.GetJobBuild:    pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
-		atc.CreateJobBuild: pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
-		atc.PauseJob:       pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
-		atc.UnpauseJob:     pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
-		atc.JobBadge:       pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
+		atc.GetConfig:           http.HandlerFunc(configServer.GetConfig),
+		atc.SaveConfig:          http.HandlerFunc(configServer.SaveConfig),
+		atc.ListConfigRevisions: http.HandlerFunc(configServer.ListConfigRevisions),
+		atc.DiffConfigRevisions: http.HandlerFunc(configServer.DiffConfigRevisions),
+		atc.RollbackConfig:      http.HandlerFunc(configServer.RollbackConfig),
+		atc.ImportPipeline:      http.HandlerFunc(configServer.ImportPipeline),
+
+		atc.GetBuild:              buildHandlerFactory.HandlerFor(buildServer.GetBuild),
+		atc.ListBuilds:            http.HandlerFunc(buildServer.ListBuilds),
+		atc.CreateBuild:           teamHandlerFactory.HandlerFor(buildServer.CreateBuild),
+		atc.BuildResources:        buildHandlerFactory.HandlerFor(buildServer.BuildResources),
+		atc.AbortBuild:            buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
+		atc.RerunBuild:            buildHandlerFactory.HandlerFor(buildServer.RerunBuild),
+		atc.GetBuildPlan:          buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
+		atc.GetBuildPreparation:   buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
+		atc.GetBuildResourceUsage: buildHandlerFactory.HandlerFor(buildServer.GetBuildResourceUsage),
+		atc.BuildEvents:           buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
+
+		atc.ListJobs:                    pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
+		atc.GetJob:                      pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
+		atc.ListJobBuilds:               pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
+		atc.ListJobInputs:               pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
+		atc.GetJobSchedulingExplanation: pipelineHandlerFactory.HandlerFor(jobServer.GetSchedulingExplanation),
+		atc.GetJobBuild:                 pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
+		atc.SearchJobBuilds:             pipelineHandlerFactory.HandlerFor(jobServer.SearchJobBuilds),
+		atc.CreateJobBuild:              pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
+		atc.PauseJob:                    pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
+		atc.UnpauseJob:                  pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
+		atc.ClearJobCaches:              pipelineHandlerFactory.HandlerFor(jobServer.ClearJobCaches),
+		atc.JobBadge:                    pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
 
 		atc.ListAllPipelines: http.HandlerFunc(pipelineServer.ListAllPipelines),
 		atc.ListPipelines:    http.HandlerFunc(pipelineServer.ListPipelines),
@@ -157,12 +172,16 @@ func NewHandler(
 		atc.HidePipeline:     pipelineHandlerFactory.HandlerFor(pipelineServer.HidePipeline),
 		atc.GetVersionsDB:    pipelineHandlerFactory.HandlerFor(pipelineServer.GetVersionsDB),
 		atc.RenamePipeline:   pipelineHandlerFactory.HandlerFor(pipelineServer.RenamePipeline),
+		atc.ExportPipeline:   pipelineHandlerFactory.HandlerFor(pipelineServer.ExportPipeline),
 
-		atc.ListResources:   pipelineHandlerFactory.HandlerFor(resourceServer.ListResources),
-		atc.GetResource:     pipelineHandlerFactory.HandlerFor(resourceServer.GetResource),
-		atc.PauseResource:   pipelineHandlerFactory.HandlerFor(resourceServer.PauseResource),
-		atc.UnpauseResource: pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResource),
-		atc.CheckResource:   pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
+		atc.ListResources:        pipelineHandlerFactory.HandlerFor(resourceServer.ListResources),
+		atc.GetResource:          pipelineHandlerFactory.HandlerFor(resourceServer.GetResource),
+		atc.PauseResource:        pipelineHandlerFactory.HandlerFor(resourceServer.PauseResource),
+		atc.UnpauseResource:      pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResource),
+		atc.PinResource:          pipelineHandlerFactory.HandlerFor(resourceServer.PinResource),
+		atc.UnpinResource:        pipelineHandlerFactory.HandlerFor(resourceServer.UnpinResource),
+		atc.CheckResource:        pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
+		atc.CheckResourceWebHook: pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceWebHook),
 
 		atc.ListResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions),
 		atc.EnableResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
@@ -176,6 +195,9 @@ func NewHandler(
 
 		atc.ListWorkers:    teamHandlerFactory.HandlerFor(workerServer.ListWorkers),
 		atc.RegisterWorker: http.HandlerFunc(workerServer.RegisterWorker),
+		atc.LandWorker:     http.HandlerFunc(workerServer.LandWorker),
+		atc.RetireWorker:   http.HandlerFunc(workerServer.RetireWorker),
+		atc.PruneWorker:    http.HandlerFunc(workerServer.PruneWorker),
 
 		atc.SetLogLevel: http.HandlerFunc(logLevelServer.SetMinLevel),
 		atc.GetLogLevel: http.HandlerFunc(logLevelServer.GetMinLevel),
@@ -192,6 +214,12 @@ func NewHandler(
 
 		atc.ListTeams: http.HandlerFunc(teamServer.ListTeams),
 		atc.SetTeam:   http.HandlerFunc(teamServer.SetTeam),
+
+		atc.CreateAPIToken: http.HandlerFunc(teamServer.CreateAPIToken),
+		atc.ListAPITokens:  http.HandlerFunc(teamServer.ListAPITokens),
+		atc.DeleteAPIToken: http.HandlerFunc(teamServer.DeleteAPIToken),
+
+		atc.ListAuditEvents: http.HandlerFunc(auditServer.ListAuditEvents),
 	}
 
 	return rata.NewRouter(atc.Routes, wrapper.Wrap(handlers))
@@ -6,15 +6,22 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"time"
 
+	"code.cloudfoundry.org/lager"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/scheduler/schedulerfakes"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
 )
 
 var _ = Describe("Jobs API", func() {
@@ -1558,6 +1565,271 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/scheduling-explanation", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/scheduling-explanation")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, true, true)
+			})
+
+			Context("when the config contains the requested job", func() {
+				someJob := atc.JobConfig{
+					Name: "some-job",
+					Plan: atc.PlanSequence{
+						{Get: "some-input", Passed: []string{"job-a"}},
+					},
+				}
+
+				var fakeScheduler *schedulerfakes.FakeBuildScheduler
+
+				BeforeEach(func() {
+					fakeScheduler = new(schedulerfakes.FakeBuildScheduler)
+					fakeSchedulerFactory.BuildSchedulerReturns(fakeScheduler)
+
+					pipelineDB.GetConfigReturns(atc.Config{
+						Jobs: atc.JobConfigs{someJob},
+					}, 42, true, nil)
+				})
+
+				Context("when the explanation can be determined", func() {
+					BeforeEach(func() {
+						pipelineDB.GetSchedulingExplanationReturns(algorithm.Explanation{
+							"some-input": {
+								Reason: algorithm.ExplanationPassedConstraint,
+								Passed: []string{"job-a"},
+							},
+						}, nil)
+					})
+
+					It("returns 200 OK", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("does not determine the inputs outside of the scheduler", func() {
+						Expect(fakeScheduler.SaveNextInputMappingCallCount()).To(BeZero())
+					})
+
+					It("loaded the explanation of the correct job", func() {
+						Expect(pipelineDB.GetSchedulingExplanationArgsForCall(0)).To(Equal("some-job"))
+					})
+
+					It("returns the explanation", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"some-input": {
+								"reason": "passed_constraint",
+								"passed": ["job-a"]
+							}
+						}`))
+					})
+				})
+
+				Context("when getting the explanation fails", func() {
+					BeforeEach(func() {
+						pipelineDB.GetSchedulingExplanationReturns(nil, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the config does not contain the requested job", func() {
+				BeforeEach(func() {
+					pipelineDB.GetConfigReturns(atc.Config{
+						Jobs: atc.JobConfigs{
+							{Name: "some-bogus-job"},
+						},
+					}, 42, true, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the pipeline is no longer configured", func() {
+				BeforeEach(func() {
+					pipelineDB.GetConfigReturns(atc.Config{}, 0, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/search", func() {
+		var query string
+		var response *http.Response
+
+		BeforeEach(func() {
+			query = "nil pointer"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/search?q=" + url.QueryEscape(query))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, true, true)
+			})
+
+			Context("when the config contains the requested job", func() {
+				BeforeEach(func() {
+					pipelineDB.GetConfigReturns(atc.Config{
+						Jobs: atc.JobConfigs{{Name: "some-job"}},
+					}, 42, true, nil)
+				})
+
+				Context("when the search succeeds", func() {
+					BeforeEach(func() {
+						build := new(dbfakes.FakeBuild)
+						build.IDReturns(3)
+						build.NameReturns("2")
+						build.JobNameReturns("some-job")
+						build.PipelineNameReturns("some-pipeline")
+						build.TeamNameReturns("some-team")
+						build.StatusReturns(db.StatusFailed)
+
+						pipelineDB.SearchJobBuildLogsReturns([]db.BuildLogSearchResult{
+							{
+								Build: build,
+								Matches: []db.BuildLogMatch{
+									{
+										Origin: event.Origin{
+											ID:     "some-origin-id",
+											Name:   "unit",
+											Source: event.OriginSourceStderr,
+										},
+										Lines: []string{"panic: runtime error: invalid memory address or nil pointer dereference"},
+									},
+								},
+							},
+						}, nil)
+					})
+
+					It("returns 200 OK", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("returns application/json", func() {
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+					})
+
+					It("searches the job's build logs", func() {
+						Expect(pipelineDB.SearchJobBuildLogsCallCount()).To(Equal(1))
+						jobName, searchQuery, limit := pipelineDB.SearchJobBuildLogsArgsForCall(0)
+						Expect(jobName).To(Equal("some-job"))
+						Expect(searchQuery).To(Equal("nil pointer"))
+						Expect(limit).To(Equal(atc.PaginationAPIDefaultLimit))
+					})
+
+					It("returns the matching builds with their matching lines", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`[
+							{
+								"build": {
+									"id": 3,
+									"name": "2",
+									"job_name": "some-job",
+									"status": "failed",
+									"url": "/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/2",
+									"api_url": "/api/v1/builds/3",
+									"pipeline_name": "some-pipeline",
+									"team_name": "some-team"
+								},
+								"matches": [
+									{
+										"origin": {
+											"id": "some-origin-id",
+											"name": "unit",
+											"source": "stderr"
+										},
+										"lines": ["panic: runtime error: invalid memory address or nil pointer dereference"]
+									}
+								]
+							}
+						]`))
+					})
+				})
+
+				Context("when the search fails", func() {
+					BeforeEach(func() {
+						pipelineDB.SearchJobBuildLogsReturns(nil, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the query is empty", func() {
+					BeforeEach(func() {
+						query = " "
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(pipelineDB.SearchJobBuildLogsCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the config does not contain the requested job", func() {
+				BeforeEach(func() {
+					pipelineDB.GetConfigReturns(atc.Config{
+						Jobs: atc.JobConfigs{{Name: "some-bogus-job"}},
+					}, 42, true, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", func() {
 		var response *http.Response
 
@@ -1793,4 +2065,108 @@ var _ = Describe("Jobs API", func() {
 			})
 		})
 	})
+
+	Describe("DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/caches", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/job-name/caches", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, true, true)
+			})
+
+			It("injects the PipelineDB", func() {
+				pipelineName := teamDB.GetPipelineByNameArgsForCall(0)
+				Expect(pipelineName).To(Equal("some-pipeline"))
+				Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
+				actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
+				Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
+			})
+
+			Context("when clearing the caches succeeds", func() {
+				var fakeWorker *workerfakes.FakeWorker
+				var fakeVolume *workerfakes.FakeVolume
+
+				BeforeEach(func() {
+					pipelineDB.ClearTaskCachesReturns([]db.SavedVolume{
+						{Volume: db.Volume{Handle: "cache-handle-1", WorkerName: "some-worker"}},
+						{Volume: db.Volume{Handle: "cache-handle-2", WorkerName: "some-worker"}},
+					}, nil)
+
+					fakeWorker = new(workerfakes.FakeWorker)
+					fakeWorkerClient.GetWorkerReturns(fakeWorker, nil)
+
+					fakeVolume = new(workerfakes.FakeVolume)
+					fakeWorker.LookupVolumeStub = func(_ lager.Logger, handle string) (worker.Volume, bool, error) {
+						if handle == "cache-handle-1" {
+							return fakeVolume, true, nil
+						}
+
+						return nil, false, nil
+					}
+				})
+
+				It("cleared the right job's caches", func() {
+					Expect(pipelineDB.ClearTaskCachesArgsForCall(0)).To(Equal("job-name"))
+				})
+
+				It("releases the cache volumes that are still on their workers", func() {
+					Expect(fakeWorkerClient.GetWorkerCallCount()).To(Equal(2))
+					Expect(fakeWorkerClient.GetWorkerArgsForCall(0)).To(Equal("some-worker"))
+
+					Expect(fakeVolume.ReleaseCallCount()).To(Equal(1))
+					Expect(fakeVolume.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(worker.VolumeTTL)))
+				})
+
+				It("returns 200 with the number of caches removed", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{"caches_removed":2}`))
+				})
+
+				Context("when a cache's worker cannot be found", func() {
+					BeforeEach(func() {
+						fakeWorkerClient.GetWorkerReturns(nil, errors.New("nope"))
+					})
+
+					It("still returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+			})
+
+			Context("when clearing the caches fails", func() {
+				BeforeEach(func() {
+					pipelineDB.ClearTaskCachesReturns(nil, errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
 })
@@ -0,0 +1,63 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker"
+)
+
+func (s *Server) ClearJobCaches(pipelineDB db.PipelineDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("clear-job-caches")
+
+		jobName := r.FormValue(":job_name")
+
+		volumes, err := pipelineDB.ClearTaskCaches(jobName)
+		if err != nil {
+			logger.Error("failed-to-clear-task-caches", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// the caches can no longer be found by later builds; release them so
+		// that they expire on their workers instead of waiting out their TTL
+		for _, volume := range volumes {
+			s.releaseCache(logger, volume)
+		}
+
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(atc.ClearJobCachesResponse{
+			CachesRemoved: len(volumes),
+		})
+	})
+}
+
+func (s *Server) releaseCache(logger lager.Logger, volume db.SavedVolume) {
+	logger = logger.WithData(lager.Data{
+		"worker": volume.WorkerName,
+		"handle": volume.Handle,
+	})
+
+	cacheWorker, err := s.workerClient.GetWorker(volume.WorkerName)
+	if err != nil {
+		logger.Error("failed-to-get-worker", err)
+		return
+	}
+
+	cacheVolume, found, err := cacheWorker.LookupVolume(logger, volume.Handle)
+	if err != nil {
+		logger.Error("failed-to-lookup-volume", err)
+		return
+	}
+
+	if !found {
+		return
+	}
+
+	cacheVolume.Release(worker.FinalTTL(worker.VolumeTTL))
+}
@@ -0,0 +1,46 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+// GetSchedulingExplanation returns the explanation stored the last time the
+// job's inputs were determined by the scheduler. It does not determine them
+// itself, as that must only be done while holding the scheduling lease.
+func (s *Server) GetSchedulingExplanation(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("get-scheduling-explanation")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		pipelineConfig, _, found, err := pipelineDB.GetConfig()
+		if err != nil {
+			logger.Error("failed-to-get-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, found = pipelineConfig.Jobs.Lookup(jobName)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		explanation, err := pipelineDB.GetSchedulingExplanation(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-scheduling-explanation", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(present.SchedulingExplanation(explanation))
+	})
+}
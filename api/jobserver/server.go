@@ -5,6 +5,7 @@ import (
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/scheduler"
+	"github.com/concourse/atc/worker"
 )
 
 //go:generate counterfeiter . SchedulerFactory
@@ -19,17 +20,20 @@ type Server struct {
 	schedulerFactory SchedulerFactory
 	externalURL      string
 	rejector         auth.Rejector
+	workerClient     worker.Client
 }
 
 func NewServer(
 	logger lager.Logger,
 	schedulerFactory SchedulerFactory,
 	externalURL string,
+	workerClient worker.Client,
 ) *Server {
 	return &Server{
 		logger:           logger,
 		schedulerFactory: schedulerFactory,
 		externalURL:      externalURL,
 		rejector:         auth.UnauthorizedRejector{},
+		workerClient:     workerClient,
 	}
 }
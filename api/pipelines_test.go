@@ -2,6 +2,7 @@ package api_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"io"
 	"io/ioutil"
@@ -1197,4 +1198,423 @@ var _ = Describe("Pipelines API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/export", func() {
+		var response *http.Response
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/export"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when exporting succeeds", func() {
+				BeforeEach(func() {
+					pipelineDB.ExportReturns(atc.PipelineArchive{
+						Version: atc.PipelineArchiveVersion,
+						Config: atc.Config{
+							Resources: atc.ResourceConfigs{
+								{Name: "some-resource", Type: "some-type"},
+							},
+						},
+						Resources: []atc.ArchivedResource{
+							{
+								Name: "some-resource",
+								Versions: []atc.ArchivedVersion{
+									{
+										Type:     "some-type",
+										Version:  atc.Version{"ref": "v1"},
+										Metadata: []atc.MetadataField{{Name: "some", Value: "metadata"}},
+										Enabled:  true,
+									},
+									{
+										Type:    "some-type",
+										Version: atc.Version{"ref": "v2"},
+										Enabled: false,
+									},
+								},
+							},
+						},
+					}, nil)
+				})
+
+				It("injects the proper pipelineDB", func() {
+					pipelineName := teamDB.GetPipelineByNameArgsForCall(0)
+					Expect(pipelineName).To(Equal("a-pipeline"))
+					Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
+					actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
+					Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns application/json", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns the archive", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"version": 1,
+						"config": {
+							"groups": null,
+							"resources": [
+								{
+									"name": "some-resource",
+									"type": "some-type",
+									"source": null,
+									"check_every": ""
+								}
+							],
+							"resource_types": null,
+							"jobs": null
+						},
+						"resources": [
+							{
+								"name": "some-resource",
+								"versions": [
+									{
+										"type": "some-type",
+										"version": {"ref": "v1"},
+										"metadata": [{"name": "some", "value": "metadata"}],
+										"enabled": true
+									},
+									{
+										"type": "some-type",
+										"version": {"ref": "v2"},
+										"enabled": false
+									}
+								]
+							}
+						]
+					}`))
+				})
+
+				It("does not include builds", func() {
+					Expect(pipelineDB.ExportCallCount()).To(Equal(1))
+					Expect(pipelineDB.ExportArgsForCall(0)).To(BeFalse())
+				})
+
+				Context("when builds are asked for", func() {
+					BeforeEach(func() {
+						query = "?builds=true"
+					})
+
+					It("includes builds", func() {
+						Expect(pipelineDB.ExportCallCount()).To(Equal(1))
+						Expect(pipelineDB.ExportArgsForCall(0)).To(BeTrue())
+					})
+				})
+			})
+
+			Context("when exporting fails", func() {
+				BeforeEach(func() {
+					pipelineDB.ExportReturns(atc.PipelineArchive{}, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/import", func() {
+		var (
+			archive     atc.PipelineArchive
+			archiveBody string
+			response    *http.Response
+		)
+
+		BeforeEach(func() {
+			archive = atc.PipelineArchive{
+				Version: atc.PipelineArchiveVersion,
+				Config: atc.Config{
+					Resources: atc.ResourceConfigs{
+						{Name: "some-resource", Type: "some-type"},
+					},
+					Jobs: atc.JobConfigs{
+						{
+							Name: "some-job",
+							Plan: atc.PlanSequence{{Get: "some-resource"}},
+						},
+					},
+				},
+				Resources: []atc.ArchivedResource{
+					{
+						Name: "some-resource",
+						Versions: []atc.ArchivedVersion{
+							{Type: "some-type", Version: atc.Version{"ref": "v1"}, Enabled: true},
+						},
+					},
+				},
+				Builds: []atc.ArchivedBuild{
+					{
+						JobName: "some-job",
+						Name:    "1",
+						Status:  "succeeded",
+						Inputs: []atc.ArchivedBuildInput{
+							{Name: "some-resource", Resource: "some-resource", Type: "some-type", Version: atc.Version{"ref": "v1"}},
+						},
+					},
+				},
+			}
+
+			archiveBody = ""
+		})
+
+		JustBeforeEach(func() {
+			if archiveBody == "" {
+				payload, err := json.Marshal(archive)
+				Expect(err).NotTo(HaveOccurred())
+
+				archiveBody = string(payload)
+			}
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/import", bytes.NewBufferString(archiveBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when the pipeline does not exist", func() {
+				var savedPipeline db.SavedPipeline
+
+				BeforeEach(func() {
+					savedPipeline = db.SavedPipeline{ID: 7, TeamID: 42}
+					teamDB.GetConfigReturns(atc.Config{}, "", 0, nil)
+					teamDB.SaveConfigReturns(savedPipeline, true, nil)
+				})
+
+				It("returns 201", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				})
+
+				It("saves the archive's config", func() {
+					Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+
+					name, savedConfig, id, pipelineState, savedBy := teamDB.SaveConfigArgsForCall(0)
+					Expect(name).To(Equal("a-pipeline"))
+					Expect(savedConfig).To(Equal(archive.Config))
+					Expect(id).To(Equal(db.ConfigVersion(0)))
+					Expect(pipelineState).To(Equal(db.PipelineNoChange))
+					Expect(savedBy).To(Equal("a-team"))
+				})
+
+				It("imports the archive into the saved pipeline", func() {
+					Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
+					Expect(pipelineDBFactory.BuildArgsForCall(0)).To(Equal(savedPipeline))
+
+					Expect(pipelineDB.ImportCallCount()).To(Equal(1))
+					Expect(pipelineDB.ImportArgsForCall(0)).To(Equal(archive))
+				})
+
+				Context("when importing fails", func() {
+					BeforeEach(func() {
+						pipelineDB.ImportReturns(errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the pipeline already has the archive's config", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigReturns(archive.Config, "", db.ConfigVersion(3), nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("does not save the config again", func() {
+					Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+				})
+
+				It("imports the archive into the existing pipeline", func() {
+					Expect(teamDB.GetPipelineByNameArgsForCall(0)).To(Equal("a-pipeline"))
+					Expect(pipelineDBFactory.BuildArgsForCall(0)).To(Equal(expectedSavedPipeline))
+
+					Expect(pipelineDB.ImportCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the pipeline has a different config", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigReturns(atc.Config{}, "", db.ConfigVersion(3), nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("replaces the current config", func() {
+					Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+
+					_, savedConfig, id, _, _ := teamDB.SaveConfigArgsForCall(0)
+					Expect(savedConfig).To(Equal(archive.Config))
+					Expect(id).To(Equal(db.ConfigVersion(3)))
+				})
+
+				Context("when saving fails", func() {
+					BeforeEach(func() {
+						teamDB.SaveConfigReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+
+					It("does not import anything", func() {
+						Expect(pipelineDB.ImportCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the archive is malformed", func() {
+				BeforeEach(func() {
+					archiveBody = "{"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{"errors": ["malformed archive"]}`))
+				})
+			})
+
+			Context("when the archive version is not supported", func() {
+				BeforeEach(func() {
+					archive.Version = 2
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{"errors": ["unsupported archive version: 2"]}`))
+				})
+			})
+
+			Context("when the config does not validate", func() {
+				BeforeEach(func() {
+					configValidationErrorMessages = []string{"totally invalid"}
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{"errors": ["totally invalid"]}`))
+				})
+
+				It("does not save or import anything", func() {
+					Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+					Expect(pipelineDB.ImportCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the archive refers to things which are not in its config", func() {
+				BeforeEach(func() {
+					archive.Resources[0].Name = "bogus-resource"
+					archive.Builds[0].Inputs[0].Resource = "bogus-input"
+					archive.Builds = append(archive.Builds, atc.ArchivedBuild{
+						JobName: "bogus-job",
+						Name:    "1",
+					})
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+						"errors": [
+							"archive has versions of unknown resource 'bogus-resource'",
+							"build 'some-job/1' has input of unknown resource 'bogus-input'",
+							"archive has build '1' of unknown job 'bogus-job'"
+						]
+					}`))
+				})
+
+				It("does not save or import anything", func() {
+					Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+					Expect(pipelineDB.ImportCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the archive has builds which have not finished", func() {
+				BeforeEach(func() {
+					archive.Builds[0].Status = "started"
+					archive.Builds = append(archive.Builds,
+						atc.ArchivedBuild{JobName: "some-job", Name: "2", Status: "pending"},
+						atc.ArchivedBuild{JobName: "some-job", Name: "3", Status: "bogus"},
+						atc.ArchivedBuild{JobName: "some-job", Name: "4", Status: "aborted"},
+					)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+						"errors": [
+							"build 'some-job/1' has unfinished status 'started'",
+							"build 'some-job/2' has unfinished status 'pending'",
+							"build 'some-job/3' has unfinished status 'bogus'"
+						]
+					}`))
+				})
+
+				It("does not save or import anything", func() {
+					Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+					Expect(pipelineDB.ImportCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not save or import anything", func() {
+				Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+				Expect(pipelineDB.ImportCallCount()).To(BeZero())
+			})
+		})
+	})
 })
@@ -0,0 +1,30 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc/db"
+)
+
+// ExportPipeline returns an archive of the pipeline which can be imported
+// into another pipeline. Its builds are only included when the builds query
+// parameter is true, as there may be a great many of them.
+func (s *Server) ExportPipeline(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("export-pipeline")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		includeBuilds := r.URL.Query().Get("builds") == "true"
+
+		archive, err := pipelineDB.Export(includeBuilds)
+		if err != nil {
+			logger.Error("failed-to-export-pipeline", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(archive)
+	})
+}
@@ -0,0 +1,20 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func APIToken(token db.APIToken) atc.APIToken {
+	presented := atc.APIToken{
+		Name:      token.Name,
+		Role:      token.Role,
+		CreatedAt: token.CreatedAt.Unix(),
+	}
+
+	if !token.LastUsedAt.IsZero() {
+		presented.LastUsedAt = token.LastUsedAt.Unix()
+	}
+
+	return presented
+}
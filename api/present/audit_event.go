@@ -0,0 +1,21 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func AuditEvent(event db.AuditEvent) atc.AuditEvent {
+	return atc.AuditEvent{
+		ID:       event.ID,
+		Time:     event.Time.Unix(),
+		Team:     event.TeamName,
+		IsAdmin:  event.IsAdmin,
+		Username: event.Username,
+		Route:    event.Route,
+		Method:   event.Method,
+		Path:     event.Path,
+		Params:   event.Params,
+		Status:   event.Status,
+	}
+}
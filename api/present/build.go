@@ -52,6 +52,7 @@ func Build(build db.Build) atc.Build {
 		TeamName:     build.TeamName(),
 		URL:          reqURL,
 		APIURL:       apiURL,
+		RerunOf:      build.RerunOf(),
 	}
 
 	if !build.StartTime().IsZero() {
@@ -0,0 +1,25 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func BuildLogSearchResult(result db.BuildLogSearchResult) atc.BuildLogSearchResult {
+	matches := make([]atc.BuildLogMatch, len(result.Matches))
+	for i, match := range result.Matches {
+		matches[i] = atc.BuildLogMatch{
+			Origin: atc.BuildLogOrigin{
+				ID:     string(match.Origin.ID),
+				Name:   match.Origin.Name,
+				Source: string(match.Origin.Source),
+			},
+			Lines: match.Lines,
+		}
+	}
+
+	return atc.BuildLogSearchResult{
+		Build:   Build(result.Build),
+		Matches: matches,
+	}
+}
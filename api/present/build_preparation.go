@@ -3,6 +3,7 @@ package present
 import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/algorithm"
 )
 
 func BuildPreparation(preparation db.BuildPreparation) atc.BuildPreparation {
@@ -12,6 +13,11 @@ func BuildPreparation(preparation db.BuildPreparation) atc.BuildPreparation {
 		inputs[k] = atc.BuildPreparationStatus(v)
 	}
 
+	var schedulingExplanation atc.SchedulingExplanation
+	if len(preparation.SchedulingExplanation) > 0 {
+		schedulingExplanation = SchedulingExplanation(preparation.SchedulingExplanation)
+	}
+
 	return atc.BuildPreparation{
 		BuildID:             preparation.BuildID,
 		PausedPipeline:      atc.BuildPreparationStatus(preparation.PausedPipeline),
@@ -20,5 +26,21 @@ func BuildPreparation(preparation db.BuildPreparation) atc.BuildPreparation {
 		Inputs:              inputs,
 		InputsSatisfied:     atc.BuildPreparationStatus(preparation.InputsSatisfied),
 		MissingInputReasons: atc.MissingInputReasons(preparation.MissingInputReasons),
+
+		SchedulingExplanation: schedulingExplanation,
+	}
+}
+
+func SchedulingExplanation(explanation algorithm.Explanation) atc.SchedulingExplanation {
+	presented := atc.SchedulingExplanation{}
+
+	for input, inputExplanation := range explanation {
+		presented[input] = atc.InputSchedulingExplanation{
+			Reason:        string(inputExplanation.Reason),
+			Passed:        inputExplanation.Passed,
+			ConflictsWith: inputExplanation.ConflictsWith,
+		}
 	}
+
+	return presented
 }
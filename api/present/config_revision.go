@@ -0,0 +1,16 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func ConfigRevision(revision db.ConfigRevision) atc.ConfigRevision {
+	return atc.ConfigRevision{
+		ID:        revision.ID,
+		Version:   int(revision.Version),
+		Templated: revision.Template != nil,
+		SavedBy:   revision.SavedBy,
+		SavedAt:   revision.SavedAt.Unix(),
+	}
+}
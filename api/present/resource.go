@@ -45,6 +45,9 @@ func Resource(resource atc.ResourceConfig, groups atc.GroupConfigs, dbResource d
 
 		Paused: dbResource.Paused,
 
+		PinnedVersion: atc.Version(dbResource.PinnedVersion),
+		PinComment:    dbResource.PinComment,
+
 		FailingToCheck: dbResource.FailingToCheck(),
 		CheckError:     checkErrString,
 	}
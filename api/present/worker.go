@@ -18,5 +18,6 @@ func Worker(workerInfo db.SavedWorker) atc.Worker {
 		Tags:             workerInfo.Tags,
 		Name:             workerInfo.Name,
 		Team:             workerInfo.TeamName,
+		State:            atc.WorkerState(workerInfo.State),
 	}
 }
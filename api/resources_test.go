@@ -386,6 +386,9 @@ var _ = Describe("Resources API", func() {
 								Resource: db.Resource{
 									Name: "resource-1",
 								},
+								PinnedVersionID: 3,
+								PinnedVersion:   db.Version{"ref": "abc"},
+								PinComment:      "broken after abc",
 							}, true, nil)
 						})
 
@@ -404,6 +407,8 @@ var _ = Describe("Resources API", func() {
 								"groups": ["group-1", "group-2"],
 								"url": "/teams/a-team/pipelines/a-pipeline/resources/resource-1",
 								"paused": true,
+								"pinned_version": {"ref": "abc"},
+								"pin_comment": "broken after abc",
 								"failing_to_check": true,
 								"check_error": "sup"
 							}`))
@@ -578,6 +583,218 @@ var _ = Describe("Resources API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", func() {
+		var (
+			versionID   string
+			requestBody []byte
+			response    *http.Response
+		)
+
+		BeforeEach(func() {
+			versionID = "42"
+			requestBody = nil
+
+			fakePipelineDB.GetResourceReturns(db.SavedResource{
+				Resource: db.Resource{
+					Name: "resource-name",
+				},
+			}, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/"+versionID+"/pin", bytes.NewBuffer(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			It("injects the proper pipelineDB", func() {
+				Expect(teamDB.GetPipelineByNameCallCount()).To(Equal(1))
+				pipelineName := teamDB.GetPipelineByNameArgsForCall(0)
+				Expect(pipelineName).To(Equal("a-pipeline"))
+				Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
+				actualSavedPipeline := pipelineDBFactory.BuildArgsForCall(0)
+				Expect(actualSavedPipeline).To(Equal(expectedSavedPipeline))
+			})
+
+			Context("when pinning the resource succeeds", func() {
+				It("pins the right resource to the version without a comment", func() {
+					Expect(fakePipelineDB.PinResourceCallCount()).To(Equal(1))
+					resourceName, versionedResourceID, comment := fakePipelineDB.PinResourceArgsForCall(0)
+					Expect(resourceName).To(Equal("resource-name"))
+					Expect(versionedResourceID).To(Equal(42))
+					Expect(comment).To(BeEmpty())
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				Context("with a comment", func() {
+					BeforeEach(func() {
+						var err error
+						requestBody, err = json.Marshal(atc.PinRequestBody{
+							Comment: "v2 is broken",
+						})
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("pins the resource with the comment", func() {
+						Expect(fakePipelineDB.PinResourceCallCount()).To(Equal(1))
+						_, _, comment := fakePipelineDB.PinResourceArgsForCall(0)
+						Expect(comment).To(Equal("v2 is broken"))
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+			})
+
+			Context("when the request body is malformed", func() {
+				BeforeEach(func() {
+					requestBody = []byte("{")
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not pin the resource", func() {
+					Expect(fakePipelineDB.PinResourceCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the version id is not a number", func() {
+				BeforeEach(func() {
+					versionID = "latest"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when resource can not be found", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetResourceReturns(db.SavedResource{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the version is not a version of the resource", func() {
+				BeforeEach(func() {
+					fakePipelineDB.PinResourceReturns(db.ErrResourceVersionNotFound)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when pinning the resource fails", func() {
+				BeforeEach(func() {
+					fakePipelineDB.PinResourceReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakePipelineDB.GetResourceReturns(db.SavedResource{
+				Resource: db.Resource{
+					Name: "resource-name",
+				},
+			}, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/unpin", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when unpinning the resource succeeds", func() {
+				It("unpinned the right resource", func() {
+					Expect(fakePipelineDB.UnpinResourceArgsForCall(0)).To(Equal("resource-name"))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when resource can not be found", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetResourceReturns(db.SavedResource{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when unpinning the resource fails", func() {
+				BeforeEach(func() {
+					fakePipelineDB.UnpinResourceReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", func() {
 		var fakeScanner *radarfakes.FakeScanner
 		var checkRequestBody atc.CheckRequestBody
@@ -750,4 +967,161 @@ var _ = Describe("Resources API", func() {
 			})
 		})
 	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", func() {
+		var fakeScanner *radarfakes.FakeScanner
+		var webhookToken string
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakeScanner = new(radarfakes.FakeScanner)
+			fakeScannerFactory.NewResourceScannerReturns(fakeScanner)
+
+			webhookToken = "some-token"
+
+			fakePipelineDB.GetConfigReturns(atc.Config{
+				Resources: atc.ResourceConfigs{
+					{
+						Name:         "resource-name",
+						Type:         "git",
+						WebhookToken: "some-token",
+					},
+				},
+			}, 1, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check/webhook?webhook_token="+webhookToken, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("injects the proper pipelineDB", func() {
+				Expect(teamDB.GetPipelineByNameCallCount()).To(Equal(1))
+				pipelineName := teamDB.GetPipelineByNameArgsForCall(0)
+				Expect(pipelineName).To(Equal("a-pipeline"))
+				Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
+			})
+
+			Context("when the webhook token matches", func() {
+				It("tries to scan with no version specified", func() {
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+					_, actualResourceName, actualFromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+					Expect(actualResourceName).To(Equal("resource-name"))
+					Expect(actualFromVersion).To(BeNil())
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				Context("when the resource already has versions", func() {
+					BeforeEach(func() {
+						fakePipelineDB.GetLatestVersionedResourceReturns(db.SavedVersionedResource{
+							ID: 4,
+							VersionedResource: db.VersionedResource{
+								Resource: "resource-name",
+								Version:  db.Version{"some": "version"},
+							},
+						}, true, nil)
+					})
+
+					It("tries to scan from the latest version", func() {
+						Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+						_, _, actualFromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+						Expect(actualFromVersion).To(Equal(atc.Version{"some": "version"}))
+					})
+				})
+
+				Context("when checking fails with ResourceNotFoundError", func() {
+					BeforeEach(func() {
+						fakeScanner.ScanFromVersionReturns(db.ResourceNotFoundError{})
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when checking the resource fails internally", func() {
+					BeforeEach(func() {
+						fakeScanner.ScanFromVersionReturns(errors.New("welp"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the webhook token does not match", func() {
+				BeforeEach(func() {
+					webhookToken = "wrong-token"
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+
+				It("does not scan", func() {
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when no webhook token is given", func() {
+				BeforeEach(func() {
+					webhookToken = ""
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when the resource has no webhook token configured", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetConfigReturns(atc.Config{
+						Resources: atc.ResourceConfigs{
+							{Name: "resource-name", Type: "git"},
+						},
+					}, 1, true, nil)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+
+				It("does not scan", func() {
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the resource is not in the config", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetConfigReturns(atc.Config{}, 1, true, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the config fails", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetConfigReturns(atc.Config{}, 0, false, errors.New("disaster"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
 })
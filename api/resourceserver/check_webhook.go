@@ -0,0 +1,75 @@
+package resourceserver
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) CheckResourceWebHook(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("check-resource-webhook")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+		webhookToken := r.URL.Query().Get("webhook_token")
+
+		if webhookToken == "" {
+			logger.Info("no-webhook-token", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		pipelineConfig, _, found, err := pipelineDB.GetConfig()
+		if err != nil {
+			logger.Error("failed-to-get-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		resourceConfig, found := pipelineConfig.Resources.Lookup(resourceName)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if resourceConfig.WebhookToken == "" ||
+			subtle.ConstantTimeCompare([]byte(resourceConfig.WebhookToken), []byte(webhookToken)) != 1 {
+			logger.Info("invalid-webhook-token", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var fromVersion atc.Version
+		latestVersion, found, err := pipelineDB.GetLatestVersionedResource(resourceName)
+		if err != nil {
+			logger.Info("failed-to-get-latest-versioned-resource", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if found {
+			fromVersion = atc.Version(latestVersion.Version)
+		}
+
+		scanner := s.scannerFactory.NewResourceScanner(pipelineDB)
+
+		err = scanner.ScanFromVersion(logger, resourceName, fromVersion)
+		switch err.(type) {
+		case db.ResourceNotFoundError:
+			w.WriteHeader(http.StatusNotFound)
+		case error:
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+}
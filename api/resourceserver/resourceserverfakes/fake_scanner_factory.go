@@ -5,14 +5,15 @@ import (
 	"sync"
 
 	"github.com/concourse/atc/api/resourceserver"
+	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/radar"
 )
 
 type FakeScannerFactory struct {
-	NewResourceScannerStub        func(db radar.RadarDB) radar.Scanner
+	NewResourceScannerStub        func(pipelineDB db.PipelineDB) radar.Scanner
 	newResourceScannerMutex       sync.RWMutex
 	newResourceScannerArgsForCall []struct {
-		db radar.RadarDB
+		pipelineDB db.PipelineDB
 	}
 	newResourceScannerReturns struct {
 		result1 radar.Scanner
@@ -21,15 +22,15 @@ type FakeScannerFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeScannerFactory) NewResourceScanner(db radar.RadarDB) radar.Scanner {
+func (fake *FakeScannerFactory) NewResourceScanner(pipelineDB db.PipelineDB) radar.Scanner {
 	fake.newResourceScannerMutex.Lock()
 	fake.newResourceScannerArgsForCall = append(fake.newResourceScannerArgsForCall, struct {
-		db radar.RadarDB
-	}{db})
-	fake.recordInvocation("NewResourceScanner", []interface{}{db})
+		pipelineDB db.PipelineDB
+	}{pipelineDB})
+	fake.recordInvocation("NewResourceScanner", []interface{}{pipelineDB})
 	fake.newResourceScannerMutex.Unlock()
 	if fake.NewResourceScannerStub != nil {
-		return fake.NewResourceScannerStub(db)
+		return fake.NewResourceScannerStub(pipelineDB)
 	} else {
 		return fake.newResourceScannerReturns.result1
 	}
@@ -41,10 +42,10 @@ func (fake *FakeScannerFactory) NewResourceScannerCallCount() int {
 	return len(fake.newResourceScannerArgsForCall)
 }
 
-func (fake *FakeScannerFactory) NewResourceScannerArgsForCall(i int) radar.RadarDB {
+func (fake *FakeScannerFactory) NewResourceScannerArgsForCall(i int) db.PipelineDB {
 	fake.newResourceScannerMutex.RLock()
 	defer fake.newResourceScannerMutex.RUnlock()
-	return fake.newResourceScannerArgsForCall[i].db
+	return fake.newResourceScannerArgsForCall[i].pipelineDB
 }
 
 func (fake *FakeScannerFactory) NewResourceScannerReturns(result1 radar.Scanner) {
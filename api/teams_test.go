@@ -45,6 +45,8 @@ func atcDBTeamEquality(atcTeam atc.Team, dbTeam db.Team) {
 			Expect(dbGitHubTeam.TeamName).To(Equal(atcGitHubTeam.TeamName))
 		}
 	}
+	Expect(dbTeam.NotificationHooks).To(Equal(atcTeam.NotificationHooks))
+	Expect(dbTeam.Roles).To(Equal(atcTeam.Roles))
 }
 
 var _ = Describe("Teams API", func() {
@@ -501,6 +503,224 @@ var _ = Describe("Teams API", func() {
 				})
 			})
 
+			Describe("OIDC Authentication", func() {
+				BeforeEach(func() {
+					team = atc.Team{
+						OIDCAuth: &atc.OIDCAuth{
+							Issuer:       "https://sso.example.com",
+							ClientID:     "Brock Samson",
+							ClientSecret: "09262-8765-001",
+							Groups:       []string{"OSI"},
+						},
+					}
+				})
+
+				Context("when passed a valid team with OIDC Auth", func() {
+					It("responds with 201", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					})
+				})
+
+				Context("when only claims grant membership", func() {
+					BeforeEach(func() {
+						team.OIDCAuth.Groups = nil
+						team.OIDCAuth.Claims = map[string]string{"department": "OSI"}
+					})
+
+					It("responds with 201", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					})
+				})
+
+				Context("ClientSecret not filled in", func() {
+					BeforeEach(func() {
+						team.OIDCAuth.ClientSecret = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("Issuer is not a URL", func() {
+					BeforeEach(func() {
+						team.OIDCAuth.Issuer = "sso.example.com"
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("CACert is invalid", func() {
+					BeforeEach(func() {
+						team.OIDCAuth.CACert = "not a certificate"
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("no groups, users or claims", func() {
+					BeforeEach(func() {
+						team.OIDCAuth.Groups = nil
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
+			Describe("LDAP Authentication", func() {
+				BeforeEach(func() {
+					team = atc.Team{
+						LDAPAuth: &atc.LDAPAuth{
+							Host: "ldap.example.com:636",
+							UserSearch: atc.LDAPUserSearch{
+								BaseDN: "ou=people,dc=example,dc=com",
+							},
+							GroupSearch: atc.LDAPGroupSearch{
+								BaseDN: "ou=groups,dc=example,dc=com",
+							},
+							Groups: []string{"OSI"},
+						},
+					}
+				})
+
+				Context("when passed a valid team with LDAP Auth", func() {
+					It("responds with 201", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					})
+				})
+
+				Context("Host not filled in", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.Host = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("user search BaseDN not filled in", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.UserSearch.BaseDN = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("group search BaseDN not filled in", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.GroupSearch.BaseDN = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("CACert is invalid", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.CACert = "not a certificate"
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("no groups", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.Groups = nil
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
+			Describe("notification hooks", func() {
+				BeforeEach(func() {
+					team = atc.Team{
+						NotificationHooks: []atc.NotificationHook{
+							{Name: "chat", URL: "https://chat.example.com/hook", Secret: "shh"},
+						},
+					}
+				})
+
+				Context("when passed valid notification hooks", func() {
+					It("responds with 201", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					})
+				})
+
+				Context("Name not filled in", func() {
+					BeforeEach(func() {
+						team.NotificationHooks[0].Name = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("URL is not http or https", func() {
+					BeforeEach(func() {
+						team.NotificationHooks[0].URL = "ftp://chat.example.com/hook"
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("Name is used by more than one hook", func() {
+					BeforeEach(func() {
+						team.NotificationHooks = append(team.NotificationHooks, atc.NotificationHook{
+							Name: "chat",
+							URL:  "https://other.example.com/hook",
+						})
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
+			Describe("roles", func() {
+				BeforeEach(func() {
+					team = atc.Team{
+						Roles: []atc.TeamRoleMapping{
+							{Role: atc.RoleOperator, BasicAuthUsers: []string{"some-user"}},
+						},
+					}
+				})
+
+				Context("when passed valid roles", func() {
+					It("responds with 201", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					})
+				})
+
+				Context("Role is unknown", func() {
+					BeforeEach(func() {
+						team.Roles[0].Role = "superuser"
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
 			Context("when there's a problem finding teams", func() {
 				BeforeEach(func() {
 					teamDB.GetTeamReturns(db.SavedTeam{}, false, errors.New("a dingo ate my baby!"))
@@ -537,6 +757,8 @@ var _ = Describe("Teams API", func() {
 					var gitHubAuth *atc.GitHubAuth
 					var uaaAuth *atc.UAAAuth
 					var genericOAuth *atc.GenericOAuth
+					var oidcAuth *atc.OIDCAuth
+					var ldapAuth *atc.LDAPAuth
 
 					BeforeEach(func() {
 						basicAuth = &atc.BasicAuth{
@@ -567,6 +789,27 @@ var _ = Describe("Teams API", func() {
 							TokenURL:      "https://goa.token.url",
 							DisplayName:   "CSI",
 						}
+
+						oidcAuth = &atc.OIDCAuth{
+							Issuer:       "https://sso.example.com",
+							ClientID:     "Dean Venture",
+							ClientSecret: "Giant Boy Detective",
+							Users:        []string{"dean@venture.example.com"},
+							Claims:       map[string]string{"department": "CSI"},
+						}
+
+						ldapAuth = &atc.LDAPAuth{
+							Host:         "ldap.example.com:636",
+							BindDN:       "cn=concourse,dc=example,dc=com",
+							BindPassword: "Giant Boy Detective",
+							UserSearch: atc.LDAPUserSearch{
+								BaseDN: "ou=people,dc=example,dc=com",
+							},
+							GroupSearch: atc.LDAPGroupSearch{
+								BaseDN: "ou=groups,dc=example,dc=com",
+							},
+							Groups: []string{"CSI"},
+						}
 					})
 
 					Context("when passed basic auth credentials", func() {
@@ -666,6 +909,106 @@ var _ = Describe("Teams API", func() {
 						})
 					})
 
+					Context("when passed OIDC auth credentials", func() {
+						BeforeEach(func() {
+							teamDB.UpdateOIDCAuthStub = func(oidcAuth *db.OIDCAuth) (db.SavedTeam, error) {
+								Expect(oidcAuth.Issuer).To(Equal(team.OIDCAuth.Issuer))
+								Expect(oidcAuth.ClientID).To(Equal(team.OIDCAuth.ClientID))
+								Expect(oidcAuth.ClientSecret).To(Equal(team.OIDCAuth.ClientSecret))
+								Expect(oidcAuth.Users).To(Equal(team.OIDCAuth.Users))
+								Expect(oidcAuth.Claims).To(Equal(team.OIDCAuth.Claims))
+
+								savedTeam.OIDCAuth = oidcAuth
+								return savedTeam, nil
+							}
+
+							team.OIDCAuth = oidcAuth
+						})
+
+						It("updates the OIDC auth for that team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(teamDB.UpdateOIDCAuthCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("when passed LDAP auth credentials", func() {
+						BeforeEach(func() {
+							teamDB.UpdateLDAPAuthStub = func(ldapAuth *db.LDAPAuth) (db.SavedTeam, error) {
+								Expect(ldapAuth.Host).To(Equal(team.LDAPAuth.Host))
+								Expect(ldapAuth.BindDN).To(Equal(team.LDAPAuth.BindDN))
+								Expect(ldapAuth.BindPassword).To(Equal(team.LDAPAuth.BindPassword))
+								Expect(ldapAuth.UserSearch.BaseDN).To(Equal(team.LDAPAuth.UserSearch.BaseDN))
+								Expect(ldapAuth.Groups).To(Equal(team.LDAPAuth.Groups))
+
+								savedTeam.LDAPAuth = ldapAuth
+								return savedTeam, nil
+							}
+
+							team.LDAPAuth = ldapAuth
+						})
+
+						It("updates the LDAP auth for that team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(teamDB.UpdateLDAPAuthCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("when passed notification hooks", func() {
+						var notificationHooks []atc.NotificationHook
+
+						BeforeEach(func() {
+							notificationHooks = []atc.NotificationHook{
+								{Name: "chat", URL: "https://chat.example.com/hook", Secret: "shh"},
+							}
+
+							team.NotificationHooks = notificationHooks
+						})
+
+						It("updates the notification hooks for that team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(teamDB.UpdateNotificationHooksCallCount()).To(Equal(1))
+							Expect(teamDB.UpdateNotificationHooksArgsForCall(0)).To(Equal(notificationHooks))
+						})
+
+						Context("when updating the notification hooks fails", func() {
+							BeforeEach(func() {
+								teamDB.UpdateNotificationHooksReturns(db.SavedTeam{}, errors.New("nope"))
+							})
+
+							It("returns 500 Internal Server Error", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+					})
+
+					Context("when passed roles", func() {
+						var roles []atc.TeamRoleMapping
+
+						BeforeEach(func() {
+							roles = []atc.TeamRoleMapping{
+								{Role: atc.RoleViewer, GitHubOrganizations: []string{"some-org"}},
+							}
+
+							team.Roles = roles
+						})
+
+						It("updates the roles for that team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(teamDB.UpdateRolesCallCount()).To(Equal(1))
+							Expect(teamDB.UpdateRolesArgsForCall(0)).To(Equal(roles))
+						})
+
+						Context("when updating the roles fails", func() {
+							BeforeEach(func() {
+								teamDB.UpdateRolesReturns(db.SavedTeam{}, errors.New("nope"))
+							})
+
+							It("returns 500 Internal Server Error", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+					})
+
 				})
 			})
 
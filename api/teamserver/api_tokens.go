@@ -0,0 +1,117 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) CreateAPIToken(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("create-api-token")
+
+	teamName := r.FormValue(":team_name")
+
+	var request atc.CreateAPITokenRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "malformed request: %s", err)
+		return
+	}
+
+	if request.Name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "a token must have a name")
+		return
+	}
+
+	if request.Role == "" {
+		request.Role = atc.RoleMember
+	}
+
+	if !request.Role.IsValid() {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "unknown role: %s", request.Role)
+		return
+	}
+
+	token, err := auth.GenerateAPIToken()
+	if err != nil {
+		logger.Error("failed-to-generate-api-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	savedToken, err := teamDB.CreateAPIToken(request.Name, request.Role, auth.HashAPIToken(token))
+	if err == db.ErrAPITokenAlreadyExists {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprintf(w, "%s", err)
+		return
+	}
+
+	if err != nil {
+		logger.Error("failed-to-create-api-token", err, lager.Data{"name": request.Name})
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presented := present.APIToken(savedToken)
+	presented.Token = token
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	json.NewEncoder(w).Encode(presented)
+}
+
+func (s *Server) ListAPITokens(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-api-tokens")
+
+	teamDB := s.teamDBFactory.GetTeamDB(r.FormValue(":team_name"))
+
+	tokens, err := teamDB.GetAPITokens()
+	if err != nil {
+		logger.Error("failed-to-get-api-tokens", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presented := make([]atc.APIToken, len(tokens))
+	for i, token := range tokens {
+		presented[i] = present.APIToken(token)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(presented)
+}
+
+func (s *Server) DeleteAPIToken(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("delete-api-token")
+
+	tokenName := r.FormValue(":token_name")
+	teamDB := s.teamDBFactory.GetTeamDB(r.FormValue(":team_name"))
+
+	deleted, err := teamDB.DeleteAPIToken(tokenName)
+	if err != nil {
+		logger.Error("failed-to-delete-api-token", err, lager.Data{"name": tokenName})
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !deleted {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
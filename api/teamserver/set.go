@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/concourse/atc/api/present"
 	"github.com/concourse/atc/auth"
@@ -62,6 +64,20 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		_, err = teamDB.UpdateNotificationHooks(team.NotificationHooks)
+		if err != nil {
+			hLog.Error("failed-to-update-notification-hooks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_, err = teamDB.UpdateRoles(team.Roles)
+		if err != nil {
+			hLog.Error("failed-to-update-roles", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
 		w.WriteHeader(http.StatusOK)
 	} else if authTeam.IsAdmin() {
 		hLog.Debug("creating team")
@@ -102,6 +118,16 @@ func (s *Server) updateCredentials(team db.Team, teamDB db.TeamDB) error {
 		return err
 	}
 
+	_, err = teamDB.UpdateOIDCAuth(team.OIDCAuth)
+	if err != nil {
+		return err
+	}
+
+	_, err = teamDB.UpdateLDAPAuth(team.LDAPAuth)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -166,5 +192,92 @@ func (s *Server) validate(team db.Team) error {
 		}
 	}
 
+	if team.OIDCAuth != nil {
+		if team.OIDCAuth.ClientID == "" || team.OIDCAuth.ClientSecret == "" {
+			return errors.New("OIDC auth requires ClientID and ClientSecret")
+		}
+
+		issuerURL, err := url.Parse(team.OIDCAuth.Issuer)
+		if err != nil || (issuerURL.Scheme != "http" && issuerURL.Scheme != "https") || issuerURL.Host == "" {
+			return errors.New("OIDC auth requires an http or https Issuer")
+		}
+
+		if team.OIDCAuth.CACert != "" {
+			block, _ := pem.Decode([]byte(team.OIDCAuth.CACert))
+			invalidCertErr := errors.New("OIDC certificate is invalid")
+
+			if block == nil {
+				return invalidCertErr
+			}
+
+			_, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return invalidCertErr
+			}
+		}
+
+		if len(team.OIDCAuth.Groups) == 0 &&
+			len(team.OIDCAuth.Users) == 0 &&
+			len(team.OIDCAuth.Claims) == 0 {
+			return errors.New("OIDC auth requires at least one Group, User, or Claim")
+		}
+	}
+
+	if team.LDAPAuth != nil {
+		if team.LDAPAuth.Host == "" {
+			return errors.New("LDAP auth requires a Host")
+		}
+
+		if team.LDAPAuth.UserSearch.BaseDN == "" {
+			return errors.New("LDAP auth requires a user search BaseDN")
+		}
+
+		if team.LDAPAuth.GroupSearch.BaseDN == "" {
+			return errors.New("LDAP auth requires a group search BaseDN")
+		}
+
+		if team.LDAPAuth.CACert != "" {
+			block, _ := pem.Decode([]byte(team.LDAPAuth.CACert))
+			invalidCertErr := errors.New("LDAP certificate is invalid")
+
+			if block == nil {
+				return invalidCertErr
+			}
+
+			_, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return invalidCertErr
+			}
+		}
+
+		if len(team.LDAPAuth.Groups) == 0 {
+			return errors.New("LDAP auth requires at least one Group")
+		}
+	}
+
+	hookNames := map[string]bool{}
+	for _, hook := range team.NotificationHooks {
+		if hook.Name == "" {
+			return errors.New("notification hook requires a Name")
+		}
+
+		if hookNames[hook.Name] {
+			return fmt.Errorf("notification hook '%s' is configured more than once", hook.Name)
+		}
+
+		hookNames[hook.Name] = true
+
+		hookURL, err := url.Parse(hook.URL)
+		if err != nil || (hookURL.Scheme != "http" && hookURL.Scheme != "https") || hookURL.Host == "" {
+			return fmt.Errorf("notification hook '%s' requires an http or https URL", hook.Name)
+		}
+	}
+
+	for _, mapping := range team.Roles {
+		if !mapping.Role.IsValid() {
+			return fmt.Errorf("unknown role '%s'", mapping.Role)
+		}
+	}
+
 	return nil
 }
@@ -56,6 +56,7 @@ var _ = Describe("Workers API", func() {
 								Platform: "freebsd",
 								Tags:     []string{"demon"},
 							},
+							State: db.WorkerStateRunning,
 						},
 						{
 							WorkerInfo: db.WorkerInfo{
@@ -93,6 +94,7 @@ var _ = Describe("Workers API", func() {
 							},
 							Platform: "freebsd",
 							Tags:     []string{"demon"},
+							State:    atc.WorkerStateRunning,
 						},
 						{
 							GardenAddr:       "1.2.3.4:8888",
@@ -320,6 +322,16 @@ var _ = Describe("Workers API", func() {
 				})
 			})
 
+			Context("when the worker is retiring", func() {
+				BeforeEach(func() {
+					workerDB.SaveWorkerReturns(db.SavedWorker{}, db.ErrWorkerRetiring)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
 			Context("when the TTL is invalid", func() {
 				BeforeEach(func() {
 					ttl = "invalid-duration"
@@ -371,4 +383,209 @@ var _ = Describe("Workers API", func() {
 			})
 		})
 	})
+
+	Describe("PUT /api/v1/workers/:worker_name/land", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/workers/some-worker/land", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetSystemReturns(true, true)
+			})
+
+			Context("when the worker can be landed", func() {
+				BeforeEach(func() {
+					workerDB.LandWorkerReturns(true, nil)
+				})
+
+				It("lands the worker", func() {
+					Expect(workerDB.LandWorkerCallCount()).To(Equal(1))
+					Expect(workerDB.LandWorkerArgsForCall(0)).To(Equal("some-worker"))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when the request is from an admin", func() {
+				BeforeEach(func() {
+					userContextReader.GetSystemReturns(false, false)
+					userContextReader.GetTeamReturns("main", 1, true, true)
+					workerDB.LandWorkerReturns(true, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when the request is from neither the tsa nor an admin", func() {
+				BeforeEach(func() {
+					userContextReader.GetSystemReturns(false, false)
+					userContextReader.GetTeamReturns("some-team", 5, false, true)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+
+				It("does not land the worker", func() {
+					Expect(workerDB.LandWorkerCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the worker does not exist", func() {
+				BeforeEach(func() {
+					workerDB.LandWorkerReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the worker has stalled", func() {
+				BeforeEach(func() {
+					workerDB.LandWorkerReturns(true, db.ErrWorkerStalled)
+				})
+
+				It("returns 400 with the reason", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("worker is stalled")))
+				})
+			})
+
+			Context("when landing the worker fails", func() {
+				BeforeEach(func() {
+					workerDB.LandWorkerReturns(false, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not land the worker", func() {
+				Expect(workerDB.LandWorkerCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/workers/:worker_name/retire", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/workers/some-worker/retire", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as the tsa", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetSystemReturns(true, true)
+				workerDB.RetireWorkerReturns(true, nil)
+			})
+
+			It("retires the worker", func() {
+				Expect(workerDB.RetireWorkerCallCount()).To(Equal(1))
+				Expect(workerDB.RetireWorkerArgsForCall(0)).To(Equal("some-worker"))
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			Context("when the worker does not exist", func() {
+				BeforeEach(func() {
+					workerDB.RetireWorkerReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/workers/:worker_name/prune", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/workers/some-worker/prune", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("main", 1, true, true)
+				workerDB.PruneWorkerReturns(true, nil)
+			})
+
+			It("prunes the worker", func() {
+				Expect(workerDB.PruneWorkerCallCount()).To(Equal(1))
+				Expect(workerDB.PruneWorkerArgsForCall(0)).To(Equal("some-worker"))
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			Context("when the worker is still running", func() {
+				BeforeEach(func() {
+					workerDB.PruneWorkerReturns(true, db.ErrCannotPruneRunningWorker)
+				})
+
+				It("returns 400 with the reason", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte(db.ErrCannotPruneRunningWorker.Error())))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
 })
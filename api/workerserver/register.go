@@ -95,6 +95,12 @@ func (s *Server) RegisterWorker(w http.ResponseWriter, r *http.Request) {
 		Name:             registration.Name,
 		StartTime:        registration.StartTime,
 	}, ttl)
+	if err == db.ErrWorkerRetiring {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprintf(w, "%s", err)
+		return
+	}
+
 	if err != nil {
 		logger.Error("failed-to-save-worker", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -19,6 +19,9 @@ type Server struct {
 type WorkerDB interface {
 	SaveWorker(db.WorkerInfo, time.Duration) (db.SavedWorker, error)
 	Workers() ([]db.SavedWorker, error)
+	LandWorker(string) (bool, error)
+	RetireWorker(string) (bool, error)
+	PruneWorker(string) (bool, error)
 }
 
 func NewServer(
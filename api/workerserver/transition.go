@@ -0,0 +1,60 @@
+package workerserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) LandWorker(w http.ResponseWriter, r *http.Request) {
+	s.transitionWorker(s.logger.Session("land-worker"), s.db.LandWorker, w, r)
+}
+
+func (s *Server) RetireWorker(w http.ResponseWriter, r *http.Request) {
+	s.transitionWorker(s.logger.Session("retire-worker"), s.db.RetireWorker, w, r)
+}
+
+func (s *Server) PruneWorker(w http.ResponseWriter, r *http.Request) {
+	s.transitionWorker(s.logger.Session("prune-worker"), s.db.PruneWorker, w, r)
+}
+
+// Workers may only be transitioned by themselves, via the TSA, or by an admin.
+func (s *Server) transitionWorker(
+	logger lager.Logger,
+	transition func(string) (bool, error),
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	isSystem, _ := r.Context().Value("system").(bool)
+	authTeam, authTeamFound := auth.GetTeam(r)
+
+	if !isSystem && !(authTeamFound && authTeam.IsAdmin()) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	workerName := r.FormValue(":worker_name")
+
+	found, err := transition(workerName)
+	switch err {
+	case nil:
+	case db.ErrWorkerStalled, db.ErrCannotPruneRunningWorker:
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "%s", err)
+		return
+	default:
+		logger.Error("failed-to-transition-worker", err, lager.Data{"worker": workerName})
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
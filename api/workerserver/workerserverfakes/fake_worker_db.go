@@ -27,6 +27,33 @@ type FakeWorkerDB struct {
 		result1 []db.SavedWorker
 		result2 error
 	}
+	LandWorkerStub        func(string) (bool, error)
+	landWorkerMutex       sync.RWMutex
+	landWorkerArgsForCall []struct {
+		arg1 string
+	}
+	landWorkerReturns struct {
+		result1 bool
+		result2 error
+	}
+	RetireWorkerStub        func(string) (bool, error)
+	retireWorkerMutex       sync.RWMutex
+	retireWorkerArgsForCall []struct {
+		arg1 string
+	}
+	retireWorkerReturns struct {
+		result1 bool
+		result2 error
+	}
+	PruneWorkerStub        func(string) (bool, error)
+	pruneWorkerMutex       sync.RWMutex
+	pruneWorkerArgsForCall []struct {
+		arg1 string
+	}
+	pruneWorkerReturns struct {
+		result1 bool
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -92,6 +119,108 @@ func (fake *FakeWorkerDB) WorkersReturns(result1 []db.SavedWorker, result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeWorkerDB) LandWorker(arg1 string) (bool, error) {
+	fake.landWorkerMutex.Lock()
+	fake.landWorkerArgsForCall = append(fake.landWorkerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("LandWorker", []interface{}{arg1})
+	fake.landWorkerMutex.Unlock()
+	if fake.LandWorkerStub != nil {
+		return fake.LandWorkerStub(arg1)
+	} else {
+		return fake.landWorkerReturns.result1, fake.landWorkerReturns.result2
+	}
+}
+
+func (fake *FakeWorkerDB) LandWorkerCallCount() int {
+	fake.landWorkerMutex.RLock()
+	defer fake.landWorkerMutex.RUnlock()
+	return len(fake.landWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerDB) LandWorkerArgsForCall(i int) string {
+	fake.landWorkerMutex.RLock()
+	defer fake.landWorkerMutex.RUnlock()
+	return fake.landWorkerArgsForCall[i].arg1
+}
+
+func (fake *FakeWorkerDB) LandWorkerReturns(result1 bool, result2 error) {
+	fake.LandWorkerStub = nil
+	fake.landWorkerReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerDB) RetireWorker(arg1 string) (bool, error) {
+	fake.retireWorkerMutex.Lock()
+	fake.retireWorkerArgsForCall = append(fake.retireWorkerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("RetireWorker", []interface{}{arg1})
+	fake.retireWorkerMutex.Unlock()
+	if fake.RetireWorkerStub != nil {
+		return fake.RetireWorkerStub(arg1)
+	} else {
+		return fake.retireWorkerReturns.result1, fake.retireWorkerReturns.result2
+	}
+}
+
+func (fake *FakeWorkerDB) RetireWorkerCallCount() int {
+	fake.retireWorkerMutex.RLock()
+	defer fake.retireWorkerMutex.RUnlock()
+	return len(fake.retireWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerDB) RetireWorkerArgsForCall(i int) string {
+	fake.retireWorkerMutex.RLock()
+	defer fake.retireWorkerMutex.RUnlock()
+	return fake.retireWorkerArgsForCall[i].arg1
+}
+
+func (fake *FakeWorkerDB) RetireWorkerReturns(result1 bool, result2 error) {
+	fake.RetireWorkerStub = nil
+	fake.retireWorkerReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerDB) PruneWorker(arg1 string) (bool, error) {
+	fake.pruneWorkerMutex.Lock()
+	fake.pruneWorkerArgsForCall = append(fake.pruneWorkerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("PruneWorker", []interface{}{arg1})
+	fake.pruneWorkerMutex.Unlock()
+	if fake.PruneWorkerStub != nil {
+		return fake.PruneWorkerStub(arg1)
+	} else {
+		return fake.pruneWorkerReturns.result1, fake.pruneWorkerReturns.result2
+	}
+}
+
+func (fake *FakeWorkerDB) PruneWorkerCallCount() int {
+	fake.pruneWorkerMutex.RLock()
+	defer fake.pruneWorkerMutex.RUnlock()
+	return len(fake.pruneWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerDB) PruneWorkerArgsForCall(i int) string {
+	fake.pruneWorkerMutex.RLock()
+	defer fake.pruneWorkerMutex.RUnlock()
+	return fake.pruneWorkerArgsForCall[i].arg1
+}
+
+func (fake *FakeWorkerDB) PruneWorkerReturns(result1 bool, result2 error) {
+	fake.PruneWorkerStub = nil
+	fake.pruneWorkerReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorkerDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -99,6 +228,12 @@ func (fake *FakeWorkerDB) Invocations() map[string][][]interface{} {
 	defer fake.saveWorkerMutex.RUnlock()
 	fake.workersMutex.RLock()
 	defer fake.workersMutex.RUnlock()
+	fake.landWorkerMutex.RLock()
+	defer fake.landWorkerMutex.RUnlock()
+	fake.retireWorkerMutex.RLock()
+	defer fake.retireWorkerMutex.RUnlock()
+	fake.pruneWorkerMutex.RLock()
+	defer fake.pruneWorkerMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -0,0 +1,16 @@
+package atc
+
+type APIToken struct {
+	Name       string   `json:"name"`
+	Role       TeamRole `json:"role"`
+	CreatedAt  int64    `json:"created_at"`
+	LastUsedAt int64    `json:"last_used_at,omitempty"`
+
+	// Token is only ever returned when the token is created.
+	Token string `json:"token,omitempty"`
+}
+
+type CreateAPITokenRequest struct {
+	Name string   `json:"name"`
+	Role TeamRole `json:"role,omitempty"`
+}
@@ -21,10 +21,15 @@ import (
 	"github.com/concourse/atc/api/buildserver"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/provider"
+	"github.com/concourse/atc/blobstore"
+	"github.com/concourse/atc/buildarchiver"
 	"github.com/concourse/atc/buildreaper"
 	"github.com/concourse/atc/builds"
 	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/containerkeepaliver"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/local"
+	"github.com/concourse/atc/creds/vault"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/migrations"
 	"github.com/concourse/atc/engine"
@@ -32,6 +37,7 @@ import (
 	"github.com/concourse/atc/leaserunner"
 	"github.com/concourse/atc/lostandfound"
 	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/notifications"
 	"github.com/concourse/atc/pipelines"
 	"github.com/concourse/atc/radar"
 	"github.com/concourse/atc/resource"
@@ -72,12 +78,15 @@ type ATCCommand struct {
 
 	SessionSigningKey FileFlag `long:"session-signing-key" description:"File containing an RSA private key, used to sign session tokens."`
 
-	ResourceCheckingInterval     time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
-	OldResourceGracePeriod       time.Duration `long:"old-resource-grace-period" default:"5m" description:"How long to cache the result of a get step after a newer version of the resource is found."`
-	ResourceCacheCleanupInterval time.Duration `long:"resource-cache-cleanup-interval" default:"30s" description:"Interval on which to cleanup old caches of resources."`
+	ResourceCheckingInterval            time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
+	ResourceWithWebhookCheckingInterval time.Duration `long:"resource-with-webhook-checking-interval" default:"1h" description:"Interval on which to check for new versions of resources that have a webhook token configured."`
+	OldResourceGracePeriod              time.Duration `long:"old-resource-grace-period" default:"5m" description:"How long to cache the result of a get step after a newer version of the resource is found."`
+	ResourceCacheCleanupInterval        time.Duration `long:"resource-cache-cleanup-interval" default:"30s" description:"Interval on which to cleanup old caches of resources."`
 
 	CLIArtifactsDir DirFlag `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
+	ContainerPlacementStrategy string `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-containers" description:"Method by which a worker is chosen from those able to run a container."`
+
 	Developer struct {
 		DevelopmentMode bool `short:"d" long:"development-mode"  description:"Lax security rules to make local development easier."`
 		Noop            bool `short:"n" long:"noop"              description:"Don't actually do any automatic scheduling or checking."`
@@ -99,6 +108,35 @@ type ATCCommand struct {
 
 	GenericOAuth atc.GenericOAuthFlag `group:"Generic OAuth Authentication (Allows access to ALL authenticated users)" namespace:"generic-oauth"`
 
+	OIDCAuth atc.OIDCAuthFlag `group:"OIDC Authentication" namespace:"oidc-auth"`
+
+	Vault vault.VaultManager `group:"Vault Credential Management" namespace:"vault"`
+
+	LocalCredentials local.LocalManager `group:"Local Credential Management" namespace:"local-credentials"`
+
+	BuildLogArchive struct {
+		After time.Duration `long:"after" description:"Move the events of builds that finished longer ago than this out of the database and into the configured store."`
+
+		Dir DirFlag `long:"dir" description:"Directory in which to store archived build events."`
+
+		S3Bucket          string `long:"s3-bucket"            description:"S3 bucket in which to store archived build events."`
+		S3Endpoint        string `long:"s3-endpoint"          description:"Endpoint of an S3-compatible service to use instead of AWS."`
+		S3Region          string `long:"s3-region"            default:"us-east-1" description:"Region of the S3 bucket."`
+		S3AccessKeyID     string `long:"s3-access-key-id"     description:"Access key used to authenticate with S3. If not specified, the default AWS credential chain is used."`
+		S3SecretAccessKey string `long:"s3-secret-access-key" description:"Secret key used to authenticate with S3."`
+		S3ForcePathStyle  bool   `long:"s3-force-path-style"  description:"Use path-style bucket addressing, as required by many S3-compatible services."`
+	} `group:"Build Log Archive" namespace:"build-log-archive"`
+
+	ContainerLimits struct {
+		DefaultMemory uint64 `long:"default-memory" description:"Memory limit in bytes for task containers that do not specify one."`
+		DefaultCPU    uint64 `long:"default-cpu"    description:"CPU shares for task containers that do not specify them."`
+		DefaultDisk   uint64 `long:"default-disk"   description:"Disk limit in bytes for task containers that do not specify one."`
+
+		MaxMemory uint64 `long:"max-memory" description:"Largest memory limit in bytes a task container may have. Larger or missing limits are lowered to this."`
+		MaxCPU    uint64 `long:"max-cpu"    description:"Most CPU shares a task container may have. Larger or missing limits are lowered to this."`
+		MaxDisk   uint64 `long:"max-disk"   description:"Largest disk limit in bytes a task container may have. Larger or missing limits are lowered to this."`
+	} `group:"Task Container Limits" namespace:"container-limits"`
+
 	Metrics struct {
 		HostName   string            `long:"metrics-host-name"   description:"Host string to attach to emitted metrics."`
 		Tags       []string          `long:"metrics-tag"         description:"Tag to attach to emitted metrics. Can be specified multiple times." value-name:"TAG"`
@@ -109,6 +147,8 @@ type ATCCommand struct {
 
 		RiemannHost string `long:"riemann-host"                description:"Riemann server address to emit metrics to."`
 		RiemannPort uint16 `long:"riemann-port" default:"5555" description:"Port of the Riemann server to emit metrics to."`
+
+		Prometheus bool `long:"prometheus" description:"Expose metrics for Prometheus to scrape at /metrics on the debug listener."`
 	} `group:"Metrics & Diagnostics"`
 }
 
@@ -129,11 +169,9 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 
 	logger, reconfigurableSink := cmd.constructLogger()
 
-	go metric.PeriodicallyEmit(logger.Session("periodic-metrics"), 10*time.Second)
+	cmd.configureMetrics(logger)
 
-	if cmd.Metrics.RiemannHost != "" {
-		cmd.configureMetrics(logger)
-	}
+	go metric.PeriodicallyEmit(logger.Session("periodic-metrics"), 10*time.Second)
 
 	dbConn, err := cmd.constructDBConn(logger)
 	if err != nil {
@@ -142,26 +180,50 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 	listener := pq.NewListener(cmd.PostgresDataSource, time.Second, time.Minute, nil)
 	bus := db.NewNotificationsBus(listener, dbConn)
 
-	sqlDB := db.NewSQL(dbConn, bus)
+	buildLogStore, err := cmd.constructBuildLogStore()
+	if err != nil {
+		return nil, err
+	}
+
+	var buildLogArchive db.BuildLogArchive
+	if buildLogStore != nil {
+		buildLogArchive = buildarchiver.NewArchive(buildLogStore)
+	}
+
+	sqlDB := db.NewSQL(dbConn, bus, buildLogArchive)
+
 	trackerFactory := resource.NewTrackerFactory()
 	resourceFetcherFactory := resource.NewFetcherFactory(sqlDB, clock.NewClock())
-	workerClient := cmd.constructWorkerPool(logger, sqlDB, trackerFactory, resourceFetcherFactory)
+	workerClient, err := cmd.constructWorkerPool(logger, sqlDB, trackerFactory, resourceFetcherFactory)
+	if err != nil {
+		return nil, err
+	}
 
 	tracker := trackerFactory.TrackerFor(workerClient)
 	resourceFetcher := resourceFetcherFactory.FetcherFor(workerClient)
-	teamDBFactory := db.NewTeamDBFactory(dbConn, bus)
-	engine := cmd.constructEngine(workerClient, tracker, resourceFetcher, teamDBFactory)
+	teamDBFactory := db.NewTeamDBFactory(dbConn, bus, buildLogArchive)
+
+	variablesFactory, err := cmd.constructVariablesFactory(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	engine := cmd.constructEngine(workerClient, tracker, resourceFetcher, teamDBFactory, variablesFactory, sqlDB)
 
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
 		tracker,
 		cmd.ResourceCheckingInterval,
+		cmd.ResourceWithWebhookCheckingInterval,
 		engine,
+		variablesFactory,
 	)
 
 	radarScannerFactory := radar.NewScannerFactory(
 		tracker,
 		cmd.ResourceCheckingInterval,
+		cmd.ResourceWithWebhookCheckingInterval,
 		cmd.ExternalURL.String(),
+		variablesFactory,
 	)
 
 	signingKey, err := cmd.loadOrGenerateSigningKey()
@@ -191,7 +253,7 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 
 	drain := make(chan struct{})
 
-	pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus)
+	pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, buildLogArchive)
 	apiHandler, err := cmd.constructAPIHandler(
 		logger,
 		reconfigurableSink,
@@ -338,12 +400,29 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 			clock.NewClock(),
 			30*time.Second,
 		)},
+
+		{"notification-deliverer", leaserunner.NewRunner(
+			logger.Session("notification-deliverer-runner"),
+			notifications.NewDeliverer(
+				logger.Session("notification-deliverer"),
+				sqlDB,
+				&http.Client{Timeout: 30 * time.Second},
+			),
+			"notification-deliverer",
+			sqlDB,
+			clock.NewClock(),
+			10*time.Second,
+		)},
 	}
 
 	if cmd.Worker.GardenURL.URL() != nil {
 		members = cmd.appendStaticWorker(logger, sqlDB, members)
 	}
 
+	if buildLogStore != nil && cmd.BuildLogArchive.After > 0 {
+		members = cmd.appendBuildArchiver(logger, sqlDB, buildLogStore, members)
+	}
+
 	if httpsHandler != nil {
 		cert, err := tls.LoadX509KeyPair(string(cmd.TLSCert), string(cmd.TLSKey))
 		if err != nil {
@@ -425,7 +504,7 @@ func (cmd *ATCCommand) oauthBaseURL() string {
 }
 
 func (cmd *ATCCommand) authConfigured() bool {
-	return cmd.BasicAuth.IsConfigured() || cmd.GitHubAuth.IsConfigured() || cmd.UAAAuth.IsConfigured() || cmd.GenericOAuth.IsConfigured()
+	return cmd.BasicAuth.IsConfigured() || cmd.GitHubAuth.IsConfigured() || cmd.UAAAuth.IsConfigured() || cmd.GenericOAuth.IsConfigured() || cmd.OIDCAuth.IsConfigured()
 }
 
 func (cmd *ATCCommand) gitHubAuthConfigured() bool {
@@ -479,6 +558,78 @@ func (cmd *ATCCommand) validate() error {
 		}
 	}
 
+	if cmd.OIDCAuth.IsConfigured() {
+		if cmd.ExternalURL.URL() == nil {
+			errs = multierror.Append(
+				errs,
+				errors.New("must specify --external-url to use OIDC"),
+			)
+		}
+
+		err := cmd.OIDCAuth.Validate()
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	configuredCredentialManagers := 0
+	for _, manager := range cmd.credentialManagers() {
+		if !manager.IsConfigured() {
+			continue
+		}
+
+		configuredCredentialManagers++
+
+		err := manager.Validate()
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	if configuredCredentialManagers > 1 {
+		errs = multierror.Append(
+			errs,
+			errors.New("must configure at most one credential manager"),
+		)
+	}
+
+	if cmd.BuildLogArchive.Dir != "" && cmd.BuildLogArchive.S3Bucket != "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must configure at most one of --build-log-archive-dir and --build-log-archive-s3-bucket"),
+		)
+	}
+
+	if cmd.BuildLogArchive.After > 0 && cmd.BuildLogArchive.Dir == "" && cmd.BuildLogArchive.S3Bucket == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --build-log-archive-dir or --build-log-archive-s3-bucket to archive build logs"),
+		)
+	}
+
+	for _, message := range cmd.defaultContainerLimits().Validate() {
+		errs = multierror.Append(
+			errs,
+			fmt.Errorf("invalid default container limits: %s", strings.TrimSpace(message)),
+		)
+	}
+
+	for _, message := range cmd.maxContainerLimits().Validate() {
+		errs = multierror.Append(
+			errs,
+			fmt.Errorf("invalid maximum container limits: %s", strings.TrimSpace(message)),
+		)
+	}
+
+	if exceedsLimit(cmd.ContainerLimits.DefaultMemory, cmd.ContainerLimits.MaxMemory) ||
+		exceedsLimit(cmd.ContainerLimits.DefaultCPU, cmd.ContainerLimits.MaxCPU) ||
+		exceedsLimit(cmd.ContainerLimits.DefaultDisk, cmd.ContainerLimits.MaxDisk) {
+		errs = multierror.Append(
+			errs,
+			errors.New("default container limits must not exceed the maximum container limits"),
+		)
+	}
+
 	tlsFlagCount := 0
 	if cmd.TLSBindPort != 0 {
 		tlsFlagCount++
@@ -556,12 +707,26 @@ func (cmd *ATCCommand) configureMetrics(logger lager.Logger) {
 		host, _ = os.Hostname()
 	}
 
+	emitters := []metric.Emitter{}
+
+	if cmd.Metrics.RiemannHost != "" {
+		emitters = append(emitters, metric.NewRiemannEmitter(
+			fmt.Sprintf("%s:%d", cmd.Metrics.RiemannHost, cmd.Metrics.RiemannPort),
+			cmd.Metrics.Tags,
+		))
+	}
+
+	if cmd.Metrics.Prometheus {
+		prometheusEmitter := metric.NewPrometheusEmitter()
+		http.Handle("/metrics", prometheusEmitter.Handler())
+		emitters = append(emitters, prometheusEmitter)
+	}
+
 	metric.Initialize(
 		logger.Session("metrics"),
-		fmt.Sprintf("%s:%d", cmd.Metrics.RiemannHost, cmd.Metrics.RiemannPort),
 		host,
-		cmd.Metrics.Tags,
 		cmd.Metrics.Attributes,
+		emitters...,
 	)
 }
 
@@ -584,7 +749,12 @@ func (cmd *ATCCommand) constructWorkerPool(
 	sqlDB *db.SQLDB,
 	trackerFactory resource.TrackerFactory,
 	resourceFetcherFactory resource.FetcherFactory,
-) worker.Client {
+) (worker.Client, error) {
+	strategy, err := worker.NewPlacementStrategy(cmd.ContainerPlacementStrategy)
+	if err != nil {
+		return nil, err
+	}
+
 	return worker.NewPool(
 		worker.NewDBWorkerProvider(
 			logger,
@@ -595,7 +765,8 @@ func (cmd *ATCCommand) constructWorkerPool(
 			},
 			image.NewFactory(trackerFactory, resourceFetcherFactory),
 		),
-	)
+		strategy,
+	), nil
 }
 
 func (cmd *ATCCommand) loadOrGenerateSigningKey() (*rsa.PrivateKey, error) {
@@ -709,31 +880,109 @@ func (cmd *ATCCommand) configureAuthForDefaultTeam(teamDBFactory db.TeamDBFactor
 		return err
 	}
 
+	var oidcAuth *db.OIDCAuth
+	if cmd.OIDCAuth.IsConfigured() {
+		caCert := ""
+		if cmd.OIDCAuth.CACert != "" {
+			caCertFileContents, err := ioutil.ReadFile(string(cmd.OIDCAuth.CACert))
+			if err != nil {
+				return err
+			}
+			caCert = string(caCertFileContents)
+		}
+
+		oidcAuth = &db.OIDCAuth{
+			DisplayName:  cmd.OIDCAuth.DisplayName,
+			Issuer:       cmd.OIDCAuth.Issuer,
+			ClientID:     cmd.OIDCAuth.ClientID,
+			ClientSecret: cmd.OIDCAuth.ClientSecret,
+			Scopes:       cmd.OIDCAuth.Scopes,
+			Groups:       cmd.OIDCAuth.Groups,
+			GroupsClaim:  cmd.OIDCAuth.GroupsClaim,
+			Users:        cmd.OIDCAuth.Users,
+			UserClaim:    cmd.OIDCAuth.UserClaim,
+			Claims:       cmd.OIDCAuth.Claims,
+			CACert:       caCert,
+		}
+	}
+
+	_, err = teamDB.UpdateOIDCAuth(oidcAuth)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+func (cmd *ATCCommand) credentialManagers() []creds.Manager {
+	return []creds.Manager{
+		cmd.Vault,
+		cmd.LocalCredentials,
+	}
+}
+
+func (cmd *ATCCommand) constructVariablesFactory(logger lager.Logger) (creds.VariablesFactory, error) {
+	for _, manager := range cmd.credentialManagers() {
+		if manager.IsConfigured() {
+			return manager.NewVariablesFactory(logger.Session("credential-manager"))
+		}
+	}
+
+	return creds.NoopVariablesFactory{}, nil
+}
+
+func (cmd *ATCCommand) defaultContainerLimits() atc.ContainerLimits {
+	return atc.ContainerLimits{
+		Memory: cmd.ContainerLimits.DefaultMemory,
+		CPU:    cmd.ContainerLimits.DefaultCPU,
+		Disk:   cmd.ContainerLimits.DefaultDisk,
+	}
+}
+
+func (cmd *ATCCommand) maxContainerLimits() atc.ContainerLimits {
+	return atc.ContainerLimits{
+		Memory: cmd.ContainerLimits.MaxMemory,
+		CPU:    cmd.ContainerLimits.MaxCPU,
+		Disk:   cmd.ContainerLimits.MaxDisk,
+	}
+}
+
+func exceedsLimit(limit uint64, max uint64) bool {
+	return max != 0 && limit > max
+}
+
 func (cmd *ATCCommand) constructEngine(
 	workerClient worker.Client,
 	tracker resource.Tracker,
 	resourceFetcher resource.Fetcher,
 	teamDBFactory db.TeamDBFactory,
+	variablesFactory creds.VariablesFactory,
+	notifierDB notifications.NotifierDB,
 ) engine.Engine {
 	gardenFactory := exec.NewGardenFactory(
 		workerClient,
 		tracker,
 		resourceFetcher,
+		cmd.defaultContainerLimits(),
+		cmd.maxContainerLimits(),
+		clock.NewClock(),
 	)
 
 	execV2Engine := engine.NewExecEngine(
 		gardenFactory,
 		engine.NewBuildDelegateFactory(),
 		teamDBFactory,
+		variablesFactory,
 		cmd.ExternalURL.String(),
 	)
 
 	execV1Engine := engine.NewExecV1DummyEngine()
 
-	return engine.NewDBEngine(engine.Engines{execV2Engine, execV1Engine})
+	return engine.NewDBEngine(
+		engine.Engines{execV2Engine, execV1Engine},
+		notifications.NewNotifier(notifierDB, teamDBFactory),
+		cmd.ExternalURL.String(),
+	)
 }
 
 func (cmd *ATCCommand) constructHTTPHandler(
@@ -773,11 +1022,24 @@ func (cmd *ATCCommand) constructAPIHandler(
 	radarSchedulerFactory pipelines.RadarSchedulerFactory,
 	radarScannerFactory radar.ScannerFactory,
 ) (http.Handler, error) {
-	authValidator := auth.JWTValidator{
+	jwtValidator := auth.JWTValidator{
 		PublicKey: &signingKey.PublicKey,
 	}
 
-	getTokenValidator := auth.NewTeamAuthValidator(teamDBFactory, authValidator)
+	apiTokenValidator := auth.NewAPITokenValidator(logger, sqlDB)
+
+	authValidator := auth.AnyValidator{jwtValidator, apiTokenValidator}
+
+	userContextReader := auth.AnyUserContextReader{
+		auth.JWTReader{PublicKey: &signingKey.PublicKey},
+		apiTokenValidator,
+	}
+
+	ldapFactory := provider.NewLDAPFactory()
+
+	// API tokens can't be exchanged for a session token; that would let a
+	// token's role outlive the token.
+	getTokenValidator := auth.NewTeamAuthValidator(logger, teamDBFactory, ldapFactory, jwtValidator)
 
 	checkPipelineAccessHandlerFactory := auth.NewCheckPipelineAccessHandlerFactory(
 		pipelineDBFactory,
@@ -793,11 +1055,16 @@ func (cmd *ATCCommand) constructAPIHandler(
 		wrappa.NewAPIAuthWrappa(
 			authValidator,
 			getTokenValidator,
-			auth.JWTReader{PublicKey: &signingKey.PublicKey},
+			userContextReader,
 			checkPipelineAccessHandlerFactory,
 			checkBuildReadAccessHandlerFactory,
 			checkBuildWriteAccessHandlerFactory,
 		),
+		wrappa.NewAuditWrappa(
+			logger,
+			sqlDB,
+			userContextReader,
+		),
 		wrappa.NewConcourseVersionWrappa(Version),
 	}
 
@@ -808,6 +1075,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 
 		auth.NewTokenGenerator(signingKey),
 		providerFactory,
+		ldapFactory,
 		cmd.oauthBaseURL(),
 
 		pipelineDBFactory,
@@ -820,6 +1088,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 		sqlDB, // volumeserver.VolumesDB
 		sqlDB, // pipes.PipeDB
 		sqlDB, // db.PipelinesDB
+		sqlDB, // auditserver.AuditDB
 
 		config.ValidateConfig,
 		cmd.PeerURL.String(),
@@ -926,3 +1195,50 @@ func (cmd *ATCCommand) appendStaticWorker(
 		},
 	)
 }
+
+func (cmd *ATCCommand) constructBuildLogStore() (blobstore.Store, error) {
+	if cmd.BuildLogArchive.Dir != "" {
+		return blobstore.NewLocalStore(string(cmd.BuildLogArchive.Dir)), nil
+	}
+
+	if cmd.BuildLogArchive.S3Bucket != "" {
+		return blobstore.NewS3Store(blobstore.S3Config{
+			Bucket:          cmd.BuildLogArchive.S3Bucket,
+			Endpoint:        cmd.BuildLogArchive.S3Endpoint,
+			Region:          cmd.BuildLogArchive.S3Region,
+			AccessKeyID:     cmd.BuildLogArchive.S3AccessKeyID,
+			SecretAccessKey: cmd.BuildLogArchive.S3SecretAccessKey,
+			ForcePathStyle:  cmd.BuildLogArchive.S3ForcePathStyle,
+		})
+	}
+
+	return nil, nil
+}
+
+func (cmd *ATCCommand) appendBuildArchiver(
+	logger lager.Logger,
+	sqlDB *db.SQLDB,
+	store blobstore.Store,
+	members []grouper.Member,
+) []grouper.Member {
+	return append(members,
+		grouper.Member{
+			Name: "build-archiver",
+			Runner: leaserunner.NewRunner(
+				logger.Session("build-archiver-runner"),
+				buildarchiver.NewBuildArchiver(
+					logger.Session("build-archiver"),
+					sqlDB,
+					store,
+					clock.NewClock(),
+					cmd.BuildLogArchive.After,
+					100,
+				),
+				"build-archiver",
+				sqlDB,
+				clock.NewClock(),
+				time.Minute,
+			),
+		},
+	)
+}
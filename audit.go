@@ -0,0 +1,17 @@
+package atc
+
+type AuditEvent struct {
+	ID   int   `json:"id"`
+	Time int64 `json:"time"`
+
+	Team     string `json:"team"`
+	IsAdmin  bool   `json:"is_admin"`
+	Username string `json:"username,omitempty"`
+
+	Route  string            `json:"route"`
+	Method string            `json:"method"`
+	Path   string            `json:"path"`
+	Params map[string]string `json:"params"`
+
+	Status int `json:"status"`
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+)
+
+// AnyValidator authenticates requests which any of its validators
+// authenticate.
+type AnyValidator []Validator
+
+func (validators AnyValidator) IsAuthenticated(r *http.Request) bool {
+	for _, validator := range validators {
+		if validator.IsAuthenticated(r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AnyUserContextReader reads the user context from the first of its readers
+// which finds it.
+type AnyUserContextReader []UserContextReader
+
+func (readers AnyUserContextReader) GetTeam(r *http.Request) (string, int, bool, bool) {
+	for _, reader := range readers {
+		teamName, teamID, isAdmin, found := reader.GetTeam(r)
+		if found {
+			return teamName, teamID, isAdmin, true
+		}
+	}
+
+	return "", 0, false, false
+}
+
+func (readers AnyUserContextReader) GetSystem(r *http.Request) (bool, bool) {
+	for _, reader := range readers {
+		isSystem, found := reader.GetSystem(r)
+		if found {
+			return isSystem, true
+		}
+	}
+
+	return false, false
+}
+
+func (readers AnyUserContextReader) GetRole(r *http.Request) (atc.TeamRole, bool) {
+	for _, reader := range readers {
+		role, found := reader.GetRole(r)
+		if found {
+			return role, true
+		}
+	}
+
+	return "", false
+}
+
+func (readers AnyUserContextReader) GetUsername(r *http.Request) (string, bool) {
+	for _, reader := range readers {
+		username, found := reader.GetUsername(r)
+		if found {
+			return username, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// APITokenPrefix begins every API token, telling them apart from JWTs.
+const APITokenPrefix = "atc-api-token-"
+
+// GenerateAPIToken returns a new random API token.
+func GenerateAPIToken() (string, error) {
+	secret := make([]byte, 32)
+
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return APITokenPrefix + hex.EncodeToString(secret), nil
+}
+
+// HashAPIToken returns the hash which is stored in place of the token. Tokens
+// are long and random, so they need no salt.
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func getAPIToken(r *http.Request) (string, bool) {
+	if ah := r.Header.Get("Authorization"); ah != "" {
+		if len(ah) > 6 && strings.ToUpper(ah[0:6]) == "BEARER" {
+			token := ah[7:]
+			if strings.HasPrefix(token, APITokenPrefix) {
+				return token, true
+			}
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+//go:generate counterfeiter . APITokenDB
+
+type APITokenDB interface {
+	UseAPIToken(tokenHash string) (db.APIToken, bool, error)
+}
+
+// apiTokenCacheTTL bounds how long a token is trusted without looking it up
+// again, and so how long a deleted token keeps working and how stale its last
+// used time may be.
+const apiTokenCacheTTL = 10 * time.Second
+
+// APITokenValidator authenticates requests bearing an API token as the token's
+// team, with the token's role. It is both a Validator and a
+// UserContextReader.
+type APITokenValidator struct {
+	logger lager.Logger
+	db     APITokenDB
+
+	cache     map[string]cachedAPIToken
+	cacheLock sync.Mutex
+}
+
+type cachedAPIToken struct {
+	token     db.APIToken
+	expiresAt time.Time
+}
+
+func NewAPITokenValidator(logger lager.Logger, db APITokenDB) *APITokenValidator {
+	return &APITokenValidator{
+		logger: logger,
+		db:     db,
+		cache:  map[string]cachedAPIToken{},
+	}
+}
+
+func (validator *APITokenValidator) IsAuthenticated(r *http.Request) bool {
+	_, found := validator.lookup(r)
+	return found
+}
+
+// GetTeam only reports a token of the admin team as an admin if it has the
+// owner role, so that tokens with lesser roles cannot act on other teams.
+func (validator *APITokenValidator) GetTeam(r *http.Request) (string, int, bool, bool) {
+	token, found := validator.lookup(r)
+	if !found {
+		return "", 0, false, false
+	}
+
+	isAdmin := token.IsAdmin && token.Role == atc.RoleOwner
+
+	return token.TeamName, token.TeamID, isAdmin, true
+}
+
+func (validator *APITokenValidator) GetSystem(r *http.Request) (bool, bool) {
+	return false, false
+}
+
+func (validator *APITokenValidator) GetRole(r *http.Request) (atc.TeamRole, bool) {
+	token, found := validator.lookup(r)
+	if !found {
+		return "", false
+	}
+
+	return token.Role, true
+}
+
+// GetUsername names the token, so that what it does can be told apart from
+// what the team's users do.
+func (validator *APITokenValidator) GetUsername(r *http.Request) (string, bool) {
+	token, found := validator.lookup(r)
+	if !found {
+		return "", false
+	}
+
+	return "token:" + token.Name, true
+}
+
+func (validator *APITokenValidator) lookup(r *http.Request) (db.APIToken, bool) {
+	apiToken, found := getAPIToken(r)
+	if !found {
+		return db.APIToken{}, false
+	}
+
+	hash := HashAPIToken(apiToken)
+
+	validator.cacheLock.Lock()
+	defer validator.cacheLock.Unlock()
+
+	now := time.Now()
+
+	cached, found := validator.cache[hash]
+	if found && now.Before(cached.expiresAt) {
+		return cached.token, true
+	}
+
+	delete(validator.cache, hash)
+
+	token, found, err := validator.db.UseAPIToken(hash)
+	if err != nil {
+		validator.logger.Error("failed-to-use-api-token", err)
+		return db.APIToken{}, false
+	}
+
+	if !found {
+		return db.APIToken{}, false
+	}
+
+	validator.cache[hash] = cachedAPIToken{
+		token:     token,
+		expiresAt: now.Add(apiTokenCacheTTL),
+	}
+
+	return token, true
+}
@@ -0,0 +1,151 @@
+package auth_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("APITokenValidator", func() {
+	var (
+		fakeAPITokenDB *authfakes.FakeAPITokenDB
+		validator      *auth.APITokenValidator
+
+		token   string
+		request *http.Request
+	)
+
+	BeforeEach(func() {
+		fakeAPITokenDB = new(authfakes.FakeAPITokenDB)
+		validator = auth.NewAPITokenValidator(lagertest.NewTestLogger("test"), fakeAPITokenDB)
+
+		var err error
+		token, err = auth.GenerateAPIToken()
+		Expect(err).NotTo(HaveOccurred())
+
+		request, err = http.NewRequest("GET", "http://example.com", nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("generates distinct prefixed tokens", func() {
+		otherToken, err := auth.GenerateAPIToken()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(strings.HasPrefix(token, auth.APITokenPrefix)).To(BeTrue())
+		Expect(otherToken).NotTo(Equal(token))
+		Expect(auth.HashAPIToken(token)).NotTo(Equal(auth.HashAPIToken(otherToken)))
+		Expect(auth.HashAPIToken(token)).NotTo(ContainSubstring(token))
+	})
+
+	Context("when the request bears an API token", func() {
+		BeforeEach(func() {
+			request.Header.Set("Authorization", "Bearer "+token)
+		})
+
+		Context("when the token exists", func() {
+			BeforeEach(func() {
+				fakeAPITokenDB.UseAPITokenReturns(db.APIToken{
+					TeamID:   42,
+					TeamName: "some-team",
+					IsAdmin:  true,
+					Name:     "some-bot",
+					Role:     atc.RoleOperator,
+				}, true, nil)
+			})
+
+			It("looks it up by its hash", func() {
+				Expect(validator.IsAuthenticated(request)).To(BeTrue())
+				Expect(fakeAPITokenDB.UseAPITokenArgsForCall(0)).To(Equal(auth.HashAPIToken(token)))
+			})
+
+			It("reads the context from the token", func() {
+				teamName, teamID, isAdmin, found := validator.GetTeam(request)
+				Expect(found).To(BeTrue())
+				Expect(teamName).To(Equal("some-team"))
+				Expect(teamID).To(Equal(42))
+				Expect(isAdmin).To(BeFalse())
+
+				role, found := validator.GetRole(request)
+				Expect(found).To(BeTrue())
+				Expect(role).To(Equal(atc.RoleOperator))
+
+				username, found := validator.GetUsername(request)
+				Expect(found).To(BeTrue())
+				Expect(username).To(Equal("token:some-bot"))
+
+				_, found = validator.GetSystem(request)
+				Expect(found).To(BeFalse())
+			})
+
+			Context("when the token has the owner role", func() {
+				BeforeEach(func() {
+					fakeAPITokenDB.UseAPITokenReturns(db.APIToken{
+						TeamID:   42,
+						TeamName: "some-team",
+						IsAdmin:  true,
+						Name:     "some-bot",
+						Role:     atc.RoleOwner,
+					}, true, nil)
+				})
+
+				It("acts as an admin of the admin team", func() {
+					_, _, isAdmin, found := validator.GetTeam(request)
+					Expect(found).To(BeTrue())
+					Expect(isAdmin).To(BeTrue())
+				})
+			})
+
+			It("does not look the token up again for every read", func() {
+				validator.IsAuthenticated(request)
+				validator.GetTeam(request)
+				validator.GetRole(request)
+				validator.GetUsername(request)
+
+				Expect(fakeAPITokenDB.UseAPITokenCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the token does not exist", func() {
+			BeforeEach(func() {
+				fakeAPITokenDB.UseAPITokenReturns(db.APIToken{}, false, nil)
+			})
+
+			It("is not authenticated", func() {
+				Expect(validator.IsAuthenticated(request)).To(BeFalse())
+
+				_, _, _, found := validator.GetTeam(request)
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when looking up the token fails", func() {
+			BeforeEach(func() {
+				fakeAPITokenDB.UseAPITokenReturns(db.APIToken{}, false, errors.New("nope"))
+			})
+
+			It("is not authenticated", func() {
+				Expect(validator.IsAuthenticated(request)).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when the request bears some other token", func() {
+		BeforeEach(func() {
+			request.Header.Set("Authorization", "Bearer some.jwt.token")
+		})
+
+		It("is not authenticated, and does not look it up", func() {
+			Expect(validator.IsAuthenticated(request)).To(BeFalse())
+			Expect(fakeAPITokenDB.UseAPITokenCallCount()).To(BeZero())
+		})
+	})
+})
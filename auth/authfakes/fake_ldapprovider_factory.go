@@ -0,0 +1,80 @@
+// This file was generated by counterfeiter
+package authfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/provider"
+	"github.com/concourse/atc/db"
+)
+
+type FakeLDAPProviderFactory struct {
+	GetLDAPProviderStub        func(db.SavedTeam) (provider.LDAPProvider, bool)
+	getLDAPProviderMutex       sync.RWMutex
+	getLDAPProviderArgsForCall []struct {
+		arg1 db.SavedTeam
+	}
+	getLDAPProviderReturns struct {
+		result1 provider.LDAPProvider
+		result2 bool
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLDAPProviderFactory) GetLDAPProvider(arg1 db.SavedTeam) (provider.LDAPProvider, bool) {
+	fake.getLDAPProviderMutex.Lock()
+	fake.getLDAPProviderArgsForCall = append(fake.getLDAPProviderArgsForCall, struct {
+		arg1 db.SavedTeam
+	}{arg1})
+	fake.recordInvocation("GetLDAPProvider", []interface{}{arg1})
+	fake.getLDAPProviderMutex.Unlock()
+	if fake.GetLDAPProviderStub != nil {
+		return fake.GetLDAPProviderStub(arg1)
+	} else {
+		return fake.getLDAPProviderReturns.result1, fake.getLDAPProviderReturns.result2
+	}
+}
+
+func (fake *FakeLDAPProviderFactory) GetLDAPProviderCallCount() int {
+	fake.getLDAPProviderMutex.RLock()
+	defer fake.getLDAPProviderMutex.RUnlock()
+	return len(fake.getLDAPProviderArgsForCall)
+}
+
+func (fake *FakeLDAPProviderFactory) GetLDAPProviderArgsForCall(i int) db.SavedTeam {
+	fake.getLDAPProviderMutex.RLock()
+	defer fake.getLDAPProviderMutex.RUnlock()
+	return fake.getLDAPProviderArgsForCall[i].arg1
+}
+
+func (fake *FakeLDAPProviderFactory) GetLDAPProviderReturns(result1 provider.LDAPProvider, result2 bool) {
+	fake.GetLDAPProviderStub = nil
+	fake.getLDAPProviderReturns = struct {
+		result1 provider.LDAPProvider
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeLDAPProviderFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getLDAPProviderMutex.RLock()
+	defer fake.getLDAPProviderMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeLDAPProviderFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ auth.LDAPProviderFactory = new(FakeLDAPProviderFactory)
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"sync"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 )
 
@@ -29,6 +30,24 @@ type FakeUserContextReader struct {
 		result1 bool
 		result2 bool
 	}
+	GetRoleStub        func(r *http.Request) (atc.TeamRole, bool)
+	getRoleMutex       sync.RWMutex
+	getRoleArgsForCall []struct {
+		r *http.Request
+	}
+	getRoleReturns struct {
+		result1 atc.TeamRole
+		result2 bool
+	}
+	GetUsernameStub        func(r *http.Request) (string, bool)
+	getUsernameMutex       sync.RWMutex
+	getUsernameArgsForCall []struct {
+		r *http.Request
+	}
+	getUsernameReturns struct {
+		result1 string
+		result2 bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -103,6 +122,74 @@ func (fake *FakeUserContextReader) GetSystemReturns(result1 bool, result2 bool)
 	}{result1, result2}
 }
 
+func (fake *FakeUserContextReader) GetRole(r *http.Request) (atc.TeamRole, bool) {
+	fake.getRoleMutex.Lock()
+	fake.getRoleArgsForCall = append(fake.getRoleArgsForCall, struct {
+		r *http.Request
+	}{r})
+	fake.recordInvocation("GetRole", []interface{}{r})
+	fake.getRoleMutex.Unlock()
+	if fake.GetRoleStub != nil {
+		return fake.GetRoleStub(r)
+	} else {
+		return fake.getRoleReturns.result1, fake.getRoleReturns.result2
+	}
+}
+
+func (fake *FakeUserContextReader) GetRoleCallCount() int {
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
+	return len(fake.getRoleArgsForCall)
+}
+
+func (fake *FakeUserContextReader) GetRoleArgsForCall(i int) *http.Request {
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
+	return fake.getRoleArgsForCall[i].r
+}
+
+func (fake *FakeUserContextReader) GetRoleReturns(result1 atc.TeamRole, result2 bool) {
+	fake.GetRoleStub = nil
+	fake.getRoleReturns = struct {
+		result1 atc.TeamRole
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeUserContextReader) GetUsername(r *http.Request) (string, bool) {
+	fake.getUsernameMutex.Lock()
+	fake.getUsernameArgsForCall = append(fake.getUsernameArgsForCall, struct {
+		r *http.Request
+	}{r})
+	fake.recordInvocation("GetUsername", []interface{}{r})
+	fake.getUsernameMutex.Unlock()
+	if fake.GetUsernameStub != nil {
+		return fake.GetUsernameStub(r)
+	} else {
+		return fake.getUsernameReturns.result1, fake.getUsernameReturns.result2
+	}
+}
+
+func (fake *FakeUserContextReader) GetUsernameCallCount() int {
+	fake.getUsernameMutex.RLock()
+	defer fake.getUsernameMutex.RUnlock()
+	return len(fake.getUsernameArgsForCall)
+}
+
+func (fake *FakeUserContextReader) GetUsernameArgsForCall(i int) *http.Request {
+	fake.getUsernameMutex.RLock()
+	defer fake.getUsernameMutex.RUnlock()
+	return fake.getUsernameArgsForCall[i].r
+}
+
+func (fake *FakeUserContextReader) GetUsernameReturns(result1 string, result2 bool) {
+	fake.GetUsernameStub = nil
+	fake.getUsernameReturns = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *FakeUserContextReader) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -110,6 +197,10 @@ func (fake *FakeUserContextReader) Invocations() map[string][][]interface{} {
 	defer fake.getTeamMutex.RUnlock()
 	fake.getSystemMutex.RLock()
 	defer fake.getSystemMutex.RUnlock()
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
+	fake.getUsernameMutex.RLock()
+	defer fake.getUsernameMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+)
+
+type checkRoleHandler struct {
+	handler  http.Handler
+	role     atc.TeamRole
+	rejector Rejector
+}
+
+// CheckRoleHandler rejects requests from users whose role within their team
+// does not include the given role. Requests without a team are left for the
+// wrapped handler to authenticate or authorize.
+func CheckRoleHandler(
+	handler http.Handler,
+	role atc.TeamRole,
+	rejector Rejector,
+) http.Handler {
+	return checkRoleHandler{
+		handler:  handler,
+		role:     role,
+		rejector: rejector,
+	}
+}
+
+func (h checkRoleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if IsAuthenticated(r) {
+		authTeam, found := GetTeam(r)
+		if found && !authTeam.HasRole(h.role) {
+			h.rejector.Forbidden(w, r)
+			return
+		}
+	}
+
+	h.handler.ServeHTTP(w, r)
+}
@@ -0,0 +1,128 @@
+package auth_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckRoleHandler", func() {
+	var (
+		fakeValidator         *authfakes.FakeValidator
+		fakeUserContextReader *authfakes.FakeUserContextReader
+		fakeRejector          *authfakes.FakeRejector
+
+		server *httptest.Server
+		client *http.Client
+	)
+
+	simpleHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buffer := bytes.NewBufferString("simple ")
+
+		io.Copy(w, buffer)
+		io.Copy(w, r.Body)
+	})
+
+	BeforeEach(func() {
+		fakeValidator = new(authfakes.FakeValidator)
+		fakeUserContextReader = new(authfakes.FakeUserContextReader)
+		fakeRejector = new(authfakes.FakeRejector)
+
+		fakeRejector.ForbiddenStub = func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "nope", http.StatusForbidden)
+		}
+
+		server = httptest.NewServer(
+			auth.WrapHandler( // for setting context on the request
+				auth.CheckRoleHandler(
+					simpleHandler,
+					atc.RoleOperator,
+					fakeRejector,
+				),
+				fakeValidator,
+				fakeUserContextReader,
+			),
+		)
+
+		client = &http.Client{
+			Transport: &http.Transport{},
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when a request is made", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("GET", server.URL, bytes.NewBufferString("hello"))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the request is authenticated", func() {
+			BeforeEach(func() {
+				fakeValidator.IsAuthenticatedReturns(true)
+				fakeUserContextReader.GetTeamReturns("some-team", 1, false, true)
+			})
+
+			Context("when the user's role includes the required role", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetRoleReturns(atc.RoleMember, true)
+				})
+
+				It("proxies to the handler", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					responseBody, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(responseBody)).To(Equal("simple hello"))
+				})
+			})
+
+			Context("when the user's role does not include the required role", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetRoleReturns(atc.RoleViewer, true)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when the token does not have a role", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetRoleReturns("", false)
+				})
+
+				It("treats the user as an owner", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+		})
+
+		Context("when the request is not authenticated", func() {
+			BeforeEach(func() {
+				fakeValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("leaves it to the handler", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakeRejector.ForbiddenCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth/verifier"
 	"github.com/concourse/atc/db"
 	"golang.org/x/net/context"
@@ -18,8 +19,25 @@ func (v NoopVerifier) Verify(logger lager.Logger, client *http.Client) (bool, er
 	return true, nil
 }
 
+// NoopRoleVerifier never grants a role, as there is nothing to map roles to
+// for a generic OAuth provider.
+type NoopRoleVerifier struct{}
+
+func (v NoopRoleVerifier) VerifyRole(logger lager.Logger, client *http.Client) (atc.TeamRole, bool, error) {
+	return "", false, nil
+}
+
+// NoopUserIdentifier does not know who the user is, as a generic OAuth
+// provider has no standard way of telling.
+type NoopUserIdentifier struct{}
+
+func (i NoopUserIdentifier) Username(logger lager.Logger, client *http.Client) (string, error) {
+	return "", nil
+}
+
 func NewProvider(
 	genericOAuth *db.GenericOAuth,
+	roles []atc.TeamRoleMapping,
 	redirectURL string,
 ) Provider {
 	endpoint := oauth2.Endpoint{}
@@ -29,7 +47,9 @@ func NewProvider(
 	}
 
 	return Provider{
-		Verifier: NoopVerifier{},
+		Verifier:       NoopVerifier{},
+		RoleVerifier:   NoopRoleVerifier{},
+		UserIdentifier: NoopUserIdentifier{},
 		Config: ConfigOverride{
 			Config: oauth2.Config{
 				ClientID:     genericOAuth.ClientID,
@@ -44,6 +64,8 @@ func NewProvider(
 
 type Provider struct {
 	verifier.Verifier
+	verifier.RoleVerifier
+	verifier.UserIdentifier
 	Config ConfigOverride
 }
 
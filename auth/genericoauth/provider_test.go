@@ -24,7 +24,7 @@ var _ = Describe("Generic OAuth Provider", func() {
 	)
 
 	JustBeforeEach(func() {
-		goaProvider = genericoauth.NewProvider(dbGenericOAuth, redirectURI)
+		goaProvider = genericoauth.NewProvider(dbGenericOAuth, nil, redirectURI)
 	})
 
 	BeforeEach(func() {
@@ -59,6 +59,12 @@ var _ = Describe("Generic OAuth Provider", func() {
 		Expect(verifyResult).To(Equal(true))
 	})
 
+	It("doesn't grant any role", func() {
+		_, found, err := goaProvider.VerifyRole(lagertest.NewTestLogger("test"), nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
 	Context("Auth URL params are configured", func() {
 		BeforeEach(func() {
 			redirectURI = "redirect-uri"
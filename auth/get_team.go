@@ -1,16 +1,22 @@
 package auth
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+)
 
 type Team interface {
 	Name() string
 	IsAdmin() bool
 	IsAuthorized(teamName string) bool
+	HasRole(role atc.TeamRole) bool
 }
 
 type team struct {
 	name    string
 	isAdmin bool
+	role    atc.TeamRole
 }
 
 func (t *team) Name() string {
@@ -25,6 +31,10 @@ func (t *team) IsAuthorized(teamName string) bool {
 	return t.name == teamName
 }
 
+func (t *team) HasRole(role atc.TeamRole) bool {
+	return t.role.Includes(role)
+}
+
 func GetTeam(r *http.Request) (Team, bool) {
 	teamName, namePresent := r.Context().Value(teamNameKey).(string)
 	isAdmin, adminPresent := r.Context().Value(isAdminKey).(bool)
@@ -33,8 +43,22 @@ func GetTeam(r *http.Request) (Team, bool) {
 		return nil, false
 	}
 
+	role, rolePresent := r.Context().Value(roleKey).(atc.TeamRole)
+	if !rolePresent {
+		// tokens issued before roles existed had full access to their team
+		role = atc.RoleOwner
+	}
+
 	return &team{
 		name:    teamName,
 		isAdmin: isAdmin,
+		role:    role,
 	}, true
 }
+
+// GetUsername returns the name of the basic auth user the request's token was
+// issued to. Tokens issued through OAuth do not name a user.
+func GetUsername(r *http.Request) (string, bool) {
+	username, found := r.Context().Value(usernameKey).(string)
+	return username, found
+}
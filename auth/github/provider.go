@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth/verifier"
 	"github.com/concourse/atc/db"
 	"golang.org/x/net/context"
@@ -22,6 +23,8 @@ type Provider interface {
 
 	OAuthClient
 	Verifier
+	RoleVerifier
+	UserIdentifier
 }
 
 type OAuthClient interface {
@@ -34,8 +37,17 @@ type Verifier interface {
 	Verify(lager.Logger, *http.Client) (bool, error)
 }
 
+type RoleVerifier interface {
+	VerifyRole(lager.Logger, *http.Client) (atc.TeamRole, bool, error)
+}
+
+type UserIdentifier interface {
+	Username(lager.Logger, *http.Client) (string, error)
+}
+
 func NewProvider(
 	gitHubAuth *db.GitHubAuth,
+	roles []atc.TeamRoleMapping,
 	redirectURL string,
 ) Provider {
 	client := NewClient(gitHubAuth.APIURL)
@@ -52,6 +64,8 @@ func NewProvider(
 			NewOrganizationVerifier(gitHubAuth.Organizations, client),
 			NewUserVerifier(gitHubAuth.Users, client),
 		),
+		RoleVerifier:   verifier.NewRoleBasket(roleVerifiers(roles, client)),
+		UserIdentifier: NewUserIdentifier(client),
 		Config: &oauth2.Config{
 			ClientID:     gitHubAuth.ClientID,
 			ClientSecret: gitHubAuth.ClientSecret,
@@ -70,6 +84,28 @@ type gitHubProvider struct {
 	// Client(context.Context, *oauth2.Token) *http.Client
 
 	verifier.Verifier
+	verifier.RoleVerifier
+	verifier.UserIdentifier
+}
+
+func roleVerifiers(roles []atc.TeamRoleMapping, client Client) map[atc.TeamRole]verifier.Verifier {
+	verifiers := map[atc.TeamRole]verifier.Verifier{}
+	for _, mapping := range roles {
+		teams := []Team{}
+		for _, team := range mapping.GitHubTeams {
+			teams = append(teams, Team{
+				Name:         team.TeamName,
+				Organization: team.OrganizationName,
+			})
+		}
+
+		verifiers[mapping.Role] = verifier.NewVerifierBasket(
+			NewTeamVerifier(teams, client),
+			NewOrganizationVerifier(mapping.GitHubOrganizations, client),
+			NewUserVerifier(mapping.GitHubUsers, client),
+		)
+	}
+	return verifiers
 }
 
 func dbTeamsToGitHubTeams(dbteams []db.GitHubTeam) []Team {
@@ -0,0 +1,28 @@
+package github
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/auth/verifier"
+)
+
+type UserIdentifier struct {
+	gitHubClient Client
+}
+
+func NewUserIdentifier(gitHubClient Client) verifier.UserIdentifier {
+	return UserIdentifier{
+		gitHubClient: gitHubClient,
+	}
+}
+
+func (identifier UserIdentifier) Username(logger lager.Logger, httpClient *http.Client) (string, error) {
+	currentUser, err := identifier.gitHubClient.CurrentUser(httpClient)
+	if err != nil {
+		logger.Error("failed-to-get-current-user", err)
+		return "", err
+	}
+
+	return currentUser, nil
+}
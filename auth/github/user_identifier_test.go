@@ -0,0 +1,72 @@
+package github_test
+
+import (
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/auth/github/githubfakes"
+	"github.com/concourse/atc/auth/verifier"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UserIdentifier", func() {
+	var (
+		fakeClient *githubfakes.FakeClient
+
+		identifier verifier.UserIdentifier
+	)
+
+	BeforeEach(func() {
+		fakeClient = new(githubfakes.FakeClient)
+
+		identifier = NewUserIdentifier(fakeClient)
+	})
+
+	Describe("Username", func() {
+		var (
+			httpClient *http.Client
+
+			username    string
+			usernameErr error
+		)
+
+		BeforeEach(func() {
+			httpClient = &http.Client{}
+		})
+
+		JustBeforeEach(func() {
+			username, usernameErr = identifier.Username(lagertest.NewTestLogger("test"), httpClient)
+		})
+
+		Context("when the client returns the current user", func() {
+			BeforeEach(func() {
+				fakeClient.CurrentUserReturns("some-user", nil)
+			})
+
+			It("returns their login", func() {
+				Expect(usernameErr).NotTo(HaveOccurred())
+				Expect(username).To(Equal("some-user"))
+			})
+
+			It("uses the given HTTP client", func() {
+				Expect(fakeClient.CurrentUserArgsForCall(0)).To(Equal(httpClient))
+			})
+		})
+
+		Context("when the client fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeClient.CurrentUserReturns("", disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(usernameErr).To(Equal(disaster))
+			})
+		})
+	})
+})
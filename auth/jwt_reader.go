@@ -4,6 +4,7 @@ import (
 	"crypto/rsa"
 	"net/http"
 
+	"github.com/concourse/atc"
 	jwt "github.com/dgrijalva/jwt-go"
 )
 
@@ -47,3 +48,43 @@ func (jr JWTReader) GetSystem(r *http.Request) (bool, bool) {
 
 	return isSystemInterface.(bool), true
 }
+
+func (jr JWTReader) GetRole(r *http.Request) (atc.TeamRole, bool) {
+	token, err := getJWT(r, jr.PublicKey)
+	if err != nil {
+		return "", false
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	roleInterface, roleOK := claims[roleClaimKey]
+	if !roleOK {
+		return "", false
+	}
+
+	role, roleOK := roleInterface.(string)
+	if !roleOK {
+		return "", false
+	}
+
+	return atc.TeamRole(role), true
+}
+
+func (jr JWTReader) GetUsername(r *http.Request) (string, bool) {
+	token, err := getJWT(r, jr.PublicKey)
+	if err != nil {
+		return "", false
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	usernameInterface, usernameOK := claims[usernameClaimKey]
+	if !usernameOK {
+		return "", false
+	}
+
+	username, usernameOK := usernameInterface.(string)
+	if !usernameOK || username == "" {
+		return "", false
+	}
+
+	return username, true
+}
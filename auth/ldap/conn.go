@@ -0,0 +1,52 @@
+package ldap
+
+import (
+	"crypto/tls"
+
+	ldap "gopkg.in/ldap.v2"
+)
+
+//go:generate counterfeiter . Conn
+
+// Conn is the part of an LDAP connection used to authenticate users.
+// *ldap.Conn is one.
+type Conn interface {
+	Bind(username string, password string) error
+	Search(*ldap.SearchRequest) (*ldap.SearchResult, error)
+	Close()
+}
+
+//go:generate counterfeiter . Dialer
+
+type Dialer interface {
+	Dial(host string, tlsConfig *tls.Config, startTLS bool) (Conn, error)
+}
+
+// NetDialer connects to the directory over the network. Connections are
+// plain if there is no TLS config.
+type NetDialer struct{}
+
+func (NetDialer) Dial(host string, tlsConfig *tls.Config, startTLS bool) (Conn, error) {
+	var conn *ldap.Conn
+	var err error
+
+	if tlsConfig != nil && !startTLS {
+		conn, err = ldap.DialTLS("tcp", host, tlsConfig)
+	} else {
+		conn, err = ldap.Dial("tcp", host)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if tlsConfig != nil && startTLS {
+		err = conn.StartTLS(tlsConfig)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
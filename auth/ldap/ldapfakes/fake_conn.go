@@ -0,0 +1,145 @@
+// This file was generated by counterfeiter
+package ldapfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/auth/ldap"
+	ldapv2 "gopkg.in/ldap.v2"
+)
+
+type FakeConn struct {
+	BindStub        func(username string, password string) error
+	bindMutex       sync.RWMutex
+	bindArgsForCall []struct {
+		username string
+		password string
+	}
+	bindReturns struct {
+		result1 error
+	}
+	SearchStub        func(*ldapv2.SearchRequest) (*ldapv2.SearchResult, error)
+	searchMutex       sync.RWMutex
+	searchArgsForCall []struct {
+		arg1 *ldapv2.SearchRequest
+	}
+	searchReturns struct {
+		result1 *ldapv2.SearchResult
+		result2 error
+	}
+	CloseStub        func()
+	closeMutex       sync.RWMutex
+	closeArgsForCall []struct{}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeConn) Bind(username string, password string) error {
+	fake.bindMutex.Lock()
+	fake.bindArgsForCall = append(fake.bindArgsForCall, struct {
+		username string
+		password string
+	}{username, password})
+	fake.recordInvocation("Bind", []interface{}{username, password})
+	fake.bindMutex.Unlock()
+	if fake.BindStub != nil {
+		return fake.BindStub(username, password)
+	} else {
+		return fake.bindReturns.result1
+	}
+}
+
+func (fake *FakeConn) BindCallCount() int {
+	fake.bindMutex.RLock()
+	defer fake.bindMutex.RUnlock()
+	return len(fake.bindArgsForCall)
+}
+
+func (fake *FakeConn) BindArgsForCall(i int) (string, string) {
+	fake.bindMutex.RLock()
+	defer fake.bindMutex.RUnlock()
+	return fake.bindArgsForCall[i].username, fake.bindArgsForCall[i].password
+}
+
+func (fake *FakeConn) BindReturns(result1 error) {
+	fake.BindStub = nil
+	fake.bindReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeConn) Search(arg1 *ldapv2.SearchRequest) (*ldapv2.SearchResult, error) {
+	fake.searchMutex.Lock()
+	fake.searchArgsForCall = append(fake.searchArgsForCall, struct {
+		arg1 *ldapv2.SearchRequest
+	}{arg1})
+	fake.recordInvocation("Search", []interface{}{arg1})
+	fake.searchMutex.Unlock()
+	if fake.SearchStub != nil {
+		return fake.SearchStub(arg1)
+	} else {
+		return fake.searchReturns.result1, fake.searchReturns.result2
+	}
+}
+
+func (fake *FakeConn) SearchCallCount() int {
+	fake.searchMutex.RLock()
+	defer fake.searchMutex.RUnlock()
+	return len(fake.searchArgsForCall)
+}
+
+func (fake *FakeConn) SearchArgsForCall(i int) *ldapv2.SearchRequest {
+	fake.searchMutex.RLock()
+	defer fake.searchMutex.RUnlock()
+	return fake.searchArgsForCall[i].arg1
+}
+
+func (fake *FakeConn) SearchReturns(result1 *ldapv2.SearchResult, result2 error) {
+	fake.SearchStub = nil
+	fake.searchReturns = struct {
+		result1 *ldapv2.SearchResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeConn) Close() {
+	fake.closeMutex.Lock()
+	fake.closeArgsForCall = append(fake.closeArgsForCall, struct{}{})
+	fake.recordInvocation("Close", []interface{}{})
+	fake.closeMutex.Unlock()
+	if fake.CloseStub != nil {
+		fake.CloseStub()
+	}
+}
+
+func (fake *FakeConn) CloseCallCount() int {
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	return len(fake.closeArgsForCall)
+}
+
+func (fake *FakeConn) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.bindMutex.RLock()
+	defer fake.bindMutex.RUnlock()
+	fake.searchMutex.RLock()
+	defer fake.searchMutex.RUnlock()
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeConn) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ ldap.Conn = new(FakeConn)
@@ -0,0 +1,83 @@
+// This file was generated by counterfeiter
+package ldapfakes
+
+import (
+	"crypto/tls"
+	"sync"
+
+	"github.com/concourse/atc/auth/ldap"
+)
+
+type FakeDialer struct {
+	DialStub        func(host string, tlsConfig *tls.Config, startTLS bool) (ldap.Conn, error)
+	dialMutex       sync.RWMutex
+	dialArgsForCall []struct {
+		host      string
+		tlsConfig *tls.Config
+		startTLS  bool
+	}
+	dialReturns struct {
+		result1 ldap.Conn
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDialer) Dial(host string, tlsConfig *tls.Config, startTLS bool) (ldap.Conn, error) {
+	fake.dialMutex.Lock()
+	fake.dialArgsForCall = append(fake.dialArgsForCall, struct {
+		host      string
+		tlsConfig *tls.Config
+		startTLS  bool
+	}{host, tlsConfig, startTLS})
+	fake.recordInvocation("Dial", []interface{}{host, tlsConfig, startTLS})
+	fake.dialMutex.Unlock()
+	if fake.DialStub != nil {
+		return fake.DialStub(host, tlsConfig, startTLS)
+	} else {
+		return fake.dialReturns.result1, fake.dialReturns.result2
+	}
+}
+
+func (fake *FakeDialer) DialCallCount() int {
+	fake.dialMutex.RLock()
+	defer fake.dialMutex.RUnlock()
+	return len(fake.dialArgsForCall)
+}
+
+func (fake *FakeDialer) DialArgsForCall(i int) (string, *tls.Config, bool) {
+	fake.dialMutex.RLock()
+	defer fake.dialMutex.RUnlock()
+	return fake.dialArgsForCall[i].host, fake.dialArgsForCall[i].tlsConfig, fake.dialArgsForCall[i].startTLS
+}
+
+func (fake *FakeDialer) DialReturns(result1 ldap.Conn, result2 error) {
+	fake.DialStub = nil
+	fake.dialReturns = struct {
+		result1 ldap.Conn
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDialer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.dialMutex.RLock()
+	defer fake.dialMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeDialer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ ldap.Dialer = new(FakeDialer)
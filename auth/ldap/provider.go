@@ -0,0 +1,274 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	ldap "gopkg.in/ldap.v2"
+)
+
+const DisplayName = "LDAP"
+
+const (
+	DefaultUsernameAttribute = "uid"
+	DefaultGroupAttribute    = "member"
+	DefaultNameAttribute     = "cn"
+)
+
+// User is a directory user who logged in with their password. Role is the
+// most privileged role mapped to any of their groups, or empty if none is.
+type User struct {
+	Username string
+	DN       string
+	Groups   []string
+	Role     atc.TeamRole
+}
+
+type Provider struct {
+	ldapAuth *db.LDAPAuth
+	roles    []atc.TeamRoleMapping
+	dialer   Dialer
+}
+
+func NewProvider(ldapAuth *db.LDAPAuth, roles []atc.TeamRoleMapping, dialer Dialer) Provider {
+	return Provider{
+		ldapAuth: ldapAuth,
+		roles:    roles,
+		dialer:   dialer,
+	}
+}
+
+// Authenticate looks the user up, binds as them to check their password, and
+// then finds their groups. The user is only returned if their password is
+// right and they are in one of the team's groups.
+func (p Provider) Authenticate(logger lager.Logger, username string, password string) (User, bool, error) {
+	user, found, err := p.login(logger, username, password)
+	if err != nil {
+		return User{}, false, err
+	}
+
+	if !found {
+		return User{}, false, nil
+	}
+
+	if !containsAny(p.ldapAuth.Groups, user.Groups) {
+		logger.Info("not-in-any-group", lager.Data{"username": username})
+		return User{}, false, nil
+	}
+
+	user.Role = p.role(user.Groups)
+
+	return user, true, nil
+}
+
+func (p Provider) login(logger lager.Logger, username string, password string) (User, bool, error) {
+	// an empty password would make this an unauthenticated bind, which
+	// directories accept for any DN
+	if username == "" || password == "" {
+		return User{}, false, nil
+	}
+
+	tlsConfig, err := p.tlsConfig()
+	if err != nil {
+		return User{}, false, err
+	}
+
+	conn, err := p.dialer.Dial(p.ldapAuth.Host, tlsConfig, p.ldapAuth.StartTLS)
+	if err != nil {
+		logger.Error("failed-to-connect", err)
+		return User{}, false, err
+	}
+
+	defer conn.Close()
+
+	err = p.bindForSearch(conn)
+	if err != nil {
+		logger.Error("failed-to-bind", err)
+		return User{}, false, err
+	}
+
+	userSearch := p.ldapAuth.UserSearch
+	usernameAttribute := orDefault(userSearch.UsernameAttribute, DefaultUsernameAttribute)
+
+	attributes := []string{usernameAttribute}
+	groupSearch := p.ldapAuth.GroupSearch
+	if groupSearch.UserAttribute != "" {
+		attributes = append(attributes, groupSearch.UserAttribute)
+	}
+
+	result, err := conn.Search(ldap.NewSearchRequest(
+		userSearch.BaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		filter(userSearch.Filter, usernameAttribute, username),
+		attributes,
+		nil,
+	))
+	if err != nil {
+		logger.Error("failed-to-search-for-user", err)
+		return User{}, false, err
+	}
+
+	if len(result.Entries) != 1 {
+		logger.Info("user-not-found", lager.Data{"username": username, "entries": len(result.Entries)})
+		return User{}, false, nil
+	}
+
+	entry := result.Entries[0]
+
+	err = conn.Bind(entry.DN, password)
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			logger.Info("invalid-credentials", lager.Data{"username": username})
+			return User{}, false, nil
+		}
+
+		logger.Error("failed-to-bind-as-user", err)
+		return User{}, false, err
+	}
+
+	groups, err := p.groups(conn, entry)
+	if err != nil {
+		logger.Error("failed-to-search-for-groups", err)
+		return User{}, false, err
+	}
+
+	return User{
+		Username: entry.GetAttributeValue(usernameAttribute),
+		DN:       entry.DN,
+		Groups:   groups,
+	}, true, nil
+}
+
+func (p Provider) groups(conn Conn, user *ldap.Entry) ([]string, error) {
+	groupSearch := p.ldapAuth.GroupSearch
+	if groupSearch.BaseDN == "" {
+		return nil, nil
+	}
+
+	// the user may not be allowed to search for groups themselves
+	err := p.bindForSearch(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	member := user.DN
+	if groupSearch.UserAttribute != "" {
+		member = user.GetAttributeValue(groupSearch.UserAttribute)
+	}
+
+	nameAttribute := orDefault(groupSearch.NameAttribute, DefaultNameAttribute)
+
+	result, err := conn.Search(ldap.NewSearchRequest(
+		groupSearch.BaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		filter(groupSearch.Filter, orDefault(groupSearch.GroupAttribute, DefaultGroupAttribute), member),
+		[]string{nameAttribute},
+		nil,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []string{}
+	for _, entry := range result.Entries {
+		name := entry.GetAttributeValue(nameAttribute)
+		if name != "" {
+			groups = append(groups, name)
+		}
+	}
+
+	return groups, nil
+}
+
+func (p Provider) bindForSearch(conn Conn) error {
+	if p.ldapAuth.BindDN == "" {
+		return nil
+	}
+
+	return conn.Bind(p.ldapAuth.BindDN, p.ldapAuth.BindPassword)
+}
+
+func (p Provider) role(groups []string) atc.TeamRole {
+	for _, role := range atc.TeamRoles {
+		for _, mapping := range p.roles {
+			if mapping.Role == role && containsAny(mapping.LDAPGroups, groups) {
+				return role
+			}
+		}
+	}
+
+	return ""
+}
+
+func (p Provider) tlsConfig() (*tls.Config, error) {
+	if p.ldapAuth.InsecureNoSSL {
+		return nil, nil
+	}
+
+	serverName, _, err := net.SplitHostPort(p.ldapAuth.Host)
+	if err != nil {
+		serverName = p.ldapAuth.Host
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: p.ldapAuth.InsecureSkipVerify,
+	}
+
+	if p.ldapAuth.CACert != "" {
+		caCertPool := x509.NewCertPool()
+		ok := caCertPool.AppendCertsFromPEM([]byte(p.ldapAuth.CACert))
+		if !ok {
+			return nil, errors.New("failed to use ldap certificate")
+		}
+
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	return tlsConfig, nil
+}
+
+// filter matches entries which match the configured filter, if any, and
+// whose attribute is the value.
+func filter(configured string, attribute string, value string) string {
+	match := fmt.Sprintf("(%s=%s)", attribute, ldap.EscapeFilter(value))
+	if configured == "" {
+		return match
+	}
+
+	return fmt.Sprintf("(&%s%s)", configured, match)
+}
+
+func orDefault(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
+
+func containsAny(xs []string, ys []string) bool {
+	for _, x := range xs {
+		for _, y := range ys {
+			if x == y {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,258 @@
+package ldap_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth/ldap"
+	"github.com/concourse/atc/auth/ldap/ldapfakes"
+	"github.com/concourse/atc/db"
+	ldapv2 "gopkg.in/ldap.v2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Provider", func() {
+	var (
+		fakeDialer *ldapfakes.FakeDialer
+		fakeConn   *ldapfakes.FakeConn
+
+		ldapAuth *db.LDAPAuth
+		roles    []atc.TeamRoleMapping
+
+		userEntries  []*ldapv2.Entry
+		groupEntries []*ldapv2.Entry
+
+		username string
+		password string
+
+		user     ldap.User
+		found    bool
+		authErr  error
+		provider ldap.Provider
+	)
+
+	BeforeEach(func() {
+		fakeConn = new(ldapfakes.FakeConn)
+		fakeDialer = new(ldapfakes.FakeDialer)
+		fakeDialer.DialReturns(fakeConn, nil)
+
+		ldapAuth = &db.LDAPAuth{
+			Host:         "ldap.example.com:636",
+			BindDN:       "cn=concourse,dc=example,dc=com",
+			BindPassword: "service-password",
+			UserSearch: db.LDAPUserSearch{
+				BaseDN: "ou=people,dc=example,dc=com",
+				Filter: "(objectClass=person)",
+			},
+			GroupSearch: db.LDAPGroupSearch{
+				BaseDN: "ou=groups,dc=example,dc=com",
+			},
+			Groups: []string{"developers", "admins"},
+		}
+
+		roles = nil
+
+		userEntries = []*ldapv2.Entry{
+			ldapv2.NewEntry("uid=some-user,ou=people,dc=example,dc=com", map[string][]string{
+				"uid": {"some-user"},
+			}),
+		}
+
+		groupEntries = []*ldapv2.Entry{
+			ldapv2.NewEntry("cn=developers,ou=groups,dc=example,dc=com", map[string][]string{
+				"cn": {"developers"},
+			}),
+		}
+
+		fakeConn.SearchStub = func(request *ldapv2.SearchRequest) (*ldapv2.SearchResult, error) {
+			if request.BaseDN == "ou=people,dc=example,dc=com" {
+				return &ldapv2.SearchResult{Entries: userEntries}, nil
+			}
+
+			return &ldapv2.SearchResult{Entries: groupEntries}, nil
+		}
+
+		username = "some-user"
+		password = "some-password"
+	})
+
+	JustBeforeEach(func() {
+		provider = ldap.NewProvider(ldapAuth, roles, fakeDialer)
+		user, found, authErr = provider.Authenticate(lagertest.NewTestLogger("test"), username, password)
+	})
+
+	It("connects to the host over TLS", func() {
+		Expect(fakeDialer.DialCallCount()).To(Equal(1))
+		host, tlsConfig, startTLS := fakeDialer.DialArgsForCall(0)
+		Expect(host).To(Equal("ldap.example.com:636"))
+		Expect(tlsConfig).NotTo(BeNil())
+		Expect(tlsConfig.ServerName).To(Equal("ldap.example.com"))
+		Expect(startTLS).To(BeFalse())
+	})
+
+	It("closes the connection", func() {
+		Expect(fakeConn.CloseCallCount()).To(Equal(1))
+	})
+
+	It("searches for the user as the service account", func() {
+		Expect(fakeConn.BindCallCount()).To(BeNumerically(">=", 1))
+		bindDN, bindPassword := fakeConn.BindArgsForCall(0)
+		Expect(bindDN).To(Equal("cn=concourse,dc=example,dc=com"))
+		Expect(bindPassword).To(Equal("service-password"))
+
+		request := fakeConn.SearchArgsForCall(0)
+		Expect(request.BaseDN).To(Equal("ou=people,dc=example,dc=com"))
+		Expect(request.Scope).To(Equal(ldapv2.ScopeWholeSubtree))
+		Expect(request.Filter).To(Equal("(&(objectClass=person)(uid=some-user))"))
+	})
+
+	It("checks the password by binding as the user", func() {
+		bindDN, bindPassword := fakeConn.BindArgsForCall(1)
+		Expect(bindDN).To(Equal("uid=some-user,ou=people,dc=example,dc=com"))
+		Expect(bindPassword).To(Equal("some-password"))
+	})
+
+	It("searches for the user's groups by their DN", func() {
+		Expect(fakeConn.SearchCallCount()).To(Equal(2))
+		request := fakeConn.SearchArgsForCall(1)
+		Expect(request.BaseDN).To(Equal("ou=groups,dc=example,dc=com"))
+		Expect(request.Filter).To(Equal("(member=uid=some-user,ou=people,dc=example,dc=com)"))
+		Expect(request.Attributes).To(Equal([]string{"cn"}))
+	})
+
+	It("returns the user and their groups", func() {
+		Expect(authErr).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(user).To(Equal(ldap.User{
+			Username: "some-user",
+			DN:       "uid=some-user,ou=people,dc=example,dc=com",
+			Groups:   []string{"developers"},
+		}))
+	})
+
+	Context("when the username has filter characters in it", func() {
+		BeforeEach(func() {
+			username = "*)(uid=*"
+		})
+
+		It("escapes them", func() {
+			request := fakeConn.SearchArgsForCall(0)
+			Expect(request.Filter).To(Equal("(&(objectClass=person)(uid=\\2a\\29\\28uid=\\2a))"))
+		})
+	})
+
+	Context("when the password is empty", func() {
+		BeforeEach(func() {
+			password = ""
+		})
+
+		It("does not connect or find the user", func() {
+			Expect(authErr).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+			Expect(fakeDialer.DialCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the user is not found", func() {
+		BeforeEach(func() {
+			userEntries = nil
+		})
+
+		It("does not find the user", func() {
+			Expect(authErr).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when the password is wrong", func() {
+		BeforeEach(func() {
+			fakeConn.BindStub = func(dn string, password string) error {
+				if dn == "uid=some-user,ou=people,dc=example,dc=com" {
+					return ldapv2.NewError(ldapv2.LDAPResultInvalidCredentials, errors.New("invalid credentials"))
+				}
+
+				return nil
+			}
+		})
+
+		It("does not find the user", func() {
+			Expect(authErr).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when the directory can not be reached", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDialer.DialReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(authErr).To(Equal(disaster))
+		})
+	})
+
+	Context("when the user is not in any of the team's groups", func() {
+		BeforeEach(func() {
+			ldapAuth.Groups = []string{"admins"}
+		})
+
+		It("does not find the user", func() {
+			Expect(authErr).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when groups are matched by a user attribute", func() {
+		BeforeEach(func() {
+			ldapAuth.GroupSearch.UserAttribute = "uid"
+			ldapAuth.GroupSearch.GroupAttribute = "memberUid"
+			ldapAuth.GroupSearch.Filter = "(objectClass=posixGroup)"
+		})
+
+		It("searches for the user's groups by the attribute", func() {
+			request := fakeConn.SearchArgsForCall(1)
+			Expect(request.Filter).To(Equal("(&(objectClass=posixGroup)(memberUid=some-user))"))
+		})
+	})
+
+	Context("when roles are mapped to groups", func() {
+		BeforeEach(func() {
+			roles = []atc.TeamRoleMapping{
+				{Role: atc.RoleViewer, LDAPGroups: []string{"developers"}},
+				{Role: atc.RoleMember, LDAPGroups: []string{"developers", "admins"}},
+				{Role: atc.RoleOwner, LDAPGroups: []string{"admins"}},
+			}
+		})
+
+		It("returns the most privileged role of the user's groups", func() {
+			Expect(user.Role).To(Equal(atc.RoleMember))
+		})
+	})
+
+	Context("when SSL is disabled", func() {
+		BeforeEach(func() {
+			ldapAuth.InsecureNoSSL = true
+		})
+
+		It("connects without TLS", func() {
+			_, tlsConfig, _ := fakeDialer.DialArgsForCall(0)
+			Expect(tlsConfig).To(BeNil())
+		})
+	})
+
+	Context("when the CA cert is invalid", func() {
+		BeforeEach(func() {
+			ldapAuth.CACert = "not a cert"
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(HaveOccurred())
+			Expect(fakeDialer.DialCallCount()).To(BeZero())
+		})
+	})
+})
@@ -154,9 +154,29 @@ func (handler *OAuthCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	role := team.DefaultRole()
+
+	verifiedRole, found, err := provider.VerifyRole(hLog.Session("verify-role"), httpClient)
+	if err != nil {
+		hLog.Error("failed-to-verify-role", err)
+		http.Error(w, "failed to verify role", http.StatusInternalServerError)
+		return
+	}
+
+	if found {
+		role = verifiedRole
+	}
+
+	username, err := provider.Username(hLog.Session("username"), httpClient)
+	if err != nil {
+		hLog.Error("failed-to-identify-user", err)
+		http.Error(w, "failed to identify user", http.StatusInternalServerError)
+		return
+	}
+
 	exp := time.Now().Add(CookieAge)
 
-	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(exp, team.Name, team.ID, team.Admin)
+	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(exp, team.Name, team.ID, team.Admin, role, username)
 	if err != nil {
 		hLog.Error("failed-to-sign-token", err)
 		http.Error(w, "failed to sign token", http.StatusInternalServerError)
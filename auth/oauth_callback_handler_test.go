@@ -22,12 +22,16 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/authfakes"
 	"github.com/concourse/atc/auth/provider"
 	"github.com/concourse/atc/auth/provider/providerfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/wrappa"
+	"github.com/concourse/atc/wrappa/wrappafakes"
+	"github.com/tedsuo/rata"
 )
 
 var _ = Describe("OAuthCallbackHandler", func() {
@@ -237,6 +241,97 @@ var _ = Describe("OAuthCallbackHandler", func() {
 								Expect(claims["teamID"]).To(BeNumerically("==", team.ID))
 								Expect(token.Valid).To(BeTrue())
 							})
+
+							It("contains the team's default role", func() {
+								token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+								Expect(err).ToNot(HaveOccurred())
+
+								claims := token.Claims.(jwt.MapClaims)
+								Expect(claims["role"]).To(Equal("owner"))
+							})
+
+							Context("when the provider verifies a role", func() {
+								BeforeEach(func() {
+									fakeProvider.VerifyRoleReturns(atc.RoleOperator, true, nil)
+								})
+
+								It("contains the verified role", func() {
+									token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+									Expect(err).ToNot(HaveOccurred())
+
+									claims := token.Claims.(jwt.MapClaims)
+									Expect(claims["role"]).To(Equal("operator"))
+								})
+							})
+
+							Context("when the provider identifies the user", func() {
+								BeforeEach(func() {
+									fakeProvider.UsernameReturns("some-user", nil)
+								})
+
+								It("identifies the user using the provider's HTTP client", func() {
+									Expect(fakeProvider.UsernameCallCount()).To(Equal(1))
+									_, client := fakeProvider.UsernameArgsForCall(0)
+									Expect(client).To(Equal(httpClient))
+								})
+
+								It("contains the user's name", func() {
+									token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+									Expect(err).ToNot(HaveOccurred())
+
+									claims := token.Claims.(jwt.MapClaims)
+									Expect(claims["username"]).To(Equal("some-user"))
+								})
+
+								It("produces audit events with the user's name", func() {
+									fakeAuditDB := new(wrappafakes.FakeAuditDB)
+
+									handlers := wrappa.NewAuditWrappa(
+										lagertest.NewTestLogger("test"),
+										fakeAuditDB,
+										auth.JWTReader{PublicKey: &signingKey.PublicKey},
+									).Wrap(rata.Handlers{
+										atc.PausePipeline: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+									})
+
+									auditedRequest, err := http.NewRequest("PUT", "/api/v1/teams/some-team/pipelines/some-pipeline/pause", nil)
+									Expect(err).NotTo(HaveOccurred())
+									auditedRequest.Header.Set("Authorization", cookie.Value)
+
+									handlers[atc.PausePipeline].ServeHTTP(httptest.NewRecorder(), auditedRequest)
+
+									Expect(fakeAuditDB.SaveAuditEventCallCount()).To(Equal(1))
+									Expect(fakeAuditDB.SaveAuditEventArgsForCall(0).Username).To(Equal("some-user"))
+								})
+							})
+						})
+
+						Context("when the user cannot be identified", func() {
+							BeforeEach(func() {
+								fakeProvider.UsernameReturns("", errors.New("nope"))
+							})
+
+							It("returns Internal Server Error", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+
+							It("does not set a cookie", func() {
+								Expect(response.Cookies()).To(BeEmpty())
+							})
+						})
+
+						Context("when the role cannot be verified", func() {
+							BeforeEach(func() {
+								fakeProvider.VerifyRoleReturns("", false, errors.New("nope"))
+							})
+
+							It("returns Internal Server Error", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+
+							It("does not set a cookie", func() {
+								Expect(response.Cookies()).To(BeEmpty())
+							})
 						})
 
 						It("does not redirect", func() {
@@ -0,0 +1,99 @@
+package oidc
+
+import (
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+// Membership describes whose ID tokens a ClaimsVerifier accepts: those whose
+// UserClaim is one of the Users, whose GroupsClaim lists one of the Groups,
+// or which have any one of the Claims.
+type Membership struct {
+	Groups      []string
+	GroupsClaim string
+
+	Users     []string
+	UserClaim string
+
+	Claims map[string]string
+}
+
+type ClaimsVerifier struct {
+	idTokens   *IDTokenVerifier
+	membership Membership
+}
+
+func NewClaimsVerifier(idTokens *IDTokenVerifier, membership Membership) ClaimsVerifier {
+	return ClaimsVerifier{
+		idTokens:   idTokens,
+		membership: membership,
+	}
+}
+
+func (verifier ClaimsVerifier) Verify(logger lager.Logger, httpClient *http.Client) (bool, error) {
+	claims, err := verifier.idTokens.Claims(httpClient)
+	if err != nil {
+		logger.Error("failed-to-verify-id-token", err)
+		return false, err
+	}
+
+	if verifier.membership.Matches(claims) {
+		return true, nil
+	}
+
+	logger.Info("not-a-member", lager.Data{
+		"sub": claims["sub"],
+	})
+
+	return false, nil
+}
+
+func (membership Membership) Matches(claims jwt.MapClaims) bool {
+	if len(membership.Users) > 0 {
+		user, ok := claims[membership.UserClaim].(string)
+		if ok && contains(membership.Users, user) {
+			return true
+		}
+	}
+
+	if len(membership.Groups) > 0 {
+		for _, group := range claimValues(claims[membership.GroupsClaim]) {
+			if contains(membership.Groups, group) {
+				return true
+			}
+		}
+	}
+
+	for name, expected := range membership.Claims {
+		for _, value := range claimValues(claims[name]) {
+			if value == expected {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// claimValues flattens a claim into strings so that it can be compared with
+// the configured values. A list claim has each of its elements compared.
+func claimValues(claim interface{}) []string {
+	switch value := claim.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{value}
+	case []interface{}:
+		values := []string{}
+		for _, v := range value {
+			values = append(values, claimValues(v)...)
+		}
+
+		return values
+	default:
+		return []string{fmt.Sprint(value)}
+	}
+}
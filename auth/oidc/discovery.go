@@ -0,0 +1,51 @@
+package oidc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Discovery is the part of an issuer's OpenID Provider Metadata needed to
+// log users in and verify their ID tokens.
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Discover fetches the issuer's metadata from its well-known location. The
+// metadata must name the same issuer, or tokens from one issuer could be
+// passed off as another's.
+func Discover(client *http.Client, issuer string) (Discovery, error) {
+	issuer = strings.TrimSuffix(issuer, "/")
+
+	response, err := client.Get(issuer + "/.well-known/openid-configuration")
+	if err != nil {
+		return Discovery{}, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return Discovery{}, fmt.Errorf("unexpected response from oidc discovery: %d", response.StatusCode)
+	}
+
+	var discovery Discovery
+	err = json.NewDecoder(response.Body).Decode(&discovery)
+	if err != nil {
+		return Discovery{}, err
+	}
+
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuer {
+		return Discovery{}, fmt.Errorf("oidc discovery is for issuer '%s', not '%s'", discovery.Issuer, issuer)
+	}
+
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return Discovery{}, fmt.Errorf("oidc discovery for '%s' is missing endpoints", issuer)
+	}
+
+	return discovery, nil
+}
@@ -0,0 +1,99 @@
+package oidc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"golang.org/x/oauth2"
+)
+
+// IDTokenVerifier checks the ID token that the issuer returned alongside the
+// access token: that the issuer signed it with one of its keys, that it was
+// issued to this client, and that it has not expired.
+type IDTokenVerifier struct {
+	issuer   string
+	clientID string
+	keys     *KeySet
+}
+
+func NewIDTokenVerifier(issuer string, clientID string, keys *KeySet) *IDTokenVerifier {
+	return &IDTokenVerifier{
+		issuer:   issuer,
+		clientID: clientID,
+		keys:     keys,
+	}
+}
+
+// Claims verifies the ID token of the client's OAuth token and returns its
+// claims.
+func (v *IDTokenVerifier) Claims(httpClient *http.Client) (jwt.MapClaims, error) {
+	oauth2Transport, ok := httpClient.Transport.(*oauth2.Transport)
+	if !ok {
+		return nil, errors.New("httpClient transport must be of type oauth2.Transport")
+	}
+
+	token, err := oauth2Transport.Source.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, errors.New("token response did not include an id_token")
+	}
+
+	return v.Verify(rawIDToken)
+}
+
+func (v *IDTokenVerifier) Verify(rawIDToken string) (jwt.MapClaims, error) {
+	idToken, err := jwt.Parse(rawIDToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+
+		keyID, _ := token.Header["kid"].(string)
+
+		return v.keys.Key(keyID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := idToken.Claims.(jwt.MapClaims)
+	if !ok || !idToken.Valid {
+		return nil, errors.New("invalid id_token")
+	}
+
+	if _, found := claims["exp"]; !found {
+		return nil, errors.New("id_token has no expiry")
+	}
+
+	if !claims.VerifyIssuer(v.issuer, true) {
+		return nil, fmt.Errorf("id_token was not issued by '%s'", v.issuer)
+	}
+
+	if !hasAudience(claims, v.clientID) {
+		return nil, fmt.Errorf("id_token was not issued to '%s'", v.clientID)
+	}
+
+	return claims, nil
+}
+
+// hasAudience accepts both forms of the aud claim: a single string, or an
+// array of them.
+func hasAudience(claims jwt.MapClaims, clientID string) bool {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == clientID
+	case []interface{}:
+		for _, a := range aud {
+			if a == clientID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,136 @@
+package oidc
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+)
+
+// KeySet fetches an issuer's signing keys from its JWKS endpoint. Keys are
+// fetched once and then reused, unless a token names a key which isn't among
+// them, as happens when the issuer rotates its keys. It is shared by every
+// login to the team, so it is safe for concurrent use.
+type KeySet struct {
+	client  *http.Client
+	jwksURI string
+
+	keysLock sync.Mutex
+	keys     map[string]*rsa.PublicKey
+}
+
+func NewKeySet(client *http.Client, jwksURI string) *KeySet {
+	return &KeySet{
+		client:  client,
+		jwksURI: jwksURI,
+	}
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	KeyID   string `json:"kid"`
+	KeyType string `json:"kty"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+}
+
+// Key returns the key with the given ID. Tokens need not name their key if
+// the issuer only has one.
+func (ks *KeySet) Key(keyID string) (*rsa.PublicKey, error) {
+	ks.keysLock.Lock()
+	defer ks.keysLock.Unlock()
+
+	key, found := ks.find(keyID)
+	if found {
+		return key, nil
+	}
+
+	err := ks.fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	key, found = ks.find(keyID)
+	if !found {
+		return nil, fmt.Errorf("unknown oidc signing key '%s'", keyID)
+	}
+
+	return key, nil
+}
+
+func (ks *KeySet) find(keyID string) (*rsa.PublicKey, bool) {
+	if keyID == "" && len(ks.keys) == 1 {
+		for _, key := range ks.keys {
+			return key, true
+		}
+	}
+
+	key, found := ks.keys[keyID]
+	return key, found
+}
+
+func (ks *KeySet) fetch() error {
+	response, err := ks.client.Get(ks.jwksURI)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response from oidc jwks endpoint: %d", response.StatusCode)
+	}
+
+	var keySet jsonWebKeySet
+	err = json.NewDecoder(response.Body).Decode(&keySet)
+	if err != nil {
+		return err
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, jwk := range keySet.Keys {
+		if jwk.KeyType != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+
+		key, err := jwk.rsaPublicKey()
+		if err != nil {
+			return err
+		}
+
+		keys[jwk.KeyID] = key
+	}
+
+	ks.keys = keys
+
+	return nil
+}
+
+func (jwk jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(n) == 0 || len(e) == 0 || len(e) > 4 {
+		return nil, errors.New("invalid oidc rsa key")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
@@ -0,0 +1,190 @@
+package oidc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth/verifier"
+	"github.com/concourse/atc/db"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+)
+
+const ProviderName = "oidc"
+const DisplayName = "OpenID Connect"
+
+// DefaultGroupsClaim and DefaultUserClaim are the ID token claims checked
+// against the configured groups and users when no others are configured.
+const DefaultGroupsClaim = "groups"
+const DefaultUserClaim = "email"
+
+var Scopes = []string{"openid", "profile", "email"}
+
+type Provider interface {
+	PreTokenClient() (*http.Client, error)
+
+	OAuthClient
+	Verifier
+	RoleVerifier
+	UserIdentifier
+}
+
+type OAuthClient interface {
+	AuthCodeURL(string, ...oauth2.AuthCodeOption) string
+	Exchange(context.Context, string) (*oauth2.Token, error)
+	Client(context.Context, *oauth2.Token) *http.Client
+}
+
+type Verifier interface {
+	Verify(lager.Logger, *http.Client) (bool, error)
+}
+
+type RoleVerifier interface {
+	VerifyRole(lager.Logger, *http.Client) (atc.TeamRole, bool, error)
+}
+
+type UserIdentifier interface {
+	Username(lager.Logger, *http.Client) (string, error)
+}
+
+// NewProvider discovers the issuer's endpoints, so unlike the other providers
+// it needs to make a request and may fail.
+func NewProvider(
+	oidcAuth *db.OIDCAuth,
+	roles []atc.TeamRoleMapping,
+	redirectURL string,
+) (Provider, error) {
+	preTokenClient, err := newPreTokenClient(oidcAuth.CACert)
+	if err != nil {
+		return nil, err
+	}
+
+	discovery, err := Discover(preTokenClient, oidcAuth.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	idTokens := NewIDTokenVerifier(
+		discovery.Issuer,
+		oidcAuth.ClientID,
+		NewKeySet(preTokenClient, discovery.JWKSURI),
+	)
+
+	groupsClaim := oidcAuth.GroupsClaim
+	if groupsClaim == "" {
+		groupsClaim = DefaultGroupsClaim
+	}
+
+	userClaim := oidcAuth.UserClaim
+	if userClaim == "" {
+		userClaim = DefaultUserClaim
+	}
+
+	scopes := append([]string{}, Scopes...)
+	for _, scope := range oidcAuth.Scopes {
+		if !contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return oidcProvider{
+		Verifier: NewClaimsVerifier(idTokens, Membership{
+			Groups:      oidcAuth.Groups,
+			GroupsClaim: groupsClaim,
+			Users:       oidcAuth.Users,
+			UserClaim:   userClaim,
+			Claims:      oidcAuth.Claims,
+		}),
+		RoleVerifier:   verifier.NewRoleBasket(roleVerifiers(roles, idTokens, groupsClaim, userClaim)),
+		UserIdentifier: NewClaimsUserIdentifier(idTokens, userClaim),
+		Config: &oauth2.Config{
+			ClientID:     oidcAuth.ClientID,
+			ClientSecret: oidcAuth.ClientSecret,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  discovery.AuthorizationEndpoint,
+				TokenURL: discovery.TokenEndpoint,
+			},
+			Scopes:      scopes,
+			RedirectURL: redirectURL,
+		},
+		preTokenClient: preTokenClient,
+	}, nil
+}
+
+type oidcProvider struct {
+	*oauth2.Config
+	// oauth2.Config implements the required Provider methods:
+	// AuthCodeURL(string, ...oauth2.AuthCodeOption) string
+	// Exchange(context.Context, string) (*oauth2.Token, error)
+	// Client(context.Context, *oauth2.Token) *http.Client
+
+	verifier.Verifier
+	verifier.RoleVerifier
+	verifier.UserIdentifier
+
+	preTokenClient *http.Client
+}
+
+func (p oidcProvider) PreTokenClient() (*http.Client, error) {
+	return p.preTokenClient, nil
+}
+
+func roleVerifiers(roles []atc.TeamRoleMapping, idTokens *IDTokenVerifier, groupsClaim string, userClaim string) map[atc.TeamRole]verifier.Verifier {
+	mappings := map[atc.TeamRole][]verifier.Verifier{}
+	for _, mapping := range roles {
+		if len(mapping.OIDCGroups) == 0 && len(mapping.OIDCUsers) == 0 && len(mapping.OIDCClaims) == 0 {
+			continue
+		}
+
+		mappings[mapping.Role] = append(mappings[mapping.Role], NewClaimsVerifier(idTokens, Membership{
+			Groups:      mapping.OIDCGroups,
+			GroupsClaim: groupsClaim,
+			Users:       mapping.OIDCUsers,
+			UserClaim:   userClaim,
+			Claims:      mapping.OIDCClaims,
+		}))
+	}
+
+	verifiers := map[atc.TeamRole]verifier.Verifier{}
+	for role, roleVerifiers := range mappings {
+		verifiers[role] = verifier.NewVerifierBasket(roleVerifiers...)
+	}
+
+	return verifiers
+}
+
+func newPreTokenClient(caCert string) (*http.Client, error) {
+	transport := &http.Transport{
+		DisableKeepAlives: true,
+	}
+
+	if caCert != "" {
+		caCertPool := x509.NewCertPool()
+		ok := caCertPool.AppendCertsFromPEM([]byte(caCert))
+		if !ok {
+			return nil, errors.New("failed to use oidc certificate")
+		}
+
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs: caCertPool,
+		}
+	}
+
+	return &http.Client{
+		Transport: transport,
+	}, nil
+}
+
+func contains(xs []string, x string) bool {
+	for _, y := range xs {
+		if x == y {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,64 @@
+package oidc
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+// ProviderCache keeps each team's provider, so that the issuer is only
+// discovered, and its keys only fetched, again once the team's configuration
+// changes. Providers which fail to be constructed are not kept.
+type ProviderCache struct {
+	lock      sync.Mutex
+	providers map[string]cachedProvider
+}
+
+type cachedProvider struct {
+	oidcAuth    db.OIDCAuth
+	roles       []atc.TeamRoleMapping
+	redirectURL string
+
+	provider Provider
+}
+
+func NewProviderCache() *ProviderCache {
+	return &ProviderCache{
+		providers: map[string]cachedProvider{},
+	}
+}
+
+func (cache *ProviderCache) Provider(
+	teamName string,
+	oidcAuth *db.OIDCAuth,
+	roles []atc.TeamRoleMapping,
+	redirectURL string,
+) (Provider, error) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cached, found := cache.providers[teamName]
+	if found &&
+		reflect.DeepEqual(cached.oidcAuth, *oidcAuth) &&
+		reflect.DeepEqual(cached.roles, roles) &&
+		cached.redirectURL == redirectURL {
+		return cached.provider, nil
+	}
+
+	provider, err := NewProvider(oidcAuth, roles, redirectURL)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.providers[teamName] = cachedProvider{
+		oidcAuth:    *oidcAuth,
+		roles:       roles,
+		redirectURL: redirectURL,
+
+		provider: provider,
+	}
+
+	return provider, nil
+}
@@ -0,0 +1,121 @@
+package oidc_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth/oidc"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ProviderCache", func() {
+	var (
+		idp      *ghttp.Server
+		oidcAuth *db.OIDCAuth
+
+		cache *oidc.ProviderCache
+	)
+
+	discoveries := func() int {
+		count := 0
+		for _, request := range idp.ReceivedRequests() {
+			if request.URL.Path == "/.well-known/openid-configuration" {
+				count++
+			}
+		}
+
+		return count
+	}
+
+	BeforeEach(func() {
+		idp = ghttp.NewServer()
+
+		idp.RouteToHandler("GET", "/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+			ghttp.RespondWithJSONEncoded(http.StatusOK, oidc.Discovery{
+				Issuer:                idp.URL(),
+				AuthorizationEndpoint: idp.URL() + "/authorize",
+				TokenEndpoint:         idp.URL() + "/token",
+				JWKSURI:               idp.URL() + "/keys",
+			})(w, r)
+		})
+
+		oidcAuth = &db.OIDCAuth{
+			Issuer:       idp.URL(),
+			ClientID:     "some-client-id",
+			ClientSecret: "some-client-secret",
+			Groups:       []string{"some-group"},
+		}
+
+		cache = oidc.NewProviderCache()
+	})
+
+	AfterEach(func() {
+		idp.Close()
+	})
+
+	It("discovers the issuer once per team", func() {
+		provider, err := cache.Provider("some-team", oidcAuth, nil, "http://atc.example.com/auth/oidc/callback")
+		Expect(err).NotTo(HaveOccurred())
+
+		sameProvider, err := cache.Provider("some-team", &db.OIDCAuth{
+			Issuer:       idp.URL(),
+			ClientID:     "some-client-id",
+			ClientSecret: "some-client-secret",
+			Groups:       []string{"some-group"},
+		}, nil, "http://atc.example.com/auth/oidc/callback")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sameProvider).To(BeIdenticalTo(provider))
+
+		Expect(discoveries()).To(Equal(1))
+
+		_, err = cache.Provider("some-other-team", oidcAuth, nil, "http://atc.example.com/auth/oidc/callback")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(discoveries()).To(Equal(2))
+	})
+
+	It("discovers the issuer again once the team's auth changes", func() {
+		provider, err := cache.Provider("some-team", oidcAuth, nil, "http://atc.example.com/auth/oidc/callback")
+		Expect(err).NotTo(HaveOccurred())
+
+		oidcAuth.ClientSecret = "some-new-client-secret"
+
+		newProvider, err := cache.Provider("some-team", oidcAuth, nil, "http://atc.example.com/auth/oidc/callback")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(newProvider).NotTo(BeIdenticalTo(provider))
+
+		Expect(discoveries()).To(Equal(2))
+	})
+
+	It("discovers the issuer again once the team's roles change", func() {
+		_, err := cache.Provider("some-team", oidcAuth, nil, "http://atc.example.com/auth/oidc/callback")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = cache.Provider("some-team", oidcAuth, []atc.TeamRoleMapping{
+			{Role: atc.RoleViewer, OIDCGroups: []string{"some-viewers"}},
+		}, "http://atc.example.com/auth/oidc/callback")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(discoveries()).To(Equal(2))
+	})
+
+	Context("when the issuer cannot be discovered", func() {
+		BeforeEach(func() {
+			idp.RouteToHandler("GET", "/.well-known/openid-configuration", ghttp.RespondWith(http.StatusNotFound, ""))
+		})
+
+		It("tries again next time", func() {
+			_, err := cache.Provider("some-team", oidcAuth, nil, "http://atc.example.com/auth/oidc/callback")
+			Expect(err).To(HaveOccurred())
+
+			_, err = cache.Provider("some-team", oidcAuth, nil, "http://atc.example.com/auth/oidc/callback")
+			Expect(err).To(HaveOccurred())
+
+			Expect(discoveries()).To(Equal(2))
+		})
+	})
+})
@@ -0,0 +1,381 @@
+package oidc_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth/oidc"
+	"github.com/concourse/atc/db"
+	jwt "github.com/dgrijalva/jwt-go"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Provider", func() {
+	var (
+		idp        *ghttp.Server
+		signingKey *rsa.PrivateKey
+		issuer     string
+
+		idTokenClaims jwt.MapClaims
+		idTokenKey    *rsa.PrivateKey
+
+		oidcAuth *db.OIDCAuth
+		roles    []atc.TeamRoleMapping
+
+		oidcProvider oidc.Provider
+		providerErr  error
+	)
+
+	signIDToken := func() string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, idTokenClaims)
+		token.Header["kid"] = "some-key"
+
+		signed, err := token.SignedString(idTokenKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		return signed
+	}
+
+	BeforeEach(func() {
+		var err error
+		signingKey, err = rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+
+		idp = ghttp.NewServer()
+		issuer = idp.URL()
+
+		idp.RouteToHandler("GET", "/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+			ghttp.RespondWithJSONEncoded(http.StatusOK, oidc.Discovery{
+				Issuer:                issuer,
+				AuthorizationEndpoint: idp.URL() + "/authorize",
+				TokenEndpoint:         idp.URL() + "/token",
+				JWKSURI:               idp.URL() + "/keys",
+			})(w, r)
+		})
+
+		idp.RouteToHandler("GET", "/keys", ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+			"keys": []map[string]string{
+				{
+					"kid": "some-key",
+					"kty": "RSA",
+					"use": "sig",
+					"n":   base64.RawURLEncoding.EncodeToString(signingKey.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(signingKey.E)).Bytes()),
+				},
+			},
+		}))
+
+		idp.RouteToHandler("POST", "/token", func(w http.ResponseWriter, r *http.Request) {
+			ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+				"access_token": "some-access-token",
+				"token_type":   "Bearer",
+				"expires_in":   3600,
+				"id_token":     signIDToken(),
+			})(w, r)
+		})
+
+		idTokenKey = signingKey
+		idTokenClaims = jwt.MapClaims{
+			"iss":    idp.URL(),
+			"aud":    "some-client-id",
+			"sub":    "some-subject",
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"email":  "someone@example.com",
+			"groups": []string{"engineering", "ci-operators"},
+			"dept":   "research",
+		}
+
+		oidcAuth = &db.OIDCAuth{
+			Issuer:       idp.URL(),
+			ClientID:     "some-client-id",
+			ClientSecret: "some-client-secret",
+			Scopes:       []string{"groups"},
+		}
+
+		roles = nil
+	})
+
+	AfterEach(func() {
+		idp.Close()
+	})
+
+	JustBeforeEach(func() {
+		oidcProvider, providerErr = oidc.NewProvider(oidcAuth, roles, "http://atc.example.com/auth/oidc/callback")
+	})
+
+	logIn := func() *http.Client {
+		Expect(providerErr).NotTo(HaveOccurred())
+
+		preTokenClient, err := oidcProvider.PreTokenClient()
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx := context.WithValue(oauth2.NoContext, oauth2.HTTPClient, preTokenClient)
+
+		token, err := oidcProvider.Exchange(ctx, "some-code")
+		Expect(err).NotTo(HaveOccurred())
+
+		return oidcProvider.Client(ctx, token)
+	}
+
+	verify := func() (bool, error) {
+		return oidcProvider.Verify(lagertest.NewTestLogger("test"), logIn())
+	}
+
+	Describe("AuthCodeURL", func() {
+		It("sends users to the issuer's authorization endpoint for an ID token", func() {
+			Expect(providerErr).NotTo(HaveOccurred())
+
+			authCodeURL, err := url.Parse(oidcProvider.AuthCodeURL("some-state"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(authCodeURL.Host).To(Equal(idp.Addr()))
+			Expect(authCodeURL.Path).To(Equal("/authorize"))
+			Expect(authCodeURL.Query().Get("client_id")).To(Equal("some-client-id"))
+			Expect(authCodeURL.Query().Get("state")).To(Equal("some-state"))
+			Expect(authCodeURL.Query().Get("scope")).To(Equal("openid profile email groups"))
+		})
+	})
+
+	Context("when the issuer's discovery is for another issuer", func() {
+		BeforeEach(func() {
+			issuer = "https://elsewhere.example.com"
+		})
+
+		It("errors", func() {
+			Expect(providerErr).To(HaveOccurred())
+		})
+	})
+
+	Context("when the issuer cannot be reached", func() {
+		BeforeEach(func() {
+			oidcAuth.Issuer = "http://127.0.0.1:1"
+		})
+
+		It("errors", func() {
+			Expect(providerErr).To(HaveOccurred())
+		})
+	})
+
+	Describe("Verify", func() {
+		Context("when the user is one of the users", func() {
+			BeforeEach(func() {
+				oidcAuth.Users = []string{"someone@example.com"}
+			})
+
+			It("verifies them", func() {
+				Expect(verify()).To(BeTrue())
+			})
+
+			It("fetches the issuer's keys once for every login", func() {
+				Expect(verify()).To(BeTrue())
+				Expect(verify()).To(BeTrue())
+
+				keyRequests := 0
+				for _, request := range idp.ReceivedRequests() {
+					if request.URL.Path == "/keys" {
+						keyRequests++
+					}
+				}
+
+				Expect(keyRequests).To(Equal(1))
+			})
+
+			Context("when another claim names users", func() {
+				BeforeEach(func() {
+					oidcAuth.UserClaim = "sub"
+				})
+
+				It("does not verify them", func() {
+					Expect(verify()).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when the user is in one of the groups", func() {
+			BeforeEach(func() {
+				oidcAuth.Groups = []string{"ci-operators"}
+			})
+
+			It("verifies them", func() {
+				Expect(verify()).To(BeTrue())
+			})
+		})
+
+		Context("when the groups are in a custom claim", func() {
+			BeforeEach(func() {
+				idTokenClaims["roles"] = idTokenClaims["groups"]
+				delete(idTokenClaims, "groups")
+
+				oidcAuth.Groups = []string{"ci-operators"}
+				oidcAuth.GroupsClaim = "roles"
+			})
+
+			It("verifies them", func() {
+				Expect(verify()).To(BeTrue())
+			})
+		})
+
+		Context("when the user has one of the claims", func() {
+			BeforeEach(func() {
+				oidcAuth.Claims = map[string]string{"dept": "research"}
+			})
+
+			It("verifies them", func() {
+				Expect(verify()).To(BeTrue())
+			})
+		})
+
+		Context("when the user matches none of the users, groups or claims", func() {
+			BeforeEach(func() {
+				oidcAuth.Users = []string{"someone-else@example.com"}
+				oidcAuth.Groups = []string{"finance"}
+				oidcAuth.Claims = map[string]string{"dept": "sales"}
+			})
+
+			It("does not verify them", func() {
+				Expect(verify()).To(BeFalse())
+			})
+		})
+
+		Context("when nothing grants membership", func() {
+			It("does not verify anyone", func() {
+				Expect(verify()).To(BeFalse())
+			})
+		})
+
+		Context("when the ID token is invalid", func() {
+			BeforeEach(func() {
+				oidcAuth.Groups = []string{"ci-operators"}
+			})
+
+			Context("because it is signed by another key", func() {
+				BeforeEach(func() {
+					var err error
+					idTokenKey, err = rsa.GenerateKey(rand.Reader, 1024)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("errors", func() {
+					verified, err := verify()
+					Expect(err).To(HaveOccurred())
+					Expect(verified).To(BeFalse())
+				})
+			})
+
+			Context("because it was issued to another client", func() {
+				BeforeEach(func() {
+					idTokenClaims["aud"] = []string{"some-other-client-id"}
+				})
+
+				It("errors", func() {
+					verified, err := verify()
+					Expect(err).To(HaveOccurred())
+					Expect(verified).To(BeFalse())
+				})
+			})
+
+			Context("because it was issued by another issuer", func() {
+				BeforeEach(func() {
+					idTokenClaims["iss"] = "https://elsewhere.example.com"
+				})
+
+				It("errors", func() {
+					verified, err := verify()
+					Expect(err).To(HaveOccurred())
+					Expect(verified).To(BeFalse())
+				})
+			})
+
+			Context("because it has expired", func() {
+				BeforeEach(func() {
+					idTokenClaims["exp"] = time.Now().Add(-time.Hour).Unix()
+				})
+
+				It("errors", func() {
+					verified, err := verify()
+					Expect(err).To(HaveOccurred())
+					Expect(verified).To(BeFalse())
+				})
+			})
+		})
+	})
+
+	Describe("VerifyRole", func() {
+		BeforeEach(func() {
+			oidcAuth.Groups = []string{"engineering"}
+
+			roles = []atc.TeamRoleMapping{
+				{Role: atc.RoleOwner, OIDCUsers: []string{"boss@example.com"}},
+				{Role: atc.RoleOperator, OIDCGroups: []string{"ci-operators"}},
+				{Role: atc.RoleViewer, OIDCClaims: map[string]string{"dept": "research"}},
+				{Role: atc.RoleMember, BasicAuthUsers: []string{"someone"}},
+			}
+		})
+
+		It("returns the most privileged role mapped to the user", func() {
+			Expect(providerErr).NotTo(HaveOccurred())
+
+			role, found, err := oidcProvider.VerifyRole(lagertest.NewTestLogger("test"), logIn())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(role).To(Equal(atc.RoleOperator))
+		})
+
+		Context("when no role is mapped to the user", func() {
+			BeforeEach(func() {
+				idTokenClaims["groups"] = []string{"engineering"}
+				idTokenClaims["dept"] = "sales"
+			})
+
+			It("does not find a role", func() {
+				Expect(providerErr).NotTo(HaveOccurred())
+
+				_, found, err := oidcProvider.VerifyRole(lagertest.NewTestLogger("test"), logIn())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("Username", func() {
+		username := func() (string, error) {
+			return oidcProvider.Username(lagertest.NewTestLogger("test"), logIn())
+		}
+
+		It("returns the user claim", func() {
+			Expect(username()).To(Equal("someone@example.com"))
+		})
+
+		Context("when another claim names users", func() {
+			BeforeEach(func() {
+				oidcAuth.UserClaim = "dept"
+			})
+
+			It("returns that claim", func() {
+				Expect(username()).To(Equal("research"))
+			})
+		})
+
+		Context("when the ID token has no user claim", func() {
+			BeforeEach(func() {
+				delete(idTokenClaims, "email")
+			})
+
+			It("returns the subject", func() {
+				Expect(username()).To(Equal("some-subject"))
+			})
+		})
+	})
+})
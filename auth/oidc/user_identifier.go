@@ -0,0 +1,37 @@
+package oidc
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// ClaimsUserIdentifier reads the user's name from the user claim of their ID
+// token, falling back to its subject.
+type ClaimsUserIdentifier struct {
+	idTokens  *IDTokenVerifier
+	userClaim string
+}
+
+func NewClaimsUserIdentifier(idTokens *IDTokenVerifier, userClaim string) ClaimsUserIdentifier {
+	return ClaimsUserIdentifier{
+		idTokens:  idTokens,
+		userClaim: userClaim,
+	}
+}
+
+func (identifier ClaimsUserIdentifier) Username(logger lager.Logger, httpClient *http.Client) (string, error) {
+	claims, err := identifier.idTokens.Claims(httpClient)
+	if err != nil {
+		logger.Error("failed-to-verify-id-token", err)
+		return "", err
+	}
+
+	if user, ok := claims[identifier.userClaim].(string); ok && user != "" {
+		return user, nil
+	}
+
+	subject, _ := claims["sub"].(string)
+
+	return subject, nil
+}
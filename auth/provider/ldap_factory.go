@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"github.com/concourse/atc/auth/ldap"
+	"github.com/concourse/atc/db"
+)
+
+type LDAPFactory struct {
+	dialer ldap.Dialer
+}
+
+func NewLDAPFactory() LDAPFactory {
+	return LDAPFactory{
+		dialer: ldap.NetDialer{},
+	}
+}
+
+func (lf LDAPFactory) GetLDAPProvider(team db.SavedTeam) (LDAPProvider, bool) {
+	if team.LDAPAuth == nil {
+		return nil, false
+	}
+
+	return ldap.NewProvider(team.LDAPAuth, team.Roles, lf.dialer), true
+}
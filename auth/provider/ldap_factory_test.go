@@ -0,0 +1,45 @@
+package provider_test
+
+import (
+	"github.com/concourse/atc/auth/provider"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LDAPFactory", func() {
+	var ldapFactory provider.LDAPFactory
+
+	BeforeEach(func() {
+		ldapFactory = provider.NewLDAPFactory()
+	})
+
+	Describe("GetLDAPProvider", func() {
+		Context("when LDAP is set up", func() {
+			It("returns an LDAP provider", func() {
+				ldapProvider, found := ldapFactory.GetLDAPProvider(db.SavedTeam{
+					Team: db.Team{
+						Name: "some-team",
+						LDAPAuth: &db.LDAPAuth{
+							Host: "ldap.example.com:636",
+						},
+					},
+				})
+				Expect(found).To(BeTrue())
+				Expect(ldapProvider).NotTo(BeNil())
+			})
+		})
+
+		Context("when LDAP is not set up", func() {
+			It("returns false", func() {
+				_, found := ldapFactory.GetLDAPProvider(db.SavedTeam{
+					Team: db.Team{
+						Name: "some-team",
+					},
+				})
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
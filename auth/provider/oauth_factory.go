@@ -5,6 +5,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/auth/genericoauth"
 	"github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/auth/oidc"
 	"github.com/concourse/atc/auth/uaa"
 	"github.com/concourse/atc/db"
 	"github.com/tedsuo/rata"
@@ -15,6 +16,8 @@ type OAuthFactory struct {
 	atcExternalURL string
 	routes         rata.Routes
 	callback       string
+
+	oidcProviders *oidc.ProviderCache
 }
 
 func NewOAuthFactory(logger lager.Logger, atcExternalURL string, routes rata.Routes, callback string) OAuthFactory {
@@ -23,6 +26,8 @@ func NewOAuthFactory(logger lager.Logger, atcExternalURL string, routes rata.Rou
 		atcExternalURL: atcExternalURL,
 		routes:         routes,
 		callback:       callback,
+
+		oidcProviders: oidc.NewProviderCache(),
 	}
 }
 
@@ -41,7 +46,7 @@ func (of OAuthFactory) GetProvider(team db.SavedTeam, providerName string) (Prov
 			return nil, false, nil
 		}
 
-		return github.NewProvider(team.GitHubAuth, urljoiner.Join(of.atcExternalURL, redirectURL)), true, nil
+		return github.NewProvider(team.GitHubAuth, team.Roles, urljoiner.Join(of.atcExternalURL, redirectURL)), true, nil
 
 	case uaa.ProviderName:
 		if team.UAAAuth == nil {
@@ -49,14 +54,27 @@ func (of OAuthFactory) GetProvider(team db.SavedTeam, providerName string) (Prov
 			return nil, false, nil
 		}
 
-		return uaa.NewProvider(team.UAAAuth, urljoiner.Join(of.atcExternalURL, redirectURL)), true, nil
+		return uaa.NewProvider(team.UAAAuth, team.Roles, urljoiner.Join(of.atcExternalURL, redirectURL)), true, nil
 
 	case genericoauth.ProviderName:
 		if team.GenericOAuth == nil {
 			return nil, false, nil
 		}
 
-		return genericoauth.NewProvider(team.GenericOAuth, urljoiner.Join(of.atcExternalURL, redirectURL)), true, nil
+		return genericoauth.NewProvider(team.GenericOAuth, team.Roles, urljoiner.Join(of.atcExternalURL, redirectURL)), true, nil
+
+	case oidc.ProviderName:
+		if team.OIDCAuth == nil {
+			return nil, false, nil
+		}
+
+		p, err := of.oidcProviders.Provider(team.Name, team.OIDCAuth, team.Roles, urljoiner.Join(of.atcExternalURL, redirectURL))
+		if err != nil {
+			of.logger.Error("failed-to-construct-oidc-provider", err, lager.Data{"issuer": team.OIDCAuth.Issuer})
+			return nil, false, err
+		}
+
+		return p, true, nil
 
 	}
 
@@ -5,6 +5,7 @@ import (
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/genericoauth"
 	"github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/auth/oidc"
 	"github.com/concourse/atc/auth/provider"
 	"github.com/concourse/atc/auth/uaa"
 	"github.com/concourse/atc/db"
@@ -120,6 +121,100 @@ var _ = Describe("OAuthFactory", func() {
 			})
 		})
 
+		Context("when asking for oidc provider", func() {
+			var issuer *ghttp.Server
+
+			BeforeEach(func() {
+				issuer = ghttp.NewServer()
+			})
+
+			AfterEach(func() {
+				issuer.Close()
+			})
+
+			Context("when OIDC provider is setup", func() {
+				BeforeEach(func() {
+					issuer.RouteToHandler("GET", "/.well-known/openid-configuration", ghttp.RespondWithJSONEncoded(200, oidc.Discovery{
+						Issuer:                issuer.URL(),
+						AuthorizationEndpoint: issuer.URL() + "/authorize",
+						TokenEndpoint:         issuer.URL() + "/token",
+						JWKSURI:               issuer.URL() + "/keys",
+					}))
+				})
+
+				It("returns back OIDC's auth provider", func() {
+					provider, found, err := oauthFactory.GetProvider(db.SavedTeam{
+						Team: db.Team{
+							Name: "some-team",
+							OIDCAuth: &db.OIDCAuth{
+								Issuer:       issuer.URL(),
+								ClientID:     "user1",
+								ClientSecret: "password1",
+								Groups:       []string{"some-group"},
+							},
+						},
+					}, oidc.ProviderName)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(provider).NotTo(BeNil())
+				})
+
+				It("discovers the issuer once for the team", func() {
+					team := db.SavedTeam{
+						Team: db.Team{
+							Name: "some-team",
+							OIDCAuth: &db.OIDCAuth{
+								Issuer:       issuer.URL(),
+								ClientID:     "user1",
+								ClientSecret: "password1",
+								Groups:       []string{"some-group"},
+							},
+						},
+					}
+
+					_, _, err := oauthFactory.GetProvider(team, oidc.ProviderName)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, _, err = oauthFactory.GetProvider(team, oidc.ProviderName)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(issuer.ReceivedRequests()).To(HaveLen(1))
+				})
+			})
+
+			Context("when the issuer cannot be discovered", func() {
+				BeforeEach(func() {
+					issuer.RouteToHandler("GET", "/.well-known/openid-configuration", ghttp.RespondWith(404, ""))
+				})
+
+				It("returns an error", func() {
+					_, _, err := oauthFactory.GetProvider(db.SavedTeam{
+						Team: db.Team{
+							Name: "some-team",
+							OIDCAuth: &db.OIDCAuth{
+								Issuer:       issuer.URL(),
+								ClientID:     "user1",
+								ClientSecret: "password1",
+							},
+						},
+					}, oidc.ProviderName)
+					Expect(err).To(HaveOccurred())
+				})
+			})
+
+			Context("when OIDC provider is not setup", func() {
+				It("returns false", func() {
+					_, found, err := oauthFactory.GetProvider(db.SavedTeam{
+						Team: db.Team{
+							Name: "some-team",
+						},
+					}, oidc.ProviderName)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+			})
+		})
+
 		Context("when asking for unknown provider", func() {
 			It("returns false", func() {
 				_, found, err := oauthFactory.GetProvider(db.SavedTeam{
@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth/ldap"
 
 	"golang.org/x/net/context"
 	"golang.org/x/oauth2"
@@ -18,6 +20,8 @@ type Provider interface {
 
 	OAuthClient
 	Verifier
+	RoleVerifier
+	UserIdentifier
 }
 
 type OAuthClient interface {
@@ -31,3 +35,17 @@ type OAuthClient interface {
 type Verifier interface {
 	Verify(lager.Logger, *http.Client) (bool, error)
 }
+
+type RoleVerifier interface {
+	VerifyRole(lager.Logger, *http.Client) (atc.TeamRole, bool, error)
+}
+
+type UserIdentifier interface {
+	Username(lager.Logger, *http.Client) (string, error)
+}
+
+//go:generate counterfeiter . LDAPProvider
+
+type LDAPProvider interface {
+	Authenticate(logger lager.Logger, username string, password string) (ldap.User, bool, error)
+}
@@ -0,0 +1,86 @@
+// This file was generated by counterfeiter
+package providerfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/auth/ldap"
+	"github.com/concourse/atc/auth/provider"
+)
+
+type FakeLDAPProvider struct {
+	AuthenticateStub        func(logger lager.Logger, username string, password string) (ldap.User, bool, error)
+	authenticateMutex       sync.RWMutex
+	authenticateArgsForCall []struct {
+		logger   lager.Logger
+		username string
+		password string
+	}
+	authenticateReturns struct {
+		result1 ldap.User
+		result2 bool
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLDAPProvider) Authenticate(logger lager.Logger, username string, password string) (ldap.User, bool, error) {
+	fake.authenticateMutex.Lock()
+	fake.authenticateArgsForCall = append(fake.authenticateArgsForCall, struct {
+		logger   lager.Logger
+		username string
+		password string
+	}{logger, username, password})
+	fake.recordInvocation("Authenticate", []interface{}{logger, username, password})
+	fake.authenticateMutex.Unlock()
+	if fake.AuthenticateStub != nil {
+		return fake.AuthenticateStub(logger, username, password)
+	} else {
+		return fake.authenticateReturns.result1, fake.authenticateReturns.result2, fake.authenticateReturns.result3
+	}
+}
+
+func (fake *FakeLDAPProvider) AuthenticateCallCount() int {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return len(fake.authenticateArgsForCall)
+}
+
+func (fake *FakeLDAPProvider) AuthenticateArgsForCall(i int) (lager.Logger, string, string) {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return fake.authenticateArgsForCall[i].logger, fake.authenticateArgsForCall[i].username, fake.authenticateArgsForCall[i].password
+}
+
+func (fake *FakeLDAPProvider) AuthenticateReturns(result1 ldap.User, result2 bool, result3 error) {
+	fake.AuthenticateStub = nil
+	fake.authenticateReturns = struct {
+		result1 ldap.User
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeLDAPProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeLDAPProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ provider.LDAPProvider = new(FakeLDAPProvider)
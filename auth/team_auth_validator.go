@@ -3,20 +3,34 @@ package auth
 import (
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/auth/provider"
 	"github.com/concourse/atc/db"
 )
 
+//go:generate counterfeiter . LDAPProviderFactory
+
+type LDAPProviderFactory interface {
+	GetLDAPProvider(db.SavedTeam) (provider.LDAPProvider, bool)
+}
+
 type teamAuthValidator struct {
+	logger        lager.Logger
 	teamDBFactory db.TeamDBFactory
+	ldapFactory   LDAPProviderFactory
 	jwtValidator  Validator
 }
 
 func NewTeamAuthValidator(
+	logger lager.Logger,
 	teamDBFactory db.TeamDBFactory,
+	ldapFactory LDAPProviderFactory,
 	jwtValidator Validator,
 ) Validator {
 	return &teamAuthValidator{
+		logger:        logger,
 		teamDBFactory: teamDBFactory,
+		ldapFactory:   ldapFactory,
 		jwtValidator:  jwtValidator,
 	}
 }
@@ -37,5 +51,31 @@ func (v teamAuthValidator) IsAuthenticated(r *http.Request) bool {
 		return true
 	}
 
+	if team.LDAPAuth != nil && v.isLDAPAuthenticated(team, r) {
+		return true
+	}
+
 	return v.jwtValidator.IsAuthenticated(r)
 }
+
+func (v teamAuthValidator) isLDAPAuthenticated(team db.SavedTeam, r *http.Request) bool {
+	username, password, err := extractUsernameAndPassword(r.Header.Get("Authorization"))
+	if err != nil {
+		return false
+	}
+
+	ldapProvider, found := v.ldapFactory.GetLDAPProvider(team)
+	if !found {
+		return false
+	}
+
+	logger := v.logger.Session("ldap", lager.Data{"team": team.Name})
+
+	_, found, err = ldapProvider.Authenticate(logger, username, password)
+	if err != nil {
+		logger.Error("failed-to-authenticate", err)
+		return false
+	}
+
+	return found
+}
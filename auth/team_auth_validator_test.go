@@ -5,9 +5,12 @@ import (
 
 	"golang.org/x/crypto/bcrypt"
 
+	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/authfakes"
+	"github.com/concourse/atc/auth/ldap"
+	"github.com/concourse/atc/auth/provider/providerfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 
@@ -21,6 +24,7 @@ var _ = Describe("TeamAuthValidator", func() {
 		team         db.SavedTeam
 		teamDB       *dbfakes.FakeTeamDB
 		jwtValidator *authfakes.FakeValidator
+		ldapFactory  *authfakes.FakeLDAPProviderFactory
 
 		request           *http.Request
 		isAuthenticated   bool
@@ -48,7 +52,9 @@ var _ = Describe("TeamAuthValidator", func() {
 		teamDB = new(dbfakes.FakeTeamDB)
 		teamDBFactory.GetTeamDBReturns(teamDB)
 
-		validator = auth.NewTeamAuthValidator(teamDBFactory, jwtValidator)
+		ldapFactory = new(authfakes.FakeLDAPProviderFactory)
+
+		validator = auth.NewTeamAuthValidator(lagertest.NewTestLogger("test"), teamDBFactory, ldapFactory, jwtValidator)
 
 		request, err = http.NewRequest("GET", "http://example.com", nil)
 		Expect(err).ToNot(HaveOccurred())
@@ -99,6 +105,64 @@ var _ = Describe("TeamAuthValidator", func() {
 			})
 		})
 
+		Context("when team has ldap auth configured", func() {
+			var fakeLDAPProvider *providerfakes.FakeLDAPProvider
+
+			BeforeEach(func() {
+				team.LDAPAuth = &db.LDAPAuth{
+					Host: "ldap.example.com:636",
+				}
+				teamDB.GetTeamReturns(team, true, nil)
+
+				fakeLDAPProvider = new(providerfakes.FakeLDAPProvider)
+				ldapFactory.GetLDAPProviderReturns(fakeLDAPProvider, true)
+			})
+
+			Context("when the request has basic auth credentials", func() {
+				BeforeEach(func() {
+					request.Header.Set("Authorization", "Basic "+b64(username+":"+password))
+				})
+
+				It("authenticates them against the directory", func() {
+					Expect(ldapFactory.GetLDAPProviderArgsForCall(0)).To(Equal(team))
+					Expect(fakeLDAPProvider.AuthenticateCallCount()).To(Equal(1))
+					_, authUsername, authPassword := fakeLDAPProvider.AuthenticateArgsForCall(0)
+					Expect(authUsername).To(Equal(username))
+					Expect(authPassword).To(Equal(password))
+				})
+
+				Context("when the directory accepts them", func() {
+					BeforeEach(func() {
+						fakeLDAPProvider.AuthenticateReturns(ldap.User{Username: username}, true, nil)
+					})
+
+					It("returns true", func() {
+						Expect(isAuthenticated).To(BeTrue())
+					})
+				})
+
+				Context("when the directory rejects them", func() {
+					BeforeEach(func() {
+						fakeLDAPProvider.AuthenticateReturns(ldap.User{}, false, nil)
+					})
+
+					It("returns false", func() {
+						Expect(isAuthenticated).To(BeFalse())
+					})
+				})
+			})
+
+			Context("when the request has no basic auth credentials", func() {
+				It("does not ask the directory", func() {
+					Expect(fakeLDAPProvider.AuthenticateCallCount()).To(BeZero())
+				})
+
+				It("delegates to jwtValidator", func() {
+					Expect(jwtValidator.IsAuthenticatedCallCount()).To(Equal(1))
+				})
+			})
+		})
+
 		Context("when team has uaa auth configured", func() {
 			BeforeEach(func() {
 				team.UAAAuth = &db.UAAAuth{
@@ -4,6 +4,7 @@ import (
 	"crypto/rsa"
 	"time"
 
+	"github.com/concourse/atc"
 	"github.com/dgrijalva/jwt-go"
 )
 
@@ -17,9 +18,11 @@ const expClaimKey = "exp"
 const teamNameClaimKey = "teamName"
 const teamIDClaimKey = "teamID"
 const isAdminClaimKey = "isAdmin"
+const roleClaimKey = "role"
+const usernameClaimKey = "username"
 
 type TokenGenerator interface {
-	GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool) (TokenType, TokenValue, error)
+	GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool, role atc.TeamRole, username string) (TokenType, TokenValue, error)
 }
 
 type tokenGenerator struct {
@@ -32,12 +35,14 @@ func NewTokenGenerator(privateKey *rsa.PrivateKey) TokenGenerator {
 	}
 }
 
-func (generator *tokenGenerator) GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool) (TokenType, TokenValue, error) {
+func (generator *tokenGenerator) GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool, role atc.TeamRole, username string) (TokenType, TokenValue, error) {
 	jwtToken := jwt.NewWithClaims(SigningMethod, jwt.MapClaims{
 		"exp":      expiration.Unix(),
 		"teamName": teamName,
 		"teamID":   teamID,
 		"isAdmin":  isAdmin,
+		"role":     string(role),
+		"username": username,
 	})
 
 	signed, err := jwtToken.SignedString(generator.privateKey)
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth/verifier"
 	"github.com/concourse/atc/db"
 	"golang.org/x/net/context"
@@ -23,6 +24,8 @@ type Provider interface {
 
 	OAuthClient
 	Verifier
+	RoleVerifier
+	UserIdentifier
 }
 
 type OAuthClient interface {
@@ -35,8 +38,17 @@ type Verifier interface {
 	Verify(lager.Logger, *http.Client) (bool, error)
 }
 
+type RoleVerifier interface {
+	VerifyRole(lager.Logger, *http.Client) (atc.TeamRole, bool, error)
+}
+
+type UserIdentifier interface {
+	Username(lager.Logger, *http.Client) (string, error)
+}
+
 func NewProvider(
 	uaaAuth *db.UAAAuth,
+	roles []atc.TeamRoleMapping,
 	redirectURL string,
 ) Provider {
 	endpoint := oauth2.Endpoint{}
@@ -50,6 +62,8 @@ func NewProvider(
 			spaceGUIDs: uaaAuth.CFSpaces,
 			cfAPIURL:   uaaAuth.CFURL,
 		},
+		RoleVerifier:   verifier.NewRoleBasket(roleVerifiers(roles, uaaAuth.CFURL)),
+		UserIdentifier: TokenUserIdentifier{},
 		Config: &oauth2.Config{
 			ClientID:     uaaAuth.ClientID,
 			ClientSecret: uaaAuth.ClientSecret,
@@ -69,9 +83,22 @@ type uaaProvider struct {
 	// Client(context.Context, *oauth2.Token) *http.Client
 
 	verifier.Verifier
+	verifier.RoleVerifier
+	verifier.UserIdentifier
 	CFCACert string
 }
 
+func roleVerifiers(roles []atc.TeamRoleMapping, cfAPIURL string) map[atc.TeamRole]verifier.Verifier {
+	verifiers := map[atc.TeamRole]verifier.Verifier{}
+	for _, mapping := range roles {
+		verifiers[mapping.Role] = SpaceVerifier{
+			spaceGUIDs: mapping.CFSpaces,
+			cfAPIURL:   cfAPIURL,
+		}
+	}
+	return verifiers
+}
+
 func (p uaaProvider) PreTokenClient() (*http.Client, error) {
 	transport := &http.Transport{
 		DisableKeepAlives: true,
@@ -20,7 +20,7 @@ var _ = Describe("Provider", func() {
 	)
 
 	JustBeforeEach(func() {
-		uaaProvider = uaa.NewProvider(dbUAAAuth, redirectURI)
+		uaaProvider = uaa.NewProvider(dbUAAAuth, nil, redirectURI)
 	})
 
 	Describe("PreTokenClient", func() {
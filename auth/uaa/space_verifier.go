@@ -30,7 +30,8 @@ func NewSpaceVerifier(
 }
 
 type UAAToken struct {
-	UserID string `json:"user_id"`
+	UserID   string `json:"user_id"`
+	UserName string `json:"user_name"`
 }
 
 type CFSpaceDevelopersResponse struct {
@@ -45,28 +46,7 @@ type CFUserInfo struct {
 }
 
 func (verifier SpaceVerifier) Verify(logger lager.Logger, httpClient *http.Client) (bool, error) {
-	oauth2Transport, ok := httpClient.Transport.(*oauth2.Transport)
-	if !ok {
-		return false, errors.New("httpClient transport must be of type oauth2.Transport")
-	}
-
-	token, err := oauth2Transport.Source.Token()
-	if err != nil {
-		return false, err
-	}
-
-	tokenParts := strings.Split(token.AccessToken, ".")
-	if len(tokenParts) < 2 {
-		return false, errors.New("access token contains an invalid number of segments")
-	}
-
-	decodedClaims, err := jwt.DecodeSegment(tokenParts[1])
-	if err != nil {
-		return false, err
-	}
-
-	var uaaToken UAAToken
-	err = json.Unmarshal(decodedClaims, &uaaToken)
+	uaaToken, err := decodeUAAToken(httpClient)
 	if err != nil {
 		return false, err
 	}
@@ -139,3 +119,34 @@ func (verifier SpaceVerifier) isSpaceDeveloper(
 
 	return false, cfSpaceDevelopersResponse.NextUrl, nil
 }
+
+// decodeUAAToken reads the claims of the client's access token.
+func decodeUAAToken(httpClient *http.Client) (UAAToken, error) {
+	oauth2Transport, ok := httpClient.Transport.(*oauth2.Transport)
+	if !ok {
+		return UAAToken{}, errors.New("httpClient transport must be of type oauth2.Transport")
+	}
+
+	token, err := oauth2Transport.Source.Token()
+	if err != nil {
+		return UAAToken{}, err
+	}
+
+	tokenParts := strings.Split(token.AccessToken, ".")
+	if len(tokenParts) < 2 {
+		return UAAToken{}, errors.New("access token contains an invalid number of segments")
+	}
+
+	decodedClaims, err := jwt.DecodeSegment(tokenParts[1])
+	if err != nil {
+		return UAAToken{}, err
+	}
+
+	var uaaToken UAAToken
+	err = json.Unmarshal(decodedClaims, &uaaToken)
+	if err != nil {
+		return UAAToken{}, err
+	}
+
+	return uaaToken, nil
+}
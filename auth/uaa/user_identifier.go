@@ -0,0 +1,20 @@
+package uaa
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// TokenUserIdentifier reads the user's name from their UAA access token.
+type TokenUserIdentifier struct{}
+
+func (TokenUserIdentifier) Username(logger lager.Logger, httpClient *http.Client) (string, error) {
+	uaaToken, err := decodeUAAToken(httpClient)
+	if err != nil {
+		logger.Error("failed-to-decode-token", err)
+		return "", err
+	}
+
+	return uaaToken.UserName, nil
+}
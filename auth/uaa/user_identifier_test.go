@@ -0,0 +1,46 @@
+package uaa_test
+
+import (
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/concourse/atc/auth/uaa"
+	"github.com/dgrijalva/jwt-go"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TokenUserIdentifier", func() {
+	var httpClient *http.Client
+
+	BeforeEach(func() {
+		jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"exp":       time.Now().Add(time.Hour * 72).Unix(),
+			"user_id":   "my-user-id",
+			"user_name": "my-user",
+		})
+
+		accessToken, err := jwtToken.SigningString()
+		Expect(err).NotTo(HaveOccurred())
+
+		c := &oauth2.Config{}
+		httpClient = c.Client(oauth2.NoContext, &oauth2.Token{
+			AccessToken: accessToken,
+		})
+	})
+
+	It("returns the user name from the access token", func() {
+		username, err := TokenUserIdentifier{}.Username(lagertest.NewTestLogger("test"), httpClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(username).To(Equal("my-user"))
+	})
+
+	It("fails when the client has no access token", func() {
+		_, err := TokenUserIdentifier{}.Username(lagertest.NewTestLogger("test"), &http.Client{})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,46 @@
+package verifier
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/hashicorp/go-multierror"
+)
+
+type RoleVerifier interface {
+	VerifyRole(lager.Logger, *http.Client) (atc.TeamRole, bool, error)
+}
+
+type RoleBasket struct {
+	verifiers map[atc.TeamRole]Verifier
+}
+
+func NewRoleBasket(verifiers map[atc.TeamRole]Verifier) RoleBasket {
+	return RoleBasket{verifiers: verifiers}
+}
+
+// VerifyRole returns the most privileged role whose verifier accepts the
+// user. Errors are only returned if no verifier accepts the user.
+func (rb RoleBasket) VerifyRole(logger lager.Logger, client *http.Client) (atc.TeamRole, bool, error) {
+	var errors error
+
+	for _, role := range atc.TeamRoles {
+		verifier, found := rb.verifiers[role]
+		if !found {
+			continue
+		}
+
+		verified, err := verifier.Verify(logger.Session("verify-role", lager.Data{"role": role}), client)
+		if err != nil {
+			errors = multierror.Append(errors, err)
+			continue
+		}
+
+		if verified {
+			return role, true, nil
+		}
+	}
+
+	return "", false, errors
+}
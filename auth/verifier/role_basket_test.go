@@ -0,0 +1,85 @@
+package verifier_test
+
+import (
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth/provider/providerfakes"
+
+	. "github.com/concourse/atc/auth/verifier"
+)
+
+var _ = Describe("RoleBasket", func() {
+	var (
+		fakeOwnerVerifier    *providerfakes.FakeVerifier
+		fakeOperatorVerifier *providerfakes.FakeVerifier
+
+		httpClient *http.Client
+		roleBasket RoleVerifier
+	)
+
+	BeforeEach(func() {
+		fakeOwnerVerifier = new(providerfakes.FakeVerifier)
+		fakeOperatorVerifier = new(providerfakes.FakeVerifier)
+
+		httpClient = &http.Client{}
+		roleBasket = NewRoleBasket(map[atc.TeamRole]Verifier{
+			atc.RoleOwner:    fakeOwnerVerifier,
+			atc.RoleOperator: fakeOperatorVerifier,
+		})
+	})
+
+	It("returns the most privileged role that verifies", func() {
+		fakeOwnerVerifier.VerifyReturns(true, nil)
+		fakeOperatorVerifier.VerifyReturns(true, nil)
+
+		role, found, err := roleBasket.VerifyRole(lagertest.NewTestLogger("test"), httpClient)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(role).To(Equal(atc.RoleOwner))
+	})
+
+	It("falls back to less privileged roles", func() {
+		fakeOwnerVerifier.VerifyReturns(false, nil)
+		fakeOperatorVerifier.VerifyReturns(true, nil)
+
+		role, found, err := roleBasket.VerifyRole(lagertest.NewTestLogger("test"), httpClient)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(role).To(Equal(atc.RoleOperator))
+	})
+
+	It("does not find a role if none verify", func() {
+		fakeOwnerVerifier.VerifyReturns(false, nil)
+		fakeOperatorVerifier.VerifyReturns(false, nil)
+
+		_, found, err := roleBasket.VerifyRole(lagertest.NewTestLogger("test"), httpClient)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("does not error if a less privileged role verifies", func() {
+		fakeOwnerVerifier.VerifyReturns(false, errors.New("owner error"))
+		fakeOperatorVerifier.VerifyReturns(true, nil)
+
+		role, found, err := roleBasket.VerifyRole(lagertest.NewTestLogger("test"), httpClient)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(role).To(Equal(atc.RoleOperator))
+	})
+
+	It("errors if no role verifies and at least one errors", func() {
+		fakeOwnerVerifier.VerifyReturns(false, errors.New("owner error"))
+		fakeOperatorVerifier.VerifyReturns(false, nil)
+
+		_, found, err := roleBasket.VerifyRole(lagertest.NewTestLogger("test"), httpClient)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("owner error"))
+		Expect(found).To(BeFalse())
+	})
+})
@@ -9,3 +9,9 @@ import (
 type Verifier interface {
 	Verify(lager.Logger, *http.Client) (bool, error)
 }
+
+// UserIdentifier returns the name of the user who authorized the client, such
+// as their login or email, so that it can be recorded in their token.
+type UserIdentifier interface {
+	Username(lager.Logger, *http.Client) (string, error)
+}
@@ -10,6 +10,8 @@ var teamNameKey = "teamName"
 var teamIDKey = "teamID"
 var isAdminKey = "isAdmin"
 var isSystemKey = "system"
+var roleKey = "role"
+var usernameKey = "username"
 
 func WrapHandler(
 	handler http.Handler,
@@ -36,6 +38,16 @@ func (h authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		ctx = context.WithValue(ctx, teamNameKey, teamName)
 		ctx = context.WithValue(ctx, teamIDKey, teamID)
 		ctx = context.WithValue(ctx, isAdminKey, isAdmin)
+
+		role, found := h.userContextReader.GetRole(r)
+		if found {
+			ctx = context.WithValue(ctx, roleKey, role)
+		}
+
+		username, found := h.userContextReader.GetUsername(r)
+		if found {
+			ctx = context.WithValue(ctx, usernameKey, username)
+		}
 	}
 
 	isSystem, found := h.userContextReader.GetSystem(r)
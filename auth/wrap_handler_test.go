@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/authfakes"
 )
@@ -22,7 +23,9 @@ var _ = Describe("WrapHandler", func() {
 
 		authenticated   <-chan bool
 		teamNameChan    <-chan string
+		usernameChan    <-chan string
 		isAdminChan     <-chan bool
+		isMemberChan    <-chan bool
 		isSystemChan    <-chan bool
 		foundChan       <-chan bool
 		systemFoundChan <-chan bool
@@ -34,14 +37,18 @@ var _ = Describe("WrapHandler", func() {
 
 		a := make(chan bool, 1)
 		tn := make(chan string, 1)
+		un := make(chan string, 1)
 		ia := make(chan bool, 1)
+		im := make(chan bool, 1)
 		is := make(chan bool, 1)
 		f := make(chan bool, 1)
 		sf := make(chan bool, 1)
 
 		authenticated = a
 		teamNameChan = tn
+		usernameChan = un
 		isAdminChan = ia
+		isMemberChan = im
 		isSystemChan = is
 		foundChan = f
 		systemFoundChan = sf
@@ -55,6 +62,10 @@ var _ = Describe("WrapHandler", func() {
 			if authTeam != nil {
 				tn <- authTeam.Name()
 				ia <- authTeam.IsAdmin()
+				im <- authTeam.HasRole(atc.RoleMember)
+			}
+			if username, found := auth.GetUsername(r); found {
+				un <- username
 			}
 			if systemFound {
 				is <- isSystem
@@ -120,6 +131,40 @@ var _ = Describe("WrapHandler", func() {
 				Expect(<-teamNameChan).To(Equal("some-team"))
 				Expect(<-isAdminChan).To(BeTrue())
 			})
+
+			It("treats the team as having every role", func() {
+				Expect(<-isMemberChan).To(BeTrue())
+			})
+
+			Context("when the userContextReader finds a role", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetRoleReturns(atc.RoleOperator, true)
+				})
+
+				It("passes the role along in the request object", func() {
+					Expect(<-isMemberChan).To(BeFalse())
+				})
+			})
+
+			Context("when the userContextReader finds a username", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetUsernameReturns("some-user", true)
+				})
+
+				It("passes the username along in the request object", func() {
+					Expect(<-usernameChan).To(Equal("some-user"))
+				})
+			})
+
+			Context("when the userContextReader does not find a username", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetUsernameReturns("", false)
+				})
+
+				It("does not pass a username along in the request object", func() {
+					Consistently(usernameChan).ShouldNot(Receive())
+				})
+			})
 		})
 
 		Context("when the userContextReader does not find team information", func() {
@@ -149,3 +149,49 @@ func (auth *UAAAuthFlag) Validate() error {
 	}
 	return errs.ErrorOrNil()
 }
+
+type OIDCAuthFlag struct {
+	DisplayName  string            `long:"display-name"  description:"Name for this auth method on the web UI."`
+	Issuer       string            `long:"issuer"        description:"OIDC issuer URL. Its endpoints and signing keys are discovered from it."`
+	ClientID     string            `long:"client-id"     description:"Application client ID for enabling OIDC."`
+	ClientSecret string            `long:"client-secret" description:"Application client secret for enabling OIDC."`
+	Scopes       []string          `long:"scope"         description:"Scope to request in addition to openid, profile and email. Can be specified multiple times."`
+	Groups       []string          `long:"group"         description:"Group whose members will have access." value-name:"GROUP"`
+	GroupsClaim  string            `long:"groups-claim"  description:"ID token claim listing the user's groups." default:"groups"`
+	Users        []string          `long:"user"          description:"User to permit access." value-name:"USER"`
+	UserClaim    string            `long:"user-claim"    description:"ID token claim naming the user." default:"email"`
+	Claims       map[string]string `long:"claim"         description:"ID token claim and value which permit access. Can be specified multiple times." value-name:"CLAIM:VALUE"`
+	CACert       PathFlag          `long:"ca-cert"       description:"Path to the issuer's PEM-encoded CA certificate file."`
+}
+
+func (auth *OIDCAuthFlag) IsConfigured() bool {
+	return auth.Issuer != "" ||
+		auth.ClientID != "" ||
+		auth.ClientSecret != "" ||
+		len(auth.Groups) > 0 ||
+		len(auth.Users) > 0 ||
+		len(auth.Claims) > 0
+}
+
+func (auth *OIDCAuthFlag) Validate() error {
+	var errs *multierror.Error
+	if auth.ClientID == "" || auth.ClientSecret == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --oidc-auth-client-id and --oidc-auth-client-secret to use OIDC."),
+		)
+	}
+	if auth.Issuer == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --oidc-auth-issuer to use OIDC."),
+		)
+	}
+	if len(auth.Groups) == 0 && len(auth.Users) == 0 && len(auth.Claims) == 0 {
+		errs = multierror.Append(
+			errs,
+			errors.New("at least one of the following is required for oidc-auth: groups, users, claims."),
+		)
+	}
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,76 @@
+package blobstore_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/concourse/atc/blobstore"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LocalStore", func() {
+	var (
+		dir   string
+		store Store
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "blobstore")
+		Expect(err).NotTo(HaveOccurred())
+
+		store = NewLocalStore(dir)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("returns what was put under the same key", func() {
+		err := store.Put("some/nested/key", bytes.NewBufferString("some-contents"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(filepath.Join(dir, "some", "nested", "key")).To(BeARegularFile())
+
+		reader, err := store.Get("some/nested/key")
+		Expect(err).NotTo(HaveOccurred())
+		defer reader.Close()
+
+		contents, err := ioutil.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).To(Equal("some-contents"))
+	})
+
+	It("replaces existing contents", func() {
+		err := store.Put("some-key", bytes.NewBufferString("old-contents"))
+		Expect(err).NotTo(HaveOccurred())
+
+		err = store.Put("some-key", bytes.NewBufferString("new-contents"))
+		Expect(err).NotTo(HaveOccurred())
+
+		reader, err := store.Get("some-key")
+		Expect(err).NotTo(HaveOccurred())
+		defer reader.Close()
+
+		contents, err := ioutil.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).To(Equal("new-contents"))
+	})
+
+	It("returns ErrNotFound for missing keys", func() {
+		_, err := store.Get("bogus-key")
+		Expect(err).To(Equal(ErrNotFound))
+	})
+
+	It("rejects keys that escape the directory", func() {
+		err := store.Put("../some-key", bytes.NewBufferString("some-contents"))
+		Expect(err).To(HaveOccurred())
+
+		_, err = store.Get("some/../../key")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,17 @@
+package blobstore
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+//go:generate counterfeiter . Store
+
+// Store persists opaque blobs by key. Get returns ErrNotFound if nothing has
+// been stored under the key.
+type Store interface {
+	Put(key string, contents io.Reader) error
+	Get(key string) (io.ReadCloser, error)
+}
@@ -23,6 +23,7 @@ type Build struct {
 	StartTime    int64  `json:"start_time,omitempty"`
 	EndTime      int64  `json:"end_time,omitempty"`
 	ReapTime     int64  `json:"reap_time,omitempty"`
+	RerunOf      int    `json:"rerun_of,omitempty"`
 }
 
 func (b Build) IsRunning() bool {
@@ -52,6 +53,16 @@ const (
 
 type MissingInputReasons map[string]string
 
+// SchedulingExplanation describes, for each input keeping a job's next build
+// from being scheduled, why it does.
+type SchedulingExplanation map[string]InputSchedulingExplanation
+
+type InputSchedulingExplanation struct {
+	Reason        string   `json:"reason"`
+	Passed        []string `json:"passed,omitempty"`
+	ConflictsWith []string `json:"conflicts_with,omitempty"`
+}
+
 type BuildPreparation struct {
 	BuildID             int                               `json:"build_id"`
 	PausedPipeline      BuildPreparationStatus            `json:"paused_pipeline"`
@@ -60,4 +71,24 @@ type BuildPreparation struct {
 	Inputs              map[string]BuildPreparationStatus `json:"inputs"`
 	InputsSatisfied     BuildPreparationStatus            `json:"inputs_satisfied"`
 	MissingInputReasons MissingInputReasons               `json:"missing_input_reasons"`
+
+	SchedulingExplanation SchedulingExplanation `json:"scheduling_explanation,omitempty"`
+}
+
+// BuildLogSearchResult is a build whose logs matched a search.
+type BuildLogSearchResult struct {
+	Build   Build           `json:"build"`
+	Matches []BuildLogMatch `json:"matches"`
+}
+
+// BuildLogMatch is the matching lines a step printed.
+type BuildLogMatch struct {
+	Origin BuildLogOrigin `json:"origin"`
+	Lines  []string       `json:"lines"`
+}
+
+type BuildLogOrigin struct {
+	ID     string `json:"id,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Source string `json:"source,omitempty"`
 }
@@ -0,0 +1,87 @@
+package buildarchiver
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+
+	"github.com/concourse/atc/blobstore"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+)
+
+type archive struct {
+	store blobstore.Store
+}
+
+// NewArchive returns a db.BuildLogArchive which reads the events written by
+// the BuildArchiver back out of the store.
+func NewArchive(store blobstore.Store) db.BuildLogArchive {
+	return &archive{store: store}
+}
+
+func (a *archive) Events(buildID int, from uint) (db.EventSource, error) {
+	blob, err := a.store.Get(BuildEventsKey(buildID))
+	if err != nil {
+		return nil, err
+	}
+
+	gz, err := gzip.NewReader(blob)
+	if err != nil {
+		blob.Close()
+		return nil, err
+	}
+
+	source := &archivedEventSource{
+		blob:    blob,
+		decoder: json.NewDecoder(gz),
+	}
+
+	for i := uint(0); i < from; i++ {
+		_, err := source.Next()
+		if err == db.ErrEndOfBuildEventStream {
+			break
+		}
+
+		if err != nil {
+			source.Close()
+			return nil, err
+		}
+	}
+
+	return source, nil
+}
+
+type archivedEventSource struct {
+	blob    io.ReadCloser
+	decoder *json.Decoder
+	closed  bool
+}
+
+func (source *archivedEventSource) Next() (event.Envelope, error) {
+	if source.closed {
+		return event.Envelope{}, db.ErrBuildEventStreamClosed
+	}
+
+	var envelope event.Envelope
+	err := source.decoder.Decode(&envelope)
+	if err != nil {
+		if err == io.EOF {
+			return event.Envelope{}, db.ErrEndOfBuildEventStream
+		}
+
+		return event.Envelope{}, err
+	}
+
+	return envelope, nil
+}
+
+func (source *archivedEventSource) Close() error {
+	if source.closed {
+		return nil
+	}
+
+	source.closed = true
+
+	return source.blob.Close()
+}
@@ -0,0 +1,136 @@
+package buildarchiver
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/blobstore"
+	"github.com/concourse/atc/db"
+)
+
+//go:generate counterfeiter . BuildArchiverDB
+
+type BuildArchiverDB interface {
+	GetBuildsToArchive(finishedBefore time.Time, limit int) ([]db.Build, error)
+	MarkBuildArchived(buildID int) error
+}
+
+type BuildArchiver interface {
+	Run() error
+}
+
+type buildArchiver struct {
+	logger       lager.Logger
+	db           BuildArchiverDB
+	store        blobstore.Store
+	clock        clock.Clock
+	archiveAfter time.Duration
+	batchSize    int
+}
+
+// NewBuildArchiver returns a BuildArchiver which moves the events of builds
+// that finished more than archiveAfter ago out of the database and into the
+// given store, batchSize builds at a time.
+func NewBuildArchiver(
+	logger lager.Logger,
+	db BuildArchiverDB,
+	store blobstore.Store,
+	clock clock.Clock,
+	archiveAfter time.Duration,
+	batchSize int,
+) BuildArchiver {
+	return &buildArchiver{
+		logger:       logger,
+		db:           db,
+		store:        store,
+		clock:        clock,
+		archiveAfter: archiveAfter,
+		batchSize:    batchSize,
+	}
+}
+
+// BuildEventsKey is the key under which a build's archived events are stored.
+func BuildEventsKey(buildID int) string {
+	return fmt.Sprintf("builds/%d/events.json.gz", buildID)
+}
+
+func (ba *buildArchiver) Run() error {
+	builds, err := ba.db.GetBuildsToArchive(ba.clock.Now().Add(-ba.archiveAfter), ba.batchSize)
+	if err != nil {
+		ba.logger.Error("failed-to-get-builds-to-archive", err)
+		return err
+	}
+
+	for _, build := range builds {
+		logger := ba.logger.Session("archive", lager.Data{"build-id": build.ID()})
+
+		err := ba.archive(build)
+		if err != nil {
+			logger.Error("failed-to-archive-build", err)
+			continue
+		}
+
+		err = ba.db.MarkBuildArchived(build.ID())
+		if err != nil {
+			logger.Error("failed-to-mark-build-archived", err)
+			continue
+		}
+
+		logger.Info("archived")
+	}
+
+	return nil
+}
+
+func (ba *buildArchiver) archive(build db.Build) error {
+	events, err := build.Events(0)
+	if err != nil {
+		return err
+	}
+
+	defer events.Close()
+
+	reader, writer := io.Pipe()
+
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		writer.CloseWithError(writeEvents(writer, events))
+	}()
+
+	err = ba.store.Put(BuildEventsKey(build.ID()), reader)
+
+	// unblock the writer if the store gave up before reading everything
+	reader.Close()
+	<-written
+
+	return err
+}
+
+func writeEvents(w io.Writer, events db.EventSource) error {
+	gz := gzip.NewWriter(w)
+	encoder := json.NewEncoder(gz)
+
+	for {
+		ev, err := events.Next()
+		if err != nil {
+			if err == db.ErrEndOfBuildEventStream {
+				break
+			}
+
+			return err
+		}
+
+		err = encoder.Encode(ev)
+		if err != nil {
+			return err
+		}
+	}
+
+	return gz.Close()
+}
@@ -0,0 +1,13 @@
+package buildarchiver_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestBuildArchiver(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Build Archiver Suite")
+}
@@ -0,0 +1,203 @@
+package buildarchiver_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/blobstore"
+	"github.com/concourse/atc/blobstore/blobstorefakes"
+	. "github.com/concourse/atc/buildarchiver"
+	"github.com/concourse/atc/buildarchiver/buildarchiverfakes"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildArchiver", func() {
+	var (
+		fakeDB    *buildarchiverfakes.FakeBuildArchiverDB
+		fakeClock *fakeclock.FakeClock
+		storeDir  string
+		store     blobstore.Store
+
+		archiver BuildArchiver
+
+		fakeBuild *dbfakes.FakeBuild
+		events    []event.Envelope
+		runErr    error
+	)
+
+	BeforeEach(func() {
+		fakeDB = new(buildarchiverfakes.FakeBuildArchiverDB)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+		var err error
+		storeDir, err = ioutil.TempDir("", "build-archiver")
+		Expect(err).NotTo(HaveOccurred())
+
+		store = blobstore.NewLocalStore(storeDir)
+
+		events = []event.Envelope{
+			envelope(event.Log{Payload: "some log"}),
+			envelope(event.Status{Status: atc.StatusSucceeded}),
+		}
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDStub = func() int { return 42 }
+		fakeBuild.EventsStub = func(from uint) (db.EventSource, error) {
+			return eventSource(events), nil
+		}
+
+		fakeDB.GetBuildsToArchiveReturns([]db.Build{fakeBuild}, nil)
+	})
+
+	JustBeforeEach(func() {
+		archiver = NewBuildArchiver(lagertest.NewTestLogger("test"), fakeDB, store, fakeClock, time.Hour, 10)
+		runErr = archiver.Run()
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(storeDir)
+	})
+
+	It("looks for builds that finished before the archive threshold", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+
+		Expect(fakeDB.GetBuildsToArchiveCallCount()).To(Equal(1))
+		finishedBefore, limit := fakeDB.GetBuildsToArchiveArgsForCall(0)
+		Expect(finishedBefore).To(Equal(fakeClock.Now().Add(-time.Hour)))
+		Expect(limit).To(Equal(10))
+	})
+
+	It("stores the build's events so that the archive can read them back", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+
+		source, err := NewArchive(store).Events(42, 0)
+		Expect(err).NotTo(HaveOccurred())
+		defer source.Close()
+
+		Expect(readAll(source)).To(Equal(events))
+	})
+
+	It("marks the build as archived", func() {
+		Expect(fakeDB.MarkBuildArchivedCallCount()).To(Equal(1))
+		Expect(fakeDB.MarkBuildArchivedArgsForCall(0)).To(Equal(42))
+	})
+
+	Context("when reading events from an offset", func() {
+		It("skips the events before it", func() {
+			source, err := NewArchive(store).Events(42, 1)
+			Expect(err).NotTo(HaveOccurred())
+			defer source.Close()
+
+			Expect(readAll(source)).To(Equal(events[1:]))
+		})
+	})
+
+	Context("when the events cannot be read", func() {
+		BeforeEach(func() {
+			fakeBuild.EventsReturns(nil, errors.New("nope"))
+			fakeBuild.EventsStub = nil
+		})
+
+		It("does not mark the build as archived", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeDB.MarkBuildArchivedCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when storing the events fails", func() {
+		BeforeEach(func() {
+			fakeStore := new(blobstorefakes.FakeStore)
+			fakeStore.PutStub = func(key string, contents io.Reader) error {
+				return errors.New("nope")
+			}
+
+			store = fakeStore
+		})
+
+		It("does not mark the build as archived", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeDB.MarkBuildArchivedCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when getting the builds fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDB.GetBuildsToArchiveReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+})
+
+var _ = Describe("Archive", func() {
+	It("returns an error when the build has not been archived", func() {
+		fakeStore := new(blobstorefakes.FakeStore)
+		fakeStore.GetReturns(nil, blobstore.ErrNotFound)
+
+		_, err := NewArchive(fakeStore).Events(42, 0)
+		Expect(err).To(Equal(blobstore.ErrNotFound))
+
+		Expect(fakeStore.GetArgsForCall(0)).To(Equal(BuildEventsKey(42)))
+	})
+})
+
+func envelope(ev atc.Event) event.Envelope {
+	payload, err := json.Marshal(ev)
+	Expect(err).ToNot(HaveOccurred())
+
+	data := json.RawMessage(payload)
+
+	return event.Envelope{
+		Event:   ev.EventType(),
+		Version: ev.Version(),
+		Data:    &data,
+	}
+}
+
+func eventSource(events []event.Envelope) db.EventSource {
+	source := new(dbfakes.FakeEventSource)
+
+	remaining := events
+	source.NextStub = func() (event.Envelope, error) {
+		if len(remaining) == 0 {
+			return event.Envelope{}, db.ErrEndOfBuildEventStream
+		}
+
+		next := remaining[0]
+		remaining = remaining[1:]
+
+		return next, nil
+	}
+
+	return source
+}
+
+func readAll(source db.EventSource) []event.Envelope {
+	var envelopes []event.Envelope
+
+	for {
+		ev, err := source.Next()
+		if err == db.ErrEndOfBuildEventStream {
+			return envelopes
+		}
+
+		Expect(err).NotTo(HaveOccurred())
+		envelopes = append(envelopes, ev)
+	}
+}
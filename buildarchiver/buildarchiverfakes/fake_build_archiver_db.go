@@ -0,0 +1,125 @@
+// This file was generated by counterfeiter
+package buildarchiverfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/atc/buildarchiver"
+	"github.com/concourse/atc/db"
+)
+
+type FakeBuildArchiverDB struct {
+	GetBuildsToArchiveStub        func(finishedBefore time.Time, limit int) ([]db.Build, error)
+	getBuildsToArchiveMutex       sync.RWMutex
+	getBuildsToArchiveArgsForCall []struct {
+		finishedBefore time.Time
+		limit          int
+	}
+	getBuildsToArchiveReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	MarkBuildArchivedStub        func(buildID int) error
+	markBuildArchivedMutex       sync.RWMutex
+	markBuildArchivedArgsForCall []struct {
+		buildID int
+	}
+	markBuildArchivedReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBuildArchiverDB) GetBuildsToArchive(finishedBefore time.Time, limit int) ([]db.Build, error) {
+	fake.getBuildsToArchiveMutex.Lock()
+	fake.getBuildsToArchiveArgsForCall = append(fake.getBuildsToArchiveArgsForCall, struct {
+		finishedBefore time.Time
+		limit          int
+	}{finishedBefore, limit})
+	fake.recordInvocation("GetBuildsToArchive", []interface{}{finishedBefore, limit})
+	fake.getBuildsToArchiveMutex.Unlock()
+	if fake.GetBuildsToArchiveStub != nil {
+		return fake.GetBuildsToArchiveStub(finishedBefore, limit)
+	} else {
+		return fake.getBuildsToArchiveReturns.result1, fake.getBuildsToArchiveReturns.result2
+	}
+}
+
+func (fake *FakeBuildArchiverDB) GetBuildsToArchiveCallCount() int {
+	fake.getBuildsToArchiveMutex.RLock()
+	defer fake.getBuildsToArchiveMutex.RUnlock()
+	return len(fake.getBuildsToArchiveArgsForCall)
+}
+
+func (fake *FakeBuildArchiverDB) GetBuildsToArchiveArgsForCall(i int) (time.Time, int) {
+	fake.getBuildsToArchiveMutex.RLock()
+	defer fake.getBuildsToArchiveMutex.RUnlock()
+	return fake.getBuildsToArchiveArgsForCall[i].finishedBefore, fake.getBuildsToArchiveArgsForCall[i].limit
+}
+
+func (fake *FakeBuildArchiverDB) GetBuildsToArchiveReturns(result1 []db.Build, result2 error) {
+	fake.GetBuildsToArchiveStub = nil
+	fake.getBuildsToArchiveReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildArchiverDB) MarkBuildArchived(buildID int) error {
+	fake.markBuildArchivedMutex.Lock()
+	fake.markBuildArchivedArgsForCall = append(fake.markBuildArchivedArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.recordInvocation("MarkBuildArchived", []interface{}{buildID})
+	fake.markBuildArchivedMutex.Unlock()
+	if fake.MarkBuildArchivedStub != nil {
+		return fake.MarkBuildArchivedStub(buildID)
+	} else {
+		return fake.markBuildArchivedReturns.result1
+	}
+}
+
+func (fake *FakeBuildArchiverDB) MarkBuildArchivedCallCount() int {
+	fake.markBuildArchivedMutex.RLock()
+	defer fake.markBuildArchivedMutex.RUnlock()
+	return len(fake.markBuildArchivedArgsForCall)
+}
+
+func (fake *FakeBuildArchiverDB) MarkBuildArchivedArgsForCall(i int) int {
+	fake.markBuildArchivedMutex.RLock()
+	defer fake.markBuildArchivedMutex.RUnlock()
+	return fake.markBuildArchivedArgsForCall[i].buildID
+}
+
+func (fake *FakeBuildArchiverDB) MarkBuildArchivedReturns(result1 error) {
+	fake.MarkBuildArchivedStub = nil
+	fake.markBuildArchivedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildArchiverDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getBuildsToArchiveMutex.RLock()
+	defer fake.getBuildsToArchiveMutex.RUnlock()
+	fake.markBuildArchivedMutex.RLock()
+	defer fake.markBuildArchivedMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeBuildArchiverDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ buildarchiver.BuildArchiverDB = new(FakeBuildArchiverDB)
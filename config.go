@@ -17,6 +17,11 @@ type ConfigResponse struct {
 	Config    *Config   `json:"config"`
 	Errors    []string  `json:"errors"`
 	RawConfig RawConfig `json:"raw_config"`
+
+	// Template and Vars are only set for pipelines which were configured from
+	// a template.
+	Template RawConfig `json:"template,omitempty"`
+	Vars     Vars      `json:"vars,omitempty"`
 }
 
 type Config struct {
@@ -32,6 +37,10 @@ func (r RawConfig) String() string {
 	return string(r)
 }
 
+// Vars are substituted for the ((var)) placeholders of a config template when
+// the pipeline is configured.
+type Vars map[string]interface{}
+
 type GroupConfig struct {
 	Name      string   `yaml:"name" json:"name" mapstructure:"name"`
 	Jobs      []string `yaml:"jobs,omitempty" json:"jobs,omitempty" mapstructure:"jobs"`
@@ -53,9 +62,10 @@ func (groups GroupConfigs) Lookup(name string) (GroupConfig, bool) {
 type ResourceConfig struct {
 	Name string `yaml:"name" json:"name" mapstructure:"name"`
 
-	Type       string `yaml:"type" json:"type" mapstructure:"type"`
-	Source     Source `yaml:"source" json:"source" mapstructure:"source"`
-	CheckEvery string `yaml:"check_every,omitempty" json:"check_every" mapstructure:"check_every"`
+	Type         string `yaml:"type" json:"type" mapstructure:"type"`
+	Source       Source `yaml:"source" json:"source" mapstructure:"source"`
+	CheckEvery   string `yaml:"check_every,omitempty" json:"check_every" mapstructure:"check_every"`
+	WebhookToken string `yaml:"webhook_token,omitempty" json:"webhook_token,omitempty" mapstructure:"webhook_token"`
 }
 
 type ResourceType struct {
@@ -242,6 +252,10 @@ type PlanConfig struct {
 	// corresponds to an Aggregate plan, keyed by the name of each sub-plan
 	Aggregate *PlanSequence `yaml:"aggregate,omitempty" json:"aggregate,omitempty" mapstructure:"aggregate"`
 
+	// corresponds to an InParallel plan, which runs a bounded number of
+	// sub-plans at a time and optionally stops at the first failure
+	InParallel *InParallelConfig `yaml:"in_parallel,omitempty" json:"in_parallel,omitempty" mapstructure:"in_parallel"`
+
 	// corresponds to Get and Put resource plans, respectively
 	// name of 'input', e.g. bosh-stemcell
 	Get string `yaml:"get,omitempty" json:"get,omitempty" mapstructure:"get"`
@@ -294,6 +308,10 @@ type PlanConfig struct {
 	// used on any step to swallow failures and errors
 	Try *PlanConfig `yaml:"try,omitempty" json:"try,omitempty" mapstructure:"try"`
 
+	// run the step once for every combination of the given vars' values,
+	// substituting them for ((var)) placeholders in its params and task config
+	Across []AcrossVarConfig `yaml:"across,omitempty" json:"across,omitempty" mapstructure:"across"`
+
 	// used on any step to interrupt the step after a given duration
 	Timeout string `yaml:"timeout,omitempty" json:"timeout,omitempty" mapstructure:"timeout"`
 
@@ -306,6 +324,24 @@ type PlanConfig struct {
 	Version *VersionConfig `yaml:"version,omitempty" json:"version,omitempty" mapstructure:"version"`
 }
 
+// An AcrossVarConfig names a var and the values that an across step will
+// substitute for it.
+type AcrossVarConfig struct {
+	Var    string        `yaml:"var" json:"var" mapstructure:"var"`
+	Values []interface{} `yaml:"values" json:"values" mapstructure:"values"`
+}
+
+// An InParallelConfig configures a set of steps to run in parallel.
+type InParallelConfig struct {
+	Steps PlanSequence `yaml:"steps,omitempty" json:"steps,omitempty" mapstructure:"steps"`
+
+	// maximum number of steps to run at once; 0 means no limit
+	Limit int `yaml:"limit,omitempty" json:"limit,omitempty" mapstructure:"limit"`
+
+	// interrupt the remaining steps as soon as one of them fails
+	FailFast bool `yaml:"fail_fast,omitempty" json:"fail_fast,omitempty" mapstructure:"fail_fast"`
+}
+
 func (config PlanConfig) Name() string {
 	if config.RawName != "" {
 		return config.RawName
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/concourse/atc"
+)
+
+// Diff compares two configs. Groups, resources, resource types and jobs are
+// matched up by name; any whose configuration differs at all is changed.
+func Diff(from atc.Config, to atc.Config) atc.ConfigDiff {
+	fromGroups, toGroups := map[string]interface{}{}, map[string]interface{}{}
+	for _, group := range from.Groups {
+		fromGroups[group.Name] = group
+	}
+	for _, group := range to.Groups {
+		toGroups[group.Name] = group
+	}
+
+	fromResources, toResources := map[string]interface{}{}, map[string]interface{}{}
+	for _, resource := range from.Resources {
+		fromResources[resource.Name] = resource
+	}
+	for _, resource := range to.Resources {
+		toResources[resource.Name] = resource
+	}
+
+	fromResourceTypes, toResourceTypes := map[string]interface{}{}, map[string]interface{}{}
+	for _, resourceType := range from.ResourceTypes {
+		fromResourceTypes[resourceType.Name] = resourceType
+	}
+	for _, resourceType := range to.ResourceTypes {
+		toResourceTypes[resourceType.Name] = resourceType
+	}
+
+	fromJobs, toJobs := map[string]interface{}{}, map[string]interface{}{}
+	for _, job := range from.Jobs {
+		fromJobs[job.Name] = job
+	}
+	for _, job := range to.Jobs {
+		toJobs[job.Name] = job
+	}
+
+	return atc.ConfigDiff{
+		Groups:        diffNamed(fromGroups, toGroups),
+		Resources:     diffNamed(fromResources, toResources),
+		ResourceTypes: diffNamed(fromResourceTypes, toResourceTypes),
+		Jobs:          diffNamed(fromJobs, toJobs),
+	}
+}
+
+func diffNamed(from map[string]interface{}, to map[string]interface{}) atc.ConfigChanges {
+	var changes atc.ConfigChanges
+
+	for name, fromConfig := range from {
+		toConfig, found := to[name]
+		if !found {
+			changes.Removed = append(changes.Removed, name)
+		} else if !reflect.DeepEqual(fromConfig, toConfig) {
+			changes.Changed = append(changes.Changed, name)
+		}
+	}
+
+	for name := range to {
+		if _, found := from[name]; !found {
+			changes.Added = append(changes.Added, name)
+		}
+	}
+
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Removed)
+	sort.Strings(changes.Changed)
+
+	return changes
+}
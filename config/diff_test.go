@@ -0,0 +1,65 @@
+package config_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diff", func() {
+	var from atc.Config
+
+	BeforeEach(func() {
+		from = atc.Config{
+			Groups: atc.GroupConfigs{
+				{Name: "some-group", Jobs: []string{"some-job"}},
+			},
+			Resources: atc.ResourceConfigs{
+				{Name: "some-resource", Type: "git", Source: atc.Source{"uri": "some-uri"}},
+				{Name: "removed-resource", Type: "git"},
+			},
+			ResourceTypes: atc.ResourceTypes{
+				{Name: "some-resource-type", Type: "docker-image"},
+			},
+			Jobs: atc.JobConfigs{
+				{Name: "some-job", Public: true},
+				{Name: "other-job"},
+			},
+		}
+	})
+
+	It("finds no changes between identical configs", func() {
+		Expect(config.Diff(from, from)).To(Equal(atc.ConfigDiff{}))
+	})
+
+	It("lists what was added, removed and changed by name", func() {
+		to := atc.Config{
+			Groups: from.Groups,
+			Resources: atc.ResourceConfigs{
+				{Name: "some-resource", Type: "git", Source: atc.Source{"uri": "other-uri"}},
+				{Name: "added-resource", Type: "time"},
+			},
+			Jobs: atc.JobConfigs{
+				{Name: "other-job"},
+				{Name: "some-job", Public: false},
+				{Name: "added-job"},
+			},
+		}
+
+		Expect(config.Diff(from, to)).To(Equal(atc.ConfigDiff{
+			Resources: atc.ConfigChanges{
+				Added:   []string{"added-resource"},
+				Removed: []string{"removed-resource"},
+				Changed: []string{"some-resource"},
+			},
+			ResourceTypes: atc.ConfigChanges{
+				Removed: []string{"some-resource-type"},
+			},
+			Jobs: atc.ConfigChanges{
+				Added:   []string{"added-job"},
+				Changed: []string{"some-job"},
+			},
+		}))
+	})
+})
@@ -1,6 +1,10 @@
 package config
 
-import "github.com/concourse/atc"
+import (
+	"strings"
+
+	"github.com/concourse/atc"
+)
 
 // these are expressly tucked away so as to avoid accidental use in public API
 // endpoints as that could leak credentials
@@ -20,6 +24,52 @@ type JobOutput struct {
 	Resource string
 }
 
+// PassedJobSeparator separates the pipeline name from the job name in a
+// passed constraint on a job in another pipeline of the same team, e.g.
+// "other-pipeline/some-job".
+const PassedJobSeparator = "/"
+
+// ParsePassedJob splits a passed constraint into the pipeline and job it
+// refers to. The pipeline name is empty for jobs in the same pipeline.
+func ParsePassedJob(passed string) (string, string) {
+	segs := strings.SplitN(passed, PassedJobSeparator, 2)
+	if len(segs) == 1 {
+		return "", passed
+	}
+
+	return segs[0], segs[1]
+}
+
+// UpstreamPipelineJobs returns every job in another pipeline that the jobs
+// of the given config have passed constraints on. Constraints naming one of
+// the config's own jobs refer to that job, even if its name contains the
+// separator, so that pipelines configured before constraints could refer to
+// other pipelines keep their meaning.
+func UpstreamPipelineJobs(c atc.Config) []string {
+	seen := map[string]bool{}
+	upstream := []string{}
+
+	for _, job := range c.Jobs {
+		for _, input := range JobInputs(job) {
+			for _, passed := range input.Passed {
+				pipelineName, _ := ParsePassedJob(passed)
+				if pipelineName == "" || seen[passed] {
+					continue
+				}
+
+				if _, found := c.Jobs.Lookup(passed); found {
+					continue
+				}
+
+				seen[passed] = true
+				upstream = append(upstream, passed)
+			}
+		}
+	}
+
+	return upstream
+}
+
 func JobInputs(config atc.JobConfig) []JobInput {
 	return collectInputs(atc.PlanConfig{Do: &config.Plan})
 }
@@ -59,6 +109,12 @@ func collectInputs(plan atc.PlanConfig) []JobInput {
 		}
 	}
 
+	if plan.InParallel != nil {
+		for _, p := range plan.InParallel.Steps {
+			inputs = append(inputs, collectInputs(p)...)
+		}
+	}
+
 	if plan.Get != "" {
 		get := plan.Get
 
@@ -116,6 +172,12 @@ func collectOutputs(plan atc.PlanConfig) []JobOutput {
 		return outputs
 	}
 
+	if plan.InParallel != nil {
+		for _, p := range plan.InParallel.Steps {
+			outputs = append(outputs, collectOutputs(p)...)
+		}
+	}
+
 	if plan.Put != "" {
 		put := plan.Put
 
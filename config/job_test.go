@@ -451,4 +451,66 @@ var _ = Describe("Job config", func() {
 			})
 		})
 	})
+
+	Describe("ParsePassedJob", func() {
+		It("returns no pipeline for jobs in the same pipeline", func() {
+			pipelineName, jobName := config.ParsePassedJob("some-job")
+			Expect(pipelineName).To(BeEmpty())
+			Expect(jobName).To(Equal("some-job"))
+		})
+
+		It("splits jobs in other pipelines", func() {
+			pipelineName, jobName := config.ParsePassedJob("some-pipeline/some-job")
+			Expect(pipelineName).To(Equal("some-pipeline"))
+			Expect(jobName).To(Equal("some-job"))
+		})
+	})
+
+	Describe("UpstreamPipelineJobs", func() {
+		It("returns each job in another pipeline once", func() {
+			pipelineConfig := atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						Plan: atc.PlanSequence{
+							{Get: "a", Passed: []string{"local-job", "other-pipeline/some-job"}},
+						},
+					},
+					{
+						Name: "some-other-job",
+						Plan: atc.PlanSequence{
+							{Get: "a", Passed: []string{"other-pipeline/some-job"}},
+							{Get: "b", Passed: []string{"another-pipeline/some-job"}},
+						},
+					},
+				},
+			}
+
+			Expect(config.UpstreamPipelineJobs(pipelineConfig)).To(Equal([]string{
+				"other-pipeline/some-job",
+				"another-pipeline/some-job",
+			}))
+		})
+
+		It("leaves out jobs of the same pipeline whose names contain the separator", func() {
+			pipelineConfig := atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name: "build/linux",
+						Plan: atc.PlanSequence{{Get: "a"}},
+					},
+					{
+						Name: "some-job",
+						Plan: atc.PlanSequence{
+							{Get: "a", Passed: []string{"build/linux", "other-pipeline/some-job"}},
+						},
+					},
+				},
+			}
+
+			Expect(config.UpstreamPipelineJobs(pipelineConfig)).To(Equal([]string{
+				"other-pipeline/some-job",
+			}))
+		})
+	})
 })
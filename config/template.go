@@ -0,0 +1,99 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+)
+
+// RenderTemplate substitutes vars for the ((var)) placeholders of an untyped
+// config template. Placeholders with no matching var are left in place for the
+// credential manager to resolve at runtime, and are reported by ValidateConfig;
+// any vars that the template never references are reported as warnings.
+func RenderTemplate(template interface{}, vars atc.Vars) (interface{}, []Warning, error) {
+	variables := &templateVariables{
+		vars: vars,
+		used: map[string]bool{},
+	}
+
+	rendered, _, err := creds.Interpolate(variables, template)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// vars which are missing are left as placeholders in the rendered config,
+	// and are reported by ValidateConfig
+	warnings := []Warning{}
+
+	unused := []string{}
+	for name := range vars {
+		if !variables.used[name] {
+			unused = append(unused, name)
+		}
+	}
+
+	sort.Strings(unused)
+
+	for _, name := range unused {
+		warnings = append(warnings, newVarsWarning(fmt.Sprintf(
+			"var '%s' is not used by the config",
+			name,
+		)))
+	}
+
+	return rendered, warnings, nil
+}
+
+// validateVars warns about the ((var)) placeholders left in the config, which
+// are looked up in the credential manager when the pipeline runs.
+func validateVars(c atc.Config) []Warning {
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+
+	var document interface{}
+	err = json.Unmarshal(payload, &document)
+	if err != nil {
+		return nil
+	}
+
+	_, missing, err := creds.Interpolate(&templateVariables{used: map[string]bool{}}, document)
+	if err != nil {
+		return nil
+	}
+
+	warnings := []Warning{}
+	for _, name := range missing {
+		warnings = append(warnings, newVarsWarning(fmt.Sprintf(
+			"var '%s' is not defined; it will be looked up in the credential manager",
+			name,
+		)))
+	}
+
+	return warnings
+}
+
+func newVarsWarning(message string) Warning {
+	return Warning{
+		Type:    "vars",
+		Message: message,
+	}
+}
+
+type templateVariables struct {
+	vars atc.Vars
+	used map[string]bool
+}
+
+func (variables *templateVariables) Get(name string) (interface{}, bool, error) {
+	val, found := variables.vars[name]
+	if found {
+		variables.used[name] = true
+	}
+
+	return val, found, nil
+}
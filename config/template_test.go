@@ -0,0 +1,80 @@
+package config_test
+
+import (
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RenderTemplate", func() {
+	var template map[interface{}]interface{}
+
+	BeforeEach(func() {
+		template = map[interface{}]interface{}{
+			"resources": []interface{}{
+				map[interface{}]interface{}{
+					"name": "((name))",
+					"type": "git",
+					"source": map[interface{}]interface{}{
+						"branch":      "((branch))",
+						"private_key": "((private-key))",
+					},
+				},
+			},
+		}
+	})
+
+	It("substitutes the vars into the template", func() {
+		rendered, warnings, err := RenderTemplate(template, atc.Vars{
+			"name":        "some-repo",
+			"branch":      "master",
+			"private-key": "some-key",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+
+		Expect(rendered).To(Equal(map[interface{}]interface{}{
+			"resources": []interface{}{
+				map[interface{}]interface{}{
+					"name": "some-repo",
+					"type": "git",
+					"source": map[interface{}]interface{}{
+						"branch":      "master",
+						"private_key": "some-key",
+					},
+				},
+			},
+		}))
+	})
+
+	It("leaves missing vars in place for ValidateConfig to warn about", func() {
+		rendered, warnings, err := RenderTemplate(template, atc.Vars{
+			"name":   "some-repo",
+			"branch": "master",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+
+		resource := rendered.(map[interface{}]interface{})["resources"].([]interface{})[0]
+		source := resource.(map[interface{}]interface{})["source"]
+		Expect(source).To(HaveKeyWithValue("private_key", "((private-key))"))
+	})
+
+	It("warns about vars that are not used", func() {
+		_, warnings, err := RenderTemplate(template, atc.Vars{
+			"name":        "some-repo",
+			"branch":      "master",
+			"private-key": "some-key",
+			"unused-b":    "b",
+			"unused-a":    "a",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(warnings).To(Equal([]Warning{
+			{Type: "vars", Message: "var 'unused-a' is not used by the config"},
+			{Type: "vars", Message: "var 'unused-b' is not used by the config"},
+		}))
+	})
+})
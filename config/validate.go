@@ -58,9 +58,42 @@ func ValidateConfig(c atc.Config) ([]Warning, []string) {
 	}
 	warnings = append(warnings, jobWarnings...)
 
+	warnings = append(warnings, validateVars(c)...)
+
 	return warnings, errorMessages
 }
 
+// ValidateUpstreamJobs warns about passed constraints on jobs of other
+// pipelines which are not configured in the team, given the configs of the
+// team's pipelines by name. Such constraints are allowed, as the other
+// pipeline may be configured later, but are never satisfied until it is.
+func ValidateUpstreamJobs(c atc.Config, pipelineConfigs map[string]atc.Config) []Warning {
+	warnings := []Warning{}
+
+	for _, upstream := range UpstreamPipelineJobs(c) {
+		pipelineName, jobName := ParsePassedJob(upstream)
+
+		pipelineConfig, found := pipelineConfigs[pipelineName]
+		if !found {
+			warnings = append(warnings, Warning{
+				Type:    "pipeline",
+				Message: fmt.Sprintf("passed constraints reference '%s', but pipeline '%s' does not exist; they cannot be satisfied until it is configured", upstream, pipelineName),
+			})
+
+			continue
+		}
+
+		if _, found := pipelineConfig.Jobs.Lookup(jobName); !found {
+			warnings = append(warnings, Warning{
+				Type:    "pipeline",
+				Message: fmt.Sprintf("passed constraints reference '%s', but pipeline '%s' has no job '%s'; they cannot be satisfied until the job is configured", upstream, pipelineName, jobName),
+			})
+		}
+	}
+
+	return warnings
+}
+
 func validateGroups(c atc.Config) error {
 	errorMessages := []string{}
 
@@ -203,6 +236,12 @@ func doesAnyStepMatch(planSequence atc.PlanSequence, predicate func(step atc.Pla
 			}
 		}
 
+		if planStep.InParallel != nil {
+			if doesAnyStepMatch(planStep.InParallel.Steps, predicate) {
+				return true
+			}
+		}
+
 		if planStep.Do != nil {
 			if doesAnyStepMatch(*planStep.Do, predicate) {
 				return true
@@ -246,6 +285,29 @@ func (ft foundTypes) IsValid() (bool, string) {
 	return true, ""
 }
 
+func validateAcross(vars []atc.AcrossVarConfig, identifier string) []string {
+	errorMessages := []string{}
+
+	seen := map[string]bool{}
+	for i, v := range vars {
+		varIdentifier := fmt.Sprintf("%s.across[%d]", identifier, i)
+
+		if v.Var == "" {
+			errorMessages = append(errorMessages, varIdentifier+" has no var name")
+		} else if seen[v.Var] {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s repeats the var '%s'", varIdentifier, v.Var))
+		}
+
+		seen[v.Var] = true
+
+		if len(v.Values) == 0 {
+			errorMessages = append(errorMessages, varIdentifier+" has no values")
+		}
+	}
+
+	return errorMessages
+}
+
 func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warning, []string) {
 	foundTypes := foundTypes{
 		identifier: identifier,
@@ -272,6 +334,10 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		foundTypes.Find("aggregate")
 	}
 
+	if plan.InParallel != nil {
+		foundTypes.Find("in_parallel")
+	}
+
 	if plan.Try != nil {
 		foundTypes.Find("try")
 	}
@@ -283,6 +349,8 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 	errorMessages := []string{}
 	warnings := []Warning{}
 
+	errorMessages = append(errorMessages, validateAcross(plan.Across, identifier)...)
+
 	switch {
 	case plan.Do != nil:
 		for i, plan := range *plan.Do {
@@ -300,6 +368,21 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 			errorMessages = append(errorMessages, planErrMessages...)
 		}
 
+	case plan.InParallel != nil:
+		if plan.InParallel.Limit < 0 {
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf("%s.in_parallel has a negative limit (%d)", identifier, plan.InParallel.Limit),
+			)
+		}
+
+		for i, plan := range plan.InParallel.Steps {
+			subIdentifier := fmt.Sprintf("%s.in_parallel.steps[%d]", identifier, i)
+			planWarnings, planErrMessages := validatePlan(c, subIdentifier, plan)
+			warnings = append(warnings, planWarnings...)
+			errorMessages = append(errorMessages, planErrMessages...)
+		}
+
 	case plan.Get != "":
 		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
 
@@ -334,7 +417,29 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		}
 
 		for _, job := range plan.Passed {
+			// a job of this pipeline is referred to by its name even if it
+			// contains the separator, so that pipelines configured before
+			// passed constraints could refer to other pipelines stay valid
 			jobConfig, found := c.Jobs.Lookup(job)
+			if !found && strings.Contains(job, PassedJobSeparator) {
+				pipelineName, jobName := ParsePassedJob(job)
+				if pipelineName == "" || jobName == "" || strings.Contains(jobName, PassedJobSeparator) {
+					errorMessages = append(
+						errorMessages,
+						fmt.Sprintf(
+							"%s.passed references a job in another pipeline as '%s'; expected 'pipeline/job'",
+							identifier,
+							job,
+						),
+					)
+				}
+
+				// jobs in other pipelines are matched up by resource name when
+				// scheduling, as the other pipeline may not be configured yet;
+				// see ValidateUpstreamJobs
+				continue
+			}
+
 			if !found {
 				errorMessages = append(
 					errorMessages,
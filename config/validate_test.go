@@ -89,6 +89,21 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 
+	Context("when the config has ((var)) placeholders", func() {
+		BeforeEach(func() {
+			config.Resources[0].Source["private_key"] = "((private-key))"
+			config.Resources[0].Source["uri"] = "https://((host))/repo.git"
+		})
+
+		It("warns that they will be looked up in the credential manager", func() {
+			Expect(errorMessages).To(BeEmpty())
+			Expect(configWarnings).To(Equal([]Warning{
+				{Type: "vars", Message: "var 'host' is not defined; it will be looked up in the credential manager"},
+				{Type: "vars", Message: "var 'private-key' is not defined; it will be looked up in the credential manager"},
+			}))
+		})
+	})
+
 	Describe("invalid groups", func() {
 		Context("when the groups reference a bogus resource", func() {
 			BeforeEach(func() {
@@ -333,6 +348,67 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a plan runs across invalid vars", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get: "some-resource",
+						Across: []atc.AcrossVarConfig{
+							{Var: "", Values: []interface{}{"a"}},
+							{Var: "some-var"},
+							{Var: "some-var", Values: []interface{}{"b"}},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].across[0] has no var name"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].across[1] has no values"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].across[2] repeats the var 'some-var'"))
+				})
+			})
+
+			Context("when an in_parallel plan has a negative limit", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						InParallel: &atc.InParallelConfig{
+							Limit: -1,
+							Steps: atc.PlanSequence{{Get: "some-resource"}},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].in_parallel has a negative limit (-1)"))
+				})
+			})
+
+			Context("when an in_parallel plan has an invalid step", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						InParallel: &atc.InParallelConfig{
+							Limit: 2,
+							Steps: atc.PlanSequence{{}},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].in_parallel.steps[0] has no action specified"))
+				})
+			})
+
 			Context("when a get plan has task-only fields specified", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -859,6 +935,74 @@ var _ = Describe("ValidateConfig", func() {
 					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a job ('some-empty-job') which doesn't interact with the resource ('some-resource')"))
 				})
 			})
+
+			Context("when a job's input's passed constraints reference a job in another pipeline", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:    "some-resource",
+						Passed: []string{"some-other-pipeline/some-job"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a job's input's passed constraints reference a job of this pipeline whose name contains '/'", func() {
+				BeforeEach(func() {
+					config.Jobs = append(config.Jobs, atc.JobConfig{
+						Name: "some/job",
+						Plan: atc.PlanSequence{
+							{Get: "some-resource"},
+						},
+					})
+
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:    "some-resource",
+						Passed: []string{"some/job"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+
+				Context("when that job does not have the resource as an input or output", func() {
+					BeforeEach(func() {
+						config.Jobs[len(config.Jobs)-2].Plan = atc.PlanSequence{}
+					})
+
+					It("validates it as a job of this pipeline", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a job ('some/job') which doesn't interact with the resource ('some-resource')"))
+					})
+				})
+			})
+
+			for _, bogus := range []string{"/some-job", "some-other-pipeline/", "some/other/job"} {
+				passed := bogus
+
+				Context("when a job's input's passed constraints reference a job in another pipeline as '"+passed+"'", func() {
+					BeforeEach(func() {
+						job.Plan = append(job.Plan, atc.PlanConfig{
+							Get:    "some-resource",
+							Passed: []string{passed},
+						})
+
+						config.Jobs = append(config.Jobs, job)
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a job in another pipeline as '" + passed + "'; expected 'pipeline/job'"))
+					})
+				})
+			}
 		})
 
 		Context("when two jobs have the same name", func() {
@@ -873,3 +1017,76 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 })
+
+var _ = Describe("ValidateUpstreamJobs", func() {
+	var (
+		config          atc.Config
+		pipelineConfigs map[string]atc.Config
+
+		warnings []Warning
+	)
+
+	BeforeEach(func() {
+		config = atc.Config{
+			Jobs: atc.JobConfigs{
+				{
+					Name: "build/linux",
+					Plan: atc.PlanSequence{{Get: "some-resource"}},
+				},
+				{
+					Name: "some-job",
+					Plan: atc.PlanSequence{
+						{
+							Get:    "some-resource",
+							Passed: []string{"build/linux", "upstream-pipeline/some-job"},
+						},
+					},
+				},
+			},
+		}
+
+		pipelineConfigs = map[string]atc.Config{
+			"upstream-pipeline": {
+				Jobs: atc.JobConfigs{
+					{Name: "some-job"},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		warnings = ValidateUpstreamJobs(config, pipelineConfigs)
+	})
+
+	Context("when every referenced pipeline and job exists", func() {
+		It("does not warn", func() {
+			Expect(warnings).To(BeEmpty())
+		})
+	})
+
+	Context("when the referenced pipeline does not exist", func() {
+		BeforeEach(func() {
+			delete(pipelineConfigs, "upstream-pipeline")
+		})
+
+		It("warns", func() {
+			Expect(warnings).To(ConsistOf(Warning{
+				Type:    "pipeline",
+				Message: "passed constraints reference 'upstream-pipeline/some-job', but pipeline 'upstream-pipeline' does not exist; they cannot be satisfied until it is configured",
+			}))
+		})
+	})
+
+	Context("when the referenced pipeline has no such job", func() {
+		BeforeEach(func() {
+			pipelineConfigs["upstream-pipeline"] = atc.Config{}
+		})
+
+		It("warns", func() {
+			Expect(warnings).To(ConsistOf(Warning{
+				Type:    "pipeline",
+				Message: "passed constraints reference 'upstream-pipeline/some-job', but pipeline 'upstream-pipeline' has no job 'some-job'; they cannot be satisfied until the job is configured",
+			}))
+		})
+	})
+})
@@ -0,0 +1,31 @@
+package atc
+
+type ConfigRevision struct {
+	ID        int    `json:"id"`
+	Version   int    `json:"version"`
+	Templated bool   `json:"templated"`
+	SavedBy   string `json:"saved_by,omitempty"`
+	SavedAt   int64  `json:"saved_at"`
+}
+
+type ConfigRevisionDiff struct {
+	From ConfigRevision `json:"from"`
+	To   ConfigRevision `json:"to"`
+
+	Diff ConfigDiff `json:"diff"`
+}
+
+// ConfigDiff lists, by name, the groups, resources, resource types and jobs
+// which differ between two configs.
+type ConfigDiff struct {
+	Groups        ConfigChanges `json:"groups"`
+	Resources     ConfigChanges `json:"resources"`
+	ResourceTypes ConfigChanges `json:"resource_types"`
+	Jobs          ConfigChanges `json:"jobs"`
+}
+
+type ConfigChanges struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Changed []string `json:"changed,omitempty"`
+}
@@ -0,0 +1,78 @@
+// This file was generated by counterfeiter
+package credsfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/creds"
+)
+
+type FakeVariablesFactory struct {
+	NewVariablesStub        func(teamName string, pipelineName string) creds.Variables
+	newVariablesMutex       sync.RWMutex
+	newVariablesArgsForCall []struct {
+		teamName     string
+		pipelineName string
+	}
+	newVariablesReturns struct {
+		result1 creds.Variables
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeVariablesFactory) NewVariables(teamName string, pipelineName string) creds.Variables {
+	fake.newVariablesMutex.Lock()
+	fake.newVariablesArgsForCall = append(fake.newVariablesArgsForCall, struct {
+		teamName     string
+		pipelineName string
+	}{teamName, pipelineName})
+	fake.recordInvocation("NewVariables", []interface{}{teamName, pipelineName})
+	fake.newVariablesMutex.Unlock()
+	if fake.NewVariablesStub != nil {
+		return fake.NewVariablesStub(teamName, pipelineName)
+	} else {
+		return fake.newVariablesReturns.result1
+	}
+}
+
+func (fake *FakeVariablesFactory) NewVariablesCallCount() int {
+	fake.newVariablesMutex.RLock()
+	defer fake.newVariablesMutex.RUnlock()
+	return len(fake.newVariablesArgsForCall)
+}
+
+func (fake *FakeVariablesFactory) NewVariablesArgsForCall(i int) (string, string) {
+	fake.newVariablesMutex.RLock()
+	defer fake.newVariablesMutex.RUnlock()
+	return fake.newVariablesArgsForCall[i].teamName, fake.newVariablesArgsForCall[i].pipelineName
+}
+
+func (fake *FakeVariablesFactory) NewVariablesReturns(result1 creds.Variables) {
+	fake.NewVariablesStub = nil
+	fake.newVariablesReturns = struct {
+		result1 creds.Variables
+	}{result1}
+}
+
+func (fake *FakeVariablesFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.newVariablesMutex.RLock()
+	defer fake.newVariablesMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeVariablesFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ creds.VariablesFactory = new(FakeVariablesFactory)
@@ -0,0 +1,341 @@
+package creds
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/concourse/atc"
+)
+
+var placeholderRegexp = regexp.MustCompile(`\(\(([-\w\p{L}./]+)\)\)`)
+
+// UndefinedVariablesError is returned when one or more ((var)) placeholders
+// could not be found by the credential manager.
+type UndefinedVariablesError struct {
+	Names []string
+}
+
+func (err UndefinedVariablesError) Error() string {
+	return fmt.Sprintf("undefined vars: %s", strings.Join(err.Names, ", "))
+}
+
+// Source is an atc.Source whose ((var)) placeholders are resolved lazily.
+type Source struct {
+	variables Variables
+	rawSource atc.Source
+}
+
+func NewSource(variables Variables, source atc.Source) Source {
+	return Source{
+		variables: variables,
+		rawSource: source,
+	}
+}
+
+func (s Source) Evaluate() (atc.Source, error) {
+	var source atc.Source
+	err := evaluate(s.variables, s.rawSource, &source)
+	if err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+// Params is an atc.Params whose ((var)) placeholders are resolved lazily.
+type Params struct {
+	variables Variables
+	rawParams atc.Params
+}
+
+func NewParams(variables Variables, params atc.Params) Params {
+	return Params{
+		variables: variables,
+		rawParams: params,
+	}
+}
+
+func (p Params) Evaluate() (atc.Params, error) {
+	var params atc.Params
+	err := evaluate(p.variables, p.rawParams, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	return params, nil
+}
+
+// TaskParams is a TaskConfig's params whose ((var)) placeholders are resolved
+// lazily.
+type TaskParams struct {
+	variables Variables
+	rawParams map[string]string
+}
+
+func NewTaskParams(variables Variables, params map[string]string) TaskParams {
+	return TaskParams{
+		variables: variables,
+		rawParams: params,
+	}
+}
+
+func (p TaskParams) Evaluate() (map[string]string, error) {
+	if p.rawParams == nil {
+		return nil, nil
+	}
+
+	params := map[string]string{}
+	for key, val := range p.rawParams {
+		evaluated, err := evaluateString(p.variables, val)
+		if err != nil {
+			return nil, err
+		}
+
+		params[key] = evaluated
+	}
+
+	return params, nil
+}
+
+// ResourceTypes is a set of atc.ResourceTypes whose sources are resolved
+// lazily.
+type ResourceTypes struct {
+	variables        Variables
+	rawResourceTypes atc.ResourceTypes
+}
+
+func NewResourceTypes(variables Variables, resourceTypes atc.ResourceTypes) ResourceTypes {
+	return ResourceTypes{
+		variables:        variables,
+		rawResourceTypes: resourceTypes,
+	}
+}
+
+func (t ResourceTypes) Evaluate() (atc.ResourceTypes, error) {
+	var resourceTypes atc.ResourceTypes
+
+	for _, resourceType := range t.rawResourceTypes {
+		source, err := NewSource(t.variables, resourceType.Source).Evaluate()
+		if err != nil {
+			return nil, err
+		}
+
+		resourceType.Source = source
+
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+
+	return resourceTypes, nil
+}
+
+// Interpolate resolves the ((var)) placeholders of an untyped document, such as
+// a pipeline config template, in place. Placeholders which cannot be resolved
+// are left as they are and their names are returned.
+func Interpolate(variables Variables, document interface{}) (interface{}, []string, error) {
+	missing := map[string]bool{}
+
+	interpolated, err := interpolate(variables, document, missing)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return interpolated, sortedNames(missing), nil
+}
+
+// InterpolateJSON resolves the ((var)) placeholders of input and decodes the
+// result into output. The input is round-tripped through JSON so that the
+// output never shares maps or slices with it, as the input must not see
+// resolved values. Placeholders which cannot be resolved are left as they are
+// and their names are returned.
+func InterpolateJSON(variables Variables, input interface{}, output interface{}) ([]string, error) {
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var untyped interface{}
+	err = json.Unmarshal(payload, &untyped)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := map[string]bool{}
+
+	interpolated, err := interpolate(variables, untyped, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err = json.Marshal(interpolated)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(payload, output)
+	if err != nil {
+		return nil, err
+	}
+
+	return sortedNames(missing), nil
+}
+
+// InterpolateString resolves the ((var)) placeholders of a string. Values
+// which are not strings are substituted as JSON. Placeholders which cannot be
+// resolved are left as they are and their names are returned.
+func InterpolateString(variables Variables, input string) (string, []string, error) {
+	missing := map[string]bool{}
+
+	interpolated, err := interpolate(variables, input, missing)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch v := interpolated.(type) {
+	case string:
+		return v, sortedNames(missing), nil
+	default:
+		payload, err := json.Marshal(v)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return string(payload), sortedNames(missing), nil
+	}
+}
+
+func evaluate(variables Variables, input interface{}, output interface{}) error {
+	missing, err := InterpolateJSON(variables, input, output)
+	if err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		return UndefinedVariablesError{Names: missing}
+	}
+
+	return nil
+}
+
+func evaluateString(variables Variables, input string) (string, error) {
+	evaluated, missing, err := InterpolateString(variables, input)
+	if err != nil {
+		return "", err
+	}
+
+	if len(missing) > 0 {
+		return "", UndefinedVariablesError{Names: missing}
+	}
+
+	return evaluated, nil
+}
+
+func interpolate(variables Variables, node interface{}, missing map[string]bool) (interface{}, error) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			evaluated, err := interpolate(variables, val, missing)
+			if err != nil {
+				return nil, err
+			}
+
+			v[key] = evaluated
+		}
+
+		return v, nil
+
+	case map[interface{}]interface{}:
+		for key, val := range v {
+			evaluated, err := interpolate(variables, val, missing)
+			if err != nil {
+				return nil, err
+			}
+
+			v[key] = evaluated
+		}
+
+		return v, nil
+
+	case []interface{}:
+		for i, val := range v {
+			evaluated, err := interpolate(variables, val, missing)
+			if err != nil {
+				return nil, err
+			}
+
+			v[i] = evaluated
+		}
+
+		return v, nil
+
+	case string:
+		return interpolateString(variables, v, missing)
+
+	default:
+		return node, nil
+	}
+}
+
+func interpolateString(variables Variables, str string, missing map[string]bool) (interface{}, error) {
+	matches := placeholderRegexp.FindAllStringSubmatch(str, -1)
+	if len(matches) == 0 {
+		return str, nil
+	}
+
+	// a lone placeholder may resolve to a non-string value, e.g. a map
+	if len(matches) == 1 && matches[0][0] == str {
+		val, found, err := variables.Get(matches[0][1])
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			missing[matches[0][1]] = true
+			return str, nil
+		}
+
+		return val, nil
+	}
+
+	for _, match := range matches {
+		val, found, err := variables.Get(match[1])
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			missing[match[1]] = true
+			continue
+		}
+
+		var replacement string
+		switch v := val.(type) {
+		case string:
+			replacement = v
+		default:
+			payload, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+
+			replacement = string(payload)
+		}
+
+		str = strings.Replace(str, match[0], replacement, -1)
+	}
+
+	return str, nil
+}
+
+func sortedNames(set map[string]bool) []string {
+	names := []string{}
+	for name := range set {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
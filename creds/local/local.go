@@ -0,0 +1,108 @@
+package local
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/concourse/atc/creds"
+)
+
+// LookupEnv has the same signature as os.LookupEnv.
+type LookupEnv func(string) (string, bool)
+
+type localFactory struct {
+	values    map[string]interface{}
+	envPrefix string
+	lookupEnv LookupEnv
+}
+
+func NewLocalFactory(values map[string]interface{}, envPrefix string, lookupEnv LookupEnv) creds.VariablesFactory {
+	return &localFactory{
+		values:    values,
+		envPrefix: envPrefix,
+		lookupEnv: lookupEnv,
+	}
+}
+
+func (factory *localFactory) NewVariables(teamName string, pipelineName string) creds.Variables {
+	return Local{
+		values:       factory.values,
+		envPrefix:    factory.envPrefix,
+		lookupEnv:    factory.lookupEnv,
+		teamName:     teamName,
+		pipelineName: pipelineName,
+	}
+}
+
+// Local resolves ((var)) placeholders from the pipeline's scope first and then
+// from the team's scope, checking the credentials file before the environment.
+type Local struct {
+	values    map[string]interface{}
+	envPrefix string
+	lookupEnv LookupEnv
+
+	teamName     string
+	pipelineName string
+}
+
+func (l Local) Get(name string) (interface{}, bool, error) {
+	var scopes [][]string
+
+	if l.pipelineName != "" {
+		scopes = append(scopes, []string{l.teamName, l.pipelineName, name})
+	}
+
+	scopes = append(scopes, []string{l.teamName, name})
+
+	for _, scope := range scopes {
+		val, found := l.values[strings.Join(scope, "/")]
+		if found {
+			return sanitize(val), true, nil
+		}
+
+		if l.envPrefix != "" {
+			val, found := l.lookupEnv(l.envPrefix + envName(scope))
+			if found {
+				return val, true, nil
+			}
+		}
+	}
+
+	return nil, false, nil
+}
+
+func envName(scope []string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+
+		return '_'
+	}, strings.Join(scope, "_"))
+}
+
+// sanitize converts the map[interface{}]interface{} values produced by YAML
+// into something that can be marshalled as JSON.
+func sanitize(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[interface{}]interface{}:
+		sanitized := map[string]interface{}{}
+		for key, sub := range v {
+			sanitized[fmt.Sprintf("%v", key)] = sanitize(sub)
+		}
+
+		return sanitized
+
+	case []interface{}:
+		sanitized := make([]interface{}, len(v))
+		for i, sub := range v {
+			sanitized[i] = sanitize(sub)
+		}
+
+		return sanitized
+
+	default:
+		return val
+	}
+}
@@ -0,0 +1,70 @@
+package local_test
+
+import (
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/local"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Local", func() {
+	var values map[string]interface{}
+	var env map[string]string
+	var variables creds.Variables
+
+	BeforeEach(func() {
+		values = map[string]interface{}{}
+		env = map[string]string{}
+	})
+
+	JustBeforeEach(func() {
+		variables = local.NewLocalFactory(values, "CREDS_", func(name string) (string, bool) {
+			val, found := env[name]
+			return val, found
+		}).NewVariables("some-team", "some-pipeline")
+	})
+
+	Context("when the file has a pipeline-scoped and team-scoped value", func() {
+		BeforeEach(func() {
+			values["some-team/some-pipeline/foo"] = "pipeline-value"
+			values["some-team/foo"] = "team-value"
+			values["some-team/bar"] = map[interface{}]interface{}{"nested": "value"}
+		})
+
+		It("prefers the pipeline-scoped value", func() {
+			val, found, err := variables.Get("foo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("pipeline-value"))
+		})
+
+		It("sanitizes YAML maps", func() {
+			val, found, err := variables.Get("bar")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal(map[string]interface{}{"nested": "value"}))
+		})
+	})
+
+	Context("when the value is in the environment", func() {
+		BeforeEach(func() {
+			env["CREDS_SOME_TEAM_SOME_PIPELINE_FOO_BAR"] = "env-value"
+		})
+
+		It("resolves it", func() {
+			val, found, err := variables.Get("foo-bar")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("env-value"))
+		})
+	})
+
+	It("does not find values scoped to other teams", func() {
+		values["other-team/foo"] = "nope"
+
+		_, found, err := variables.Get("foo")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+})
@@ -0,0 +1,59 @@
+package local
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/creds"
+	"gopkg.in/yaml.v2"
+)
+
+// LocalManager is a stand-in credential manager for single-node deployments
+// and local development. Credentials are read from a YAML file and/or from the
+// ATC's environment.
+type LocalManager struct {
+	File      string `long:"file"       description:"YAML file mapping TEAM/PIPELINE/VAR or TEAM/VAR to credential values."`
+	EnvPrefix string `long:"env-prefix" description:"Resolve credentials from environment variables named PREFIX + TEAM_PIPELINE_VAR or PREFIX + TEAM_VAR."`
+}
+
+func (manager LocalManager) IsConfigured() bool {
+	return manager.File != "" || manager.EnvPrefix != ""
+}
+
+func (manager LocalManager) Validate() error {
+	if manager.File == "" {
+		return nil
+	}
+
+	stat, err := os.Stat(manager.File)
+	if err != nil {
+		return fmt.Errorf("invalid local credentials file: %s", err)
+	}
+
+	if stat.IsDir() {
+		return errors.New("local credentials file must not be a directory")
+	}
+
+	return nil
+}
+
+func (manager LocalManager) NewVariablesFactory(logger lager.Logger) (creds.VariablesFactory, error) {
+	values := map[string]interface{}{}
+
+	if manager.File != "" {
+		payload, err := ioutil.ReadFile(manager.File)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read local credentials file: %s", err)
+		}
+
+		err = yaml.Unmarshal(payload, &values)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse local credentials file: %s", err)
+		}
+	}
+
+	return NewLocalFactory(values, manager.EnvPrefix, os.LookupEnv), nil
+}
@@ -0,0 +1,40 @@
+package creds
+
+import "code.cloudfoundry.org/lager"
+
+//go:generate counterfeiter . Variables
+
+// Variables looks up the value of a ((var)) placeholder, scoped to a single
+// team and pipeline.
+type Variables interface {
+	Get(name string) (interface{}, bool, error)
+}
+
+//go:generate counterfeiter . VariablesFactory
+
+// VariablesFactory constructs Variables for a given team and pipeline.
+type VariablesFactory interface {
+	NewVariables(teamName string, pipelineName string) Variables
+}
+
+// Manager is implemented by each credential manager backend, and is embedded
+// as a flag group in the ATC command.
+type Manager interface {
+	IsConfigured() bool
+	Validate() error
+	NewVariablesFactory(lager.Logger) (VariablesFactory, error)
+}
+
+// NoopVariablesFactory is used when no credential manager is configured.
+// Every lookup misses, so any ((var)) placeholder results in an error.
+type NoopVariablesFactory struct{}
+
+func (NoopVariablesFactory) NewVariables(string, string) Variables {
+	return noopVariables{}
+}
+
+type noopVariables struct{}
+
+func (noopVariables) Get(string) (interface{}, bool, error) {
+	return nil, false, nil
+}
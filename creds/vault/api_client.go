@@ -0,0 +1,66 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+//go:generate counterfeiter . SecretReader
+
+// SecretReader reads the data stored at a path in Vault.
+type SecretReader interface {
+	Read(path string) (map[string]interface{}, bool, error)
+}
+
+// APIClient reads secrets using Vault's HTTP API.
+type APIClient struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func NewAPIClient(url string, token string, client *http.Client) *APIClient {
+	return &APIClient{
+		url:    strings.TrimRight(url, "/"),
+		token:  token,
+		client: client,
+	}
+}
+
+type secretResponse struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+func (client *APIClient) Read(path string) (map[string]interface{}, bool, error) {
+	req, err := http.NewRequest("GET", client.url+"/v1/"+strings.TrimLeft(path, "/"), nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	req.Header.Set("X-Vault-Token", client.token)
+
+	resp, err := client.client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, false, nil
+	default:
+		return nil, false, fmt.Errorf("vault returned unexpected status %d reading %s", resp.StatusCode, path)
+	}
+
+	var secret secretResponse
+	err = json.NewDecoder(resp.Body).Decode(&secret)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return secret.Data, true, nil
+}
@@ -0,0 +1,76 @@
+package vault
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/creds"
+)
+
+type VaultManager struct {
+	URL string `long:"url" description:"Vault server address used to access secrets."`
+
+	PathPrefix string `long:"path-prefix" default:"/concourse" description:"Path under which to namespace credential lookup."`
+
+	ClientToken string `long:"client-token" description:"Client token for accessing secrets within the Vault server."`
+
+	CACert             string `long:"ca-cert"              description:"Path to a PEM-encoded CA cert file to use to verify the vault server SSL cert."`
+	InsecureSkipVerify bool   `long:"insecure-skip-verify" description:"Enable insecure SSL verification."`
+}
+
+func (manager VaultManager) IsConfigured() bool {
+	return manager.URL != ""
+}
+
+func (manager VaultManager) Validate() error {
+	_, err := url.Parse(manager.URL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %s", err)
+	}
+
+	if manager.ClientToken == "" {
+		return errors.New("must specify --vault-client-token to use vault")
+	}
+
+	return nil
+}
+
+func (manager VaultManager) NewVariablesFactory(logger lager.Logger) (creds.VariablesFactory, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: manager.InsecureSkipVerify,
+	}
+
+	if manager.CACert != "" {
+		caCert, err := ioutil.ReadFile(manager.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read vault CA cert: %s", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("failed to parse vault CA cert")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+	}
+
+	return NewVaultFactory(
+		logger.Session("vault"),
+		NewAPIClient(manager.URL, manager.ClientToken, client),
+		manager.PathPrefix,
+	), nil
+}
@@ -0,0 +1,109 @@
+package vault
+
+import (
+	"errors"
+	"path"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/creds"
+)
+
+type vaultFactory struct {
+	logger     lager.Logger
+	reader     SecretReader
+	pathPrefix string
+}
+
+func NewVaultFactory(logger lager.Logger, reader SecretReader, pathPrefix string) creds.VariablesFactory {
+	return &vaultFactory{
+		logger:     logger,
+		reader:     reader,
+		pathPrefix: pathPrefix,
+	}
+}
+
+func (factory *vaultFactory) NewVariables(teamName string, pipelineName string) creds.Variables {
+	return &Vault{
+		logger:       factory.logger,
+		reader:       factory.reader,
+		pathPrefix:   factory.pathPrefix,
+		teamName:     teamName,
+		pipelineName: pipelineName,
+	}
+}
+
+// Vault looks up ((var)) placeholders under the pipeline's path first, and
+// then under the team's path, e.g. /concourse/TEAM/PIPELINE/VAR followed by
+// /concourse/TEAM/VAR.
+type Vault struct {
+	logger lager.Logger
+	reader SecretReader
+
+	pathPrefix   string
+	teamName     string
+	pipelineName string
+}
+
+// ErrInvalidSecretName is returned for names which would resolve to a path
+// outside of the team's, such as ((../other-team/secret)).
+var ErrInvalidSecretName = errors.New("secret name must be relative and must not contain '..'")
+
+func (v Vault) Get(name string) (interface{}, bool, error) {
+	if !validSecretName(name) {
+		return nil, false, ErrInvalidSecretName
+	}
+
+	teamPath := path.Join(v.pathPrefix, v.teamName)
+
+	var paths []string
+
+	if v.pipelineName != "" {
+		paths = append(paths, path.Join(v.pathPrefix, v.teamName, v.pipelineName, name))
+	}
+
+	paths = append(paths, path.Join(v.pathPrefix, v.teamName, name))
+
+	for _, secretPath := range paths {
+		if !strings.HasPrefix(secretPath, teamPath+"/") {
+			return nil, false, ErrInvalidSecretName
+		}
+
+		data, found, err := v.reader.Read(secretPath)
+		if err != nil {
+			v.logger.Error("failed-to-read-secret", err, lager.Data{
+				"path": secretPath,
+			})
+
+			return nil, false, err
+		}
+
+		if !found {
+			continue
+		}
+
+		// secrets written as `vault write PATH value=...` resolve to the
+		// value itself; anything else resolves to the whole map
+		if val, ok := data["value"]; ok && len(data) == 1 {
+			return val, true, nil
+		}
+
+		return data, true, nil
+	}
+
+	return nil, false, nil
+}
+
+func validSecretName(name string) bool {
+	if name == "" || strings.HasPrefix(name, "/") {
+		return false
+	}
+
+	for _, segment := range strings.Split(name, "/") {
+		if segment == ".." {
+			return false
+		}
+	}
+
+	return true
+}
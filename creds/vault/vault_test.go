@@ -0,0 +1,126 @@
+package vault_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/vault"
+	"github.com/concourse/atc/creds/vault/vaultfakes"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Vault", func() {
+	var fakeReader *vaultfakes.FakeSecretReader
+	var variables creds.Variables
+
+	BeforeEach(func() {
+		fakeReader = new(vaultfakes.FakeSecretReader)
+		variables = vault.NewVaultFactory(
+			lagertest.NewTestLogger("test"),
+			fakeReader,
+			"/concourse",
+		).NewVariables("some-team", "some-pipeline")
+	})
+
+	It("prefers the pipeline-scoped secret", func() {
+		fakeReader.ReadReturns(map[string]interface{}{"value": "pipeline-secret"}, true, nil)
+
+		val, found, err := variables.Get("foo")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(val).To(Equal("pipeline-secret"))
+
+		Expect(fakeReader.ReadCallCount()).To(Equal(1))
+		Expect(fakeReader.ReadArgsForCall(0)).To(Equal("/concourse/some-team/some-pipeline/foo"))
+	})
+
+	It("falls back to the team-scoped secret", func() {
+		fakeReader.ReadStub = func(path string) (map[string]interface{}, bool, error) {
+			if path == "/concourse/some-team/foo" {
+				return map[string]interface{}{"user": "u", "pass": "p"}, true, nil
+			}
+
+			return nil, false, nil
+		}
+
+		val, found, err := variables.Get("foo")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(val).To(Equal(map[string]interface{}{"user": "u", "pass": "p"}))
+	})
+
+	It("returns not found when no secret exists", func() {
+		_, found, err := variables.Get("foo")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+		Expect(fakeReader.ReadCallCount()).To(Equal(2))
+	})
+
+	It("refuses names which escape the team's path", func() {
+		for _, name := range []string{
+			"../../other-team/secret",
+			"../other-team/secret",
+			"foo/../../../other-team/secret",
+			"/other-team/secret",
+			"..",
+		} {
+			_, found, err := variables.Get(name)
+			Expect(err).To(Equal(vault.ErrInvalidSecretName), name)
+			Expect(found).To(BeFalse())
+		}
+
+		Expect(fakeReader.ReadCallCount()).To(BeZero())
+	})
+
+	It("allows nested names", func() {
+		_, _, err := variables.Get("some/nested/secret")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeReader.ReadArgsForCall(0)).To(Equal("/concourse/some-team/some-pipeline/some/nested/secret"))
+	})
+
+	Describe("APIClient", func() {
+		var server *ghttp.Server
+		var client *vault.APIClient
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+			client = vault.NewAPIClient(server.URL(), "some-token", http.DefaultClient)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("reads the secret's data with the client token", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v1/concourse/some-team/foo"),
+				ghttp.VerifyHeaderKV("X-Vault-Token", "some-token"),
+				ghttp.RespondWith(http.StatusOK, `{"data":{"value":"secret"}}`),
+			))
+
+			data, found, err := client.Read("/concourse/some-team/foo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(data).To(Equal(map[string]interface{}{"value": "secret"}))
+		})
+
+		It("treats 404 as not found", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, `{"errors":[]}`))
+
+			_, found, err := client.Read("/concourse/some-team/foo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("errors on any other status", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, `{"errors":["permission denied"]}`))
+
+			_, _, err := client.Read("/concourse/some-team/foo")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,80 @@
+// This file was generated by counterfeiter
+package vaultfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/creds/vault"
+)
+
+type FakeSecretReader struct {
+	ReadStub        func(path string) (map[string]interface{}, bool, error)
+	readMutex       sync.RWMutex
+	readArgsForCall []struct {
+		path string
+	}
+	readReturns struct {
+		result1 map[string]interface{}
+		result2 bool
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSecretReader) Read(path string) (map[string]interface{}, bool, error) {
+	fake.readMutex.Lock()
+	fake.readArgsForCall = append(fake.readArgsForCall, struct {
+		path string
+	}{path})
+	fake.recordInvocation("Read", []interface{}{path})
+	fake.readMutex.Unlock()
+	if fake.ReadStub != nil {
+		return fake.ReadStub(path)
+	} else {
+		return fake.readReturns.result1, fake.readReturns.result2, fake.readReturns.result3
+	}
+}
+
+func (fake *FakeSecretReader) ReadCallCount() int {
+	fake.readMutex.RLock()
+	defer fake.readMutex.RUnlock()
+	return len(fake.readArgsForCall)
+}
+
+func (fake *FakeSecretReader) ReadArgsForCall(i int) string {
+	fake.readMutex.RLock()
+	defer fake.readMutex.RUnlock()
+	return fake.readArgsForCall[i].path
+}
+
+func (fake *FakeSecretReader) ReadReturns(result1 map[string]interface{}, result2 bool, result3 error) {
+	fake.ReadStub = nil
+	fake.readReturns = struct {
+		result1 map[string]interface{}
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeSecretReader) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.readMutex.RLock()
+	defer fake.readMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeSecretReader) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ vault.SecretReader = new(FakeSecretReader)
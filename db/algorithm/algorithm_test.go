@@ -800,6 +800,69 @@ var _ = DescribeTable("Input resolving",
 		},
 	}),
 
+	Entry("resolves to the version pinned on the resource", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+
+			PinnedVersions: map[string]string{
+				"resource-x": "rxv1",
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+			},
+			{
+				Name:     "resource-x-again",
+				Resource: "resource-x",
+				Version:  Version{Every: true},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x":       "rxv1",
+				"resource-x-again": "rxv1",
+			},
+		},
+	}),
+
+	Entry("prefers the version pinned in the job config over the version pinned on the resource", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+
+			PinnedVersions: map[string]string{
+				"resource-x": "rxv1",
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Version:  Version{Pinned: "rxv2"},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv2",
+			},
+		},
+	}),
+
 	Entry("check orders take precedence over version ID", Example{
 		DB: DB{
 			Resources: []DBRow{
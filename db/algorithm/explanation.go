@@ -0,0 +1,182 @@
+package algorithm
+
+import "sort"
+
+type ExplanationReason string
+
+const (
+	ExplanationNoVersions               ExplanationReason = "no_versions"
+	ExplanationPinnedVersionUnavailable ExplanationReason = "pinned_version_unavailable"
+	ExplanationPassedConstraint         ExplanationReason = "passed_constraint"
+	ExplanationConflictingVersions      ExplanationReason = "conflicting_versions"
+)
+
+// Explanation records, for each input which prevented the inputs from being
+// resolved, why it did.
+type Explanation map[string]InputExplanation
+
+type InputExplanation struct {
+	Reason ExplanationReason `json:"reason"`
+
+	// Passed lists the jobs of the input's passed constraint which left it
+	// with no candidates, or the jobs through which it conflicts with the
+	// inputs in ConflictsWith.
+	Passed []string `json:"passed,omitempty"`
+
+	ConflictsWith []string `json:"conflicts_with,omitempty"`
+}
+
+// Equal reports whether both explanations give the same reasons for the same
+// inputs. A nil explanation is equal to an empty one.
+func (explanation Explanation) Equal(other Explanation) bool {
+	if len(explanation) != len(other) {
+		return false
+	}
+
+	for name, inputExplanation := range explanation {
+		otherExplanation, found := other[name]
+		if !found || !inputExplanation.equal(otherExplanation) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (explanation InputExplanation) equal(other InputExplanation) bool {
+	return explanation.Reason == other.Reason &&
+		equalStrings(explanation.Passed, other.Passed) &&
+		equalStrings(explanation.ConflictsWith, other.ConflictsWith)
+}
+
+func equalStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Explain works out why Resolve fails for the inputs. Each input is first
+// considered on its own; only if every input has a candidate are pairs of
+// inputs sharing passed constraints checked for versions which never came out
+// of the same build.
+func (configs InputConfigs) Explain(db *VersionsDB) Explanation {
+	explanation := Explanation{}
+
+	for _, inputConfig := range configs {
+		inputExplanation, ok := explainInput(db, inputConfig)
+		if !ok {
+			explanation[inputConfig.Name] = inputExplanation
+		}
+	}
+
+	if len(explanation) > 0 {
+		return explanation
+	}
+
+	for i, inputConfig := range configs {
+		for _, otherConfig := range configs[i+1:] {
+			sharedJobs := inputConfig.Passed.Intersect(otherConfig.Passed)
+			if len(sharedJobs) == 0 {
+				continue
+			}
+
+			_, ok := InputConfigs{inputConfig, otherConfig}.Resolve(db)
+			if ok {
+				continue
+			}
+
+			passed := db.jobNames(sharedJobs)
+			explanation.conflict(inputConfig.Name, otherConfig.Name, passed)
+			explanation.conflict(otherConfig.Name, inputConfig.Name, passed)
+		}
+	}
+
+	return explanation
+}
+
+func explainInput(db *VersionsDB, inputConfig InputConfig) (InputExplanation, bool) {
+	if len(inputConfig.Passed) == 0 {
+		if len(db.AllVersionsForResource(inputConfig.ResourceID)) == 0 {
+			return InputExplanation{Reason: ExplanationNoVersions}, false
+		}
+	} else {
+		emptyJobs := JobSet{}
+		for jobID := range inputConfig.Passed {
+			if len(db.VersionsOfResourcePassedJobs(inputConfig.ResourceID, JobSet{jobID: struct{}{}})) == 0 {
+				emptyJobs[jobID] = struct{}{}
+			}
+		}
+
+		if len(emptyJobs) == 0 && len(db.VersionsOfResourcePassedJobs(inputConfig.ResourceID, inputConfig.Passed)) == 0 {
+			emptyJobs = inputConfig.Passed
+		}
+
+		if len(emptyJobs) > 0 {
+			return InputExplanation{
+				Reason: ExplanationPassedConstraint,
+				Passed: db.jobNames(emptyJobs),
+			}, false
+		}
+	}
+
+	_, ok := InputConfigs{inputConfig}.Resolve(db)
+	if ok {
+		return InputExplanation{}, true
+	}
+
+	if inputConfig.PinnedVersionID != 0 || db.PinnedVersionIDs[inputConfig.ResourceID] != 0 {
+		return InputExplanation{Reason: ExplanationPinnedVersionUnavailable}, false
+	}
+
+	return InputExplanation{
+		Reason: ExplanationPassedConstraint,
+		Passed: db.jobNames(inputConfig.Passed),
+	}, false
+}
+
+func (explanation Explanation) conflict(input string, otherInput string, passed []string) {
+	inputExplanation := explanation[input]
+	inputExplanation.Reason = ExplanationConflictingVersions
+	inputExplanation.ConflictsWith = append(inputExplanation.ConflictsWith, otherInput)
+
+	for _, job := range passed {
+		if !containsString(inputExplanation.Passed, job) {
+			inputExplanation.Passed = append(inputExplanation.Passed, job)
+		}
+	}
+
+	sort.Strings(inputExplanation.Passed)
+
+	explanation[input] = inputExplanation
+}
+
+func (db VersionsDB) jobNames(jobs JobSet) []string {
+	names := []string{}
+	for name, id := range db.JobIDs {
+		if jobs.Contains(id) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func containsString(xs []string, x string) bool {
+	for _, y := range xs {
+		if x == y {
+			return true
+		}
+	}
+
+	return false
+}
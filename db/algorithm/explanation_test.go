@@ -0,0 +1,238 @@
+package algorithm_test
+
+import (
+	"github.com/concourse/atc/db/algorithm"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Explain", func() {
+	var (
+		db           *algorithm.VersionsDB
+		inputConfigs algorithm.InputConfigs
+		explanation  algorithm.Explanation
+	)
+
+	output := func(versionID int, resourceID int, buildID int, jobID int) algorithm.BuildOutput {
+		return algorithm.BuildOutput{
+			ResourceVersion: algorithm.ResourceVersion{
+				VersionID:  versionID,
+				ResourceID: resourceID,
+				CheckOrder: versionID,
+			},
+			BuildID: buildID,
+			JobID:   jobID,
+		}
+	}
+
+	BeforeEach(func() {
+		db = &algorithm.VersionsDB{
+			JobIDs: map[string]int{"current": 1, "unit": 2, "integration": 3},
+			ResourceVersions: []algorithm.ResourceVersion{
+				{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+				{VersionID: 2, ResourceID: 12, CheckOrder: 2},
+				{VersionID: 4, ResourceID: 11, CheckOrder: 4},
+			},
+			BuildOutputs: []algorithm.BuildOutput{
+				output(1, 11, 100, 2),
+				output(2, 12, 101, 2),
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		explanation = inputConfigs.Explain(db)
+	})
+
+	Context("when the inputs resolve", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{Name: "a", ResourceID: 11, JobID: 1},
+				{Name: "b", ResourceID: 12, JobID: 1},
+			}
+		})
+
+		It("explains nothing", func() {
+			Expect(explanation).To(BeEmpty())
+		})
+	})
+
+	Context("when an input's resource has no versions", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{Name: "a", ResourceID: 11, JobID: 1},
+				{Name: "c", ResourceID: 13, JobID: 1},
+			}
+		})
+
+		It("explains that it has no versions", func() {
+			Expect(explanation).To(Equal(algorithm.Explanation{
+				"c": {Reason: algorithm.ExplanationNoVersions},
+			}))
+		})
+	})
+
+	Context("when a job in an input's passed constraint has no outputs of the resource", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:       "a",
+					ResourceID: 11,
+					Passed:     algorithm.JobSet{2: struct{}{}, 3: struct{}{}},
+					JobID:      1,
+				},
+			}
+		})
+
+		It("explains which job left no candidates", func() {
+			Expect(explanation).To(Equal(algorithm.Explanation{
+				"a": {
+					Reason: algorithm.ExplanationPassedConstraint,
+					Passed: []string{"integration"},
+				},
+			}))
+		})
+	})
+
+	Context("when no version passed every job of an input's passed constraint", func() {
+		BeforeEach(func() {
+			db.ResourceVersions = append(db.ResourceVersions, algorithm.ResourceVersion{
+				VersionID: 3, ResourceID: 11, CheckOrder: 3,
+			})
+			db.BuildOutputs = append(db.BuildOutputs, output(3, 11, 102, 3))
+
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:       "a",
+					ResourceID: 11,
+					Passed:     algorithm.JobSet{2: struct{}{}, 3: struct{}{}},
+					JobID:      1,
+				},
+			}
+		})
+
+		It("explains that all of the jobs together left no candidates", func() {
+			Expect(explanation).To(Equal(algorithm.Explanation{
+				"a": {
+					Reason: algorithm.ExplanationPassedConstraint,
+					Passed: []string{"integration", "unit"},
+				},
+			}))
+		})
+	})
+
+	Context("when the pinned version is not available", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{Name: "a", ResourceID: 11, PinnedVersionID: 42, JobID: 1},
+			}
+		})
+
+		It("explains that the pinned version is unavailable", func() {
+			Expect(explanation).To(Equal(algorithm.Explanation{
+				"a": {Reason: algorithm.ExplanationPinnedVersionUnavailable},
+			}))
+		})
+	})
+
+	Context("when the resource is pinned to a version which is not available", func() {
+		BeforeEach(func() {
+			db.PinnedVersionIDs = map[int]int{11: 42}
+
+			inputConfigs = algorithm.InputConfigs{
+				{Name: "a", ResourceID: 11, JobID: 1},
+			}
+		})
+
+		It("explains that the pinned version is unavailable", func() {
+			Expect(explanation).To(Equal(algorithm.Explanation{
+				"a": {Reason: algorithm.ExplanationPinnedVersionUnavailable},
+			}))
+		})
+	})
+
+	Context("when inputs sharing a passed constraint never came out of the same build", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:       "a",
+					ResourceID: 11,
+					Passed:     algorithm.JobSet{2: struct{}{}},
+					JobID:      1,
+				},
+				{
+					Name:       "b",
+					ResourceID: 12,
+					Passed:     algorithm.JobSet{2: struct{}{}},
+					JobID:      1,
+				},
+			}
+		})
+
+		It("explains which inputs conflict through which jobs", func() {
+			Expect(explanation).To(Equal(algorithm.Explanation{
+				"a": {
+					Reason:        algorithm.ExplanationConflictingVersions,
+					Passed:        []string{"unit"},
+					ConflictsWith: []string{"b"},
+				},
+				"b": {
+					Reason:        algorithm.ExplanationConflictingVersions,
+					Passed:        []string{"unit"},
+					ConflictsWith: []string{"a"},
+				},
+			}))
+		})
+	})
+})
+
+var _ = Describe("Explanation", func() {
+	Describe("Equal", func() {
+		explanation := algorithm.Explanation{
+			"a": algorithm.InputExplanation{
+				Reason:        algorithm.ExplanationConflictingVersions,
+				Passed:        []string{"unit"},
+				ConflictsWith: []string{"b"},
+			},
+		}
+
+		It("is equal to the same explanation", func() {
+			Expect(explanation.Equal(algorithm.Explanation{
+				"a": algorithm.InputExplanation{
+					Reason:        algorithm.ExplanationConflictingVersions,
+					Passed:        []string{"unit"},
+					ConflictsWith: []string{"b"},
+				},
+			})).To(BeTrue())
+		})
+
+		It("is not equal to an explanation with a different reason", func() {
+			Expect(explanation.Equal(algorithm.Explanation{
+				"a": algorithm.InputExplanation{
+					Reason: algorithm.ExplanationNoVersions,
+				},
+			})).To(BeFalse())
+		})
+
+		It("is not equal to an explanation for other inputs", func() {
+			Expect(explanation.Equal(algorithm.Explanation{
+				"b": explanation["a"],
+			})).To(BeFalse())
+		})
+
+		It("is not equal to an empty explanation", func() {
+			Expect(explanation.Equal(algorithm.Explanation{})).To(BeFalse())
+		})
+
+		It("treats nil and empty explanations and lists as equal", func() {
+			Expect(algorithm.Explanation(nil).Equal(algorithm.Explanation{})).To(BeTrue())
+
+			Expect(algorithm.Explanation{
+				"a": algorithm.InputExplanation{Reason: algorithm.ExplanationNoVersions, Passed: []string{}},
+			}.Equal(algorithm.Explanation{
+				"a": algorithm.InputExplanation{Reason: algorithm.ExplanationNoVersions},
+			})).To(BeTrue())
+		})
+	})
+})
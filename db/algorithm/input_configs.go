@@ -45,6 +45,11 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 			}
 		}
 
+		pinnedVersionID := inputConfig.PinnedVersionID
+		if pinnedVersionID == 0 {
+			pinnedVersionID = db.PinnedVersionIDs[inputConfig.ResourceID]
+		}
+
 		existingBuildResolver := &ExistingBuildResolver{
 			BuildInputs: db.BuildInputs,
 			JobID:       inputConfig.JobID,
@@ -55,7 +60,7 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 			Input:                 inputConfig.Name,
 			Passed:                inputConfig.Passed,
 			UseEveryVersion:       inputConfig.UseEveryVersion,
-			PinnedVersionID:       inputConfig.PinnedVersionID,
+			PinnedVersionID:       pinnedVersionID,
 			VersionCandidates:     versionCandidates,
 			ExistingBuildResolver: existingBuildResolver,
 		})
@@ -13,6 +13,8 @@ type DB struct {
 	BuildInputs  []DBRow
 	BuildOutputs []DBRow
 	Resources    []DBRow
+
+	PinnedVersions map[string]string
 }
 
 type DBRow struct {
@@ -134,6 +136,13 @@ func (example Example) Run() {
 				JobID:           jobIDs.ID(row.Job),
 			})
 		}
+
+		if example.DB.PinnedVersions != nil {
+			db.PinnedVersionIDs = map[int]int{}
+			for resource, version := range example.DB.PinnedVersions {
+				db.PinnedVersionIDs[resourceIDs.ID(resource)] = versionIDs.ID(version)
+			}
+		}
 	}
 
 	inputConfigs := make(algorithm.InputConfigs, len(example.Inputs))
@@ -0,0 +1,26 @@
+package db
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+)
+
+// APIToken is a long-lived token which authenticates as its team with the
+// given role. Only a hash of the token itself is ever stored.
+type APIToken struct {
+	ID       int
+	TeamID   int
+	TeamName string
+	Name     string
+	Role     atc.TeamRole
+
+	// IsAdmin is whether the token's team is the admin team. The token only
+	// acts as an admin if it also has the owner role.
+	IsAdmin bool
+
+	CreatedAt time.Time
+
+	// LastUsedAt is zero if the token has never been used.
+	LastUsedAt time.Time
+}
@@ -0,0 +1,29 @@
+package db
+
+import "time"
+
+// AuditEvent records a mutating API request: who made it, what it was, and
+// the status it was answered with.
+type AuditEvent struct {
+	ID   int
+	Time time.Time
+
+	TeamName string
+	IsAdmin  bool
+	Username string
+
+	Route  string
+	Method string
+	Path   string
+	Params map[string]string
+
+	Status int
+}
+
+// AuditEventFilter narrows GetAuditEvents down to the events matching every
+// non-empty field.
+type AuditEventFilter struct {
+	TeamName string
+	Username string
+	Route    string
+}
@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/event"
 )
 
@@ -23,8 +24,8 @@ const (
 	StatusErrored   Status = "errored"
 )
 
-const buildColumns = "id, name, job_id, team_id, status, scheduled, engine, engine_metadata, start_time, end_time, reap_time"
-const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.team_id, b.status, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name"
+const buildColumns = "id, name, job_id, team_id, status, scheduled, engine, engine_metadata, start_time, end_time, reap_time, archived, rerun_of"
+const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.team_id, b.status, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, b.archived, b.rerun_of, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name"
 
 //go:generate counterfeiter . Build
 
@@ -44,6 +45,8 @@ type Build interface {
 	IsOneOff() bool
 	IsScheduled() bool
 	IsRunning() bool
+	IsArchived() bool
+	RerunOf() int
 
 	Reload() (bool, error)
 
@@ -71,9 +74,14 @@ type Build interface {
 	SaveImageResourceVersion(planID atc.PlanID, identifier ResourceCacheIdentifier) error
 	GetImageResourceCacheIdentifiers() ([]ResourceCacheIdentifier, error)
 
+	SaveResourceUsage(usage atc.StepResourceUsage) error
+	GetResourceUsage() ([]atc.StepResourceUsage, error)
+
 	GetConfig() (atc.Config, ConfigVersion, error)
 
 	GetPipeline() (SavedPipeline, error)
+
+	Rerun() (Build, error)
 }
 
 type build struct {
@@ -95,8 +103,12 @@ type build struct {
 	endTime   time.Time
 	reapTime  time.Time
 
-	conn Conn
-	bus  *notificationsBus
+	archived bool
+	rerunOf  int
+
+	conn            Conn
+	bus             *notificationsBus
+	buildLogArchive BuildLogArchive
 }
 
 func (b *build) ID() int {
@@ -164,8 +176,16 @@ func (b *build) IsRunning() bool {
 	}
 }
 
+func (b *build) IsArchived() bool {
+	return b.archived
+}
+
+func (b *build) RerunOf() int {
+	return b.rerunOf
+}
+
 func (b *build) Reload() (bool, error) {
-	buildFactory := newBuildFactory(b.conn, b.bus)
+	buildFactory := newBuildFactory(b.conn, b.bus, b.buildLogArchive)
 	newBuild, found, err := buildFactory.ScanBuild(b.conn.QueryRow(`
 		SELECT `+qualifiedBuildColumns+`
 		FROM builds b
@@ -191,6 +211,8 @@ func (b *build) Reload() (bool, error) {
 	b.startTime = newBuild.StartTime()
 	b.endTime = newBuild.EndTime()
 	b.reapTime = newBuild.ReapTime()
+	b.archived = newBuild.IsArchived()
+	b.rerunOf = newBuild.RerunOf()
 	b.teamName = newBuild.TeamName()
 	b.teamID = newBuild.TeamID()
 	b.jobName = newBuild.JobName()
@@ -199,7 +221,17 @@ func (b *build) Reload() (bool, error) {
 	return found, err
 }
 
+// Events streams the build's events from the database, or from the build log
+// archive once they have been moved out of the database.
 func (b *build) Events(from uint) (EventSource, error) {
+	if b.archived {
+		if b.buildLogArchive == nil {
+			return nil, ErrBuildLogArchiveNotConfigured
+		}
+
+		return b.buildLogArchive.Events(b.id, from)
+	}
+
 	notifier, err := newConditionNotifier(b.bus, buildEventsChannel(b.id), func() (bool, error) {
 		return true, nil
 	})
@@ -618,7 +650,7 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		}, true, nil
 	}
 
-	tdbf := NewTeamDBFactory(b.conn, b.bus)
+	tdbf := NewTeamDBFactory(b.conn, b.bus, b.buildLogArchive)
 	tdb := tdbf.GetTeamDB(b.teamName)
 	savedPipeline, found, err := tdb.GetPipelineByName(b.pipelineName)
 	if err != nil {
@@ -629,7 +661,7 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		return BuildPreparation{}, false, nil
 	}
 
-	pdbf := NewPipelineDBFactory(b.conn, b.bus)
+	pdbf := NewPipelineDBFactory(b.conn, b.bus, b.buildLogArchive)
 	pdb := pdbf.Build(savedPipeline)
 	if err != nil {
 		return BuildPreparation{}, false, err
@@ -656,6 +688,7 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 	inputsSatisfiedStatus := BuildPreparationStatusBlocking
 	inputs := map[string]BuildPreparationStatus{}
 	missingInputReasons := MissingInputReasons{}
+	schedulingExplanation := algorithm.Explanation{}
 
 	if found {
 		inputsSatisfiedStatus = BuildPreparationStatusNotBlocking
@@ -668,6 +701,11 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 			return BuildPreparation{}, false, err
 		}
 
+		schedulingExplanation, err = pdb.GetSchedulingExplanation(jobName)
+		if err != nil {
+			return BuildPreparation{}, false, err
+		}
+
 		for _, configInput := range configInputs {
 			found := false
 			for _, buildInput := range buildInputs {
@@ -724,6 +762,8 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		Inputs:              inputs,
 		InputsSatisfied:     inputsSatisfiedStatus,
 		MissingInputReasons: missingInputReasons,
+
+		SchedulingExplanation: schedulingExplanation,
 	}
 
 	return buildPreparation, true, nil
@@ -742,7 +782,7 @@ func (b *build) SaveInput(input BuildInput) (SavedVersionedResource, error) {
 		return SavedVersionedResource{}, err
 	}
 
-	pipelineDBFactory := NewPipelineDBFactory(b.conn, b.bus)
+	pipelineDBFactory := NewPipelineDBFactory(b.conn, b.bus, b.buildLogArchive)
 
 	pipelineDB := pipelineDBFactory.Build(savedPipeline)
 
@@ -761,7 +801,7 @@ func (b *build) SaveOutput(vr VersionedResource, explicit bool) (SavedVersionedR
 	if err != nil {
 		return SavedVersionedResource{}, err
 	}
-	pipelineDBFactory := NewPipelineDBFactory(b.conn, b.bus)
+	pipelineDBFactory := NewPipelineDBFactory(b.conn, b.bus, b.buildLogArchive)
 	pipelineDB := pipelineDBFactory.Build(savedPipeline)
 
 	return pipelineDB.SaveOutput(b.id, vr, explicit)
@@ -847,6 +887,84 @@ func (b *build) GetImageResourceCacheIdentifiers() ([]ResourceCacheIdentifier, e
 	return identifiers, nil
 }
 
+func (b *build) SaveResourceUsage(usage atc.StepResourceUsage) error {
+	marshalledUsage, err := json.Marshal(usage.Usage)
+	if err != nil {
+		return err
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE build_resource_usage
+		SET step_name = $3, step_type = $4, usage = $5
+		WHERE build_id = $1 AND plan_id = $2
+	`, b.id, string(usage.PlanID), usage.StepName, usage.StepType, marshalledUsage)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		_, err := tx.Exec(`
+			INSERT INTO build_resource_usage(build_id, plan_id, step_name, step_type, usage)
+			VALUES ($1, $2, $3, $4, $5)
+		`, b.id, string(usage.PlanID), usage.StepName, usage.StepType, marshalledUsage)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (b *build) GetResourceUsage() ([]atc.StepResourceUsage, error) {
+	rows, err := b.conn.Query(`
+		SELECT plan_id, step_name, step_type, usage
+		FROM build_resource_usage
+		WHERE build_id = $1
+		ORDER BY plan_id ASC
+	`, b.id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	usages := []atc.StepResourceUsage{}
+
+	for rows.Next() {
+		var usage atc.StepResourceUsage
+		var planID string
+		var marshalledUsage []byte
+
+		err := rows.Scan(&planID, &usage.StepName, &usage.StepType, &marshalledUsage)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(marshalledUsage, &usage.Usage)
+		if err != nil {
+			return nil, err
+		}
+
+		usage.PlanID = atc.PlanID(planID)
+
+		usages = append(usages, usage)
+	}
+
+	return usages, nil
+}
+
 func (b *build) LeaseTracking(logger lager.Logger, interval time.Duration) (Lease, bool, error) {
 	lease := &lease{
 		conn: b.conn,
@@ -926,6 +1044,27 @@ func (b *build) GetPipeline() (SavedPipeline, error) {
 	return scanPipeline(row)
 }
 
+// Rerun creates a pending build of the same job which will run with exactly
+// this build's inputs, rather than the job's next inputs.
+func (b *build) Rerun() (Build, error) {
+	if b.IsOneOff() {
+		return nil, ErrCannotRerunOneOffBuild
+	}
+
+	if !b.IsScheduled() {
+		return nil, ErrCannotRerunUnscheduledBuild
+	}
+
+	savedPipeline, err := b.GetPipeline()
+	if err != nil {
+		return nil, err
+	}
+
+	pipelineDB := NewPipelineDBFactory(b.conn, b.bus, b.buildLogArchive).Build(savedPipeline)
+
+	return pipelineDB.CreateRerunBuild(b)
+}
+
 func newConditionNotifier(bus *notificationsBus, channel string, cond func() (bool, error)) (Notifier, error) {
 	notified, err := bus.Listen(channel)
 	if err != nil {
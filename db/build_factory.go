@@ -6,16 +6,18 @@ import (
 	"github.com/lib/pq"
 )
 
-func newBuildFactory(conn Conn, bus *notificationsBus) *buildFactory {
+func newBuildFactory(conn Conn, bus *notificationsBus, buildLogArchive BuildLogArchive) *buildFactory {
 	return &buildFactory{
-		conn: conn,
-		bus:  bus,
+		conn:            conn,
+		bus:             bus,
+		buildLogArchive: buildLogArchive,
 	}
 }
 
 type buildFactory struct {
-	conn Conn
-	bus  *notificationsBus
+	conn            Conn
+	bus             *notificationsBus
+	buildLogArchive BuildLogArchive
 }
 
 func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
@@ -28,9 +30,11 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 	var startTime pq.NullTime
 	var endTime pq.NullTime
 	var reapTime pq.NullTime
+	var archived bool
+	var rerunOf sql.NullInt64
 	var teamName string
 
-	err := row.Scan(&id, &name, &jobID, &teamID, &status, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &teamName)
+	err := row.Scan(&id, &name, &jobID, &teamID, &status, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &reapTime, &archived, &rerunOf, &jobName, &pipelineID, &pipelineName, &teamName)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, false, nil
@@ -40,8 +44,9 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 	}
 
 	build := &build{
-		conn: f.conn,
-		bus:  f.bus,
+		conn:            f.conn,
+		bus:             f.bus,
+		buildLogArchive: f.buildLogArchive,
 
 		id:        id,
 		name:      name,
@@ -55,6 +60,8 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 		endTime:   endTime.Time,
 		reapTime:  reapTime.Time,
 
+		archived: archived,
+
 		teamName: teamName,
 	}
 
@@ -64,6 +71,10 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 		build.pipelineID = int(pipelineID.Int64)
 	}
 
+	if rerunOf.Valid {
+		build.rerunOf = int(rerunOf.Int64)
+	}
+
 	if teamID.Valid {
 		build.teamID = int(teamID.Int64)
 	}
@@ -0,0 +1,15 @@
+package db
+
+import "errors"
+
+// ErrBuildLogArchiveNotConfigured is returned when reading the events of an
+// archived build while no build log archive has been configured.
+var ErrBuildLogArchiveNotConfigured = errors.New("build log archive not configured")
+
+//go:generate counterfeiter . BuildLogArchive
+
+// BuildLogArchive serves the events of builds whose event stream has been
+// moved out of the database.
+type BuildLogArchive interface {
+	Events(buildID int, from uint) (EventSource, error)
+}
@@ -1,6 +1,10 @@
 package db
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/concourse/atc/db/algorithm"
+)
 
 type BuildPreparationStatus string
 
@@ -44,6 +48,8 @@ type BuildPreparation struct {
 	Inputs              map[string]BuildPreparationStatus
 	InputsSatisfied     BuildPreparationStatus
 	MissingInputReasons MissingInputReasons
+
+	SchedulingExplanation algorithm.Explanation
 }
 
 func NewBuildPreparation(buildID int) BuildPreparation {
@@ -35,7 +35,7 @@ var _ = Describe("Build", func() {
 		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
 		bus := db.NewNotificationsBus(listener, dbConn)
 
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, nil)
 		teamDB = teamDBFactory.GetTeamDB(atc.DefaultTeamName)
 
 		pipelineConfig = atc.Config{
@@ -64,10 +64,10 @@ var _ = Describe("Build", func() {
 		}
 
 		var err error
-		pipeline, _, err = teamDB.SaveConfig("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+		pipeline, _, err = teamDB.SaveConfig("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus)
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, nil)
 		pipelineDB = pipelineDBFactory.Build(pipeline)
 	})
 
@@ -335,6 +335,146 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("Rerun", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build has been scheduled", func() {
+			var input db.BuildInput
+
+			BeforeEach(func() {
+				input = db.BuildInput{
+					Name: "some-input",
+					VersionedResource: db.VersionedResource{
+						Resource:   "some-resource",
+						Type:       "some-type",
+						Version:    db.Version{"some": "version"},
+						Metadata:   []db.MetadataField{},
+						PipelineID: pipeline.ID,
+					},
+				}
+
+				err := pipelineDB.UseInputsForBuild(build.ID(), []db.BuildInput{input})
+				Expect(err).NotTo(HaveOccurred())
+
+				updated, err := pipelineDB.UpdateBuildToScheduled(build.ID())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updated).To(BeTrue())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = build.Finish(db.StatusErrored)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("creates a pending build of the same job linked to the build", func() {
+				rerun, err := build.Rerun()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(rerun.ID()).NotTo(Equal(build.ID()))
+				Expect(rerun.Name()).To(Equal("2"))
+				Expect(rerun.JobName()).To(Equal("some-job"))
+				Expect(rerun.PipelineName()).To(Equal("some-pipeline"))
+				Expect(rerun.Status()).To(Equal(db.StatusPending))
+				Expect(rerun.IsScheduled()).To(BeFalse())
+				Expect(rerun.RerunOf()).To(Equal(build.ID()))
+
+				found, err := rerun.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(rerun.RerunOf()).To(Equal(build.ID()))
+			})
+
+			It("gives the rerun the build's inputs", func() {
+				rerun, err := build.Rerun()
+				Expect(err).NotTo(HaveOccurred())
+
+				inputs, _, err := rerun.GetResources()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(inputs).To(HaveLen(1))
+				Expect(inputs[0].Name).To(Equal("some-input"))
+				Expect(inputs[0].VersionedResource.Resource).To(Equal("some-resource"))
+				Expect(inputs[0].VersionedResource.Version).To(Equal(db.Version{"some": "version"}))
+			})
+		})
+
+		Context("when the build has not been scheduled", func() {
+			It("returns an error", func() {
+				_, err := build.Rerun()
+				Expect(err).To(Equal(db.ErrCannotRerunUnscheduledBuild))
+			})
+		})
+
+		Context("when the build is a one-off", func() {
+			It("returns an error", func() {
+				oneOffBuild, err := teamDB.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = oneOffBuild.Rerun()
+				Expect(err).To(Equal(db.ErrCannotRerunOneOffBuild))
+			})
+		})
+	})
+
+	Describe("SaveResourceUsage", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = teamDB.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns no usage for a build that has not recorded any", func() {
+			usages, err := build.GetResourceUsage()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usages).To(BeEmpty())
+		})
+
+		It("saves the usage of each step, replacing earlier values for the same plan", func() {
+			taskUsage := atc.StepResourceUsage{
+				PlanID:   "some-plan-id",
+				StepName: "some-task",
+				StepType: "task",
+				Usage: atc.ResourceUsage{
+					PeakCPU:       2,
+					AverageCPU:    1.5,
+					PeakMemory:    2048,
+					AverageMemory: 1024,
+					Samples:       2,
+				},
+			}
+
+			getUsage := atc.StepResourceUsage{
+				PlanID:   "some-other-plan-id",
+				StepName: "some-input",
+				StepType: "get",
+				Usage: atc.ResourceUsage{
+					PeakDisk:    4096,
+					AverageDisk: 4096,
+					Samples:     1,
+				},
+			}
+
+			Expect(build.SaveResourceUsage(taskUsage)).To(Succeed())
+			Expect(build.SaveResourceUsage(getUsage)).To(Succeed())
+
+			taskUsage.Usage.PeakMemory = 4096
+			Expect(build.SaveResourceUsage(taskUsage)).To(Succeed())
+
+			usages, err := build.GetResourceUsage()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usages).To(Equal([]atc.StepResourceUsage{getUsage, taskUsage}))
+		})
+	})
+
 	Describe("build operations", func() {
 		var build db.Build
 
@@ -633,6 +773,7 @@ var _ = Describe("Build", func() {
 			Context("when inputs are not satisfied", func() {
 				BeforeEach(func() {
 					expectedBuildPrep.InputsSatisfied = db.BuildPreparationStatusBlocking
+					expectedBuildPrep.SchedulingExplanation = algorithm.Explanation{}
 				})
 
 				It("returns blocking inputs satisfied", func() {
@@ -680,7 +821,7 @@ var _ = Describe("Build", func() {
 						},
 					}
 
-					pipeline, _, err = teamDB.SaveConfig("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+					pipeline, _, err = teamDB.SaveConfig("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused, "")
 					Expect(err).NotTo(HaveOccurred())
 
 					err = pipelineDB.SaveResourceVersions(
@@ -714,6 +855,17 @@ var _ = Describe("Build", func() {
 						"input1": {VersionID: versions[0].ID, FirstOccurrence: true},
 					}, "some-job")
 
+					explanation := algorithm.Explanation{
+						"input2": {Reason: algorithm.ExplanationNoVersions},
+						"input3": {
+							Reason: algorithm.ExplanationPassedConstraint,
+							Passed: []string{"some-upstream-job"},
+						},
+					}
+
+					err = pipelineDB.SaveSchedulingExplanation(explanation, "some-job")
+					Expect(err).NotTo(HaveOccurred())
+
 					expectedBuildPrep.Inputs = map[string]db.BuildPreparationStatus{
 						"input1": db.BuildPreparationStatusNotBlocking,
 						"input2": db.BuildPreparationStatusBlocking,
@@ -730,6 +882,7 @@ var _ = Describe("Build", func() {
 						"input5": fmt.Sprintf(db.PinnedVersionUnavailable, `{"version":"v5"}`),
 						"input6": db.NoVerionsSatisfiedPassedConstraints,
 					}
+					expectedBuildPrep.SchedulingExplanation = explanation
 				})
 
 				It("returns blocking inputs satisfied", func() {
@@ -756,7 +909,7 @@ var _ = Describe("Build", func() {
 					},
 				}
 
-				pipeline, _, err = teamDB.SaveConfig("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+				pipeline, _, err = teamDB.SaveConfig("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused, "")
 				Expect(err).NotTo(HaveOccurred())
 
 				build1, err = pipelineDB.CreateJobBuild("some-job")
@@ -785,6 +938,7 @@ var _ = Describe("Build", func() {
 					"input1": db.NoVersionsAvailable,
 					"input2": db.NoVersionsAvailable,
 				}
+				expectedBuildPrep.SchedulingExplanation = algorithm.Explanation{}
 
 				buildPrep, found, err := build1.GetPreparation()
 				Expect(err).NotTo(HaveOccurred())
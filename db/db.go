@@ -91,11 +91,21 @@ type DB interface {
 
 	GetLease(logger lager.Logger, taskName string, interval time.Duration) (Lease, bool, error)
 
+	CreateNotificationDelivery(delivery NotificationDelivery) error
+	GetPendingNotificationDeliveries() ([]SavedNotificationDelivery, error)
+	SaveNotificationDeliveryAttempt(deliveryID int, attempt NotificationDeliveryAttempt) error
+
 	DeleteBuildEventsByBuildIDs(buildIDs []int) error
 
+	GetBuildsToArchive(finishedBefore time.Time, limit int) ([]Build, error)
+	MarkBuildArchived(buildID int) error
+
 	Workers() ([]SavedWorker, error) // auto-expires workers based on ttl
 	GetWorker(workerName string) (SavedWorker, bool, error)
 	SaveWorker(WorkerInfo, time.Duration) (SavedWorker, error)
+	LandWorker(workerName string) (bool, error)
+	RetireWorker(workerName string) (bool, error)
+	PruneWorker(workerName string) (bool, error)
 
 	GetContainer(string) (SavedContainer, bool, error)
 	CreateContainer(container Container, ttl time.Duration, maxLifetime time.Duration, volumeHandles []string) (SavedContainer, error)
@@ -117,6 +127,10 @@ type DB interface {
 	GetVolumesForOneOffBuildImageResources() ([]SavedVolume, error)
 
 	FindWorkerCheckResourceTypeVersion(workerName string, checkType string) (string, bool, error)
+	SaveAuditEvent(event AuditEvent) error
+	GetAuditEvents(filter AuditEventFilter, page Page) ([]AuditEvent, Pagination, error)
+
+	UseAPIToken(tokenHash string) (APIToken, bool, error)
 }
 
 //go:generate counterfeiter . Notifier
@@ -164,8 +178,20 @@ type SavedWorker struct {
 
 	TeamName  string
 	ExpiresIn time.Duration
+	State     WorkerState
 }
 
+type WorkerState string
+
+const (
+	WorkerStateRunning  WorkerState = "running"
+	WorkerStateLanding  WorkerState = "landing"
+	WorkerStateLanded   WorkerState = "landed"
+	WorkerStateRetiring WorkerState = "retiring"
+	WorkerStateRetired  WorkerState = "retired"
+	WorkerStateStalled  WorkerState = "stalled"
+)
+
 type WorkerInfo struct {
 	GardenAddr      string
 	BaggageclaimURL string
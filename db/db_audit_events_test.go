@@ -0,0 +1,137 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/db"
+)
+
+var _ = Describe("Audit events", func() {
+	var dbConn db.Conn
+	var listener *pq.Listener
+
+	var database db.DB
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		database = db.NewSQL(dbConn, bus, nil)
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when events have been saved", func() {
+		BeforeEach(func() {
+			for _, event := range []db.AuditEvent{
+				{
+					TeamName: "main",
+					IsAdmin:  true,
+					Username: "admin",
+					Route:    "SaveConfig",
+					Method:   "PUT",
+					Path:     "/api/v1/teams/main/pipelines/some-pipeline/config",
+					Params:   map[string]string{"team_name": "main", "pipeline_name": "some-pipeline"},
+					Status:   200,
+				},
+				{
+					TeamName: "other",
+					Username: "someone",
+					Route:    "PausePipeline",
+					Method:   "PUT",
+					Path:     "/api/v1/teams/other/pipelines/some-pipeline/pause",
+					Status:   403,
+				},
+				{
+					TeamName: "main",
+					Route:    "CreateJobBuild",
+					Method:   "POST",
+					Path:     "/api/v1/teams/main/pipelines/some-pipeline/jobs/some-job/builds",
+					Status:   200,
+				},
+			} {
+				err := database.SaveAuditEvent(event)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("returns them newest first", func() {
+			events, pagination, err := database.GetAuditEvents(db.AuditEventFilter{}, db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pagination).To(Equal(db.Pagination{}))
+
+			Expect(events).To(HaveLen(3))
+			Expect(events[0].Route).To(Equal("CreateJobBuild"))
+			Expect(events[0].Params).To(BeEmpty())
+			Expect(events[1].Route).To(Equal("PausePipeline"))
+			Expect(events[1].Status).To(Equal(403))
+			Expect(events[2].Route).To(Equal("SaveConfig"))
+			Expect(events[2].IsAdmin).To(BeTrue())
+			Expect(events[2].Username).To(Equal("admin"))
+			Expect(events[2].Params).To(Equal(map[string]string{"team_name": "main", "pipeline_name": "some-pipeline"}))
+			Expect(events[2].Time).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+
+		It("paginates through them", func() {
+			events, pagination, err := database.GetAuditEvents(db.AuditEventFilter{}, db.Page{Limit: 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].ID).To(Equal(3))
+			Expect(events[1].ID).To(Equal(2))
+			Expect(pagination.Previous).To(BeNil())
+			Expect(pagination.Next).To(Equal(&db.Page{Since: 2, Limit: 2}))
+
+			events, pagination, err = database.GetAuditEvents(db.AuditEventFilter{}, *pagination.Next)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].ID).To(Equal(1))
+			Expect(pagination.Previous).To(Equal(&db.Page{Until: 1, Limit: 2}))
+			Expect(pagination.Next).To(BeNil())
+
+			events, _, err = database.GetAuditEvents(db.AuditEventFilter{}, *pagination.Previous)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].ID).To(Equal(3))
+			Expect(events[1].ID).To(Equal(2))
+		})
+
+		It("filters them", func() {
+			events, pagination, err := database.GetAuditEvents(db.AuditEventFilter{TeamName: "main"}, db.Page{Limit: 1})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].ID).To(Equal(3))
+			Expect(pagination.Next).To(Equal(&db.Page{Since: 3, Limit: 1}))
+
+			events, pagination, err = database.GetAuditEvents(db.AuditEventFilter{TeamName: "main"}, *pagination.Next)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].ID).To(Equal(1))
+			Expect(pagination.Next).To(BeNil())
+
+			events, _, err = database.GetAuditEvents(db.AuditEventFilter{Username: "someone"}, db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Route).To(Equal("PausePipeline"))
+
+			events, _, err = database.GetAuditEvents(db.AuditEventFilter{TeamName: "main", Route: "SaveConfig"}, db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].ID).To(Equal(1))
+		})
+	})
+})
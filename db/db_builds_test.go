@@ -9,6 +9,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/event"
 )
 
@@ -54,14 +55,14 @@ var _ = Describe("Builds", func() {
 		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
 		bus := db.NewNotificationsBus(listener, dbConn)
 
-		database = db.NewSQL(dbConn, bus)
+		database = db.NewSQL(dbConn, bus, nil)
 		_, err := database.CreateTeam(db.Team{Name: "some-team"})
 		Expect(err).NotTo(HaveOccurred())
 
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, nil)
 		teamDB = teamDBFactory.GetTeamDB("some-team")
 
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, nil)
 
 		config = atc.Config{
 			Jobs: atc.JobConfigs{
@@ -91,10 +92,10 @@ var _ = Describe("Builds", func() {
 			},
 		}
 
-		pipeline, _, err = teamDB.SaveConfig("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+		pipeline, _, err = teamDB.SaveConfig("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus)
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, nil)
 		pipelineDB = pipelineDBFactory.Build(pipeline)
 	})
 
@@ -175,14 +176,14 @@ var _ = Describe("Builds", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			config := atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}}
-			privatePipeline, _, err := teamDB.SaveConfig("private-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			privatePipeline, _, err := teamDB.SaveConfig("private-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 			privatePipelineDB := pipelineDBFactory.Build(privatePipeline)
 
 			privateBuild, err = privatePipelineDB.CreateJobBuild("some-job")
 			Expect(err).NotTo(HaveOccurred())
 
-			publicPipeline, _, err := teamDB.SaveConfig("public-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			publicPipeline, _, err := teamDB.SaveConfig("public-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 			publicPipelineDB := pipelineDBFactory.Build(publicPipeline)
 			publicPipelineDB.Expose()
@@ -349,4 +350,128 @@ var _ = Describe("Builds", func() {
 			Expect(build4DB.ReapTime()).To(Equal(build1DB.ReapTime()))
 		})
 	})
+
+	Describe("GetBuildsToArchive", func() {
+		var finishedBuild db.Build
+
+		BeforeEach(func() {
+			var err error
+			finishedBuild, err = teamDB.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = finishedBuild.Finish(db.StatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = teamDB.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			createAndStartBuild(database, pipelineDB, "some-job", "some-engine")
+		})
+
+		It("returns completed builds that finished before the given time", func() {
+			builds, err := database.GetBuildsToArchive(time.Now().Add(time.Minute), 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(finishedBuild.ID()))
+		})
+
+		It("does not return builds that finished after the given time", func() {
+			builds, err := database.GetBuildsToArchive(time.Now().Add(-time.Minute), 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(BeEmpty())
+		})
+
+		It("does not return builds that have been reaped", func() {
+			err := database.DeleteBuildEventsByBuildIDs([]int{finishedBuild.ID()})
+			Expect(err).NotTo(HaveOccurred())
+
+			builds, err := database.GetBuildsToArchive(time.Now().Add(time.Minute), 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(BeEmpty())
+		})
+
+		It("does not return builds that have already been archived", func() {
+			err := database.MarkBuildArchived(finishedBuild.ID())
+			Expect(err).NotTo(HaveOccurred())
+
+			builds, err := database.GetBuildsToArchive(time.Now().Add(time.Minute), 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(BeEmpty())
+		})
+	})
+
+	Describe("MarkBuildArchived", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = teamDB.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{
+				Payload: "some log",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.Finish(db.StatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = database.MarkBuildArchived(build.ID())
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("marks the build as archived", func() {
+			Expect(build.IsArchived()).To(BeTrue())
+		})
+
+		It("deletes the build's events from the database", func() {
+			var count int
+			err := dbConn.QueryRow(`SELECT COUNT(*) FROM build_events WHERE build_id = $1`, build.ID()).Scan(&count)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(BeZero())
+		})
+
+		Context("when a build log archive is configured", func() {
+			var (
+				fakeArchive     *dbfakes.FakeBuildLogArchive
+				fakeEventSource *dbfakes.FakeEventSource
+			)
+
+			BeforeEach(func() {
+				fakeArchive = new(dbfakes.FakeBuildLogArchive)
+				fakeEventSource = new(dbfakes.FakeEventSource)
+				fakeArchive.EventsReturns(fakeEventSource, nil)
+
+				archivingDB := db.NewSQL(dbConn, db.NewNotificationsBus(listener, dbConn), fakeArchive)
+
+				var found bool
+				var err error
+				build, found, err = archivingDB.GetBuildByID(build.ID())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("reads the build's events from the archive", func() {
+				events, err := build.Events(2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events).To(Equal(fakeEventSource))
+
+				Expect(fakeArchive.EventsCallCount()).To(Equal(1))
+				buildID, from := fakeArchive.EventsArgsForCall(0)
+				Expect(buildID).To(Equal(build.ID()))
+				Expect(from).To(Equal(uint(2)))
+			})
+		})
+
+		Context("when no build log archive is configured", func() {
+			It("returns an error when reading the build's events", func() {
+				_, err := build.Events(0)
+				Expect(err).To(Equal(db.ErrBuildLogArchiveNotConfigured))
+			})
+		})
+	})
 })
@@ -31,8 +31,8 @@ var _ = Describe("Keeping track of containers", func() {
 		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
 		bus := db.NewNotificationsBus(listener, dbConn)
 
-		database = db.NewSQL(dbConn, bus)
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus)
+		database = db.NewSQL(dbConn, bus, nil)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, nil)
 
 		savedTeam, err := database.CreateTeam(db.Team{Name: "some-team"})
 		Expect(err).NotTo(HaveOccurred())
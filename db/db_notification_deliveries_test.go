@@ -0,0 +1,132 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+var _ = Describe("Notification deliveries", func() {
+	var dbConn db.Conn
+	var listener *pq.Listener
+
+	var database db.DB
+	var build db.Build
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		database = db.NewSQL(dbConn, bus, nil)
+
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, nil)
+		teamDB := teamDBFactory.GetTeamDB(atc.DefaultTeamName)
+
+		var err error
+		build, err = teamDB.CreateOneOffBuild()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when a delivery has been created", func() {
+		var delivery db.NotificationDelivery
+
+		BeforeEach(func() {
+			delivery = db.NotificationDelivery{
+				BuildID:   build.ID(),
+				HookName:  "chat",
+				URL:       "https://chat.example.com/hook",
+				Payload:   `{"status":"succeeded"}`,
+				Signature: "sha256=abc",
+			}
+
+			err := database.CreateNotificationDelivery(delivery)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("is pending", func() {
+			deliveries, err := database.GetPendingNotificationDeliveries()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deliveries).To(HaveLen(1))
+			Expect(deliveries[0].NotificationDelivery).To(Equal(delivery))
+			Expect(deliveries[0].Status).To(Equal(db.NotificationDeliveryStatusPending))
+			Expect(deliveries[0].Attempts).To(BeZero())
+		})
+
+		Context("when an attempt is retried later", func() {
+			BeforeEach(func() {
+				deliveries, err := database.GetPendingNotificationDeliveries()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = database.SaveNotificationDeliveryAttempt(deliveries[0].ID, db.NotificationDeliveryAttempt{
+					ResponseStatus: 502,
+					Status:         db.NotificationDeliveryStatusPending,
+					RetryAfter:     time.Hour,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("is not pending until the retry is due", func() {
+				deliveries, err := database.GetPendingNotificationDeliveries()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deliveries).To(BeEmpty())
+			})
+		})
+
+		Context("when an attempt is retried immediately", func() {
+			BeforeEach(func() {
+				deliveries, err := database.GetPendingNotificationDeliveries()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = database.SaveNotificationDeliveryAttempt(deliveries[0].ID, db.NotificationDeliveryAttempt{
+					Error:  "connection refused",
+					Status: db.NotificationDeliveryStatusPending,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("counts the attempt", func() {
+				deliveries, err := database.GetPendingNotificationDeliveries()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deliveries).To(HaveLen(1))
+				Expect(deliveries[0].Attempts).To(Equal(1))
+			})
+		})
+
+		Context("when an attempt delivers it", func() {
+			BeforeEach(func() {
+				deliveries, err := database.GetPendingNotificationDeliveries()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = database.SaveNotificationDeliveryAttempt(deliveries[0].ID, db.NotificationDeliveryAttempt{
+					ResponseStatus: 200,
+					Status:         db.NotificationDeliveryStatusDelivered,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("is no longer pending", func() {
+				deliveries, err := database.GetPendingNotificationDeliveries()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deliveries).To(BeEmpty())
+			})
+		})
+	})
+})
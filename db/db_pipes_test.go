@@ -28,9 +28,9 @@ var _ = Describe("Pipes", func() {
 		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
 		bus := db.NewNotificationsBus(listener, dbConn)
 
-		database = db.NewSQL(dbConn, bus)
+		database = db.NewSQL(dbConn, bus, nil)
 
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, nil)
 		teamDB = teamDBFactory.GetTeamDB(atc.DefaultTeamName)
 	})
 
@@ -31,8 +31,8 @@ var _ = Describe("SQL DB Teams", func() {
 		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
 		bus := db.NewNotificationsBus(listener, dbConn)
 
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus)
-		database = db.NewSQL(dbConn, bus)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, nil)
+		database = db.NewSQL(dbConn, bus, nil)
 
 		database.DeleteTeamByName(atc.DefaultTeamName)
 	})
@@ -180,6 +180,26 @@ var _ = Describe("SQL DB Teams", func() {
 			Expect(savedTeam).To(Equal(expectedSavedTeam))
 		})
 
+		It("saves a team to the db with roles", func() {
+			expectedTeam := db.Team{
+				Name: "avengers",
+				Roles: []atc.TeamRoleMapping{
+					{Role: atc.RoleOwner, BasicAuthUsers: []string{"captain"}},
+					{Role: atc.RoleViewer, GitHubOrganizations: []string{"shield"}},
+				},
+			}
+			expectedSavedTeam, err := database.CreateTeam(expectedTeam)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expectedSavedTeam.Team).To(Equal(expectedTeam))
+
+			savedTeam, found, err := teamDBFactory.GetTeamDB("avengers").GetTeam()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(savedTeam).To(Equal(expectedSavedTeam))
+
+			Expect(savedTeam.Roles).To(Equal(expectedTeam.Roles))
+		})
+
 		It("saves a team to the db with basic auth", func() {
 			expectedTeam := db.Team{
 				Name: "avengers",
@@ -202,6 +222,38 @@ var _ = Describe("SQL DB Teams", func() {
 				[]byte(expectedTeam.BasicAuth.BasicAuthPassword))).To(BeNil())
 		})
 
+		It("saves a team to the db with LDAP auth and roles", func() {
+			expectedTeam := db.Team{
+				Name: "avengers",
+				LDAPAuth: &db.LDAPAuth{
+					Host:         "ldap.example.com:636",
+					BindDN:       "cn=concourse,dc=example,dc=com",
+					BindPassword: "some-password",
+					UserSearch: db.LDAPUserSearch{
+						BaseDN:            "ou=people,dc=example,dc=com",
+						UsernameAttribute: "uid",
+					},
+					GroupSearch: db.LDAPGroupSearch{
+						BaseDN:         "ou=groups,dc=example,dc=com",
+						GroupAttribute: "member",
+						NameAttribute:  "cn",
+					},
+					Groups: []string{"ci"},
+				},
+				Roles: []atc.TeamRoleMapping{
+					{Role: atc.RoleViewer, LDAPGroups: []string{"everyone"}},
+				},
+			}
+			expectedSavedTeam, err := database.CreateTeam(expectedTeam)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expectedSavedTeam.Team).To(Equal(expectedTeam))
+
+			savedTeam, found, err := teamDBFactory.GetTeamDB("avengers").GetTeam()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(savedTeam).To(Equal(expectedSavedTeam))
+		})
+
 		It("saves a team to the db with GitHub auth", func() {
 			expectedTeam := db.Team{
 				Name: "avengers",
@@ -28,10 +28,10 @@ var _ = Describe("Keeping track of volumes", func() {
 		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
 		bus := db.NewNotificationsBus(listener, dbConn)
 
-		sqlDB := db.NewSQL(dbConn, bus)
+		sqlDB := db.NewSQL(dbConn, bus, nil)
 		database = sqlDB
 
-		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus)
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, nil)
 		team, err := database.CreateTeam(db.Team{Name: "some-team"})
 		Expect(err).NotTo(HaveOccurred())
 		teamID = team.ID
@@ -43,9 +43,9 @@ var _ = Describe("Keeping track of volumes", func() {
 				},
 			},
 		}
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, nil)
 		teamDB = teamDBFactory.GetTeamDB("some-team")
-		savedPipeline, _, err := teamDB.SaveConfig("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+		savedPipeline, _, err := teamDB.SaveConfig("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		pipelineDB = pipelineDBFactory.Build(savedPipeline)
@@ -543,6 +543,80 @@ var _ = Describe("Keeping track of volumes", func() {
 				Expect(handles).To(ConsistOf([]string{"my-import-handle", "my-other-import-handle"}))
 			})
 		})
+
+		Describe("task cache volumes", func() {
+			var firstBuild db.Build
+			var taskCacheVolume db.Volume
+
+			taskCacheIdentifier := func(build db.Build, workerName string) db.VolumeIdentifier {
+				return db.VolumeIdentifier{
+					TaskCache: &db.TaskCacheIdentifier{
+						BuildID:    build.ID(),
+						StepName:   "some-task",
+						Path:       "some/cache",
+						WorkerName: workerName,
+					},
+				}
+			}
+
+			BeforeEach(func() {
+				var err error
+				firstBuild, err = pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				taskCacheVolume = db.Volume{
+					WorkerName: "some-worker",
+					TTL:        time.Hour,
+					Handle:     "my-task-cache-handle",
+					Identifier: taskCacheIdentifier(firstBuild, "some-worker"),
+				}
+
+				err = database.InsertVolume(taskCacheVolume)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("can be retrieved by later builds of the same job on the same worker", func() {
+				secondBuild, err := pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				savedVolumes, err := database.GetVolumesByIdentifier(taskCacheIdentifier(secondBuild, "some-worker"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedVolumes).To(HaveLen(1))
+
+				savedVolume := savedVolumes[0]
+				Expect(savedVolume.Handle).To(Equal("my-task-cache-handle"))
+				Expect(savedVolume.Volume.Identifier.Type()).To(Equal("task-cache"))
+				Expect(savedVolume.Volume.Identifier.TaskCache.StepName).To(Equal("some-task"))
+				Expect(savedVolume.Volume.Identifier.TaskCache.Path).To(Equal("some/cache"))
+				Expect(savedVolume.Volume.Identifier.TaskCache.WorkerName).To(Equal("some-worker"))
+			})
+
+			It("is not found on other workers", func() {
+				savedVolumes, err := database.GetVolumesByIdentifier(taskCacheIdentifier(firstBuild, "some-other-worker"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedVolumes).To(BeEmpty())
+			})
+
+			Describe("ClearTaskCaches", func() {
+				It("returns the job's caches and stops them from being found", func() {
+					clearedVolumes, err := pipelineDB.ClearTaskCaches("some-job")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(clearedVolumes).To(HaveLen(1))
+					Expect(clearedVolumes[0].Handle).To(Equal("my-task-cache-handle"))
+					Expect(clearedVolumes[0].WorkerName).To(Equal("some-worker"))
+
+					savedVolumes, err := database.GetVolumesByIdentifier(taskCacheIdentifier(firstBuild, "some-worker"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(savedVolumes).To(BeEmpty())
+				})
+
+				It("returns nothing for a job without caches", func() {
+					clearedVolumes, err := pipelineDB.ClearTaskCaches("some-other-job")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(clearedVolumes).To(BeEmpty())
+				})
+			})
+		})
 	})
 
 	Describe("GetVolumesForOneOffBuildImageResources", func() {
@@ -27,7 +27,7 @@ var _ = Describe("Keeping track of workers", func() {
 		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
 		bus := db.NewNotificationsBus(listener, dbConn)
 
-		database = db.NewSQL(dbConn, bus)
+		database = db.NewSQL(dbConn, bus, nil)
 	})
 
 	AfterEach(func() {
@@ -71,6 +71,7 @@ var _ = Describe("Keeping track of workers", func() {
 		expectedSavedWorkerA := db.SavedWorker{
 			WorkerInfo: infoA,
 			ExpiresIn:  0,
+			State:      db.WorkerStateRunning,
 		}
 
 		By("persisting workers with no TTLs")
@@ -110,25 +111,49 @@ var _ = Describe("Keeping track of workers", func() {
 		_, err = database.SaveWorker(infoB, ttl)
 		Expect(err).NotTo(HaveOccurred())
 
-		workerInfos := func() []db.WorkerInfo {
-			return getWorkerInfos(database.Workers())
+		workerStates := func() map[string]db.WorkerState {
+			return getWorkerStates(database.Workers())
 		}
 
-		Consistently(workerInfos, ttl/2).Should(ConsistOf(infoA, infoB))
-		Eventually(workerInfos, 2*ttl).Should(ConsistOf(infoA))
+		Consistently(workerStates, ttl/2).Should(Equal(map[string]db.WorkerState{
+			infoA.Name: db.WorkerStateRunning,
+			infoB.Name: db.WorkerStateRunning,
+		}))
+
+		Eventually(workerStates, 2*ttl).Should(Equal(map[string]db.WorkerState{
+			infoA.Name: db.WorkerStateRunning,
+			infoB.Name: db.WorkerStateStalled,
+		}))
+
+		By("pruning stalled workers")
+		found, err := database.PruneWorker(infoB.Name)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		Expect(getWorkerInfos(database.Workers())).To(ConsistOf(infoA))
 
 		By("overwriting TTLs")
 		_, err = database.SaveWorker(infoA, ttl)
 		Expect(err).NotTo(HaveOccurred())
 
-		Consistently(workerInfos, ttl/2).Should(ConsistOf(infoA))
-		Eventually(workerInfos, 2*ttl).Should(BeEmpty())
+		Consistently(workerStates, ttl/2).Should(Equal(map[string]db.WorkerState{
+			infoA.Name: db.WorkerStateRunning,
+		}))
 
-		By("updating attributes by name with ttls")
+		Eventually(workerStates, 2*ttl).Should(Equal(map[string]db.WorkerState{
+			infoA.Name: db.WorkerStateStalled,
+		}))
+
+		By("resuming stalled workers when they heartbeat")
 		ttl = 1 * time.Hour
 		_, err = database.SaveWorker(infoA, ttl)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(getWorkerInfos(database.Workers())).To(ConsistOf(infoA))
+		Expect(workerStates()).To(Equal(map[string]db.WorkerState{
+			infoA.Name: db.WorkerStateRunning,
+		}))
+
+		By("updating attributes by name with ttls")
 
 		infoA.GardenAddr = "1.2.3.4:1234"
 
@@ -236,13 +261,241 @@ var _ = Describe("Keeping track of workers", func() {
 		savedWorkerA, err := database.SaveWorker(infoA, ttl)
 		Expect(err).NotTo(HaveOccurred())
 
-		workerFound := func() bool {
-			_, found, _ = database.GetWorker(savedWorkerA.Name)
-			return found
+		workerState := func() db.WorkerState {
+			savedWorker, found, err := database.GetWorker(savedWorkerA.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			return savedWorker.State
+		}
+
+		Consistently(workerState, ttl/2).Should(Equal(db.WorkerStateRunning))
+		Eventually(workerState, 2*ttl).Should(Equal(db.WorkerStateStalled))
+	})
+
+	Describe("landing, retiring and pruning workers", func() {
+		var (
+			teamDB db.TeamDB
+			build  db.Build
+		)
+
+		BeforeEach(func() {
+			var err error
+			team, err = database.CreateTeam(db.Team{Name: "some-team"})
+			Expect(err).NotTo(HaveOccurred())
+
+			teamDB = db.NewTeamDBFactory(dbConn, db.NewNotificationsBus(listener, dbConn), nil).GetTeamDB("some-team")
+
+			build, err = teamDB.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = database.SaveWorker(db.WorkerInfo{
+				Name:       "some-worker",
+				GardenAddr: "1.2.3.4:7777",
+			}, time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = database.CreateContainer(db.Container{
+				ContainerIdentifier: db.ContainerIdentifier{
+					BuildID: build.ID(),
+					PlanID:  "some-plan-id",
+					Stage:   db.ContainerStageRun,
+				},
+				ContainerMetadata: db.ContainerMetadata{
+					Handle:     "some-handle",
+					WorkerName: "some-worker",
+					Type:       db.ContainerTypeTask,
+					TeamID:     team.ID,
+				},
+			}, time.Hour, 0, []string{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		workerState := func() db.WorkerState {
+			savedWorker, found, err := database.GetWorker("some-worker")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			return savedWorker.State
 		}
 
-		Consistently(workerFound, ttl/2).Should(BeTrue())
-		Eventually(workerFound, 2*ttl).Should(BeFalse())
+		It("lands a worker once its builds have finished", func() {
+			found, err := database.LandWorker("some-worker")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(workerState()).To(Equal(db.WorkerStateLanding))
+
+			By("keeping the worker landing while it heartbeats")
+			_, err = database.SaveWorker(db.WorkerInfo{
+				Name:       "some-worker",
+				GardenAddr: "1.2.3.4:7777",
+			}, time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(workerState()).To(Equal(db.WorkerStateLanding))
+
+			err = build.Finish(db.StatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(workerState()).To(Equal(db.WorkerStateLanded))
+
+			By("keeping the worker landed while it heartbeats")
+			_, err = database.SaveWorker(db.WorkerInfo{
+				Name:       "some-worker",
+				GardenAddr: "1.2.3.4:7777",
+			}, time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(workerState()).To(Equal(db.WorkerStateLanded))
+
+			By("running the worker again once it has been pruned and registers")
+			found, err = database.PruneWorker("some-worker")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, err = database.SaveWorker(db.WorkerInfo{
+				Name:       "some-worker",
+				GardenAddr: "1.2.3.4:7777",
+			}, time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(workerState()).To(Equal(db.WorkerStateRunning))
+		})
+
+		It("runs a landed worker again once it restarts", func() {
+			found, err := database.LandWorker("some-worker")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = build.Finish(db.StatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(workerState()).To(Equal(db.WorkerStateLanded))
+
+			_, err = database.SaveWorker(db.WorkerInfo{
+				Name:       "some-worker",
+				GardenAddr: "1.2.3.4:7777",
+				StartTime:  1234,
+			}, time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(workerState()).To(Equal(db.WorkerStateRunning))
+		})
+
+		It("stalls a landed worker once it stops heartbeating", func() {
+			found, err := database.LandWorker("some-worker")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = build.Finish(db.StatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = database.SaveWorker(db.WorkerInfo{
+				Name:       "some-worker",
+				GardenAddr: "1.2.3.4:7777",
+			}, time.Second)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(workerState()).To(Equal(db.WorkerStateLanded))
+
+			Eventually(workerState, 2*time.Second).Should(Equal(db.WorkerStateStalled))
+		})
+
+		It("retires a retiring worker once its builds have finished", func() {
+			found, err := database.RetireWorker("some-worker")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(workerState()).To(Equal(db.WorkerStateRetiring))
+
+			By("refusing heartbeats while the worker is retiring")
+			_, err = database.SaveWorker(db.WorkerInfo{
+				Name:       "some-worker",
+				GardenAddr: "1.2.3.4:7777",
+			}, time.Hour)
+			Expect(err).To(Equal(db.ErrWorkerRetiring))
+
+			Expect(workerState()).To(Equal(db.WorkerStateRetiring))
+
+			err = build.Finish(db.StatusFailed)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(workerState()).To(Equal(db.WorkerStateRetired))
+
+			By("refusing heartbeats once the worker has retired")
+			_, err = database.SaveWorker(db.WorkerInfo{
+				Name:       "some-worker",
+				GardenAddr: "1.2.3.4:7777",
+				StartTime:  1234,
+			}, time.Hour)
+			Expect(err).To(Equal(db.ErrWorkerRetiring))
+
+			Expect(workerState()).To(Equal(db.WorkerStateRetired))
+
+			By("removing the worker once it has been pruned")
+			found, err = database.PruneWorker("some-worker")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, found, err = database.GetWorker("some-worker")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not prune a running worker", func() {
+			found, err := database.PruneWorker("some-worker")
+			Expect(err).To(Equal(db.ErrCannotPruneRunningWorker))
+			Expect(found).To(BeTrue())
+
+			Expect(workerState()).To(Equal(db.WorkerStateRunning))
+		})
+
+		It("does not land or retire a stalled worker", func() {
+			_, err := database.SaveWorker(db.WorkerInfo{
+				Name:       "some-worker",
+				GardenAddr: "1.2.3.4:7777",
+			}, time.Second)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(workerState, 2*time.Second).Should(Equal(db.WorkerStateStalled))
+
+			_, err = database.LandWorker("some-worker")
+			Expect(err).To(Equal(db.ErrWorkerStalled))
+
+			_, err = database.RetireWorker("some-worker")
+			Expect(err).To(Equal(db.ErrWorkerStalled))
+		})
+
+		It("runs a stalled worker again when it heartbeats", func() {
+			_, err := database.SaveWorker(db.WorkerInfo{
+				Name:       "some-worker",
+				GardenAddr: "1.2.3.4:7777",
+			}, time.Second)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(workerState, 2*time.Second).Should(Equal(db.WorkerStateStalled))
+
+			_, err = database.SaveWorker(db.WorkerInfo{
+				Name:       "some-worker",
+				GardenAddr: "1.2.3.4:7777",
+			}, time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(workerState()).To(Equal(db.WorkerStateRunning))
+		})
+
+		It("does not find workers which do not exist", func() {
+			found, err := database.LandWorker("bogus-worker")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			found, err = database.RetireWorker("bogus-worker")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			found, err = database.PruneWorker("bogus-worker")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
 	})
 
 	Describe("FindWorkerCheckResourceTypeVersion", func() {
@@ -345,3 +598,12 @@ func getWorkerInfos(savedWorkers []db.SavedWorker, err error) []db.WorkerInfo {
 	}
 	return workerInfos
 }
+
+func getWorkerStates(savedWorkers []db.SavedWorker, err error) map[string]db.WorkerState {
+	Expect(err).NotTo(HaveOccurred())
+	workerStates := map[string]db.WorkerState{}
+	for _, savedWorker := range savedWorkers {
+		workerStates[savedWorker.Name] = savedWorker.State
+	}
+	return workerStates
+}
@@ -101,6 +101,18 @@ type FakeBuild struct {
 	isRunningReturns     struct {
 		result1 bool
 	}
+	IsArchivedStub        func() bool
+	isArchivedMutex       sync.RWMutex
+	isArchivedArgsForCall []struct{}
+	isArchivedReturns     struct {
+		result1 bool
+	}
+	RerunOfStub        func() int
+	rerunOfMutex       sync.RWMutex
+	rerunOfArgsForCall []struct{}
+	rerunOfReturns     struct {
+		result1 int
+	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct{}
@@ -241,6 +253,21 @@ type FakeBuild struct {
 		result1 []db.ResourceCacheIdentifier
 		result2 error
 	}
+	SaveResourceUsageStub        func(usage atc.StepResourceUsage) error
+	saveResourceUsageMutex       sync.RWMutex
+	saveResourceUsageArgsForCall []struct {
+		usage atc.StepResourceUsage
+	}
+	saveResourceUsageReturns struct {
+		result1 error
+	}
+	GetResourceUsageStub        func() ([]atc.StepResourceUsage, error)
+	getResourceUsageMutex       sync.RWMutex
+	getResourceUsageArgsForCall []struct{}
+	getResourceUsageReturns     struct {
+		result1 []atc.StepResourceUsage
+		result2 error
+	}
 	GetConfigStub        func() (atc.Config, db.ConfigVersion, error)
 	getConfigMutex       sync.RWMutex
 	getConfigArgsForCall []struct{}
@@ -256,6 +283,13 @@ type FakeBuild struct {
 		result1 db.SavedPipeline
 		result2 error
 	}
+	RerunStub        func() (db.Build, error)
+	rerunMutex       sync.RWMutex
+	rerunArgsForCall []struct{}
+	rerunReturns     struct {
+		result1 db.Build
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -635,6 +669,56 @@ func (fake *FakeBuild) IsRunningReturns(result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeBuild) IsArchived() bool {
+	fake.isArchivedMutex.Lock()
+	fake.isArchivedArgsForCall = append(fake.isArchivedArgsForCall, struct{}{})
+	fake.recordInvocation("IsArchived", []interface{}{})
+	fake.isArchivedMutex.Unlock()
+	if fake.IsArchivedStub != nil {
+		return fake.IsArchivedStub()
+	} else {
+		return fake.isArchivedReturns.result1
+	}
+}
+
+func (fake *FakeBuild) IsArchivedCallCount() int {
+	fake.isArchivedMutex.RLock()
+	defer fake.isArchivedMutex.RUnlock()
+	return len(fake.isArchivedArgsForCall)
+}
+
+func (fake *FakeBuild) IsArchivedReturns(result1 bool) {
+	fake.IsArchivedStub = nil
+	fake.isArchivedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) RerunOf() int {
+	fake.rerunOfMutex.Lock()
+	fake.rerunOfArgsForCall = append(fake.rerunOfArgsForCall, struct{}{})
+	fake.recordInvocation("RerunOf", []interface{}{})
+	fake.rerunOfMutex.Unlock()
+	if fake.RerunOfStub != nil {
+		return fake.RerunOfStub()
+	} else {
+		return fake.rerunOfReturns.result1
+	}
+}
+
+func (fake *FakeBuild) RerunOfCallCount() int {
+	fake.rerunOfMutex.RLock()
+	defer fake.rerunOfMutex.RUnlock()
+	return len(fake.rerunOfArgsForCall)
+}
+
+func (fake *FakeBuild) RerunOfReturns(result1 int) {
+	fake.RerunOfStub = nil
+	fake.rerunOfReturns = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeBuild) Reload() (bool, error) {
 	fake.reloadMutex.Lock()
 	fake.reloadArgsForCall = append(fake.reloadArgsForCall, struct{}{})
@@ -1158,6 +1242,65 @@ func (fake *FakeBuild) GetImageResourceCacheIdentifiersReturns(result1 []db.Reso
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) SaveResourceUsage(usage atc.StepResourceUsage) error {
+	fake.saveResourceUsageMutex.Lock()
+	fake.saveResourceUsageArgsForCall = append(fake.saveResourceUsageArgsForCall, struct {
+		usage atc.StepResourceUsage
+	}{usage})
+	fake.recordInvocation("SaveResourceUsage", []interface{}{usage})
+	fake.saveResourceUsageMutex.Unlock()
+	if fake.SaveResourceUsageStub != nil {
+		return fake.SaveResourceUsageStub(usage)
+	} else {
+		return fake.saveResourceUsageReturns.result1
+	}
+}
+
+func (fake *FakeBuild) SaveResourceUsageCallCount() int {
+	fake.saveResourceUsageMutex.RLock()
+	defer fake.saveResourceUsageMutex.RUnlock()
+	return len(fake.saveResourceUsageArgsForCall)
+}
+
+func (fake *FakeBuild) SaveResourceUsageArgsForCall(i int) atc.StepResourceUsage {
+	fake.saveResourceUsageMutex.RLock()
+	defer fake.saveResourceUsageMutex.RUnlock()
+	return fake.saveResourceUsageArgsForCall[i].usage
+}
+
+func (fake *FakeBuild) SaveResourceUsageReturns(result1 error) {
+	fake.SaveResourceUsageStub = nil
+	fake.saveResourceUsageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) GetResourceUsage() ([]atc.StepResourceUsage, error) {
+	fake.getResourceUsageMutex.Lock()
+	fake.getResourceUsageArgsForCall = append(fake.getResourceUsageArgsForCall, struct{}{})
+	fake.recordInvocation("GetResourceUsage", []interface{}{})
+	fake.getResourceUsageMutex.Unlock()
+	if fake.GetResourceUsageStub != nil {
+		return fake.GetResourceUsageStub()
+	} else {
+		return fake.getResourceUsageReturns.result1, fake.getResourceUsageReturns.result2
+	}
+}
+
+func (fake *FakeBuild) GetResourceUsageCallCount() int {
+	fake.getResourceUsageMutex.RLock()
+	defer fake.getResourceUsageMutex.RUnlock()
+	return len(fake.getResourceUsageArgsForCall)
+}
+
+func (fake *FakeBuild) GetResourceUsageReturns(result1 []atc.StepResourceUsage, result2 error) {
+	fake.GetResourceUsageStub = nil
+	fake.getResourceUsageReturns = struct {
+		result1 []atc.StepResourceUsage
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) GetConfig() (atc.Config, db.ConfigVersion, error) {
 	fake.getConfigMutex.Lock()
 	fake.getConfigArgsForCall = append(fake.getConfigArgsForCall, struct{}{})
@@ -1211,6 +1354,32 @@ func (fake *FakeBuild) GetPipelineReturns(result1 db.SavedPipeline, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) Rerun() (db.Build, error) {
+	fake.rerunMutex.Lock()
+	fake.rerunArgsForCall = append(fake.rerunArgsForCall, struct{}{})
+	fake.recordInvocation("Rerun", []interface{}{})
+	fake.rerunMutex.Unlock()
+	if fake.RerunStub != nil {
+		return fake.RerunStub()
+	} else {
+		return fake.rerunReturns.result1, fake.rerunReturns.result2
+	}
+}
+
+func (fake *FakeBuild) RerunCallCount() int {
+	fake.rerunMutex.RLock()
+	defer fake.rerunMutex.RUnlock()
+	return len(fake.rerunArgsForCall)
+}
+
+func (fake *FakeBuild) RerunReturns(result1 db.Build, result2 error) {
+	fake.RerunStub = nil
+	fake.rerunReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1244,6 +1413,10 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.isScheduledMutex.RUnlock()
 	fake.isRunningMutex.RLock()
 	defer fake.isRunningMutex.RUnlock()
+	fake.isArchivedMutex.RLock()
+	defer fake.isArchivedMutex.RUnlock()
+	fake.rerunOfMutex.RLock()
+	defer fake.rerunOfMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
 	fake.eventsMutex.RLock()
@@ -1278,10 +1451,16 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.saveImageResourceVersionMutex.RUnlock()
 	fake.getImageResourceCacheIdentifiersMutex.RLock()
 	defer fake.getImageResourceCacheIdentifiersMutex.RUnlock()
+	fake.saveResourceUsageMutex.RLock()
+	defer fake.saveResourceUsageMutex.RUnlock()
+	fake.getResourceUsageMutex.RLock()
+	defer fake.getResourceUsageMutex.RUnlock()
 	fake.getConfigMutex.RLock()
 	defer fake.getConfigMutex.RUnlock()
 	fake.getPipelineMutex.RLock()
 	defer fake.getPipelineMutex.RUnlock()
+	fake.rerunMutex.RLock()
+	defer fake.rerunMutex.RUnlock()
 	return fake.invocations
 }
 
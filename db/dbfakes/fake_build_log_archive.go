@@ -0,0 +1,80 @@
+// This file was generated by counterfeiter
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/db"
+)
+
+type FakeBuildLogArchive struct {
+	EventsStub        func(buildID int, from uint) (db.EventSource, error)
+	eventsMutex       sync.RWMutex
+	eventsArgsForCall []struct {
+		buildID int
+		from    uint
+	}
+	eventsReturns struct {
+		result1 db.EventSource
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBuildLogArchive) Events(buildID int, from uint) (db.EventSource, error) {
+	fake.eventsMutex.Lock()
+	fake.eventsArgsForCall = append(fake.eventsArgsForCall, struct {
+		buildID int
+		from    uint
+	}{buildID, from})
+	fake.recordInvocation("Events", []interface{}{buildID, from})
+	fake.eventsMutex.Unlock()
+	if fake.EventsStub != nil {
+		return fake.EventsStub(buildID, from)
+	} else {
+		return fake.eventsReturns.result1, fake.eventsReturns.result2
+	}
+}
+
+func (fake *FakeBuildLogArchive) EventsCallCount() int {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	return len(fake.eventsArgsForCall)
+}
+
+func (fake *FakeBuildLogArchive) EventsArgsForCall(i int) (int, uint) {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	return fake.eventsArgsForCall[i].buildID, fake.eventsArgsForCall[i].from
+}
+
+func (fake *FakeBuildLogArchive) EventsReturns(result1 db.EventSource, result2 error) {
+	fake.EventsStub = nil
+	fake.eventsReturns = struct {
+		result1 db.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildLogArchive) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeBuildLogArchive) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.BuildLogArchive = new(FakeBuildLogArchive)
@@ -92,6 +92,23 @@ type FakePipelineDB struct {
 		result3 bool
 		result4 error
 	}
+	ExportStub        func(includeBuilds bool) (atc.PipelineArchive, error)
+	exportMutex       sync.RWMutex
+	exportArgsForCall []struct {
+		includeBuilds bool
+	}
+	exportReturns struct {
+		result1 atc.PipelineArchive
+		result2 error
+	}
+	ImportStub        func(archive atc.PipelineArchive) error
+	importMutex       sync.RWMutex
+	importArgsForCall []struct {
+		archive atc.PipelineArchive
+	}
+	importReturns struct {
+		result1 error
+	}
 	LeaseSchedulingStub        func(lager.Logger, time.Duration) (db.Lease, bool, error)
 	leaseSchedulingMutex       sync.RWMutex
 	leaseSchedulingArgsForCall []struct {
@@ -160,6 +177,24 @@ type FakePipelineDB struct {
 	unpauseResourceReturns struct {
 		result1 error
 	}
+	PinResourceStub        func(resourceName string, versionedResourceID int, comment string) error
+	pinResourceMutex       sync.RWMutex
+	pinResourceArgsForCall []struct {
+		resourceName        string
+		versionedResourceID int
+		comment             string
+	}
+	pinResourceReturns struct {
+		result1 error
+	}
+	UnpinResourceStub        func(resourceName string) error
+	unpinResourceMutex       sync.RWMutex
+	unpinResourceArgsForCall []struct {
+		resourceName string
+	}
+	unpinResourceReturns struct {
+		result1 error
+	}
 	SaveResourceVersionsStub        func(atc.ResourceConfig, []atc.Version) error
 	saveResourceVersionsMutex       sync.RWMutex
 	saveResourceVersionsArgsForCall []struct {
@@ -274,6 +309,15 @@ type FakePipelineDB struct {
 	unpauseJobReturns struct {
 		result1 error
 	}
+	ClearTaskCachesStub        func(job string) ([]db.SavedVolume, error)
+	clearTaskCachesMutex       sync.RWMutex
+	clearTaskCachesArgsForCall []struct {
+		job string
+	}
+	clearTaskCachesReturns struct {
+		result1 []db.SavedVolume
+		result2 error
+	}
 	SetMaxInFlightReachedStub        func(string, bool) error
 	setMaxInFlightReachedMutex       sync.RWMutex
 	setMaxInFlightReachedArgsForCall []struct {
@@ -333,6 +377,17 @@ type FakePipelineDB struct {
 		result2 bool
 		result3 error
 	}
+	SearchJobBuildLogsStub        func(job string, query string, limit int) ([]db.BuildLogSearchResult, error)
+	searchJobBuildLogsMutex       sync.RWMutex
+	searchJobBuildLogsArgsForCall []struct {
+		job   string
+		query string
+		limit int
+	}
+	searchJobBuildLogsReturns struct {
+		result1 []db.BuildLogSearchResult
+		result2 error
+	}
 	CreateJobBuildStub        func(job string) (db.Build, error)
 	createJobBuildMutex       sync.RWMutex
 	createJobBuildArgsForCall []struct {
@@ -342,6 +397,15 @@ type FakePipelineDB struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateRerunBuildStub        func(original db.Build) (db.Build, error)
+	createRerunBuildMutex       sync.RWMutex
+	createRerunBuildArgsForCall []struct {
+		original db.Build
+	}
+	createRerunBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
 	EnsurePendingBuildExistsStub        func(jobName string) error
 	ensurePendingBuildExistsMutex       sync.RWMutex
 	ensurePendingBuildExistsArgsForCall []struct {
@@ -444,6 +508,24 @@ type FakePipelineDB struct {
 	deleteNextInputMappingReturns struct {
 		result1 error
 	}
+	SaveSchedulingExplanationStub        func(explanation algorithm.Explanation, jobName string) error
+	saveSchedulingExplanationMutex       sync.RWMutex
+	saveSchedulingExplanationArgsForCall []struct {
+		explanation algorithm.Explanation
+		jobName     string
+	}
+	saveSchedulingExplanationReturns struct {
+		result1 error
+	}
+	GetSchedulingExplanationStub        func(jobName string) (algorithm.Explanation, error)
+	getSchedulingExplanationMutex       sync.RWMutex
+	getSchedulingExplanationArgsForCall []struct {
+		jobName string
+	}
+	getSchedulingExplanationReturns struct {
+		result1 algorithm.Explanation
+		result2 error
+	}
 	GetRunningBuildsBySerialGroupStub        func(jobName string, serialGroups []string) ([]db.Build, error)
 	getRunningBuildsBySerialGroupMutex       sync.RWMutex
 	getRunningBuildsBySerialGroupArgsForCall []struct {
@@ -857,6 +939,73 @@ func (fake *FakePipelineDB) GetConfigReturns(result1 atc.Config, result2 db.Conf
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakePipelineDB) Export(includeBuilds bool) (atc.PipelineArchive, error) {
+	fake.exportMutex.Lock()
+	fake.exportArgsForCall = append(fake.exportArgsForCall, struct {
+		includeBuilds bool
+	}{includeBuilds})
+	fake.recordInvocation("Export", []interface{}{includeBuilds})
+	fake.exportMutex.Unlock()
+	if fake.ExportStub != nil {
+		return fake.ExportStub(includeBuilds)
+	} else {
+		return fake.exportReturns.result1, fake.exportReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) ExportCallCount() int {
+	fake.exportMutex.RLock()
+	defer fake.exportMutex.RUnlock()
+	return len(fake.exportArgsForCall)
+}
+
+func (fake *FakePipelineDB) ExportArgsForCall(i int) bool {
+	fake.exportMutex.RLock()
+	defer fake.exportMutex.RUnlock()
+	return fake.exportArgsForCall[i].includeBuilds
+}
+
+func (fake *FakePipelineDB) ExportReturns(result1 atc.PipelineArchive, result2 error) {
+	fake.ExportStub = nil
+	fake.exportReturns = struct {
+		result1 atc.PipelineArchive
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) Import(archive atc.PipelineArchive) error {
+	fake.importMutex.Lock()
+	fake.importArgsForCall = append(fake.importArgsForCall, struct {
+		archive atc.PipelineArchive
+	}{archive})
+	fake.recordInvocation("Import", []interface{}{archive})
+	fake.importMutex.Unlock()
+	if fake.ImportStub != nil {
+		return fake.ImportStub(archive)
+	} else {
+		return fake.importReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) ImportCallCount() int {
+	fake.importMutex.RLock()
+	defer fake.importMutex.RUnlock()
+	return len(fake.importArgsForCall)
+}
+
+func (fake *FakePipelineDB) ImportArgsForCall(i int) atc.PipelineArchive {
+	fake.importMutex.RLock()
+	defer fake.importMutex.RUnlock()
+	return fake.importArgsForCall[i].archive
+}
+
+func (fake *FakePipelineDB) ImportReturns(result1 error) {
+	fake.ImportStub = nil
+	fake.importReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipelineDB) LeaseScheduling(arg1 lager.Logger, arg2 time.Duration) (db.Lease, bool, error) {
 	fake.leaseSchedulingMutex.Lock()
 	fake.leaseSchedulingArgsForCall = append(fake.leaseSchedulingArgsForCall, struct {
@@ -1094,6 +1243,74 @@ func (fake *FakePipelineDB) UnpauseResourceReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) PinResource(resourceName string, versionedResourceID int, comment string) error {
+	fake.pinResourceMutex.Lock()
+	fake.pinResourceArgsForCall = append(fake.pinResourceArgsForCall, struct {
+		resourceName        string
+		versionedResourceID int
+		comment             string
+	}{resourceName, versionedResourceID, comment})
+	fake.recordInvocation("PinResource", []interface{}{resourceName, versionedResourceID, comment})
+	fake.pinResourceMutex.Unlock()
+	if fake.PinResourceStub != nil {
+		return fake.PinResourceStub(resourceName, versionedResourceID, comment)
+	} else {
+		return fake.pinResourceReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) PinResourceCallCount() int {
+	fake.pinResourceMutex.RLock()
+	defer fake.pinResourceMutex.RUnlock()
+	return len(fake.pinResourceArgsForCall)
+}
+
+func (fake *FakePipelineDB) PinResourceArgsForCall(i int) (string, int, string) {
+	fake.pinResourceMutex.RLock()
+	defer fake.pinResourceMutex.RUnlock()
+	return fake.pinResourceArgsForCall[i].resourceName, fake.pinResourceArgsForCall[i].versionedResourceID, fake.pinResourceArgsForCall[i].comment
+}
+
+func (fake *FakePipelineDB) PinResourceReturns(result1 error) {
+	fake.PinResourceStub = nil
+	fake.pinResourceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) UnpinResource(resourceName string) error {
+	fake.unpinResourceMutex.Lock()
+	fake.unpinResourceArgsForCall = append(fake.unpinResourceArgsForCall, struct {
+		resourceName string
+	}{resourceName})
+	fake.recordInvocation("UnpinResource", []interface{}{resourceName})
+	fake.unpinResourceMutex.Unlock()
+	if fake.UnpinResourceStub != nil {
+		return fake.UnpinResourceStub(resourceName)
+	} else {
+		return fake.unpinResourceReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) UnpinResourceCallCount() int {
+	fake.unpinResourceMutex.RLock()
+	defer fake.unpinResourceMutex.RUnlock()
+	return len(fake.unpinResourceArgsForCall)
+}
+
+func (fake *FakePipelineDB) UnpinResourceArgsForCall(i int) string {
+	fake.unpinResourceMutex.RLock()
+	defer fake.unpinResourceMutex.RUnlock()
+	return fake.unpinResourceArgsForCall[i].resourceName
+}
+
+func (fake *FakePipelineDB) UnpinResourceReturns(result1 error) {
+	fake.UnpinResourceStub = nil
+	fake.unpinResourceReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipelineDB) SaveResourceVersions(arg1 atc.ResourceConfig, arg2 []atc.Version) error {
 	var arg2Copy []atc.Version
 	if arg2 != nil {
@@ -1513,6 +1730,40 @@ func (fake *FakePipelineDB) UnpauseJobReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) ClearTaskCaches(job string) ([]db.SavedVolume, error) {
+	fake.clearTaskCachesMutex.Lock()
+	fake.clearTaskCachesArgsForCall = append(fake.clearTaskCachesArgsForCall, struct {
+		job string
+	}{job})
+	fake.recordInvocation("ClearTaskCaches", []interface{}{job})
+	fake.clearTaskCachesMutex.Unlock()
+	if fake.ClearTaskCachesStub != nil {
+		return fake.ClearTaskCachesStub(job)
+	} else {
+		return fake.clearTaskCachesReturns.result1, fake.clearTaskCachesReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) ClearTaskCachesCallCount() int {
+	fake.clearTaskCachesMutex.RLock()
+	defer fake.clearTaskCachesMutex.RUnlock()
+	return len(fake.clearTaskCachesArgsForCall)
+}
+
+func (fake *FakePipelineDB) ClearTaskCachesArgsForCall(i int) string {
+	fake.clearTaskCachesMutex.RLock()
+	defer fake.clearTaskCachesMutex.RUnlock()
+	return fake.clearTaskCachesArgsForCall[i].job
+}
+
+func (fake *FakePipelineDB) ClearTaskCachesReturns(result1 []db.SavedVolume, result2 error) {
+	fake.ClearTaskCachesStub = nil
+	fake.clearTaskCachesReturns = struct {
+		result1 []db.SavedVolume
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) SetMaxInFlightReached(arg1 string, arg2 bool) error {
 	fake.setMaxInFlightReachedMutex.Lock()
 	fake.setMaxInFlightReachedArgsForCall = append(fake.setMaxInFlightReachedArgsForCall, struct {
@@ -1722,6 +1973,42 @@ func (fake *FakePipelineDB) GetJobBuildReturns(result1 db.Build, result2 bool, r
 	}{result1, result2, result3}
 }
 
+func (fake *FakePipelineDB) SearchJobBuildLogs(job string, query string, limit int) ([]db.BuildLogSearchResult, error) {
+	fake.searchJobBuildLogsMutex.Lock()
+	fake.searchJobBuildLogsArgsForCall = append(fake.searchJobBuildLogsArgsForCall, struct {
+		job   string
+		query string
+		limit int
+	}{job, query, limit})
+	fake.recordInvocation("SearchJobBuildLogs", []interface{}{job, query, limit})
+	fake.searchJobBuildLogsMutex.Unlock()
+	if fake.SearchJobBuildLogsStub != nil {
+		return fake.SearchJobBuildLogsStub(job, query, limit)
+	} else {
+		return fake.searchJobBuildLogsReturns.result1, fake.searchJobBuildLogsReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) SearchJobBuildLogsCallCount() int {
+	fake.searchJobBuildLogsMutex.RLock()
+	defer fake.searchJobBuildLogsMutex.RUnlock()
+	return len(fake.searchJobBuildLogsArgsForCall)
+}
+
+func (fake *FakePipelineDB) SearchJobBuildLogsArgsForCall(i int) (string, string, int) {
+	fake.searchJobBuildLogsMutex.RLock()
+	defer fake.searchJobBuildLogsMutex.RUnlock()
+	return fake.searchJobBuildLogsArgsForCall[i].job, fake.searchJobBuildLogsArgsForCall[i].query, fake.searchJobBuildLogsArgsForCall[i].limit
+}
+
+func (fake *FakePipelineDB) SearchJobBuildLogsReturns(result1 []db.BuildLogSearchResult, result2 error) {
+	fake.SearchJobBuildLogsStub = nil
+	fake.searchJobBuildLogsReturns = struct {
+		result1 []db.BuildLogSearchResult
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) CreateJobBuild(job string) (db.Build, error) {
 	fake.createJobBuildMutex.Lock()
 	fake.createJobBuildArgsForCall = append(fake.createJobBuildArgsForCall, struct {
@@ -1756,6 +2043,40 @@ func (fake *FakePipelineDB) CreateJobBuildReturns(result1 db.Build, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakePipelineDB) CreateRerunBuild(original db.Build) (db.Build, error) {
+	fake.createRerunBuildMutex.Lock()
+	fake.createRerunBuildArgsForCall = append(fake.createRerunBuildArgsForCall, struct {
+		original db.Build
+	}{original})
+	fake.recordInvocation("CreateRerunBuild", []interface{}{original})
+	fake.createRerunBuildMutex.Unlock()
+	if fake.CreateRerunBuildStub != nil {
+		return fake.CreateRerunBuildStub(original)
+	} else {
+		return fake.createRerunBuildReturns.result1, fake.createRerunBuildReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) CreateRerunBuildCallCount() int {
+	fake.createRerunBuildMutex.RLock()
+	defer fake.createRerunBuildMutex.RUnlock()
+	return len(fake.createRerunBuildArgsForCall)
+}
+
+func (fake *FakePipelineDB) CreateRerunBuildArgsForCall(i int) db.Build {
+	fake.createRerunBuildMutex.RLock()
+	defer fake.createRerunBuildMutex.RUnlock()
+	return fake.createRerunBuildArgsForCall[i].original
+}
+
+func (fake *FakePipelineDB) CreateRerunBuildReturns(result1 db.Build, result2 error) {
+	fake.CreateRerunBuildStub = nil
+	fake.createRerunBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) EnsurePendingBuildExists(jobName string) error {
 	fake.ensurePendingBuildExistsMutex.Lock()
 	fake.ensurePendingBuildExistsArgsForCall = append(fake.ensurePendingBuildExistsArgsForCall, struct {
@@ -2132,6 +2453,74 @@ func (fake *FakePipelineDB) DeleteNextInputMappingReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) SaveSchedulingExplanation(explanation algorithm.Explanation, jobName string) error {
+	fake.saveSchedulingExplanationMutex.Lock()
+	fake.saveSchedulingExplanationArgsForCall = append(fake.saveSchedulingExplanationArgsForCall, struct {
+		explanation algorithm.Explanation
+		jobName     string
+	}{explanation, jobName})
+	fake.recordInvocation("SaveSchedulingExplanation", []interface{}{explanation, jobName})
+	fake.saveSchedulingExplanationMutex.Unlock()
+	if fake.SaveSchedulingExplanationStub != nil {
+		return fake.SaveSchedulingExplanationStub(explanation, jobName)
+	} else {
+		return fake.saveSchedulingExplanationReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) SaveSchedulingExplanationCallCount() int {
+	fake.saveSchedulingExplanationMutex.RLock()
+	defer fake.saveSchedulingExplanationMutex.RUnlock()
+	return len(fake.saveSchedulingExplanationArgsForCall)
+}
+
+func (fake *FakePipelineDB) SaveSchedulingExplanationArgsForCall(i int) (algorithm.Explanation, string) {
+	fake.saveSchedulingExplanationMutex.RLock()
+	defer fake.saveSchedulingExplanationMutex.RUnlock()
+	return fake.saveSchedulingExplanationArgsForCall[i].explanation, fake.saveSchedulingExplanationArgsForCall[i].jobName
+}
+
+func (fake *FakePipelineDB) SaveSchedulingExplanationReturns(result1 error) {
+	fake.SaveSchedulingExplanationStub = nil
+	fake.saveSchedulingExplanationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) GetSchedulingExplanation(jobName string) (algorithm.Explanation, error) {
+	fake.getSchedulingExplanationMutex.Lock()
+	fake.getSchedulingExplanationArgsForCall = append(fake.getSchedulingExplanationArgsForCall, struct {
+		jobName string
+	}{jobName})
+	fake.recordInvocation("GetSchedulingExplanation", []interface{}{jobName})
+	fake.getSchedulingExplanationMutex.Unlock()
+	if fake.GetSchedulingExplanationStub != nil {
+		return fake.GetSchedulingExplanationStub(jobName)
+	} else {
+		return fake.getSchedulingExplanationReturns.result1, fake.getSchedulingExplanationReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetSchedulingExplanationCallCount() int {
+	fake.getSchedulingExplanationMutex.RLock()
+	defer fake.getSchedulingExplanationMutex.RUnlock()
+	return len(fake.getSchedulingExplanationArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetSchedulingExplanationArgsForCall(i int) string {
+	fake.getSchedulingExplanationMutex.RLock()
+	defer fake.getSchedulingExplanationMutex.RUnlock()
+	return fake.getSchedulingExplanationArgsForCall[i].jobName
+}
+
+func (fake *FakePipelineDB) GetSchedulingExplanationReturns(result1 algorithm.Explanation, result2 error) {
+	fake.GetSchedulingExplanationStub = nil
+	fake.getSchedulingExplanationReturns = struct {
+		result1 algorithm.Explanation
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) GetRunningBuildsBySerialGroup(jobName string, serialGroups []string) ([]db.Build, error) {
 	var serialGroupsCopy []string
 	if serialGroups != nil {
@@ -2490,6 +2879,10 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.destroyMutex.RUnlock()
 	fake.getConfigMutex.RLock()
 	defer fake.getConfigMutex.RUnlock()
+	fake.exportMutex.RLock()
+	defer fake.exportMutex.RUnlock()
+	fake.importMutex.RLock()
+	defer fake.importMutex.RUnlock()
 	fake.leaseSchedulingMutex.RLock()
 	defer fake.leaseSchedulingMutex.RUnlock()
 	fake.getResourceMutex.RLock()
@@ -2504,6 +2897,10 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.pauseResourceMutex.RUnlock()
 	fake.unpauseResourceMutex.RLock()
 	defer fake.unpauseResourceMutex.RUnlock()
+	fake.pinResourceMutex.RLock()
+	defer fake.pinResourceMutex.RUnlock()
+	fake.unpinResourceMutex.RLock()
+	defer fake.unpinResourceMutex.RUnlock()
 	fake.saveResourceVersionsMutex.RLock()
 	defer fake.saveResourceVersionsMutex.RUnlock()
 	fake.saveResourceTypeVersionMutex.RLock()
@@ -2528,6 +2925,8 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.pauseJobMutex.RUnlock()
 	fake.unpauseJobMutex.RLock()
 	defer fake.unpauseJobMutex.RUnlock()
+	fake.clearTaskCachesMutex.RLock()
+	defer fake.clearTaskCachesMutex.RUnlock()
 	fake.setMaxInFlightReachedMutex.RLock()
 	defer fake.setMaxInFlightReachedMutex.RUnlock()
 	fake.updateFirstLoggedBuildIDMutex.RLock()
@@ -2540,8 +2939,12 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.getAllJobBuildsMutex.RUnlock()
 	fake.getJobBuildMutex.RLock()
 	defer fake.getJobBuildMutex.RUnlock()
+	fake.searchJobBuildLogsMutex.RLock()
+	defer fake.searchJobBuildLogsMutex.RUnlock()
 	fake.createJobBuildMutex.RLock()
 	defer fake.createJobBuildMutex.RUnlock()
+	fake.createRerunBuildMutex.RLock()
+	defer fake.createRerunBuildMutex.RUnlock()
 	fake.ensurePendingBuildExistsMutex.RLock()
 	defer fake.ensurePendingBuildExistsMutex.RUnlock()
 	fake.getNextPendingBuildMutex.RLock()
@@ -2564,6 +2967,10 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.getNextBuildInputsMutex.RUnlock()
 	fake.deleteNextInputMappingMutex.RLock()
 	defer fake.deleteNextInputMappingMutex.RUnlock()
+	fake.saveSchedulingExplanationMutex.RLock()
+	defer fake.saveSchedulingExplanationMutex.RUnlock()
+	fake.getSchedulingExplanationMutex.RLock()
+	defer fake.getSchedulingExplanationMutex.RUnlock()
 	fake.getRunningBuildsBySerialGroupMutex.RLock()
 	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
 	fake.getNextPendingBuildBySerialGroupMutex.RLock()
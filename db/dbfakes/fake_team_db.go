@@ -92,7 +92,42 @@ type FakeTeamDB struct {
 		result1 db.SavedTeam
 		result2 error
 	}
-
+	UpdateOIDCAuthStub        func(oidcAuth *db.OIDCAuth) (db.SavedTeam, error)
+	updateOIDCAuthMutex       sync.RWMutex
+	updateOIDCAuthArgsForCall []struct {
+		oidcAuth *db.OIDCAuth
+	}
+	updateOIDCAuthReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	UpdateLDAPAuthStub        func(ldapAuth *db.LDAPAuth) (db.SavedTeam, error)
+	updateLDAPAuthMutex       sync.RWMutex
+	updateLDAPAuthArgsForCall []struct {
+		ldapAuth *db.LDAPAuth
+	}
+	updateLDAPAuthReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	UpdateNotificationHooksStub        func(notificationHooks []atc.NotificationHook) (db.SavedTeam, error)
+	updateNotificationHooksMutex       sync.RWMutex
+	updateNotificationHooksArgsForCall []struct {
+		notificationHooks []atc.NotificationHook
+	}
+	updateNotificationHooksReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	UpdateRolesStub        func(roles []atc.TeamRoleMapping) (db.SavedTeam, error)
+	updateRolesMutex       sync.RWMutex
+	updateRolesArgsForCall []struct {
+		roles []atc.TeamRoleMapping
+	}
+	updateRolesReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
 	GetConfigStub        func(pipelineName string) (atc.Config, atc.RawConfig, db.ConfigVersion, error)
 	getConfigMutex       sync.RWMutex
 	getConfigArgsForCall []struct {
@@ -104,19 +139,93 @@ type FakeTeamDB struct {
 		result3 db.ConfigVersion
 		result4 error
 	}
-	SaveConfigStub        func(string, atc.Config, db.ConfigVersion, db.PipelinePausedState) (db.SavedPipeline, bool, error)
+	SaveConfigStub        func(pipelineName string, config atc.Config, from db.ConfigVersion, pausedState db.PipelinePausedState, savedBy string) (db.SavedPipeline, bool, error)
 	saveConfigMutex       sync.RWMutex
 	saveConfigArgsForCall []struct {
-		arg1 string
-		arg2 atc.Config
-		arg3 db.ConfigVersion
-		arg4 db.PipelinePausedState
+		pipelineName string
+		config       atc.Config
+		from         db.ConfigVersion
+		pausedState  db.PipelinePausedState
+		savedBy      string
 	}
 	saveConfigReturns struct {
 		result1 db.SavedPipeline
 		result2 bool
 		result3 error
 	}
+	GetConfigTemplateStub        func(pipelineName string) (db.ConfigTemplate, bool, error)
+	getConfigTemplateMutex       sync.RWMutex
+	getConfigTemplateArgsForCall []struct {
+		pipelineName string
+	}
+	getConfigTemplateReturns struct {
+		result1 db.ConfigTemplate
+		result2 bool
+		result3 error
+	}
+	SaveConfigFromTemplateStub        func(pipelineName string, config atc.Config, template db.ConfigTemplate, from db.ConfigVersion, pausedState db.PipelinePausedState, savedBy string) (db.SavedPipeline, bool, error)
+	saveConfigFromTemplateMutex       sync.RWMutex
+	saveConfigFromTemplateArgsForCall []struct {
+		pipelineName string
+		config       atc.Config
+		template     db.ConfigTemplate
+		from         db.ConfigVersion
+		pausedState  db.PipelinePausedState
+		savedBy      string
+	}
+	saveConfigFromTemplateReturns struct {
+		result1 db.SavedPipeline
+		result2 bool
+		result3 error
+	}
+	GetConfigRevisionsStub        func(pipelineName string) ([]db.ConfigRevision, bool, error)
+	getConfigRevisionsMutex       sync.RWMutex
+	getConfigRevisionsArgsForCall []struct {
+		pipelineName string
+	}
+	getConfigRevisionsReturns struct {
+		result1 []db.ConfigRevision
+		result2 bool
+		result3 error
+	}
+	GetConfigRevisionStub        func(pipelineName string, revisionID int) (db.ConfigRevision, bool, error)
+	getConfigRevisionMutex       sync.RWMutex
+	getConfigRevisionArgsForCall []struct {
+		pipelineName string
+		revisionID   int
+	}
+	getConfigRevisionReturns struct {
+		result1 db.ConfigRevision
+		result2 bool
+		result3 error
+	}
+	CreateAPITokenStub        func(name string, role atc.TeamRole, tokenHash string) (db.APIToken, error)
+	createAPITokenMutex       sync.RWMutex
+	createAPITokenArgsForCall []struct {
+		name      string
+		role      atc.TeamRole
+		tokenHash string
+	}
+	createAPITokenReturns struct {
+		result1 db.APIToken
+		result2 error
+	}
+	GetAPITokensStub        func() ([]db.APIToken, error)
+	getAPITokensMutex       sync.RWMutex
+	getAPITokensArgsForCall []struct{}
+	getAPITokensReturns     struct {
+		result1 []db.APIToken
+		result2 error
+	}
+	DeleteAPITokenStub        func(name string) (bool, error)
+	deleteAPITokenMutex       sync.RWMutex
+	deleteAPITokenArgsForCall []struct {
+		name string
+	}
+	deleteAPITokenReturns struct {
+		result1 bool
+		result2 error
+	}
 	CreateOneOffBuildStub        func() (db.Build, error)
 	createOneOffBuildMutex       sync.RWMutex
 	createOneOffBuildArgsForCall []struct{}
@@ -485,6 +594,152 @@ func (fake *FakeTeamDB) UpdateGenericOAuthReturns(result1 db.SavedTeam, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeamDB) UpdateOIDCAuth(oidcAuth *db.OIDCAuth) (db.SavedTeam, error) {
+	fake.updateOIDCAuthMutex.Lock()
+	fake.updateOIDCAuthArgsForCall = append(fake.updateOIDCAuthArgsForCall, struct {
+		oidcAuth *db.OIDCAuth
+	}{oidcAuth})
+	fake.recordInvocation("UpdateOIDCAuth", []interface{}{oidcAuth})
+	fake.updateOIDCAuthMutex.Unlock()
+	if fake.UpdateOIDCAuthStub != nil {
+		return fake.UpdateOIDCAuthStub(oidcAuth)
+	} else {
+		return fake.updateOIDCAuthReturns.result1, fake.updateOIDCAuthReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) UpdateOIDCAuthCallCount() int {
+	fake.updateOIDCAuthMutex.RLock()
+	defer fake.updateOIDCAuthMutex.RUnlock()
+	return len(fake.updateOIDCAuthArgsForCall)
+}
+
+func (fake *FakeTeamDB) UpdateOIDCAuthArgsForCall(i int) *db.OIDCAuth {
+	fake.updateOIDCAuthMutex.RLock()
+	defer fake.updateOIDCAuthMutex.RUnlock()
+	return fake.updateOIDCAuthArgsForCall[i].oidcAuth
+}
+
+func (fake *FakeTeamDB) UpdateOIDCAuthReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateOIDCAuthStub = nil
+	fake.updateOIDCAuthReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) UpdateLDAPAuth(ldapAuth *db.LDAPAuth) (db.SavedTeam, error) {
+	fake.updateLDAPAuthMutex.Lock()
+	fake.updateLDAPAuthArgsForCall = append(fake.updateLDAPAuthArgsForCall, struct {
+		ldapAuth *db.LDAPAuth
+	}{ldapAuth})
+	fake.recordInvocation("UpdateLDAPAuth", []interface{}{ldapAuth})
+	fake.updateLDAPAuthMutex.Unlock()
+	if fake.UpdateLDAPAuthStub != nil {
+		return fake.UpdateLDAPAuthStub(ldapAuth)
+	} else {
+		return fake.updateLDAPAuthReturns.result1, fake.updateLDAPAuthReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) UpdateLDAPAuthCallCount() int {
+	fake.updateLDAPAuthMutex.RLock()
+	defer fake.updateLDAPAuthMutex.RUnlock()
+	return len(fake.updateLDAPAuthArgsForCall)
+}
+
+func (fake *FakeTeamDB) UpdateLDAPAuthArgsForCall(i int) *db.LDAPAuth {
+	fake.updateLDAPAuthMutex.RLock()
+	defer fake.updateLDAPAuthMutex.RUnlock()
+	return fake.updateLDAPAuthArgsForCall[i].ldapAuth
+}
+
+func (fake *FakeTeamDB) UpdateLDAPAuthReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateLDAPAuthStub = nil
+	fake.updateLDAPAuthReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) UpdateNotificationHooks(notificationHooks []atc.NotificationHook) (db.SavedTeam, error) {
+	var notificationHooksCopy []atc.NotificationHook
+	if notificationHooks != nil {
+		notificationHooksCopy = make([]atc.NotificationHook, len(notificationHooks))
+		copy(notificationHooksCopy, notificationHooks)
+	}
+	fake.updateNotificationHooksMutex.Lock()
+	fake.updateNotificationHooksArgsForCall = append(fake.updateNotificationHooksArgsForCall, struct {
+		notificationHooks []atc.NotificationHook
+	}{notificationHooksCopy})
+	fake.recordInvocation("UpdateNotificationHooks", []interface{}{notificationHooksCopy})
+	fake.updateNotificationHooksMutex.Unlock()
+	if fake.UpdateNotificationHooksStub != nil {
+		return fake.UpdateNotificationHooksStub(notificationHooks)
+	} else {
+		return fake.updateNotificationHooksReturns.result1, fake.updateNotificationHooksReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) UpdateNotificationHooksCallCount() int {
+	fake.updateNotificationHooksMutex.RLock()
+	defer fake.updateNotificationHooksMutex.RUnlock()
+	return len(fake.updateNotificationHooksArgsForCall)
+}
+
+func (fake *FakeTeamDB) UpdateNotificationHooksArgsForCall(i int) []atc.NotificationHook {
+	fake.updateNotificationHooksMutex.RLock()
+	defer fake.updateNotificationHooksMutex.RUnlock()
+	return fake.updateNotificationHooksArgsForCall[i].notificationHooks
+}
+
+func (fake *FakeTeamDB) UpdateNotificationHooksReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateNotificationHooksStub = nil
+	fake.updateNotificationHooksReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) UpdateRoles(roles []atc.TeamRoleMapping) (db.SavedTeam, error) {
+	var rolesCopy []atc.TeamRoleMapping
+	if roles != nil {
+		rolesCopy = make([]atc.TeamRoleMapping, len(roles))
+		copy(rolesCopy, roles)
+	}
+	fake.updateRolesMutex.Lock()
+	fake.updateRolesArgsForCall = append(fake.updateRolesArgsForCall, struct {
+		roles []atc.TeamRoleMapping
+	}{rolesCopy})
+	fake.recordInvocation("UpdateRoles", []interface{}{rolesCopy})
+	fake.updateRolesMutex.Unlock()
+	if fake.UpdateRolesStub != nil {
+		return fake.UpdateRolesStub(roles)
+	} else {
+		return fake.updateRolesReturns.result1, fake.updateRolesReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) UpdateRolesCallCount() int {
+	fake.updateRolesMutex.RLock()
+	defer fake.updateRolesMutex.RUnlock()
+	return len(fake.updateRolesArgsForCall)
+}
+
+func (fake *FakeTeamDB) UpdateRolesArgsForCall(i int) []atc.TeamRoleMapping {
+	fake.updateRolesMutex.RLock()
+	defer fake.updateRolesMutex.RUnlock()
+	return fake.updateRolesArgsForCall[i].roles
+}
+
+func (fake *FakeTeamDB) UpdateRolesReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateRolesStub = nil
+	fake.updateRolesReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeamDB) GetConfig(pipelineName string) (atc.Config, atc.RawConfig, db.ConfigVersion, error) {
 	fake.getConfigMutex.Lock()
 	fake.getConfigArgsForCall = append(fake.getConfigArgsForCall, struct {
@@ -521,18 +776,19 @@ func (fake *FakeTeamDB) GetConfigReturns(result1 atc.Config, result2 atc.RawConf
 	}{result1, result2, result3, result4}
 }
 
-func (fake *FakeTeamDB) SaveConfig(arg1 string, arg2 atc.Config, arg3 db.ConfigVersion, arg4 db.PipelinePausedState) (db.SavedPipeline, bool, error) {
+func (fake *FakeTeamDB) SaveConfig(pipelineName string, config atc.Config, from db.ConfigVersion, pausedState db.PipelinePausedState, savedBy string) (db.SavedPipeline, bool, error) {
 	fake.saveConfigMutex.Lock()
 	fake.saveConfigArgsForCall = append(fake.saveConfigArgsForCall, struct {
-		arg1 string
-		arg2 atc.Config
-		arg3 db.ConfigVersion
-		arg4 db.PipelinePausedState
-	}{arg1, arg2, arg3, arg4})
-	fake.recordInvocation("SaveConfig", []interface{}{arg1, arg2, arg3, arg4})
+		pipelineName string
+		config       atc.Config
+		from         db.ConfigVersion
+		pausedState  db.PipelinePausedState
+		savedBy      string
+	}{pipelineName, config, from, pausedState, savedBy})
+	fake.recordInvocation("SaveConfig", []interface{}{pipelineName, config, from, pausedState, savedBy})
 	fake.saveConfigMutex.Unlock()
 	if fake.SaveConfigStub != nil {
-		return fake.SaveConfigStub(arg1, arg2, arg3, arg4)
+		return fake.SaveConfigStub(pipelineName, config, from, pausedState, savedBy)
 	} else {
 		return fake.saveConfigReturns.result1, fake.saveConfigReturns.result2, fake.saveConfigReturns.result3
 	}
@@ -544,10 +800,10 @@ func (fake *FakeTeamDB) SaveConfigCallCount() int {
 	return len(fake.saveConfigArgsForCall)
 }
 
-func (fake *FakeTeamDB) SaveConfigArgsForCall(i int) (string, atc.Config, db.ConfigVersion, db.PipelinePausedState) {
+func (fake *FakeTeamDB) SaveConfigArgsForCall(i int) (string, atc.Config, db.ConfigVersion, db.PipelinePausedState, string) {
 	fake.saveConfigMutex.RLock()
 	defer fake.saveConfigMutex.RUnlock()
-	return fake.saveConfigArgsForCall[i].arg1, fake.saveConfigArgsForCall[i].arg2, fake.saveConfigArgsForCall[i].arg3, fake.saveConfigArgsForCall[i].arg4
+	return fake.saveConfigArgsForCall[i].pipelineName, fake.saveConfigArgsForCall[i].config, fake.saveConfigArgsForCall[i].from, fake.saveConfigArgsForCall[i].pausedState, fake.saveConfigArgsForCall[i].savedBy
 }
 
 func (fake *FakeTeamDB) SaveConfigReturns(result1 db.SavedPipeline, result2 bool, result3 error) {
@@ -559,6 +815,248 @@ func (fake *FakeTeamDB) SaveConfigReturns(result1 db.SavedPipeline, result2 bool
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeamDB) GetConfigTemplate(pipelineName string) (db.ConfigTemplate, bool, error) {
+	fake.getConfigTemplateMutex.Lock()
+	fake.getConfigTemplateArgsForCall = append(fake.getConfigTemplateArgsForCall, struct {
+		pipelineName string
+	}{pipelineName})
+	fake.recordInvocation("GetConfigTemplate", []interface{}{pipelineName})
+	fake.getConfigTemplateMutex.Unlock()
+	if fake.GetConfigTemplateStub != nil {
+		return fake.GetConfigTemplateStub(pipelineName)
+	} else {
+		return fake.getConfigTemplateReturns.result1, fake.getConfigTemplateReturns.result2, fake.getConfigTemplateReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) GetConfigTemplateCallCount() int {
+	fake.getConfigTemplateMutex.RLock()
+	defer fake.getConfigTemplateMutex.RUnlock()
+	return len(fake.getConfigTemplateArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetConfigTemplateArgsForCall(i int) string {
+	fake.getConfigTemplateMutex.RLock()
+	defer fake.getConfigTemplateMutex.RUnlock()
+	return fake.getConfigTemplateArgsForCall[i].pipelineName
+}
+
+func (fake *FakeTeamDB) GetConfigTemplateReturns(result1 db.ConfigTemplate, result2 bool, result3 error) {
+	fake.GetConfigTemplateStub = nil
+	fake.getConfigTemplateReturns = struct {
+		result1 db.ConfigTemplate
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) SaveConfigFromTemplate(pipelineName string, config atc.Config, template db.ConfigTemplate, from db.ConfigVersion, pausedState db.PipelinePausedState, savedBy string) (db.SavedPipeline, bool, error) {
+	fake.saveConfigFromTemplateMutex.Lock()
+	fake.saveConfigFromTemplateArgsForCall = append(fake.saveConfigFromTemplateArgsForCall, struct {
+		pipelineName string
+		config       atc.Config
+		template     db.ConfigTemplate
+		from         db.ConfigVersion
+		pausedState  db.PipelinePausedState
+		savedBy      string
+	}{pipelineName, config, template, from, pausedState, savedBy})
+	fake.recordInvocation("SaveConfigFromTemplate", []interface{}{pipelineName, config, template, from, pausedState, savedBy})
+	fake.saveConfigFromTemplateMutex.Unlock()
+	if fake.SaveConfigFromTemplateStub != nil {
+		return fake.SaveConfigFromTemplateStub(pipelineName, config, template, from, pausedState, savedBy)
+	} else {
+		return fake.saveConfigFromTemplateReturns.result1, fake.saveConfigFromTemplateReturns.result2, fake.saveConfigFromTemplateReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) SaveConfigFromTemplateCallCount() int {
+	fake.saveConfigFromTemplateMutex.RLock()
+	defer fake.saveConfigFromTemplateMutex.RUnlock()
+	return len(fake.saveConfigFromTemplateArgsForCall)
+}
+
+func (fake *FakeTeamDB) SaveConfigFromTemplateArgsForCall(i int) (string, atc.Config, db.ConfigTemplate, db.ConfigVersion, db.PipelinePausedState, string) {
+	fake.saveConfigFromTemplateMutex.RLock()
+	defer fake.saveConfigFromTemplateMutex.RUnlock()
+	return fake.saveConfigFromTemplateArgsForCall[i].pipelineName, fake.saveConfigFromTemplateArgsForCall[i].config, fake.saveConfigFromTemplateArgsForCall[i].template, fake.saveConfigFromTemplateArgsForCall[i].from, fake.saveConfigFromTemplateArgsForCall[i].pausedState, fake.saveConfigFromTemplateArgsForCall[i].savedBy
+}
+
+func (fake *FakeTeamDB) SaveConfigFromTemplateReturns(result1 db.SavedPipeline, result2 bool, result3 error) {
+	fake.SaveConfigFromTemplateStub = nil
+	fake.saveConfigFromTemplateReturns = struct {
+		result1 db.SavedPipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) GetConfigRevisions(pipelineName string) ([]db.ConfigRevision, bool, error) {
+	fake.getConfigRevisionsMutex.Lock()
+	fake.getConfigRevisionsArgsForCall = append(fake.getConfigRevisionsArgsForCall, struct {
+		pipelineName string
+	}{pipelineName})
+	fake.recordInvocation("GetConfigRevisions", []interface{}{pipelineName})
+	fake.getConfigRevisionsMutex.Unlock()
+	if fake.GetConfigRevisionsStub != nil {
+		return fake.GetConfigRevisionsStub(pipelineName)
+	} else {
+		return fake.getConfigRevisionsReturns.result1, fake.getConfigRevisionsReturns.result2, fake.getConfigRevisionsReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) GetConfigRevisionsCallCount() int {
+	fake.getConfigRevisionsMutex.RLock()
+	defer fake.getConfigRevisionsMutex.RUnlock()
+	return len(fake.getConfigRevisionsArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetConfigRevisionsArgsForCall(i int) string {
+	fake.getConfigRevisionsMutex.RLock()
+	defer fake.getConfigRevisionsMutex.RUnlock()
+	return fake.getConfigRevisionsArgsForCall[i].pipelineName
+}
+
+func (fake *FakeTeamDB) GetConfigRevisionsReturns(result1 []db.ConfigRevision, result2 bool, result3 error) {
+	fake.GetConfigRevisionsStub = nil
+	fake.getConfigRevisionsReturns = struct {
+		result1 []db.ConfigRevision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) GetConfigRevision(pipelineName string, revisionID int) (db.ConfigRevision, bool, error) {
+	fake.getConfigRevisionMutex.Lock()
+	fake.getConfigRevisionArgsForCall = append(fake.getConfigRevisionArgsForCall, struct {
+		pipelineName string
+		revisionID   int
+	}{pipelineName, revisionID})
+	fake.recordInvocation("GetConfigRevision", []interface{}{pipelineName, revisionID})
+	fake.getConfigRevisionMutex.Unlock()
+	if fake.GetConfigRevisionStub != nil {
+		return fake.GetConfigRevisionStub(pipelineName, revisionID)
+	} else {
+		return fake.getConfigRevisionReturns.result1, fake.getConfigRevisionReturns.result2, fake.getConfigRevisionReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) GetConfigRevisionCallCount() int {
+	fake.getConfigRevisionMutex.RLock()
+	defer fake.getConfigRevisionMutex.RUnlock()
+	return len(fake.getConfigRevisionArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetConfigRevisionArgsForCall(i int) (string, int) {
+	fake.getConfigRevisionMutex.RLock()
+	defer fake.getConfigRevisionMutex.RUnlock()
+	return fake.getConfigRevisionArgsForCall[i].pipelineName, fake.getConfigRevisionArgsForCall[i].revisionID
+}
+
+func (fake *FakeTeamDB) GetConfigRevisionReturns(result1 db.ConfigRevision, result2 bool, result3 error) {
+	fake.GetConfigRevisionStub = nil
+	fake.getConfigRevisionReturns = struct {
+		result1 db.ConfigRevision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) CreateAPIToken(name string, role atc.TeamRole, tokenHash string) (db.APIToken, error) {
+	fake.createAPITokenMutex.Lock()
+	fake.createAPITokenArgsForCall = append(fake.createAPITokenArgsForCall, struct {
+		name      string
+		role      atc.TeamRole
+		tokenHash string
+	}{name, role, tokenHash})
+	fake.recordInvocation("CreateAPIToken", []interface{}{name, role, tokenHash})
+	fake.createAPITokenMutex.Unlock()
+	if fake.CreateAPITokenStub != nil {
+		return fake.CreateAPITokenStub(name, role, tokenHash)
+	} else {
+		return fake.createAPITokenReturns.result1, fake.createAPITokenReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) CreateAPITokenCallCount() int {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	return len(fake.createAPITokenArgsForCall)
+}
+
+func (fake *FakeTeamDB) CreateAPITokenArgsForCall(i int) (string, atc.TeamRole, string) {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	return fake.createAPITokenArgsForCall[i].name, fake.createAPITokenArgsForCall[i].role, fake.createAPITokenArgsForCall[i].tokenHash
+}
+
+func (fake *FakeTeamDB) CreateAPITokenReturns(result1 db.APIToken, result2 error) {
+	fake.CreateAPITokenStub = nil
+	fake.createAPITokenReturns = struct {
+		result1 db.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) GetAPITokens() ([]db.APIToken, error) {
+	fake.getAPITokensMutex.Lock()
+	fake.getAPITokensArgsForCall = append(fake.getAPITokensArgsForCall, struct{}{})
+	fake.recordInvocation("GetAPITokens", []interface{}{})
+	fake.getAPITokensMutex.Unlock()
+	if fake.GetAPITokensStub != nil {
+		return fake.GetAPITokensStub()
+	} else {
+		return fake.getAPITokensReturns.result1, fake.getAPITokensReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) GetAPITokensCallCount() int {
+	fake.getAPITokensMutex.RLock()
+	defer fake.getAPITokensMutex.RUnlock()
+	return len(fake.getAPITokensArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetAPITokensReturns(result1 []db.APIToken, result2 error) {
+	fake.GetAPITokensStub = nil
+	fake.getAPITokensReturns = struct {
+		result1 []db.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) DeleteAPIToken(name string) (bool, error) {
+	fake.deleteAPITokenMutex.Lock()
+	fake.deleteAPITokenArgsForCall = append(fake.deleteAPITokenArgsForCall, struct {
+		name string
+	}{name})
+	fake.recordInvocation("DeleteAPIToken", []interface{}{name})
+	fake.deleteAPITokenMutex.Unlock()
+	if fake.DeleteAPITokenStub != nil {
+		return fake.DeleteAPITokenStub(name)
+	} else {
+		return fake.deleteAPITokenReturns.result1, fake.deleteAPITokenReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) DeleteAPITokenCallCount() int {
+	fake.deleteAPITokenMutex.RLock()
+	defer fake.deleteAPITokenMutex.RUnlock()
+	return len(fake.deleteAPITokenArgsForCall)
+}
+
+func (fake *FakeTeamDB) DeleteAPITokenArgsForCall(i int) string {
+	fake.deleteAPITokenMutex.RLock()
+	defer fake.deleteAPITokenMutex.RUnlock()
+	return fake.deleteAPITokenArgsForCall[i].name
+}
+
+func (fake *FakeTeamDB) DeleteAPITokenReturns(result1 bool, result2 error) {
+	fake.DeleteAPITokenStub = nil
+	fake.deleteAPITokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeamDB) CreateOneOffBuild() (db.Build, error) {
 	fake.createOneOffBuildMutex.Lock()
 	fake.createOneOffBuildArgsForCall = append(fake.createOneOffBuildArgsForCall, struct{}{})
@@ -764,10 +1262,32 @@ func (fake *FakeTeamDB) Invocations() map[string][][]interface{} {
 	defer fake.updateUAAAuthMutex.RUnlock()
 	fake.updateGenericOAuthMutex.RLock()
 	defer fake.updateGenericOAuthMutex.RUnlock()
+	fake.updateOIDCAuthMutex.RLock()
+	defer fake.updateOIDCAuthMutex.RUnlock()
+	fake.updateLDAPAuthMutex.RLock()
+	defer fake.updateLDAPAuthMutex.RUnlock()
+	fake.updateNotificationHooksMutex.RLock()
+	defer fake.updateNotificationHooksMutex.RUnlock()
+	fake.updateRolesMutex.RLock()
+	defer fake.updateRolesMutex.RUnlock()
 	fake.getConfigMutex.RLock()
 	defer fake.getConfigMutex.RUnlock()
 	fake.saveConfigMutex.RLock()
 	defer fake.saveConfigMutex.RUnlock()
+	fake.getConfigTemplateMutex.RLock()
+	defer fake.getConfigTemplateMutex.RUnlock()
+	fake.saveConfigFromTemplateMutex.RLock()
+	defer fake.saveConfigFromTemplateMutex.RUnlock()
+	fake.getConfigRevisionsMutex.RLock()
+	defer fake.getConfigRevisionsMutex.RUnlock()
+	fake.getConfigRevisionMutex.RLock()
+	defer fake.getConfigRevisionMutex.RUnlock()
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	fake.getAPITokensMutex.RLock()
+	defer fake.getAPITokensMutex.RUnlock()
+	fake.deleteAPITokenMutex.RLock()
+	defer fake.deleteAPITokenMutex.RUnlock()
 	fake.createOneOffBuildMutex.RLock()
 	defer fake.createOneOffBuildMutex.RUnlock()
 	fake.getPrivateAndPublicBuildsMutex.RLock()
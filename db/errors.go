@@ -4,12 +4,21 @@ import "errors"
 
 var ErrNoVersions = errors.New("no versions found")
 var ErrNoBuild = errors.New("no build found")
+var ErrCannotRerunOneOffBuild = errors.New("one-off builds cannot be rerun")
+var ErrCannotRerunUnscheduledBuild = errors.New("build has not been scheduled yet")
 
 var ErrPipelineNotFound = errors.New("pipeline not found")
+var ErrResourceVersionNotFound = errors.New("resource version not found")
 
 var ErrLockRowNotPresentOrAlreadyDeleted = errors.New("lock could not be acquired because it didn't exist or was already cleaned up")
 
 var ErrLockNotAvailable = errors.New("lock is currently held and cannot be immediately acquired")
 
+var ErrWorkerStalled = errors.New("worker is stalled")
+var ErrWorkerRetiring = errors.New("worker is retiring or has retired")
+var ErrCannotPruneRunningWorker = errors.New("cannot prune a worker which has not stalled, landed or retired")
+
+var ErrAPITokenAlreadyExists = errors.New("an api token with that name already exists")
+
 var ErrNoContainer = errors.New("no container found")
 var ErrMultipleContainersFound = errors.New("multiple containers found for given identifier")
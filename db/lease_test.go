@@ -38,10 +38,10 @@ var _ = Describe("Leases", func() {
 		bus := db.NewNotificationsBus(listener, dbConn)
 
 		logger = lagertest.NewTestLogger("test")
-		sqlDB = db.NewSQL(dbConn, bus)
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus)
+		sqlDB = db.NewSQL(dbConn, bus, nil)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, nil)
 
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, nil)
 		teamDB = teamDBFactory.GetTeamDB(atc.DefaultTeamName)
 	})
 
@@ -83,7 +83,7 @@ var _ = Describe("Leases", func() {
 		_, err := sqlDB.CreateTeam(db.Team{Name: "some-team"})
 		Expect(err).NotTo(HaveOccurred())
 		teamDB := teamDBFactory.GetTeamDB("some-team")
-		savedPipeline, _, err := teamDB.SaveConfig("pipeline-name", pipelineConfig, 0, db.PipelineUnpaused)
+		savedPipeline, _, err := teamDB.SaveConfig("pipeline-name", pipelineConfig, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		pipelineDB = pipelineDBFactory.Build(savedPipeline)
@@ -0,0 +1,50 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddNotificationHooks(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE teams
+		ADD COLUMN notification_hooks json null
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE notification_deliveries (
+			id serial PRIMARY KEY,
+			build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			hook_name text NOT NULL,
+			url text NOT NULL,
+			payload text NOT NULL,
+			signature text NOT NULL DEFAULT '',
+			status text NOT NULL DEFAULT 'pending',
+			attempts integer NOT NULL DEFAULT 0,
+			next_attempt_at timestamp with time zone NOT NULL DEFAULT now(),
+			created_at timestamp with time zone NOT NULL DEFAULT now()
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX notification_deliveries_status_next_attempt_at_idx
+		ON notification_deliveries (status, next_attempt_at)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE notification_delivery_attempts (
+			id serial PRIMARY KEY,
+			delivery_id integer NOT NULL REFERENCES notification_deliveries (id) ON DELETE CASCADE,
+			attempted_at timestamp with time zone NOT NULL DEFAULT now(),
+			response_status integer NOT NULL DEFAULT 0,
+			error text NOT NULL DEFAULT ''
+		)
+	`)
+	return err
+}
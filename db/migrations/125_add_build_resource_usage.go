@@ -0,0 +1,17 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddBuildResourceUsage(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE build_resource_usage (
+			build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			plan_id text NOT NULL,
+			step_name text NOT NULL,
+			step_type text NOT NULL,
+			usage text NOT NULL,
+			UNIQUE (build_id, plan_id)
+		)
+	`)
+	return err
+}
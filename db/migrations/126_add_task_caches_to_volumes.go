@@ -0,0 +1,20 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddTaskCachesToVolumes(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE volumes
+		ADD COLUMN task_cache_job_id integer REFERENCES jobs (id) ON DELETE CASCADE,
+		ADD COLUMN task_cache_step_name text,
+		ADD COLUMN task_cache_path text
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX volumes_task_cache_job_id ON volumes (task_cache_job_id)
+	`)
+	return err
+}
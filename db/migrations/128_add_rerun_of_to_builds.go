@@ -0,0 +1,11 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddRerunOfToBuilds(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN rerun_of integer REFERENCES builds (id) ON DELETE SET NULL
+	`)
+	return err
+}
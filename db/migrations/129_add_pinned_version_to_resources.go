@@ -0,0 +1,12 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddPinnedVersionToResources(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE resources
+		ADD COLUMN pinned_version_id integer REFERENCES versioned_resources (id) ON DELETE SET NULL,
+		ADD COLUMN pin_comment text
+	`)
+	return err
+}
@@ -0,0 +1,11 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddSchedulingExplanationToJobs(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE jobs
+		ADD COLUMN scheduling_explanation text
+	`)
+	return err
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddAuditEvents(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE audit_events (
+			id serial PRIMARY KEY,
+			time timestamp with time zone NOT NULL DEFAULT now(),
+			team_name text NOT NULL,
+			is_admin boolean NOT NULL DEFAULT false,
+			username text NOT NULL DEFAULT '',
+			route text NOT NULL,
+			method text NOT NULL,
+			path text NOT NULL,
+			params text NOT NULL DEFAULT '{}',
+			status integer NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX audit_events_team_name_idx ON audit_events (team_name)
+	`)
+	return err
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddPipelineConfigRevisions(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE pipeline_config_revisions (
+			id serial PRIMARY KEY,
+			pipeline_id integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+			version integer NOT NULL,
+			config text NOT NULL,
+			config_template text,
+			config_vars text,
+			saved_by text NOT NULL DEFAULT '',
+			saved_at timestamp with time zone NOT NULL DEFAULT now()
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX pipeline_config_revisions_pipeline_id_idx ON pipeline_config_revisions (pipeline_id)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO pipeline_config_revisions (pipeline_id, version, config, config_template, config_vars)
+		SELECT id, version, config, config_template, config_vars
+		FROM pipelines
+	`)
+	return err
+}
@@ -121,4 +121,20 @@ var Migrations = []migration.Migrator{
 	AddCaseInsenstiveUniqueIndexToTeamsName,
 	AddNonEmptyConstraintToTeamName,
 	AddGenericOAuthToTeams,
+	AddNotificationHooks,
+	AddRolesToTeams,
+	AddArchivedToBuilds,
+	AddConfigTemplateToPipelines,
+	AddBuildResourceUsage,
+	AddTaskCachesToVolumes,
+	AddStateToWorkers,
+	AddRerunOfToBuilds,
+	AddPinnedVersionToResources,
+	AddSchedulingExplanationToJobs,
+	AddAuditEvents,
+	AddPipelineConfigRevisions,
+	AddAPITokens,
+	AddOIDCAuthToTeams,
+	AddLDAPAuthToTeams,
+	AddBuildLogSearchIndexes,
 }
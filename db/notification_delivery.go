@@ -0,0 +1,37 @@
+package db
+
+import "time"
+
+type NotificationDeliveryStatus string
+
+const (
+	NotificationDeliveryStatusPending   NotificationDeliveryStatus = "pending"
+	NotificationDeliveryStatusDelivered NotificationDeliveryStatus = "delivered"
+	NotificationDeliveryStatusFailed    NotificationDeliveryStatus = "failed"
+)
+
+type NotificationDelivery struct {
+	BuildID   int
+	HookName  string
+	URL       string
+	Payload   string
+	Signature string
+}
+
+type SavedNotificationDelivery struct {
+	ID       int
+	Status   NotificationDeliveryStatus
+	Attempts int
+
+	NotificationDelivery
+}
+
+type NotificationDeliveryAttempt struct {
+	ResponseStatus int
+	Error          string
+
+	// Status is the state the delivery is left in after this attempt; a
+	// pending delivery is retried once RetryAfter has elapsed.
+	Status     NotificationDeliveryStatus
+	RetryAfter time.Duration
+}
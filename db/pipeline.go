@@ -1,6 +1,10 @@
 package db
 
-import "github.com/concourse/atc"
+import (
+	"time"
+
+	"github.com/concourse/atc"
+)
 
 type Pipeline struct {
 	Name    string
@@ -17,3 +21,25 @@ type SavedPipeline struct {
 
 	Pipeline
 }
+
+// ConfigTemplate is the config a pipeline was rendered from, along with the
+// vars that were substituted into it.
+type ConfigTemplate struct {
+	Template atc.RawConfig
+	Vars     atc.Vars
+}
+
+// ConfigRevision is a config as it was saved, along with who saved it.
+type ConfigRevision struct {
+	ID      int
+	Version ConfigVersion
+
+	Config    atc.Config
+	RawConfig atc.RawConfig
+
+	// Template is nil unless the config was rendered from a template.
+	Template *ConfigTemplate
+
+	SavedBy string
+	SavedAt time.Time
+}
@@ -10,6 +10,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db/algorithm"
 )
 
@@ -32,6 +33,9 @@ type PipelineDB interface {
 
 	GetConfig() (atc.Config, ConfigVersion, bool, error)
 
+	Export(includeBuilds bool) (atc.PipelineArchive, error)
+	Import(archive atc.PipelineArchive) error
+
 	LeaseScheduling(lager.Logger, time.Duration) (Lease, bool, error)
 
 	GetResource(resourceName string) (SavedResource, bool, error)
@@ -41,6 +45,8 @@ type PipelineDB interface {
 
 	PauseResource(resourceName string) error
 	UnpauseResource(resourceName string) error
+	PinResource(resourceName string, versionedResourceID int, comment string) error
+	UnpinResource(resourceName string) error
 
 	SaveResourceVersions(atc.ResourceConfig, []atc.Version) error
 	SaveResourceTypeVersion(atc.ResourceType, atc.Version) error
@@ -55,6 +61,7 @@ type PipelineDB interface {
 	GetJob(job string) (SavedJob, error)
 	PauseJob(job string) error
 	UnpauseJob(job string) error
+	ClearTaskCaches(job string) ([]SavedVolume, error)
 	SetMaxInFlightReached(string, bool) error
 	UpdateFirstLoggedBuildID(job string, newFirstLoggedBuildID int) error
 
@@ -64,7 +71,9 @@ type PipelineDB interface {
 	GetAllJobBuilds(job string) ([]Build, error)
 
 	GetJobBuild(job string, build string) (Build, bool, error)
+	SearchJobBuildLogs(job string, query string, limit int) ([]BuildLogSearchResult, error)
 	CreateJobBuild(job string) (Build, error)
+	CreateRerunBuild(original Build) (Build, error)
 	EnsurePendingBuildExists(jobName string) error
 	GetNextPendingBuild(jobName string) (Build, bool, error)
 	UseInputsForBuild(buildID int, inputs []BuildInput) error
@@ -77,6 +86,8 @@ type PipelineDB interface {
 	SaveNextInputMapping(inputMapping algorithm.InputMapping, jobName string) error
 	GetNextBuildInputs(jobName string) ([]BuildInput, bool, error)
 	DeleteNextInputMapping(jobName string) error
+	SaveSchedulingExplanation(explanation algorithm.Explanation, jobName string) error
+	GetSchedulingExplanation(jobName string) (algorithm.Explanation, error)
 
 	GetRunningBuildsBySerialGroup(jobName string, serialGroups []string) ([]Build, error)
 	GetNextPendingBuildBySerialGroup(jobName string, serialGroups []string) (Build, bool, error)
@@ -263,9 +274,10 @@ func (pdb *pipelineDB) GetResource(resourceName string) (SavedResource, bool, er
 
 func (pdb *pipelineDB) GetResources() ([]DashboardResource, atc.GroupConfigs, bool, error) {
 	rows, err := pdb.conn.Query(`
-			SELECT id, name, check_error, paused
-			FROM resources
-			WHERE pipeline_id = $1
+			SELECT `+resourceColumns+`
+			FROM resources r
+			LEFT OUTER JOIN versioned_resources v ON v.id = r.pinned_version_id
+			WHERE r.pipeline_id = $1
 		`, pdb.ID)
 
 	if err != nil {
@@ -277,16 +289,12 @@ func (pdb *pipelineDB) GetResources() ([]DashboardResource, atc.GroupConfigs, bo
 	savedResources := map[string]SavedResource{}
 
 	for rows.Next() {
-		savedResource := SavedResource{PipelineName: pdb.Name}
-		var checkErr sql.NullString
-		err := rows.Scan(&savedResource.ID, &savedResource.Name, &checkErr, &savedResource.Paused)
+		savedResource, err := scanResource(rows)
 		if err != nil {
 			return nil, nil, false, err
 		}
 
-		if checkErr.Valid {
-			savedResource.CheckError = errors.New(checkErr.String)
-		}
+		savedResource.PipelineName = pdb.Name
 		savedResources[savedResource.Name] = savedResource
 	}
 
@@ -666,15 +674,13 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 }
 
 func (pdb *pipelineDB) getResource(tx Tx, name string) (SavedResource, bool, error) {
-	var checkErr sql.NullString
-	var resource SavedResource
-
-	err := tx.QueryRow(`
-			SELECT id, name, check_error, paused
-			FROM resources
-			WHERE name = $1
-				AND pipeline_id = $2
-		`, name, pdb.ID).Scan(&resource.ID, &resource.Name, &checkErr, &resource.Paused)
+	resource, err := scanResource(tx.QueryRow(`
+			SELECT `+resourceColumns+`
+			FROM resources r
+			LEFT OUTER JOIN versioned_resources v ON v.id = r.pinned_version_id
+			WHERE r.name = $1
+				AND r.pipeline_id = $2
+		`, name, pdb.ID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return SavedResource{}, false, nil
@@ -685,11 +691,41 @@ func (pdb *pipelineDB) getResource(tx Tx, name string) (SavedResource, bool, err
 
 	resource.PipelineName = pdb.GetPipelineName()
 
+	return resource, true, nil
+}
+
+const resourceColumns = "r.id, r.name, r.check_error, r.paused, r.pinned_version_id, v.version, r.pin_comment"
+
+func scanResource(row scannable) (SavedResource, error) {
+	var resource SavedResource
+	var checkErr, pinnedVersion, pinComment sql.NullString
+	var pinnedVersionID sql.NullInt64
+
+	err := row.Scan(&resource.ID, &resource.Name, &checkErr, &resource.Paused, &pinnedVersionID, &pinnedVersion, &pinComment)
+	if err != nil {
+		return SavedResource{}, err
+	}
+
 	if checkErr.Valid {
 		resource.CheckError = errors.New(checkErr.String)
 	}
 
-	return resource, true, nil
+	if pinnedVersionID.Valid {
+		resource.PinnedVersionID = int(pinnedVersionID.Int64)
+	}
+
+	if pinnedVersion.Valid {
+		err = json.Unmarshal([]byte(pinnedVersion.String), &resource.PinnedVersion)
+		if err != nil {
+			return SavedResource{}, err
+		}
+	}
+
+	if pinComment.Valid {
+		resource.PinComment = pinComment.String
+	}
+
+	return resource, nil
 }
 
 func (pdb *pipelineDB) GetResourceType(name string) (SavedResourceType, bool, error) {
@@ -777,6 +813,96 @@ func (pdb *pipelineDB) updatePaused(resource string, pause bool) error {
 	return tx.Commit()
 }
 
+// PinResource makes every job input of the resource use the given version
+// until it is unpinned, unless the job config pins a version of its own.
+func (pdb *pipelineDB) PinResource(resource string, versionedResourceID int, comment string) error {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE resources r
+		SET pinned_version_id = v.id, pin_comment = $1
+		FROM versioned_resources v
+		WHERE v.id = $2
+			AND v.resource_id = r.id
+			AND r.name = $3
+			AND r.pipeline_id = $4
+	`, comment, versionedResourceID, resource, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return ErrResourceVersionNotFound
+	}
+
+	err = touchVersionedResource(tx, versionedResourceID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (pdb *pipelineDB) UnpinResource(resource string) error {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	var pinnedVersionID sql.NullInt64
+	err = tx.QueryRow(`
+		SELECT pinned_version_id
+		FROM resources
+		WHERE name = $1
+			AND pipeline_id = $2
+	`, resource, pdb.ID).Scan(&pinnedVersionID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE resources
+		SET pinned_version_id = NULL, pin_comment = NULL
+		WHERE name = $1
+			AND pipeline_id = $2
+	`, resource, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	if pinnedVersionID.Valid {
+		err = touchVersionedResource(tx, int(pinnedVersionID.Int64))
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// touchVersionedResource bumps the version's modified time so that the
+// pipeline's cached versions DB is reloaded.
+func touchVersionedResource(tx Tx, versionedResourceID int) error {
+	_, err := tx.Exec(`
+		UPDATE versioned_resources
+		SET modified_time = now()
+		WHERE id = $1
+	`, versionedResourceID)
+	return err
+}
+
 func (pdb *pipelineDB) SaveResourceVersions(config atc.ResourceConfig, versions []atc.Version) error {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
@@ -1226,6 +1352,59 @@ func (pdb *pipelineDB) CreateJobBuild(jobName string) (Build, error) {
 	return build, nil
 }
 
+// CreateRerunBuild creates a pending build of the original build's job with
+// the original build's inputs already decided.
+func (pdb *pipelineDB) CreateRerunBuild(original Build) (Build, error) {
+	inputs, _, err := original.GetResources()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	buildName, jobID, err := getNewBuildNameForJob(tx, original.JobName(), pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	build, _, err := pdb.buildFactory.ScanBuild(tx.QueryRow(`
+		INSERT INTO builds (name, job_id, team_id, status, rerun_of)
+		VALUES ($1, $2, $3, 'pending', $5)
+		RETURNING `+buildColumns+`,
+			(SELECT name FROM jobs WHERE id = $2),
+			(SELECT id FROM pipelines WHERE id = $4),
+			(SELECT name FROM pipelines WHERE id = $4),
+			(SELECT name FROM teams WHERE id = $3)
+	`, buildName, jobID, pdb.SavedPipeline.TeamID, pdb.ID, original.ID()))
+	if err != nil {
+		return nil, err
+	}
+
+	err = createBuildEventSeq(tx, build.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, input := range inputs {
+		_, err := pdb.saveBuildInput(tx, build.ID(), input)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
 func (pdb *pipelineDB) EnsurePendingBuildExists(jobName string) error {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
@@ -1630,7 +1809,7 @@ func (pdb *pipelineDB) UpdateBuildToScheduled(buildID int) (bool, error) {
 	return rows == 1, nil
 }
 
-func (pdb *pipelineDB) getLatestModifiedTime() (time.Time, error) {
+func (pdb *pipelineDB) getLatestModifiedTime(upstreamJobs []string) (time.Time, error) {
 	var max_modified_time time.Time
 
 	err := pdb.conn.QueryRow(`
@@ -1662,12 +1841,40 @@ func (pdb *pipelineDB) getLatestModifiedTime() (time.Time, error) {
 			WHERE r.pipeline_id = $1
 		) vr
 	`, pdb.ID).Scan(&max_modified_time)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	for _, upstreamJob := range upstreamJobs {
+		pipelineName, jobName := config.ParsePassedJob(upstreamJob)
+
+		var upstreamModifiedTime time.Time
+		err := pdb.conn.QueryRow(`
+			SELECT COALESCE(MAX(bo.modified_time), 'epoch')
+			FROM build_outputs bo, builds b, jobs j, pipelines p
+			WHERE b.id = bo.build_id
+			AND j.id = b.job_id
+			AND p.id = j.pipeline_id
+			AND p.team_id = $1
+			AND p.name = $2
+			AND j.name = $3
+		`, pdb.SavedPipeline.TeamID, pipelineName, jobName).Scan(&upstreamModifiedTime)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if upstreamModifiedTime.After(max_modified_time) {
+			max_modified_time = upstreamModifiedTime
+		}
+	}
 
-	return max_modified_time, err
+	return max_modified_time, nil
 }
 
 func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
-	latestModifiedTime, err := pdb.getLatestModifiedTime()
+	upstreamJobs := config.UpstreamPipelineJobs(pdb.Config)
+
+	latestModifiedTime, err := pdb.getLatestModifiedTime(upstreamJobs)
 	if err != nil {
 		return nil, err
 	}
@@ -1682,6 +1889,7 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		ResourceVersions: []algorithm.ResourceVersion{},
 		JobIDs:           map[string]int{},
 		ResourceIDs:      map[string]int{},
+		PinnedVersionIDs: map[int]int{},
 		CachedAt:         latestModifiedTime,
 	}
 
@@ -1799,11 +2007,98 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		db.ResourceIDs[name] = id
 	}
 
+	rows, err = pdb.conn.Query(`
+    SELECT r.id, r.pinned_version_id
+    FROM resources r
+    WHERE r.pipeline_id = $1
+    AND r.pinned_version_id IS NOT NULL
+  `, pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var resourceID, versionID int
+		err := rows.Scan(&resourceID, &versionID)
+		if err != nil {
+			return nil, err
+		}
+
+		db.PinnedVersionIDs[resourceID] = versionID
+	}
+
+	for _, upstreamJob := range upstreamJobs {
+		err := pdb.loadUpstreamJobOutputs(db, upstreamJob)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	pdb.versionsDB = db
 
 	return db, nil
 }
 
+// loadUpstreamJobOutputs adds the outputs of a job in another pipeline of the
+// team as if they were outputs of this pipeline's resource with the same
+// name, provided this pipeline has seen the same version of it.
+func (pdb *pipelineDB) loadUpstreamJobOutputs(db *algorithm.VersionsDB, upstreamJob string) error {
+	pipelineName, jobName := config.ParsePassedJob(upstreamJob)
+
+	var jobID int
+	err := pdb.conn.QueryRow(`
+		SELECT j.id
+		FROM jobs j, pipelines p
+		WHERE p.id = j.pipeline_id
+		AND p.team_id = $1
+		AND p.name = $2
+		AND j.name = $3
+	`, pdb.SavedPipeline.TeamID, pipelineName, jobName).Scan(&jobID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+
+		return err
+	}
+
+	db.JobIDs[upstreamJob] = jobID
+
+	rows, err := pdb.conn.Query(`
+		SELECT v.id, v.check_order, r.id, o.build_id
+		FROM build_outputs o, builds b, versioned_resources uv, resources ur, versioned_resources v, resources r
+		WHERE uv.id = o.versioned_resource_id
+		AND b.id = o.build_id
+		AND ur.id = uv.resource_id
+		AND r.name = ur.name
+		AND v.resource_id = r.id
+		AND v.type = uv.type
+		AND v.version = uv.version
+		AND uv.enabled
+		AND v.enabled
+		AND b.status = 'succeeded'
+		AND b.job_id = $1
+		AND r.pipeline_id = $2
+	`, jobID, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		output := algorithm.BuildOutput{JobID: jobID}
+		err := rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &output.BuildID)
+		if err != nil {
+			return err
+		}
+
+		db.BuildOutputs = append(db.BuildOutputs, output)
+	}
+
+	return nil
+}
+
 func (pdb *pipelineDB) GetVersionedResourceByVersion(atcVersion atc.Version, resourceName string) (SavedVersionedResource, bool, error) {
 	var versionBytes, metadataBytes string
 
@@ -1910,6 +2205,62 @@ func (pdb *pipelineDB) DeleteNextInputMapping(jobName string) error {
 	return nil
 }
 
+// SaveSchedulingExplanation records why the job's inputs could not be
+// resolved. An empty explanation clears it.
+func (pdb *pipelineDB) SaveSchedulingExplanation(explanation algorithm.Explanation, jobName string) error {
+	var explanationJSON sql.NullString
+	if len(explanation) > 0 {
+		payload, err := json.Marshal(explanation)
+		if err != nil {
+			return err
+		}
+
+		explanationJSON = sql.NullString{String: string(payload), Valid: true}
+	}
+
+	result, err := pdb.conn.Exec(`
+		UPDATE jobs
+		SET scheduling_explanation = $1
+		WHERE name = $2 AND pipeline_id = $3
+	`, explanationJSON, jobName, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	return nil
+}
+
+func (pdb *pipelineDB) GetSchedulingExplanation(jobName string) (algorithm.Explanation, error) {
+	var explanationJSON sql.NullString
+	err := pdb.conn.QueryRow(`
+		SELECT scheduling_explanation
+		FROM jobs
+		WHERE name = $1 AND pipeline_id = $2
+	`, jobName, pdb.ID).Scan(&explanationJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := algorithm.Explanation{}
+	if explanationJSON.Valid {
+		err = json.Unmarshal([]byte(explanationJSON.String), &explanation)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return explanation, nil
+}
+
 func (pdb *pipelineDB) saveJobInputMapping(table string, inputMapping algorithm.InputMapping, jobName string) error {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
@@ -2053,6 +2404,71 @@ func (pdb *pipelineDB) UnpauseJob(job string) error {
 	return pdb.updatePausedJob(job, false)
 }
 
+// ClearTaskCaches detaches every task cache volume from the given job so that
+// subsequent builds start with empty caches. The detached volumes are
+// returned so that the caller can expire them on their workers.
+func (pdb *pipelineDB) ClearTaskCaches(jobName string) ([]SavedVolume, error) {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		SELECT
+			v.worker_name,
+			v.ttl,
+			EXTRACT(epoch FROM v.expires_at - NOW()),
+			v.handle,
+			v.resource_version,
+			v.resource_hash,
+			v.id,
+			v.original_volume_handle,
+			v.output_name,
+			v.replicated_from,
+			v.path,
+			v.host_path_version,
+			v.task_cache_job_id,
+			v.task_cache_step_name,
+			v.task_cache_path,
+			v.size_in_bytes,
+			c.ttl,
+			v.team_id
+		FROM volumes v `+volumeJoins+`
+		INNER JOIN jobs j
+			ON j.id = v.task_cache_job_id
+		WHERE j.name = $1
+		AND j.pipeline_id = $2
+	`, jobName, pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes, err := scanVolumes(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE volumes
+		SET task_cache_job_id = NULL, task_cache_step_name = NULL, task_cache_path = NULL
+		WHERE task_cache_job_id = (
+			SELECT id FROM jobs WHERE name = $1 AND pipeline_id = $2
+		)
+	`, jobName, pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return volumes, nil
+}
+
 func (pdb *pipelineDB) SetMaxInFlightReached(jobName string, reached bool) error {
 	result, err := pdb.conn.Exec(`
 		UPDATE jobs
@@ -0,0 +1,490 @@
+package db
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/lib/pq"
+)
+
+// Export returns the pipeline's config and the versions of its resources,
+// and, if asked for, its finished builds with their inputs and outputs.
+func (pdb *pipelineDB) Export(includeBuilds bool) (atc.PipelineArchive, error) {
+	config, _, found, err := pdb.GetConfig()
+	if err != nil {
+		return atc.PipelineArchive{}, err
+	}
+
+	if !found {
+		return atc.PipelineArchive{}, ErrPipelineNotFound
+	}
+
+	resources, err := pdb.exportResources(config)
+	if err != nil {
+		return atc.PipelineArchive{}, err
+	}
+
+	archive := atc.PipelineArchive{
+		Version:   atc.PipelineArchiveVersion,
+		Config:    config,
+		Resources: resources,
+	}
+
+	if includeBuilds {
+		archive.Builds, err = pdb.exportBuilds()
+		if err != nil {
+			return atc.PipelineArchive{}, err
+		}
+	}
+
+	return archive, nil
+}
+
+func (pdb *pipelineDB) exportResources(config atc.Config) ([]atc.ArchivedResource, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT r.name, v.type, v.version, v.metadata, v.enabled
+		FROM versioned_resources v
+		INNER JOIN resources r ON r.id = v.resource_id
+		WHERE r.pipeline_id = $1
+		ORDER BY v.check_order ASC, v.id ASC
+	`, pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	versions := map[string][]atc.ArchivedVersion{}
+
+	for rows.Next() {
+		var resourceName, versionJSON, metadataJSON string
+		var version atc.ArchivedVersion
+		err := rows.Scan(&resourceName, &version.Type, &versionJSON, &metadataJSON, &version.Enabled)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(versionJSON), &version.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		var metadata []MetadataField
+		err = json.Unmarshal([]byte(metadataJSON), &metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, field := range metadata {
+			version.Metadata = append(version.Metadata, atc.MetadataField{
+				Name:  field.Name,
+				Value: field.Value,
+			})
+		}
+
+		versions[resourceName] = append(versions[resourceName], version)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	// resources which are no longer configured are left behind
+	resources := []atc.ArchivedResource{}
+	for _, resource := range config.Resources {
+		resources = append(resources, atc.ArchivedResource{
+			Name:     resource.Name,
+			Versions: versions[resource.Name],
+		})
+	}
+
+	return resources, nil
+}
+
+func (pdb *pipelineDB) exportBuilds() ([]atc.ArchivedBuild, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT b.id, j.name, b.name, b.status, b.start_time, b.end_time, rb.name
+		FROM builds b
+		INNER JOIN jobs j ON j.id = b.job_id
+		LEFT OUTER JOIN builds rb ON rb.id = b.rerun_of
+		WHERE j.pipeline_id = $1
+		AND b.completed
+		ORDER BY b.id ASC
+	`, pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	buildIDs := []int{}
+	builds := map[int]*atc.ArchivedBuild{}
+
+	for rows.Next() {
+		var id int
+		var build atc.ArchivedBuild
+		var startTime, endTime pq.NullTime
+		var rerunOf *string
+		err := rows.Scan(&id, &build.JobName, &build.Name, &build.Status, &startTime, &endTime, &rerunOf)
+		if err != nil {
+			return nil, err
+		}
+
+		if startTime.Valid {
+			build.StartTime = startTime.Time.Unix()
+		}
+
+		if endTime.Valid {
+			build.EndTime = endTime.Time.Unix()
+		}
+
+		if rerunOf != nil {
+			build.RerunOf = *rerunOf
+		}
+
+		buildIDs = append(buildIDs, id)
+		builds[id] = &build
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	err = pdb.exportBuildInputs(builds)
+	if err != nil {
+		return nil, err
+	}
+
+	err = pdb.exportBuildOutputs(builds)
+	if err != nil {
+		return nil, err
+	}
+
+	archivedBuilds := make([]atc.ArchivedBuild, len(buildIDs))
+	for i, id := range buildIDs {
+		archivedBuilds[i] = *builds[id]
+	}
+
+	return archivedBuilds, nil
+}
+
+func (pdb *pipelineDB) exportBuildInputs(builds map[int]*atc.ArchivedBuild) error {
+	rows, err := pdb.conn.Query(`
+		SELECT i.build_id, i.name, r.name, v.type, v.version
+		FROM build_inputs i
+		INNER JOIN builds b ON b.id = i.build_id
+		INNER JOIN jobs j ON j.id = b.job_id
+		INNER JOIN versioned_resources v ON v.id = i.versioned_resource_id
+		INNER JOIN resources r ON r.id = v.resource_id
+		WHERE j.pipeline_id = $1
+		ORDER BY i.build_id ASC, i.name ASC
+	`, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var buildID int
+		var versionJSON string
+		var input atc.ArchivedBuildInput
+		err := rows.Scan(&buildID, &input.Name, &input.Resource, &input.Type, &versionJSON)
+		if err != nil {
+			return err
+		}
+
+		err = json.Unmarshal([]byte(versionJSON), &input.Version)
+		if err != nil {
+			return err
+		}
+
+		build, found := builds[buildID]
+		if found {
+			build.Inputs = append(build.Inputs, input)
+		}
+	}
+
+	return rows.Err()
+}
+
+func (pdb *pipelineDB) exportBuildOutputs(builds map[int]*atc.ArchivedBuild) error {
+	rows, err := pdb.conn.Query(`
+		SELECT o.build_id, r.name, v.type, v.version, o.explicit
+		FROM build_outputs o
+		INNER JOIN builds b ON b.id = o.build_id
+		INNER JOIN jobs j ON j.id = b.job_id
+		INNER JOIN versioned_resources v ON v.id = o.versioned_resource_id
+		INNER JOIN resources r ON r.id = v.resource_id
+		WHERE j.pipeline_id = $1
+		ORDER BY o.build_id ASC, r.name ASC
+	`, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var buildID int
+		var versionJSON string
+		var output atc.ArchivedBuildOutput
+		err := rows.Scan(&buildID, &output.Resource, &output.Type, &versionJSON, &output.Explicit)
+		if err != nil {
+			return err
+		}
+
+		err = json.Unmarshal([]byte(versionJSON), &output.Version)
+		if err != nil {
+			return err
+		}
+
+		build, found := builds[buildID]
+		if found {
+			build.Outputs = append(build.Outputs, output)
+		}
+	}
+
+	return rows.Err()
+}
+
+// Import adds the archive's versions and builds to the pipeline, which must
+// already have the archive's config. Importing the same archive again
+// changes nothing: versions which already exist keep their place in the check
+// order, and builds whose job already has a build of the same name are
+// skipped.
+func (pdb *pipelineDB) Import(archive atc.PipelineArchive) error {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	for _, resource := range archive.Resources {
+		err := pdb.importResource(tx, resource)
+		if err != nil {
+			return err
+		}
+	}
+
+	// builds are imported oldest first, so reruns can find the builds they
+	// are reruns of
+	buildIDs := map[string]map[string]int{}
+	for _, build := range archive.Builds {
+		err := pdb.importBuild(tx, build, buildIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (pdb *pipelineDB) importResource(tx Tx, resource atc.ArchivedResource) error {
+	savedResource, found, err := pdb.getResource(tx, resource.Name)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return ResourceNotFoundError{Name: resource.Name}
+	}
+
+	for _, version := range resource.Versions {
+		vr := VersionedResource{
+			Resource: resource.Name,
+			Type:     version.Type,
+			Version:  Version(version.Version),
+		}
+
+		for _, field := range version.Metadata {
+			vr.Metadata = append(vr.Metadata, MetadataField{
+				Name:  field.Name,
+				Value: field.Value,
+			})
+		}
+
+		svr, err := pdb.importVersion(tx, savedResource, vr)
+		if err != nil {
+			return err
+		}
+
+		if svr.Enabled != version.Enabled {
+			_, err = tx.Exec(`
+				UPDATE versioned_resources
+				SET enabled = $1, modified_time = now()
+				WHERE id = $2
+			`, version.Enabled, svr.ID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// importVersion saves the version, placing it after the resource's other
+// versions only if it is new.
+func (pdb *pipelineDB) importVersion(tx Tx, savedResource SavedResource, vr VersionedResource) (SavedVersionedResource, error) {
+	svr, created, err := pdb.saveVersionedResource(tx, savedResource, vr)
+	if err != nil {
+		return SavedVersionedResource{}, err
+	}
+
+	if created {
+		versionJSON, err := json.Marshal(vr.Version)
+		if err != nil {
+			return SavedVersionedResource{}, err
+		}
+
+		err = pdb.incrementCheckOrderWhenNewerVersion(tx, savedResource.ID, vr.Type, string(versionJSON))
+		if err != nil {
+			return SavedVersionedResource{}, err
+		}
+	}
+
+	return svr, nil
+}
+
+// importBuild creates the build, unless its job already has one of the same
+// name, and records the IDs of the job's builds by name in buildIDs.
+func (pdb *pipelineDB) importBuild(tx Tx, build atc.ArchivedBuild, buildIDs map[string]map[string]int) error {
+	job, err := pdb.getJob(tx, build.JobName)
+	if err != nil {
+		return err
+	}
+
+	jobBuildIDs, found := buildIDs[build.JobName]
+	if !found {
+		jobBuildIDs, err = pdb.getJobBuildIDsByName(tx, job.ID)
+		if err != nil {
+			return err
+		}
+
+		buildIDs[build.JobName] = jobBuildIDs
+	}
+
+	if _, found := jobBuildIDs[build.Name]; found {
+		return nil
+	}
+
+	var rerunOf *int
+	if build.RerunOf != "" {
+		originalID, found := jobBuildIDs[build.RerunOf]
+		if found {
+			rerunOf = &originalID
+		}
+	}
+
+	var buildID int
+	err = tx.QueryRow(`
+		INSERT INTO builds (name, job_id, team_id, status, scheduled, completed, start_time, end_time, rerun_of)
+		VALUES ($1, $2, $3, $4, true, true, $5, $6, $7)
+		RETURNING id
+	`, build.Name, job.ID, pdb.TeamID, build.Status, unixTime(build.StartTime), unixTime(build.EndTime), rerunOf).Scan(&buildID)
+	if err != nil {
+		return err
+	}
+
+	jobBuildIDs[build.Name] = buildID
+
+	// keep the job's next build from being given the name of an imported one
+	buildNumber, err := strconv.Atoi(build.Name)
+	if err == nil {
+		_, err = tx.Exec(`
+			UPDATE jobs
+			SET build_number_seq = $1
+			WHERE id = $2
+			AND build_number_seq < $1
+		`, buildNumber, job.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, input := range build.Inputs {
+		_, err := pdb.saveBuildInput(tx, buildID, BuildInput{
+			Name: input.Name,
+			VersionedResource: VersionedResource{
+				Resource: input.Resource,
+				Type:     input.Type,
+				Version:  Version(input.Version),
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, output := range build.Outputs {
+		savedResource, found, err := pdb.getResource(tx, output.Resource)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return ResourceNotFoundError{Name: output.Resource}
+		}
+
+		svr, err := pdb.importVersion(tx, savedResource, VersionedResource{
+			Resource: output.Resource,
+			Type:     output.Type,
+			Version:  Version(output.Version),
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO build_outputs (build_id, versioned_resource_id, explicit)
+			VALUES ($1, $2, $3)
+		`, buildID, svr.ID, output.Explicit)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (pdb *pipelineDB) getJobBuildIDsByName(tx Tx, jobID int) (map[string]int, error) {
+	rows, err := tx.Query(`
+		SELECT id, name
+		FROM builds
+		WHERE job_id = $1
+	`, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	buildIDs := map[string]int{}
+
+	for rows.Next() {
+		var id int
+		var name string
+		err := rows.Scan(&id, &name)
+		if err != nil {
+			return nil, err
+		}
+
+		buildIDs[name] = id
+	}
+
+	return buildIDs, rows.Err()
+}
+
+func unixTime(seconds int64) pq.NullTime {
+	if seconds == 0 {
+		return pq.NullTime{}
+	}
+
+	return pq.NullTime{Time: time.Unix(seconds, 0), Valid: true}
+}
@@ -0,0 +1,178 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc/event"
+)
+
+// at most this many log events of each build are returned, so that a build
+// which printed the term in a loop doesn't drown out the rest
+const maxBuildLogSearchEvents = 20
+
+// BuildLogSearchResult is a build whose logs matched a search.
+type BuildLogSearchResult struct {
+	Build   Build
+	Matches []BuildLogMatch
+}
+
+// BuildLogMatch is the lines a step printed which contain any of the search
+// terms.
+type BuildLogMatch struct {
+	Origin event.Origin
+	Lines  []string
+}
+
+// SearchJobBuildLogs returns the job's most recent builds whose logs contain
+// every term of the query, newest first. Builds whose logs have been archived
+// are not searched.
+func (pdb *pipelineDB) SearchJobBuildLogs(jobName string, query string, limit int) ([]BuildLogSearchResult, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return []BuildLogSearchResult{}, nil
+	}
+
+	rows, err := pdb.conn.Query(fmt.Sprintf(`
+		WITH matches AS (
+			SELECT e.build_id, e.event_id, e.payload,
+				dense_rank() OVER (ORDER BY e.build_id DESC) AS build_rank,
+				row_number() OVER (PARTITION BY e.build_id ORDER BY e.event_id ASC) AS event_rank
+			FROM pipeline_build_events_%[1]d e
+			INNER JOIN builds b ON b.id = e.build_id
+			INNER JOIN jobs j ON j.id = b.job_id
+			WHERE j.name = $1
+			AND j.pipeline_id = $2
+			AND e.type = 'log'
+			AND to_tsvector('simple', e.payload::json->>'payload') @@ plainto_tsquery('simple', $3)
+		)
+		SELECT build_id, payload
+		FROM matches
+		WHERE build_rank <= $4
+		AND event_rank <= $5
+		ORDER BY build_id DESC, event_id ASC
+	`, pdb.ID), jobName, pdb.ID, query, limit, maxBuildLogSearchEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	buildIDs := []int{}
+	matches := map[int][]BuildLogMatch{}
+
+	for rows.Next() {
+		var buildID int
+		var payload string
+		err := rows.Scan(&buildID, &payload)
+		if err != nil {
+			return nil, err
+		}
+
+		var log event.Log
+		err = json.Unmarshal([]byte(payload), &log)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, found := matches[buildID]; !found {
+			buildIDs = append(buildIDs, buildID)
+		}
+
+		matches[buildID] = addBuildLogMatch(matches[buildID], log, terms)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(buildIDs) == 0 {
+		return []BuildLogSearchResult{}, nil
+	}
+
+	buildsQuery, args, err := sq.Select(qualifiedBuildColumns).From("builds b").
+		Join("jobs j ON b.job_id = j.id").
+		Join("pipelines p ON j.pipeline_id = p.id").
+		Join("teams t ON b.team_id = t.id").
+		Where(sq.Eq{"b.id": buildIDs}).
+		OrderBy("b.id DESC").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	buildRows, err := pdb.conn.Query(buildsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer buildRows.Close()
+
+	results := []BuildLogSearchResult{}
+
+	for buildRows.Next() {
+		build, _, err := pdb.buildFactory.ScanBuild(buildRows)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, BuildLogSearchResult{
+			Build:   build,
+			Matches: matches[build.ID()],
+		})
+	}
+
+	return results, nil
+}
+
+// addBuildLogMatch adds the log's matching lines to the match of the same
+// origin, so that output which was flushed in several events is grouped.
+func addBuildLogMatch(matches []BuildLogMatch, log event.Log, terms []string) []BuildLogMatch {
+	lines := matchingLines(log.Payload, terms)
+	if len(lines) == 0 {
+		return matches
+	}
+
+	for i, match := range matches {
+		if match.Origin.ID == log.Origin.ID && match.Origin.Source == log.Origin.Source {
+			matches[i].Lines = append(matches[i].Lines, lines...)
+			return matches
+		}
+	}
+
+	return append(matches, BuildLogMatch{
+		Origin: log.Origin,
+		Lines:  lines,
+	})
+}
+
+func matchingLines(payload string, terms []string) []string {
+	lines := []string{}
+
+	for _, line := range strings.Split(payload, "\n") {
+		line = strings.TrimRight(line, "\r\t ")
+		lowerLine := strings.ToLower(line)
+
+		for _, term := range terms {
+			if strings.Contains(lowerLine, term) {
+				lines = append(lines, line)
+				break
+			}
+		}
+	}
+
+	return lines
+}
+
+// searchTerms splits the query the way the 'simple' text search config does,
+// so that the lines which made an event match can be picked out of it.
+func searchTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
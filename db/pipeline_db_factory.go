@@ -7,17 +7,20 @@ type PipelineDBFactory interface {
 }
 
 type pipelineDBFactory struct {
-	conn Conn
-	bus  *notificationsBus
+	conn            Conn
+	bus             *notificationsBus
+	buildLogArchive BuildLogArchive
 }
 
 func NewPipelineDBFactory(
 	sqldbConnection Conn,
 	bus *notificationsBus,
+	buildLogArchive BuildLogArchive,
 ) *pipelineDBFactory {
 	return &pipelineDBFactory{
-		conn: sqldbConnection,
-		bus:  bus,
+		conn:            sqldbConnection,
+		bus:             bus,
+		buildLogArchive: buildLogArchive,
 	}
 }
 
@@ -26,7 +29,7 @@ func (pdbf *pipelineDBFactory) Build(pipeline SavedPipeline) PipelineDB {
 		conn: pdbf.conn,
 		bus:  pdbf.bus,
 
-		buildFactory: newBuildFactory(pdbf.conn, pdbf.bus),
+		buildFactory: newBuildFactory(pdbf.conn, pdbf.bus, pdbf.buildLogArchive),
 
 		SavedPipeline: pipeline,
 	}
@@ -29,13 +29,13 @@ var _ = Describe("Jobs Builds", func() {
 		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
 		bus := db.NewNotificationsBus(listener, dbConn)
 
-		sqlDB = db.NewSQL(dbConn, bus)
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus)
+		sqlDB = db.NewSQL(dbConn, bus, nil)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, nil)
 
 		_, err := sqlDB.CreateTeam(db.Team{Name: "some-team"})
 		Expect(err).NotTo(HaveOccurred())
 
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, nil)
 		teamDB := teamDBFactory.GetTeamDB("some-team")
 
 		config := atc.Config{
@@ -45,13 +45,13 @@ var _ = Describe("Jobs Builds", func() {
 			},
 		}
 
-		savedPipeline, _, err := teamDB.SaveConfig("a-pipeline-name", config, 0, db.PipelineUnpaused)
+		savedPipeline, _, err := teamDB.SaveConfig("a-pipeline-name", config, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		pipelineDB = pipelineDBFactory.Build(savedPipeline)
 		Expect(err).NotTo(HaveOccurred())
 
-		otherSavedPipeline, _, err := teamDB.SaveConfig("another-pipeline", atc.Config{}, 0, db.PipelineUnpaused)
+		otherSavedPipeline, _, err := teamDB.SaveConfig("another-pipeline", atc.Config{}, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		otherPipelineDB = pipelineDBFactory.Build(otherSavedPipeline)
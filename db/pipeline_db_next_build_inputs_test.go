@@ -31,8 +31,8 @@ var _ = Describe("next build inputs for job", func() {
 		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
 		bus := db.NewNotificationsBus(listener, dbConn)
 
-		sqlDB = db.NewSQL(dbConn, bus)
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus)
+		sqlDB = db.NewSQL(dbConn, bus, nil)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, nil)
 
 		_, err := sqlDB.CreateTeam(db.Team{Name: "some-team"})
 		Expect(err).NotTo(HaveOccurred())
@@ -54,9 +54,9 @@ var _ = Describe("next build inputs for job", func() {
 			Resources: atc.ResourceConfigs{resourceConfig},
 		}
 
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, nil)
 		teamDB := teamDBFactory.GetTeamDB("some-team")
-		savedPipeline, _, err := teamDB.SaveConfig("some-pipeline", config, 0, db.PipelineUnpaused)
+		savedPipeline, _, err := teamDB.SaveConfig("some-pipeline", config, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		pipelineDB = pipelineDBFactory.Build(savedPipeline)
@@ -93,7 +93,7 @@ var _ = Describe("next build inputs for job", func() {
 
 		versions = []db.SavedVersionedResource{reversions[2], reversions[1], reversions[0]}
 
-		savedPipeline2, _, err := teamDB.SaveConfig("some-pipeline-2", config, 1, db.PipelineUnpaused)
+		savedPipeline2, _, err := teamDB.SaveConfig("some-pipeline-2", config, 1, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		pipelineDB2 = pipelineDBFactory.Build(savedPipeline2)
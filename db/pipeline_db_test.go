@@ -31,9 +31,9 @@ var _ = Describe("PipelineDB", func() {
 		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
 		bus := db.NewNotificationsBus(listener, dbConn)
 
-		sqlDB = db.NewSQL(dbConn, bus)
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus)
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus)
+		sqlDB = db.NewSQL(dbConn, bus, nil)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, nil)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, nil)
 	})
 
 	AfterEach(func() {
@@ -207,10 +207,10 @@ var _ = Describe("PipelineDB", func() {
 
 		teamDB = teamDBFactory.GetTeamDB("some-team")
 
-		savedPipeline, _, err = teamDB.SaveConfig("a-pipeline-name", pipelineConfig, 0, db.PipelineUnpaused)
+		savedPipeline, _, err = teamDB.SaveConfig("a-pipeline-name", pipelineConfig, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		otherSavedPipeline, _, err = teamDB.SaveConfig("other-pipeline-name", otherPipelineConfig, 0, db.PipelineUnpaused)
+		otherSavedPipeline, _, err = teamDB.SaveConfig("other-pipeline-name", otherPipelineConfig, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		pipelineDB = pipelineDBFactory.Build(savedPipeline)
@@ -220,7 +220,7 @@ var _ = Describe("PipelineDB", func() {
 	Describe("destroying a pipeline", func() {
 		It("can be deleted", func() {
 			// populate pipelines table
-			pipelineThatWillBeDeleted, _, err := teamDB.SaveConfig("a-pipeline-that-will-be-deleted", pipelineConfig, 0, db.PipelineUnpaused)
+			pipelineThatWillBeDeleted, _, err := teamDB.SaveConfig("a-pipeline-that-will-be-deleted", pipelineConfig, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			fetchedPipeline, found, err := teamDB.GetPipelineByName("a-pipeline-that-will-be-deleted")
@@ -438,7 +438,7 @@ var _ = Describe("PipelineDB", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				team2DB = teamDBFactory.GetTeamDB(team2.Name)
-				_, _, err = team2DB.SaveConfig("a-pipeline-name", pipelineConfig, 0, db.PipelineUnpaused)
+				_, _, err = team2DB.SaveConfig("a-pipeline-name", pipelineConfig, 0, db.PipelineUnpaused, "")
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -511,9 +511,9 @@ var _ = Describe("PipelineDB", func() {
 			})
 
 			By("being able to update the config with a valid config")
-			_, _, err = teamDB.SaveConfig("a-pipeline-name", updatedConfig, configVersion, db.PipelineUnpaused)
+			_, _, err = teamDB.SaveConfig("a-pipeline-name", updatedConfig, configVersion, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
-			_, _, err = teamDB.SaveConfig("other-pipeline-name", updatedConfig, otherConfigVersion, db.PipelineUnpaused)
+			_, _, err = teamDB.SaveConfig("other-pipeline-name", updatedConfig, otherConfigVersion, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			By("returning the updated config")
@@ -1026,6 +1026,91 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Context("when a job has passed constraints on a job in another pipeline", func() {
+			var downstreamPipelineDB db.PipelineDB
+
+			BeforeEach(func() {
+				downstreamPipeline, _, err := teamDB.SaveConfig("downstream-pipeline", atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   "some-type",
+							Source: atc.Source{"source-config": "some-value"},
+						},
+					},
+					Jobs: atc.JobConfigs{
+						{
+							Name: "deploy",
+							Plan: atc.PlanSequence{
+								{
+									Get:    "some-resource",
+									Passed: []string{"other-pipeline-name/a-job"},
+								},
+							},
+						},
+					},
+				}, 0, db.PipelineUnpaused, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				downstreamPipelineDB = pipelineDBFactory.Build(downstreamPipeline)
+			})
+
+			It("includes outputs of the upstream job for versions of the resource with the same name", func() {
+				resourceConfig := atc.ResourceConfig{
+					Name:   "some-resource",
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}
+
+				err := otherPipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				upstreamVR, found, err := otherPipelineDB.GetLatestVersionedResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = downstreamPipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}, {"version": "2"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				downstreamVR, found, err := downstreamPipelineDB.GetVersionedResourceByVersion(atc.Version{"version": "1"}, "some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				downstreamResource, _, err := downstreamPipelineDB.GetResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+
+				upstreamJob, err := otherPipelineDB.GetJob("a-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				versions, err := downstreamPipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versions.BuildOutputs).To(BeEmpty())
+				Expect(versions.JobIDs).To(HaveKeyWithValue("other-pipeline-name/a-job", upstreamJob.ID))
+
+				By("including outputs once the upstream build succeeds")
+				upstreamBuild, err := otherPipelineDB.CreateJobBuild("a-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = otherPipelineDB.SaveOutput(upstreamBuild.ID(), upstreamVR.VersionedResource, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = upstreamBuild.Finish(db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				versions, err = downstreamPipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versions.BuildOutputs).To(ConsistOf(algorithm.BuildOutput{
+					ResourceVersion: algorithm.ResourceVersion{
+						VersionID:  downstreamVR.ID,
+						ResourceID: downstreamResource.ID,
+						CheckOrder: downstreamVR.CheckOrder,
+					},
+					JobID:   upstreamJob.ID,
+					BuildID: upstreamBuild.ID(),
+				}))
+			})
+		})
+
 		Describe("GetVersionedResourceByVersion", func() {
 			var savedVersion2 db.SavedVersionedResource
 			BeforeEach(func() {
@@ -1286,6 +1371,93 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Describe("pinning and unpinning resources", func() {
+			var savedVR db.SavedVersionedResource
+
+			BeforeEach(func() {
+				err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name:   resourceName,
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "1"}, {"version": "2"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				savedVR, _, err = pipelineDB.GetVersionedResourceByVersion(atc.Version{"version": "1"}, resourceName)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("starts out as unpinned", func() {
+				resource, _, err := pipelineDB.GetResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(resource.PinnedVersionID).To(BeZero())
+				Expect(resource.PinnedVersion).To(BeNil())
+				Expect(resource.PinComment).To(BeEmpty())
+			})
+
+			It("can be pinned to a version with a comment", func() {
+				err := pipelineDB.PinResource(resourceName, savedVR.ID, "v2 is broken")
+				Expect(err).NotTo(HaveOccurred())
+
+				pinnedResource, _, err := pipelineDB.GetResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pinnedResource.PinnedVersionID).To(Equal(savedVR.ID))
+				Expect(pinnedResource.PinnedVersion).To(Equal(db.Version{"version": "1"}))
+				Expect(pinnedResource.PinComment).To(Equal("v2 is broken"))
+
+				resource, _, err := otherPipelineDB.GetResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resource.PinnedVersionID).To(BeZero())
+			})
+
+			It("pins every input of the resource in the versions DB", func() {
+				err := pipelineDB.PinResource(resourceName, savedVR.ID, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				versions, err := pipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versions.PinnedVersionIDs).To(Equal(map[int]int{
+					resource.ID: savedVR.ID,
+				}))
+
+				err = pipelineDB.UnpinResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+
+				versions, err = pipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versions.PinnedVersionIDs).To(BeEmpty())
+			})
+
+			It("can be unpinned", func() {
+				err := pipelineDB.PinResource(resourceName, savedVR.ID, "v2 is broken")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = pipelineDB.UnpinResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+
+				unpinnedResource, _, err := pipelineDB.GetResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(unpinnedResource.PinnedVersionID).To(BeZero())
+				Expect(unpinnedResource.PinnedVersion).To(BeNil())
+				Expect(unpinnedResource.PinComment).To(BeEmpty())
+			})
+
+			It("cannot be pinned to a version of another resource", func() {
+				err := otherPipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name:   resourceName,
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				otherVR, _, err := otherPipelineDB.GetVersionedResourceByVersion(atc.Version{"version": "1"}, resourceName)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = pipelineDB.PinResource(resourceName, otherVR.ID, "")
+				Expect(err).To(Equal(db.ErrResourceVersionNotFound))
+			})
+		})
+
 		Describe("enabling and disabling versioned resources", func() {
 			It("returns an error if the resource or version is bogus", func() {
 				err := pipelineDB.EnableVersionedResource(42)
@@ -1833,7 +2005,7 @@ var _ = Describe("PipelineDB", func() {
 					pipelineConfig.Resources[2],
 				}
 
-				_, _, err := teamDB.SaveConfig("a-pipeline-name", pipelineConfigMinusResource, 1, db.PipelineNoChange)
+				_, _, err := teamDB.SaveConfig("a-pipeline-name", pipelineConfigMinusResource, 1, db.PipelineNoChange, "")
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -2453,6 +2625,269 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Describe("SearchJobBuildLogs", func() {
+			var firstBuild db.Build
+			var secondBuild db.Build
+			var otherJobBuild db.Build
+
+			unitOrigin := event.Origin{
+				ID:     "some-origin-id",
+				Name:   "unit",
+				Source: event.OriginSourceStderr,
+			}
+
+			BeforeEach(func() {
+				var err error
+				firstBuild, err = pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				secondBuild, err = pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				otherJobBuild, err = pipelineDB.CreateJobBuild("some-other-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = firstBuild.SaveEvent(event.Log{
+					Origin:  unitOrigin,
+					Payload: "running tests\npanic: runtime error: invalid memory address or nil pointer dereference\n",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = firstBuild.SaveEvent(event.Log{
+					Origin:  unitOrigin,
+					Payload: "goroutine 1 [running]:\nmain.main()\n",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = secondBuild.SaveEvent(event.Log{
+					Origin:  unitOrigin,
+					Payload: "all tests passed\n",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = otherJobBuild.SaveEvent(event.Log{
+					Origin:  unitOrigin,
+					Payload: "panic: nil pointer dereference\n",
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the job's builds whose logs match with the matching lines", func() {
+				results, err := pipelineDB.SearchJobBuildLogs("some-job", "nil pointer", 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(HaveLen(1))
+
+				Expect(results[0].Build.ID()).To(Equal(firstBuild.ID()))
+				Expect(results[0].Matches).To(Equal([]db.BuildLogMatch{
+					{
+						Origin: unitOrigin,
+						Lines:  []string{"panic: runtime error: invalid memory address or nil pointer dereference"},
+					},
+				}))
+			})
+
+			It("ignores case and punctuation", func() {
+				results, err := pipelineDB.SearchJobBuildLogs("some-job", "PANIC:", 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Build.ID()).To(Equal(firstBuild.ID()))
+			})
+
+			It("returns the newest builds first, up to the limit", func() {
+				err := secondBuild.SaveEvent(event.Log{
+					Origin:  unitOrigin,
+					Payload: "panic: nil pointer dereference\n",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				results, err := pipelineDB.SearchJobBuildLogs("some-job", "panic", 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(HaveLen(2))
+				Expect(results[0].Build.ID()).To(Equal(secondBuild.ID()))
+				Expect(results[1].Build.ID()).To(Equal(firstBuild.ID()))
+
+				results, err = pipelineDB.SearchJobBuildLogs("some-job", "panic", 1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Build.ID()).To(Equal(secondBuild.ID()))
+			})
+
+			It("returns nothing when no logs match", func() {
+				results, err := pipelineDB.SearchJobBuildLogs("some-job", "segfault", 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(BeEmpty())
+			})
+		})
+
+		Describe("Export and Import", func() {
+			var firstBuild db.Build
+			var rerunBuild db.Build
+
+			BeforeEach(func() {
+				someResource, found := pipelineConfig.Resources.Lookup("some-resource")
+				Expect(found).To(BeTrue())
+
+				someOtherResource, found := pipelineConfig.Resources.Lookup("some-other-resource")
+				Expect(found).To(BeTrue())
+
+				err := pipelineDB.SaveResourceVersions(someResource, []atc.Version{{"ref": "v1"}, {"ref": "v2"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = pipelineDB.SaveResourceVersions(someOtherResource, []atc.Version{{"ref": "o1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				versions, _, found, err := pipelineDB.GetResourceVersions("some-resource", db.Page{Limit: 10})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(versions).To(HaveLen(2))
+
+				err = pipelineDB.DisableVersionedResource(versions[1].ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				firstBuild, err = pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pipelineDB.SaveInput(firstBuild.ID(), db.BuildInput{
+					Name: "some-input",
+					VersionedResource: db.VersionedResource{
+						Resource: "some-resource",
+						Type:     "some-type",
+						Version:  db.Version{"ref": "v1"},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pipelineDB.SaveOutput(firstBuild.ID(), db.VersionedResource{
+					Resource: "some-other-resource",
+					Type:     "some-type",
+					Version:  db.Version{"ref": "o2"},
+				}, true)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = firstBuild.Finish(db.StatusFailed)
+				Expect(err).NotTo(HaveOccurred())
+
+				rerunBuild, err = pipelineDB.CreateRerunBuild(firstBuild)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = rerunBuild.Finish(db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("exports the config and versions in check order", func() {
+				archive, err := pipelineDB.Export(false)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(archive.Version).To(Equal(atc.PipelineArchiveVersion))
+				Expect(archive.Config).To(Equal(pipelineConfig))
+				Expect(archive.Builds).To(BeEmpty())
+
+				Expect(archive.Resources).To(HaveLen(len(pipelineConfig.Resources)))
+				Expect(archive.Resources[0]).To(Equal(atc.ArchivedResource{
+					Name: "some-resource",
+					Versions: []atc.ArchivedVersion{
+						{Type: "some-type", Version: atc.Version{"ref": "v1"}, Enabled: false},
+						{Type: "some-type", Version: atc.Version{"ref": "v2"}, Enabled: true},
+					},
+				}))
+				Expect(archive.Resources[1]).To(Equal(atc.ArchivedResource{
+					Name: "some-other-resource",
+					Versions: []atc.ArchivedVersion{
+						{Type: "some-type", Version: atc.Version{"ref": "o1"}, Enabled: true},
+						{Type: "some-type", Version: atc.Version{"ref": "o2"}, Enabled: true},
+					},
+				}))
+			})
+
+			It("exports finished builds with their inputs and outputs", func() {
+				archive, err := pipelineDB.Export(true)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(archive.Builds).To(HaveLen(2))
+
+				Expect(archive.Builds[0].JobName).To(Equal("some-job"))
+				Expect(archive.Builds[0].Name).To(Equal(firstBuild.Name()))
+				Expect(archive.Builds[0].Status).To(Equal(string(db.StatusFailed)))
+				Expect(archive.Builds[0].EndTime).NotTo(BeZero())
+				Expect(archive.Builds[0].RerunOf).To(BeEmpty())
+				Expect(archive.Builds[0].Inputs).To(Equal([]atc.ArchivedBuildInput{
+					{Name: "some-input", Resource: "some-resource", Type: "some-type", Version: atc.Version{"ref": "v1"}},
+				}))
+				Expect(archive.Builds[0].Outputs).To(Equal([]atc.ArchivedBuildOutput{
+					{Resource: "some-other-resource", Type: "some-type", Version: atc.Version{"ref": "o2"}, Explicit: true},
+				}))
+
+				Expect(archive.Builds[1].Name).To(Equal(rerunBuild.Name()))
+				Expect(archive.Builds[1].Status).To(Equal(string(db.StatusSucceeded)))
+				Expect(archive.Builds[1].RerunOf).To(Equal(firstBuild.Name()))
+				Expect(archive.Builds[1].Inputs).To(Equal(archive.Builds[0].Inputs))
+			})
+
+			Context("when imported into another pipeline", func() {
+				var archive atc.PipelineArchive
+				var importedPipelineDB db.PipelineDB
+
+				BeforeEach(func() {
+					var err error
+					archive, err = pipelineDB.Export(true)
+					Expect(err).NotTo(HaveOccurred())
+
+					importedPipeline, _, err := teamDB.SaveConfig("imported-pipeline", archive.Config, 0, db.PipelineUnpaused, "")
+					Expect(err).NotTo(HaveOccurred())
+
+					importedPipelineDB = pipelineDBFactory.Build(importedPipeline)
+
+					err = importedPipelineDB.Import(archive)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("exports the same archive", func() {
+					Expect(importedPipelineDB.Export(true)).To(Equal(archive))
+				})
+
+				It("links reruns to the imported builds", func() {
+					builds, _, err := importedPipelineDB.GetJobBuilds("some-job", db.Page{Limit: 10})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(builds).To(HaveLen(2))
+
+					Expect(builds[0].RerunOf()).To(Equal(builds[1].ID()))
+				})
+
+				It("does not reuse the imported build names", func() {
+					build, err := importedPipelineDB.CreateJobBuild("some-job")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(build.Name()).To(Equal("3"))
+				})
+
+				It("changes nothing when imported again", func() {
+					err := importedPipelineDB.Import(archive)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(importedPipelineDB.Export(true)).To(Equal(archive))
+				})
+
+				It("keeps versions which were found since in the check order", func() {
+					someResource, found := pipelineConfig.Resources.Lookup("some-resource")
+					Expect(found).To(BeTrue())
+
+					err := importedPipelineDB.SaveResourceVersions(someResource, []atc.Version{{"ref": "v3"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					err = importedPipelineDB.Import(archive)
+					Expect(err).NotTo(HaveOccurred())
+
+					reexported, err := importedPipelineDB.Export(false)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(reexported.Resources[0].Versions).To(HaveLen(3))
+					Expect(reexported.Resources[0].Versions[2].Version).To(Equal(atc.Version{"ref": "v3"}))
+				})
+			})
+		})
+
 		Describe("GetNextPendingBuildBySerialGroup", func() {
 			var jobOneConfig atc.JobConfig
 			var jobOneTwoConfig atc.JobConfig
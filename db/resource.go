@@ -16,6 +16,10 @@ type SavedResource struct {
 	Paused       bool
 	PipelineName string
 	Resource
+
+	PinnedVersionID int
+	PinnedVersion   Version
+	PinComment      string
 }
 
 type DashboardResource struct {
@@ -6,8 +6,9 @@ import (
 )
 
 type SQLDB struct {
-	conn Conn
-	bus  *notificationsBus
+	conn            Conn
+	bus             *notificationsBus
+	buildLogArchive BuildLogArchive
 
 	buildFactory *buildFactory
 }
@@ -15,11 +16,13 @@ type SQLDB struct {
 func NewSQL(
 	sqldbConnection Conn,
 	bus *notificationsBus,
+	buildLogArchive BuildLogArchive,
 ) *SQLDB {
 	return &SQLDB{
-		conn:         sqldbConnection,
-		bus:          bus,
-		buildFactory: newBuildFactory(sqldbConnection, bus),
+		conn:            sqldbConnection,
+		bus:             bus,
+		buildLogArchive: buildLogArchive,
+		buildFactory:    newBuildFactory(sqldbConnection, bus, buildLogArchive),
 	}
 }
 
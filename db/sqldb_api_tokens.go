@@ -0,0 +1,27 @@
+package db
+
+import "database/sql"
+
+// UseAPIToken finds the token with the given hash, marking it as used.
+func (db *SQLDB) UseAPIToken(tokenHash string) (APIToken, bool, error) {
+	token, err := scanAPIToken(db.conn.QueryRow(`
+		WITH a AS (
+			UPDATE api_tokens
+			SET last_used_at = now()
+			WHERE token_hash = $1
+			RETURNING id, team_id, name, role, created_at, last_used_at
+		)
+		SELECT `+apiTokenColumns+`
+		FROM a
+		INNER JOIN teams t ON t.id = a.team_id
+	`, tokenHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return APIToken{}, false, nil
+		}
+
+		return APIToken{}, false, err
+	}
+
+	return token, true, nil
+}
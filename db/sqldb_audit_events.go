@@ -0,0 +1,144 @@
+package db
+
+import (
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+func (db *SQLDB) SaveAuditEvent(event AuditEvent) error {
+	params := event.Params
+	if params == nil {
+		params = map[string]string{}
+	}
+
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.conn.Exec(`
+		INSERT INTO audit_events (team_name, is_admin, username, route, method, path, params, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, event.TeamName, event.IsAdmin, event.Username, event.Route, event.Method, event.Path, string(paramsJSON), event.Status)
+	return err
+}
+
+func (db *SQLDB) GetAuditEvents(filter AuditEventFilter, page Page) ([]AuditEvent, Pagination, error) {
+	conditions := sq.Eq{}
+	if filter.TeamName != "" {
+		conditions["team_name"] = filter.TeamName
+	}
+
+	if filter.Username != "" {
+		conditions["username"] = filter.Username
+	}
+
+	if filter.Route != "" {
+		conditions["route"] = filter.Route
+	}
+
+	eventsQuery := sq.Select(
+		"id", "time", "team_name", "is_admin", "username",
+		"route", "method", "path", "params", "status",
+	).From("audit_events")
+
+	boundsQuery := sq.Select(
+		"COALESCE(MAX(id), 0) as maxID",
+		"COALESCE(MIN(id), 0) as minID",
+	).From("audit_events")
+
+	if len(conditions) > 0 {
+		eventsQuery = eventsQuery.Where(conditions)
+		boundsQuery = boundsQuery.Where(conditions)
+	}
+
+	if page.Since == 0 && page.Until == 0 {
+		eventsQuery = eventsQuery.OrderBy("id DESC").Limit(uint64(page.Limit))
+	} else if page.Until != 0 {
+		eventsQuery = eventsQuery.Where(sq.Gt{"id": page.Until}).OrderBy("id ASC").Limit(uint64(page.Limit))
+		eventsQuery = sq.Select("sub.*").FromSelect(eventsQuery, "sub").OrderBy("sub.id DESC")
+	} else {
+		eventsQuery = eventsQuery.Where(sq.Lt{"id": page.Since}).OrderBy("id DESC").Limit(uint64(page.Limit))
+	}
+
+	query, args, err := eventsQuery.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer rows.Close()
+
+	events := []AuditEvent{}
+
+	for rows.Next() {
+		var event AuditEvent
+		var paramsJSON string
+
+		err := rows.Scan(
+			&event.ID,
+			&event.Time,
+			&event.TeamName,
+			&event.IsAdmin,
+			&event.Username,
+			&event.Route,
+			&event.Method,
+			&event.Path,
+			&paramsJSON,
+			&event.Status,
+		)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		err = json.Unmarshal([]byte(paramsJSON), &event.Params)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		events = append(events, event)
+	}
+
+	if len(events) == 0 {
+		return events, Pagination{}, nil
+	}
+
+	query, args, err = boundsQuery.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	var minID int
+	var maxID int
+
+	err = db.conn.QueryRow(query, args...).Scan(&maxID, &minID)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	first := events[0]
+	last := events[len(events)-1]
+
+	var pagination Pagination
+
+	if first.ID < maxID {
+		pagination.Previous = &Page{
+			Until: first.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	if last.ID > minID {
+		pagination.Next = &Page{
+			Since: last.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	return events, pagination, nil
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"strconv"
 	"strings"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 )
@@ -120,6 +121,68 @@ func (db *SQLDB) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 	return err
 }
 
+func (db *SQLDB) GetBuildsToArchive(finishedBefore time.Time, limit int) ([]Build, error) {
+	rows, err := db.conn.Query(`
+		SELECT `+qualifiedBuildColumns+`
+		FROM builds b
+		LEFT OUTER JOIN jobs j ON b.job_id = j.id
+		LEFT OUTER JOIN pipelines p ON j.pipeline_id = p.id
+		LEFT OUTER JOIN teams t ON b.team_id = t.id
+		WHERE b.status NOT IN ('pending', 'started')
+		AND NOT b.archived
+		AND b.reap_time IS NULL
+		AND b.end_time < $1
+		ORDER BY b.id ASC
+		LIMIT $2
+	`, finishedBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	bs := []Build{}
+
+	for rows.Next() {
+		build, _, err := db.buildFactory.ScanBuild(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, build)
+	}
+
+	return bs, nil
+}
+
+func (db *SQLDB) MarkBuildArchived(buildID int) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		DELETE FROM build_events
+		WHERE build_id = $1
+	`, buildID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE builds
+		SET archived = true
+		WHERE id = $1
+	`, buildID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (db *SQLDB) FindLatestSuccessfulBuildsPerJob() (map[int]int, error) {
 	rows, err := db.conn.Query(
 		`SELECT max(id), job_id
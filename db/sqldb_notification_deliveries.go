@@ -0,0 +1,81 @@
+package db
+
+func (db *SQLDB) CreateNotificationDelivery(delivery NotificationDelivery) error {
+	_, err := db.conn.Exec(`
+		INSERT INTO notification_deliveries (build_id, hook_name, url, payload, signature)
+		VALUES ($1, $2, $3, $4, $5)
+	`, delivery.BuildID, delivery.HookName, delivery.URL, delivery.Payload, delivery.Signature)
+	return err
+}
+
+func (db *SQLDB) GetPendingNotificationDeliveries() ([]SavedNotificationDelivery, error) {
+	rows, err := db.conn.Query(`
+		SELECT id, status, attempts, build_id, hook_name, url, payload, signature
+		FROM notification_deliveries
+		WHERE status = $1
+		AND next_attempt_at <= now()
+		ORDER BY id ASC
+	`, string(NotificationDeliveryStatusPending))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	deliveries := []SavedNotificationDelivery{}
+
+	for rows.Next() {
+		var delivery SavedNotificationDelivery
+		var status string
+
+		err := rows.Scan(
+			&delivery.ID,
+			&status,
+			&delivery.Attempts,
+			&delivery.BuildID,
+			&delivery.HookName,
+			&delivery.URL,
+			&delivery.Payload,
+			&delivery.Signature,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		delivery.Status = NotificationDeliveryStatus(status)
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+func (db *SQLDB) SaveNotificationDeliveryAttempt(deliveryID int, attempt NotificationDeliveryAttempt) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO notification_delivery_attempts (delivery_id, response_status, error)
+		VALUES ($1, $2, $3)
+	`, deliveryID, attempt.ResponseStatus, attempt.Error)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE notification_deliveries
+		SET status = $1,
+			attempts = attempts + 1,
+			next_attempt_at = now() + ($2 || ' SECONDS')::INTERVAL
+		WHERE id = $3
+	`, string(attempt.Status), attempt.RetryAfter.Seconds(), deliveryID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
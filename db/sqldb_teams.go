@@ -9,7 +9,7 @@ import (
 
 func (db *SQLDB) GetTeams() ([]SavedTeam, error) {
 	rows, err := db.conn.Query(`
-		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth FROM teams
+		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, notification_hooks, roles FROM teams
 	`)
 	if err != nil {
 		return nil, err
@@ -79,18 +79,38 @@ func (db *SQLDB) CreateTeam(team Team) (SavedTeam, error) {
 		return SavedTeam{}, err
 	}
 
+	jsonEncodedOIDCAuth, err := json.Marshal(team.OIDCAuth)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	jsonEncodedLDAPAuth, err := json.Marshal(team.LDAPAuth)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	jsonEncodedNotificationHooks, err := json.Marshal(team.NotificationHooks)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	jsonEncodedRoles, err := json.Marshal(team.Roles)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
 	return scanTeam(db.conn.QueryRow(`
 	INSERT INTO teams (
-    name, basic_auth, github_auth, uaa_auth, genericoauth_auth
+    name, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, notification_hooks, roles
 	) VALUES (
-		$1, $2, $3, $4, $5
+		$1, $2, $3, $4, $5, $6, $7, $8, $9
 	)
-	RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
-	`, team.Name, jsonEncodedBasicAuth, string(jsonEncodedGitHubAuth), string(jsonEncodedUAAAuth), string(jsonEncodedGenericOAuth)))
+	RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, notification_hooks, roles
+	`, team.Name, jsonEncodedBasicAuth, string(jsonEncodedGitHubAuth), string(jsonEncodedUAAAuth), string(jsonEncodedGenericOAuth), string(jsonEncodedOIDCAuth), string(jsonEncodedLDAPAuth), string(jsonEncodedNotificationHooks), string(jsonEncodedRoles)))
 }
 
 func scanTeam(rows scannable) (SavedTeam, error) {
-	var basicAuth, gitHubAuth, uaaAuth, genericOAuth sql.NullString
+	var basicAuth, gitHubAuth, uaaAuth, genericOAuth, oidcAuth, ldapAuth, notificationHooks, roles sql.NullString
 	var savedTeam SavedTeam
 
 	err := rows.Scan(
@@ -101,6 +121,10 @@ func scanTeam(rows scannable) (SavedTeam, error) {
 		&gitHubAuth,
 		&uaaAuth,
 		&genericOAuth,
+		&oidcAuth,
+		&ldapAuth,
+		&notificationHooks,
+		&roles,
 	)
 	if err != nil {
 		return savedTeam, err
@@ -134,6 +158,34 @@ func scanTeam(rows scannable) (SavedTeam, error) {
 		}
 	}
 
+	if oidcAuth.Valid {
+		err = json.Unmarshal([]byte(oidcAuth.String), &savedTeam.OIDCAuth)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
+	if ldapAuth.Valid {
+		err = json.Unmarshal([]byte(ldapAuth.String), &savedTeam.LDAPAuth)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
+	if notificationHooks.Valid {
+		err = json.Unmarshal([]byte(notificationHooks.String), &savedTeam.NotificationHooks)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
+	if roles.Valid {
+		err = json.Unmarshal([]byte(roles.String), &savedTeam.Roles)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
 	return savedTeam, nil
 }
 
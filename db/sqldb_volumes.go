@@ -79,6 +79,19 @@ func (db *SQLDB) InsertVolume(data Volume) error {
 		columns = append(columns, "replicated_from")
 		params = append(params, data.Identifier.Replication.ReplicatedVolumeHandle)
 		values = append(values, fmt.Sprintf("$%d", len(params)))
+
+	case data.Identifier.TaskCache != nil:
+		columns = append(columns, "task_cache_job_id")
+		params = append(params, data.Identifier.TaskCache.BuildID)
+		values = append(values, fmt.Sprintf("(SELECT job_id FROM builds WHERE id = $%d)", len(params)))
+
+		columns = append(columns, "task_cache_step_name")
+		params = append(params, data.Identifier.TaskCache.StepName)
+		values = append(values, fmt.Sprintf("$%d", len(params)))
+
+		columns = append(columns, "task_cache_path")
+		params = append(params, data.Identifier.TaskCache.Path)
+		values = append(values, fmt.Sprintf("$%d", len(params)))
 	}
 
 	_, err = tx.Exec(
@@ -132,6 +145,9 @@ func (db *SQLDB) GetVolumes() ([]SavedVolume, error) {
 			v.replicated_from,
 			v.path,
 			v.host_path_version,
+			v.task_cache_job_id,
+			v.task_cache_step_name,
+			v.task_cache_path,
 			v.size_in_bytes,
 			c.ttl,
 			v.team_id
@@ -179,6 +195,12 @@ func (db *SQLDB) GetVolumesByIdentifier(id VolumeIdentifier) ([]SavedVolume, err
 		}
 	case id.Replication != nil:
 		addParam("replicated_from", id.Replication.ReplicatedVolumeHandle)
+	case id.TaskCache != nil:
+		params = append(params, id.TaskCache.BuildID)
+		conditions = append(conditions, fmt.Sprintf("v.task_cache_job_id = (SELECT job_id FROM builds WHERE id = $%d)", len(params)))
+		addParam("task_cache_step_name", id.TaskCache.StepName)
+		addParam("task_cache_path", id.TaskCache.Path)
+		addParam("worker_name", id.TaskCache.WorkerName)
 	}
 
 	statement := `
@@ -195,6 +217,9 @@ func (db *SQLDB) GetVolumesByIdentifier(id VolumeIdentifier) ([]SavedVolume, err
 			v.replicated_from,
 			v.path,
 			v.host_path_version,
+			v.task_cache_job_id,
+			v.task_cache_step_name,
+			v.task_cache_path,
 			v.size_in_bytes,
 			c.ttl,
 			v.team_id
@@ -238,6 +263,9 @@ func (db *SQLDB) GetVolumesForOneOffBuildImageResources() ([]SavedVolume, error)
 			v.replicated_from,
 			v.path,
 			v.host_path_version,
+			v.task_cache_job_id,
+			v.task_cache_step_name,
+			v.task_cache_path,
 			v.size_in_bytes,
 			c.ttl,
 			v.team_id
@@ -332,6 +360,9 @@ func scanVolumes(rows *sql.Rows) ([]SavedVolume, error) {
 			replicationName      sql.NullString
 			path                 sql.NullString
 			hostPathVersion      sql.NullString
+			taskCacheJobID       sql.NullInt64
+			taskCacheStepName    sql.NullString
+			taskCachePath        sql.NullString
 			teamID               sql.NullInt64
 		)
 
@@ -348,6 +379,9 @@ func scanVolumes(rows *sql.Rows) ([]SavedVolume, error) {
 			&replicationName,
 			&path,
 			&hostPathVersion,
+			&taskCacheJobID,
+			&taskCacheStepName,
+			&taskCachePath,
 			&volume.SizeInBytes,
 			&volume.ContainerTTL,
 			&teamID,
@@ -394,6 +428,13 @@ func scanVolumes(rows *sql.Rows) ([]SavedVolume, error) {
 				WorkerName: volume.WorkerName,
 				Version:    &hostPathVersion.String,
 			}
+		case taskCacheJobID.Valid:
+			volume.Volume.Identifier.TaskCache = &TaskCacheIdentifier{
+				JobID:      int(taskCacheJobID.Int64),
+				StepName:   taskCacheStepName.String,
+				Path:       taskCachePath.String,
+				WorkerName: volume.WorkerName,
+			}
 		}
 
 		volumes = append(volumes, volume)
@@ -8,11 +8,11 @@ import (
 	"time"
 )
 
-var workerColumns = "EXTRACT(epoch FROM expires - NOW()), addr, baggageclaim_url, http_proxy_url, https_proxy_url, no_proxy, active_containers, resource_types, platform, tags, w.name as name, start_time, t.name as team_name, team_id"
-var actualWorkerColumns = "EXTRACT(epoch FROM expires - NOW()), addr, baggageclaim_url, http_proxy_url, https_proxy_url, no_proxy, active_containers, resource_types, platform, tags, name, start_time"
+var workerColumns = "EXTRACT(epoch FROM expires - NOW()), addr, baggageclaim_url, http_proxy_url, https_proxy_url, no_proxy, active_containers, resource_types, platform, tags, w.name as name, start_time, w.state, t.name as team_name, team_id"
+var actualWorkerColumns = "EXTRACT(epoch FROM expires - NOW()), addr, baggageclaim_url, http_proxy_url, https_proxy_url, no_proxy, active_containers, resource_types, platform, tags, name, start_time, state"
 
 func (db *SQLDB) Workers() ([]SavedWorker, error) {
-	err := reapExpiredWorkers(db.conn)
+	err := updateWorkerStates(db.conn)
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +62,7 @@ func (db *SQLDB) FindWorkerCheckResourceTypeVersion(workerName string, checkType
 }
 
 func (db *SQLDB) GetWorker(name string) (SavedWorker, bool, error) {
-	err := reapExpiredWorkers(db.conn)
+	err := updateWorkerStates(db.conn)
 	if err != nil {
 		return SavedWorker{}, false, err
 	}
@@ -105,9 +105,32 @@ func (db *SQLDB) SaveWorker(info WorkerInfo, ttl time.Duration) (SavedWorker, er
 		teamID = &info.TeamID
 	}
 
+	// a retiring worker must not be brought back by its own heartbeats
+	var retiring bool
+	err = db.conn.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM workers
+			WHERE (name = $1 OR addr = $2)
+			AND state IN ('retiring', 'retired')
+		)
+	`, info.Name, info.GardenAddr).Scan(&retiring)
+	if err != nil {
+		return SavedWorker{}, err
+	}
+
+	if retiring {
+		return SavedWorker{}, ErrWorkerRetiring
+	}
+
 	row := db.conn.QueryRow(`
   		UPDATE workers
-      SET addr = $1, expires = `+expires+`, active_containers = $2, resource_types = $3, platform = $4, tags = $5, baggageclaim_url = $6, http_proxy_url = $7, https_proxy_url = $8, no_proxy = $9, name = $10, start_time = $11, team_id = $12
+      SET addr = $1, expires = `+expires+`, active_containers = $2, resource_types = $3, platform = $4, tags = $5, baggageclaim_url = $6, http_proxy_url = $7, https_proxy_url = $8, no_proxy = $9, name = $10, start_time = $11, team_id = $12,
+				state = CASE
+					WHEN state = 'stalled' THEN 'running'
+					WHEN state = 'landed' AND start_time <> $11 THEN 'running'
+					ELSE state
+				END
 			WHERE name = $10 OR addr = $1
 			RETURNING  `+actualWorkerColumns,
 		info.GardenAddr, info.ActiveContainers, resourceTypes, info.Platform, tags, info.BaggageclaimURL, info.HTTPProxyURL, info.HTTPSProxyURL, info.NoProxy, info.Name, info.StartTime, teamID)
@@ -129,21 +152,163 @@ func (db *SQLDB) SaveWorker(info WorkerInfo, ttl time.Duration) (SavedWorker, er
 	return savedWorker, nil
 }
 
-func reapExpiredWorkers(dbConn Conn) error {
-	_, err := dbConn.Exec(`
+// LandWorker stops new containers from being placed on the worker. It will be
+// landed once the builds running on it have finished, and will run again once
+// it registers with a new start time.
+func (db *SQLDB) LandWorker(name string) (bool, error) {
+	return db.transitionWorker(name, WorkerStateLanding, WorkerStateRunning)
+}
+
+// RetireWorker stops new containers from being placed on the worker. It will
+// be retired once the builds running on it have finished, and its heartbeats
+// are refused from then on.
+func (db *SQLDB) RetireWorker(name string) (bool, error) {
+	return db.transitionWorker(name, WorkerStateRetiring, WorkerStateRunning, WorkerStateLanding, WorkerStateLanded)
+}
+
+// PruneWorker removes a worker which has stalled, landed or retired.
+func (db *SQLDB) PruneWorker(name string) (bool, error) {
+	err := updateWorkerStates(db.conn)
+	if err != nil {
+		return false, err
+	}
+
+	state, found, err := db.workerState(name)
+	if err != nil {
+		return false, err
+	}
+
+	if !found {
+		return false, nil
+	}
+
+	if state != WorkerStateStalled && state != WorkerStateLanded && state != WorkerStateRetired {
+		return true, ErrCannotPruneRunningWorker
+	}
+
+	_, err = db.conn.Exec(`
 		DELETE FROM workers
+		WHERE name = $1
+		AND state = $2
+	`, name, string(state))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// transitionWorker moves the worker to the given state if it is currently in
+// one of the from states. Workers in any other state are left alone, as they
+// are already on their way out, unless they have stalled.
+func (db *SQLDB) transitionWorker(name string, to WorkerState, from ...WorkerState) (bool, error) {
+	err := updateWorkerStates(db.conn)
+	if err != nil {
+		return false, err
+	}
+
+	state, found, err := db.workerState(name)
+	if err != nil {
+		return false, err
+	}
+
+	if !found {
+		return false, nil
+	}
+
+	if state == WorkerStateStalled {
+		return true, ErrWorkerStalled
+	}
+
+	for _, fromState := range from {
+		if state != fromState {
+			continue
+		}
+
+		_, err = db.conn.Exec(`
+			UPDATE workers
+			SET state = $1
+			WHERE name = $2
+			AND state = $3
+		`, string(to), name, string(state))
+		if err != nil {
+			return false, err
+		}
+
+		break
+	}
+
+	return true, nil
+}
+
+func (db *SQLDB) workerState(name string) (WorkerState, bool, error) {
+	var state string
+	err := db.conn.QueryRow(`
+		SELECT state
+		FROM workers
+		WHERE name = $1
+	`, name).Scan(&state)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, nil
+		}
+
+		return "", false, err
+	}
+
+	return WorkerState(state), true, nil
+}
+
+// updateWorkerStates marks workers which have missed their heartbeat as
+// stalled, and lands landing workers and retires retiring workers once they
+// have no running builds. Retiring workers do not heartbeat, so they never
+// stall.
+func updateWorkerStates(dbConn Conn) error {
+	_, err := dbConn.Exec(`
+		UPDATE workers
+		SET state = 'stalled', expires = NULL
 		WHERE expires IS NOT NULL
 		AND expires < NOW()
+		AND state IN ('running', 'landing', 'landed')
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = dbConn.Exec(`
+		UPDATE workers
+		SET state = 'landed'
+		WHERE state = 'landing'
+		AND NOT EXISTS (` + runningBuildContainers + `)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = dbConn.Exec(`
+		UPDATE workers
+		SET state = 'retired', expires = NULL
+		WHERE state = 'retiring'
+		AND NOT EXISTS (` + runningBuildContainers + `)
 	`)
 	return err
 }
 
+const runningBuildContainers = `
+	SELECT 1
+	FROM containers c
+	JOIN builds b ON b.id = c.build_id
+	WHERE c.worker_name = workers.name
+	AND b.status IN ('pending', 'started')
+`
+
 func scanWorker(row scannable, scanTeam bool) (SavedWorker, error) {
 	info := SavedWorker{}
 
 	var ttlSeconds *float64
 	var resourceTypes []byte
 	var tags []byte
+	var state string
 
 	var httpProxyURL sql.NullString
 	var httpsProxyURL sql.NullString
@@ -153,14 +318,16 @@ func scanWorker(row scannable, scanTeam bool) (SavedWorker, error) {
 	var err error
 
 	if scanTeam {
-		err = row.Scan(&ttlSeconds, &info.GardenAddr, &info.BaggageclaimURL, &httpProxyURL, &httpsProxyURL, &noProxy, &info.ActiveContainers, &resourceTypes, &info.Platform, &tags, &info.Name, &info.StartTime, &teamName, &teamID)
+		err = row.Scan(&ttlSeconds, &info.GardenAddr, &info.BaggageclaimURL, &httpProxyURL, &httpsProxyURL, &noProxy, &info.ActiveContainers, &resourceTypes, &info.Platform, &tags, &info.Name, &info.StartTime, &state, &teamName, &teamID)
 	} else {
-		err = row.Scan(&ttlSeconds, &info.GardenAddr, &info.BaggageclaimURL, &httpProxyURL, &httpsProxyURL, &noProxy, &info.ActiveContainers, &resourceTypes, &info.Platform, &tags, &info.Name, &info.StartTime)
+		err = row.Scan(&ttlSeconds, &info.GardenAddr, &info.BaggageclaimURL, &httpProxyURL, &httpsProxyURL, &noProxy, &info.ActiveContainers, &resourceTypes, &info.Platform, &tags, &info.Name, &info.StartTime, &state)
 	}
 	if err != nil {
 		return SavedWorker{}, err
 	}
 
+	info.State = WorkerState(state)
+
 	if ttlSeconds != nil {
 		info.ExpiresIn = time.Duration(*ttlSeconds) * time.Second
 	}
@@ -3,6 +3,8 @@ package db
 import (
 	"encoding/json"
 
+	"github.com/concourse/atc"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -14,10 +16,45 @@ type Team struct {
 	GitHubAuth   *GitHubAuth   `json:"github_auth"`
 	UAAAuth      *UAAAuth      `json:"uaa_auth"`
 	GenericOAuth *GenericOAuth `json:"genericoauth_auth"`
+	OIDCAuth     *OIDCAuth     `json:"oidc_auth"`
+	LDAPAuth     *LDAPAuth     `json:"ldap_auth"`
+
+	NotificationHooks []atc.NotificationHook `json:"notification_hooks"`
+
+	Roles []atc.TeamRoleMapping `json:"roles"`
 }
 
 func (t Team) IsAuthConfigured() bool {
-	return t.BasicAuth != nil || t.GitHubAuth != nil || t.UAAAuth != nil
+	return t.BasicAuth != nil || t.GitHubAuth != nil || t.UAAAuth != nil || t.OIDCAuth != nil || t.LDAPAuth != nil
+}
+
+// DefaultRole is the role of authenticated users that no role mapping
+// applies to.
+func (t Team) DefaultRole() atc.TeamRole {
+	if len(t.Roles) == 0 {
+		return atc.RoleOwner
+	}
+
+	return atc.RoleViewer
+}
+
+// BasicAuthUserRole returns the highest role mapped to the basic auth user.
+func (t Team) BasicAuthUserRole(username string) atc.TeamRole {
+	for _, role := range atc.TeamRoles {
+		for _, mapping := range t.Roles {
+			if mapping.Role != role {
+				continue
+			}
+
+			for _, user := range mapping.BasicAuthUsers {
+				if user == username {
+					return role
+				}
+			}
+		}
+	}
+
+	return t.DefaultRole()
 }
 
 type BasicAuth struct {
@@ -81,3 +118,45 @@ type GenericOAuth struct {
 	ClientSecret  string            `json:"client_secret"`
 	DisplayName   string            `json:"display_name"`
 }
+
+type OIDCAuth struct {
+	DisplayName  string            `json:"display_name"`
+	Issuer       string            `json:"issuer"`
+	ClientID     string            `json:"client_id"`
+	ClientSecret string            `json:"client_secret"`
+	Scopes       []string          `json:"scopes"`
+	Groups       []string          `json:"groups"`
+	GroupsClaim  string            `json:"groups_claim"`
+	Users        []string          `json:"users"`
+	UserClaim    string            `json:"user_claim"`
+	Claims       map[string]string `json:"claims"`
+	CACert       string            `json:"ca_cert"`
+}
+
+type LDAPAuth struct {
+	DisplayName        string          `json:"display_name"`
+	Host               string          `json:"host"`
+	InsecureNoSSL      bool            `json:"insecure_no_ssl"`
+	StartTLS           bool            `json:"start_tls"`
+	InsecureSkipVerify bool            `json:"insecure_skip_verify"`
+	CACert             string          `json:"ca_cert"`
+	BindDN             string          `json:"bind_dn"`
+	BindPassword       string          `json:"bind_password"`
+	UserSearch         LDAPUserSearch  `json:"user_search"`
+	GroupSearch        LDAPGroupSearch `json:"group_search"`
+	Groups             []string        `json:"groups"`
+}
+
+type LDAPUserSearch struct {
+	BaseDN            string `json:"base_dn"`
+	Filter            string `json:"filter"`
+	UsernameAttribute string `json:"username_attribute"`
+}
+
+type LDAPGroupSearch struct {
+	BaseDN         string `json:"base_dn"`
+	Filter         string `json:"filter"`
+	UserAttribute  string `json:"user_attribute"`
+	GroupAttribute string `json:"group_attribute"`
+	NameAttribute  string `json:"name_attribute"`
+}
@@ -27,9 +27,23 @@ type TeamDB interface {
 	UpdateGitHubAuth(gitHubAuth *GitHubAuth) (SavedTeam, error)
 	UpdateUAAAuth(uaaAuth *UAAAuth) (SavedTeam, error)
 	UpdateGenericOAuth(genericOAuth *GenericOAuth) (SavedTeam, error)
+	UpdateOIDCAuth(oidcAuth *OIDCAuth) (SavedTeam, error)
+	UpdateLDAPAuth(ldapAuth *LDAPAuth) (SavedTeam, error)
+	UpdateNotificationHooks(notificationHooks []atc.NotificationHook) (SavedTeam, error)
+	UpdateRoles(roles []atc.TeamRoleMapping) (SavedTeam, error)
 
 	GetConfig(pipelineName string) (atc.Config, atc.RawConfig, ConfigVersion, error)
-	SaveConfig(string, atc.Config, ConfigVersion, PipelinePausedState) (SavedPipeline, bool, error)
+	SaveConfig(pipelineName string, config atc.Config, from ConfigVersion, pausedState PipelinePausedState, savedBy string) (SavedPipeline, bool, error)
+
+	GetConfigTemplate(pipelineName string) (ConfigTemplate, bool, error)
+	SaveConfigFromTemplate(pipelineName string, config atc.Config, template ConfigTemplate, from ConfigVersion, pausedState PipelinePausedState, savedBy string) (SavedPipeline, bool, error)
+
+	GetConfigRevisions(pipelineName string) ([]ConfigRevision, bool, error)
+	GetConfigRevision(pipelineName string, revisionID int) (ConfigRevision, bool, error)
+
+	CreateAPIToken(name string, role atc.TeamRole, tokenHash string) (APIToken, error)
+	GetAPITokens() ([]APIToken, error)
+	DeleteAPIToken(name string) (bool, error)
 
 	CreateOneOffBuild() (Build, error)
 	GetPrivateAndPublicBuilds(page Page) ([]Build, Pagination, error)
@@ -230,11 +244,79 @@ func (db *teamDB) GetConfig(pipelineName string) (atc.Config, atc.RawConfig, Con
 	return config, atc.RawConfig(string(configBlob)), ConfigVersion(version), nil
 }
 
+func (db *teamDB) GetConfigTemplate(pipelineName string) (ConfigTemplate, bool, error) {
+	var template, vars sql.NullString
+	err := db.conn.QueryRow(`
+		SELECT config_template, config_vars
+		FROM pipelines
+		WHERE name = $1 AND team_id = (
+			SELECT id
+			FROM teams
+			WHERE LOWER(name) = LOWER($2)
+		)
+	`, pipelineName, db.teamName).Scan(&template, &vars)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ConfigTemplate{}, false, nil
+		}
+		return ConfigTemplate{}, false, err
+	}
+
+	if !template.Valid {
+		return ConfigTemplate{}, false, nil
+	}
+
+	configTemplate := ConfigTemplate{
+		Template: atc.RawConfig(template.String),
+	}
+
+	if vars.Valid {
+		err = json.Unmarshal([]byte(vars.String), &configTemplate.Vars)
+		if err != nil {
+			return ConfigTemplate{}, false, err
+		}
+	}
+
+	return configTemplate, true, nil
+}
+
 func (db *teamDB) SaveConfig(
 	pipelineName string,
 	config atc.Config,
 	from ConfigVersion,
 	pausedState PipelinePausedState,
+	savedBy string,
+) (SavedPipeline, bool, error) {
+	return db.saveConfig(pipelineName, config, nil, nil, from, pausedState, savedBy)
+}
+
+func (db *teamDB) SaveConfigFromTemplate(
+	pipelineName string,
+	config atc.Config,
+	template ConfigTemplate,
+	from ConfigVersion,
+	pausedState PipelinePausedState,
+	savedBy string,
+) (SavedPipeline, bool, error) {
+	varsPayload, err := json.Marshal(template.Vars)
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
+	return db.saveConfig(pipelineName, config, string(template.Template), string(varsPayload), from, pausedState, savedBy)
+}
+
+// saveConfig stores the rendered config along with the template and vars it
+// was rendered from, which are nil when the config was not a template. Every
+// save is also kept as a revision of the pipeline's config.
+func (db *teamDB) saveConfig(
+	pipelineName string,
+	config atc.Config,
+	template interface{},
+	vars interface{},
+	from ConfigVersion,
+	pausedState PipelinePausedState,
+	savedBy string,
 ) (SavedPipeline, bool, error) {
 	payload, err := json.Marshal(config)
 	if err != nil {
@@ -274,20 +356,22 @@ func (db *teamDB) SaveConfig(
 		}
 
 		savedPipeline, err = scanPipeline(tx.QueryRow(`
-		INSERT INTO pipelines (name, config, version, ordering, paused, team_id)
+		INSERT INTO pipelines (name, config, version, ordering, paused, team_id, config_template, config_vars)
 		VALUES (
 			$1,
 			$2,
 			nextval('config_version_seq'),
 			(SELECT COUNT(1) + 1 FROM pipelines),
 			$3,
-			$4
+			$4,
+			$5,
+			$6
 		)
 		RETURNING `+unqualifiedPipelineColumns+`,
 		(
 			SELECT t.name as team_name FROM teams t WHERE t.id = $4
 		)
-		`, pipelineName, payload, pausedState.Bool(), teamID))
+		`, pipelineName, payload, pausedState.Bool(), teamID, template, vars))
 		if err != nil {
 			return SavedPipeline{}, false, err
 		}
@@ -315,11 +399,20 @@ func (db *teamDB) SaveConfig(
 		if err != nil {
 			return SavedPipeline{}, false, err
 		}
+
+		_, err = tx.Exec(fmt.Sprintf(`
+		CREATE INDEX pipeline_build_events_%[1]d_log_search ON pipeline_build_events_%[1]d
+		USING gin (to_tsvector('simple', payload::json->>'payload'))
+		WHERE type = 'log';
+		`, savedPipeline.ID))
+		if err != nil {
+			return SavedPipeline{}, false, err
+		}
 	} else {
 		if pausedState == PipelineNoChange {
 			savedPipeline, err = scanPipeline(tx.QueryRow(`
 			UPDATE pipelines
-			SET config = $1, version = nextval('config_version_seq')
+			SET config = $1, version = nextval('config_version_seq'), config_template = $5, config_vars = $6
 			WHERE name = $2
 			AND version = $3
 			AND team_id = $4
@@ -327,11 +420,11 @@ func (db *teamDB) SaveConfig(
 			(
 				SELECT t.name as team_name FROM teams t WHERE t.id = $4
 			)
-			`, payload, pipelineName, from, teamID))
+			`, payload, pipelineName, from, teamID, template, vars))
 		} else {
 			savedPipeline, err = scanPipeline(tx.QueryRow(`
 			UPDATE pipelines
-			SET config = $1, version = nextval('config_version_seq'), paused = $2
+			SET config = $1, version = nextval('config_version_seq'), paused = $2, config_template = $6, config_vars = $7
 			WHERE name = $3
 			AND version = $4
 			AND team_id = $5
@@ -339,7 +432,7 @@ func (db *teamDB) SaveConfig(
 			(
 				SELECT t.name as team_name FROM teams t WHERE t.id = $4
 			)
-			`, payload, pausedState.Bool(), pipelineName, from, teamID))
+			`, payload, pausedState.Bool(), pipelineName, from, teamID, template, vars))
 		}
 
 		if err != nil && err != sql.ErrNoRows {
@@ -391,6 +484,14 @@ func (db *teamDB) SaveConfig(
 		}
 	}
 
+	_, err = tx.Exec(`
+		INSERT INTO pipeline_config_revisions (pipeline_id, version, config, config_template, config_vars, saved_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, savedPipeline.ID, savedPipeline.Version, payload, template, vars, savedBy)
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
 	return savedPipeline, created, tx.Commit()
 }
 
@@ -451,7 +552,7 @@ func (db *teamDB) registerResourceType(tx Tx, resourceType atc.ResourceType, pip
 
 func (db *teamDB) GetTeam() (SavedTeam, bool, error) {
 	query := `
-		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, notification_hooks, roles
 		FROM teams
 		WHERE LOWER(name) = LOWER($1)
 	`
@@ -469,7 +570,7 @@ func (db *teamDB) GetTeam() (SavedTeam, bool, error) {
 }
 
 func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, error) {
-	var basicAuth, gitHubAuth, uaaAuth, genericOAuth sql.NullString
+	var basicAuth, gitHubAuth, uaaAuth, genericOAuth, oidcAuth, ldapAuth, notificationHooks, roles sql.NullString
 	var savedTeam SavedTeam
 
 	tx, err := db.conn.Begin()
@@ -486,6 +587,10 @@ func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, erro
 		&gitHubAuth,
 		&uaaAuth,
 		&genericOAuth,
+		&oidcAuth,
+		&ldapAuth,
+		&notificationHooks,
+		&roles,
 	)
 	if err != nil {
 		return savedTeam, err
@@ -523,6 +628,34 @@ func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, erro
 		}
 	}
 
+	if oidcAuth.Valid {
+		err = json.Unmarshal([]byte(oidcAuth.String), &savedTeam.OIDCAuth)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
+	if ldapAuth.Valid {
+		err = json.Unmarshal([]byte(ldapAuth.String), &savedTeam.LDAPAuth)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
+	if notificationHooks.Valid {
+		err = json.Unmarshal([]byte(notificationHooks.String), &savedTeam.NotificationHooks)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
+	if roles.Valid {
+		err = json.Unmarshal([]byte(roles.String), &savedTeam.Roles)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
 	return savedTeam, nil
 }
 
@@ -536,7 +669,7 @@ func (db *teamDB) UpdateBasicAuth(basicAuth *BasicAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET basic_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, notification_hooks, roles
 	`
 
 	params := []interface{}{encryptedBasicAuth, db.teamName}
@@ -558,7 +691,7 @@ func (db *teamDB) UpdateGitHubAuth(gitHubAuth *GitHubAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET github_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, notification_hooks, roles
 	`
 	params := []interface{}{string(jsonEncodedGitHubAuth), db.teamName}
 	return db.queryTeam(query, params)
@@ -574,7 +707,7 @@ func (db *teamDB) UpdateUAAAuth(uaaAuth *UAAAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET uaa_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, notification_hooks, roles
 	`
 	params := []interface{}{string(jsonEncodedUAAAuth), db.teamName}
 	return db.queryTeam(query, params)
@@ -590,12 +723,76 @@ func (db *teamDB) UpdateGenericOAuth(genericOAuth *GenericOAuth) (SavedTeam, err
 		UPDATE teams
 		SET genericoauth_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, notification_hooks, roles
 	`
 	params := []interface{}{string(jsonEncodedGenericOAuth), db.teamName}
 	return db.queryTeam(query, params)
 }
 
+func (db *teamDB) UpdateOIDCAuth(oidcAuth *OIDCAuth) (SavedTeam, error) {
+	jsonEncodedOIDCAuth, err := json.Marshal(oidcAuth)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	query := `
+		UPDATE teams
+		SET oidc_auth = $1
+		WHERE LOWER(name) = LOWER($2)
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, notification_hooks, roles
+	`
+	params := []interface{}{string(jsonEncodedOIDCAuth), db.teamName}
+	return db.queryTeam(query, params)
+}
+
+func (db *teamDB) UpdateLDAPAuth(ldapAuth *LDAPAuth) (SavedTeam, error) {
+	jsonEncodedLDAPAuth, err := json.Marshal(ldapAuth)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	query := `
+		UPDATE teams
+		SET ldap_auth = $1
+		WHERE LOWER(name) = LOWER($2)
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, notification_hooks, roles
+	`
+	params := []interface{}{string(jsonEncodedLDAPAuth), db.teamName}
+	return db.queryTeam(query, params)
+}
+
+func (db *teamDB) UpdateNotificationHooks(notificationHooks []atc.NotificationHook) (SavedTeam, error) {
+	jsonEncodedNotificationHooks, err := json.Marshal(notificationHooks)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	query := `
+		UPDATE teams
+		SET notification_hooks = $1
+		WHERE LOWER(name) = LOWER($2)
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, notification_hooks, roles
+	`
+	params := []interface{}{string(jsonEncodedNotificationHooks), db.teamName}
+	return db.queryTeam(query, params)
+}
+
+func (db *teamDB) UpdateRoles(roles []atc.TeamRoleMapping) (SavedTeam, error) {
+	jsonEncodedRoles, err := json.Marshal(roles)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	query := `
+		UPDATE teams
+		SET roles = $1
+		WHERE LOWER(name) = LOWER($2)
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, notification_hooks, roles
+	`
+	params := []interface{}{string(jsonEncodedRoles), db.teamName}
+	return db.queryTeam(query, params)
+}
+
 func (db *teamDB) CreateOneOffBuild() (Build, error) {
 	tx, err := db.conn.Begin()
 	if err != nil {
@@ -631,7 +828,7 @@ func (db *teamDB) CreateOneOffBuild() (Build, error) {
 }
 
 func (db *teamDB) Workers() ([]SavedWorker, error) {
-	err := reapExpiredWorkers(db.conn)
+	err := updateWorkerStates(db.conn)
 	if err != nil {
 		return nil, err
 	}
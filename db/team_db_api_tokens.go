@@ -0,0 +1,110 @@
+package db
+
+import (
+	"github.com/concourse/atc"
+	"github.com/lib/pq"
+)
+
+const apiTokenColumns = "a.id, a.team_id, t.name, t.admin, a.name, a.role, a.created_at, a.last_used_at"
+
+func (db *teamDB) CreateAPIToken(name string, role atc.TeamRole, tokenHash string) (APIToken, error) {
+	token, err := scanAPIToken(db.conn.QueryRow(`
+		WITH a AS (
+			INSERT INTO api_tokens (team_id, name, role, token_hash)
+			SELECT id, $2, $3, $4
+			FROM teams
+			WHERE LOWER(name) = LOWER($1)
+			RETURNING id, team_id, name, role, created_at, last_used_at
+		)
+		SELECT `+apiTokenColumns+`
+		FROM a
+		INNER JOIN teams t ON t.id = a.team_id
+	`, db.teamName, name, string(role), tokenHash))
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code.Name() == "unique_violation" {
+			return APIToken{}, ErrAPITokenAlreadyExists
+		}
+
+		return APIToken{}, err
+	}
+
+	return token, nil
+}
+
+func (db *teamDB) GetAPITokens() ([]APIToken, error) {
+	rows, err := db.conn.Query(`
+		SELECT `+apiTokenColumns+`
+		FROM api_tokens a
+		INNER JOIN teams t ON t.id = a.team_id
+		WHERE LOWER(t.name) = LOWER($1)
+		ORDER BY a.name ASC
+	`, db.teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	tokens := []APIToken{}
+
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+func (db *teamDB) DeleteAPIToken(name string) (bool, error) {
+	result, err := db.conn.Exec(`
+		DELETE FROM api_tokens
+		WHERE name = $1
+		AND team_id = (
+			SELECT id
+			FROM teams
+			WHERE LOWER(name) = LOWER($2)
+		)
+	`, name, db.teamName)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func scanAPIToken(row scannable) (APIToken, error) {
+	var token APIToken
+	var role string
+	var lastUsedAt pq.NullTime
+
+	err := row.Scan(
+		&token.ID,
+		&token.TeamID,
+		&token.TeamName,
+		&token.IsAdmin,
+		&token.Name,
+		&role,
+		&token.CreatedAt,
+		&lastUsedAt,
+	)
+	if err != nil {
+		return APIToken{}, err
+	}
+
+	token.Role = atc.TeamRole(role)
+
+	if lastUsedAt.Valid {
+		token.LastUsedAt = lastUsedAt.Time
+	}
+
+	return token, nil
+}
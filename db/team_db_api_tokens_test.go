@@ -0,0 +1,132 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TeamDB API tokens", func() {
+	var dbConn db.Conn
+	var listener *pq.Listener
+
+	var database db.DB
+	var team db.SavedTeam
+	var teamDB db.TeamDB
+	var otherTeamDB db.TeamDB
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		database = db.NewSQL(dbConn, bus, nil)
+
+		var err error
+		team, err = database.CreateTeam(db.Team{Name: "some-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = database.CreateTeam(db.Team{Name: "other-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, nil)
+		teamDB = teamDBFactory.GetTeamDB("some-team")
+		otherTeamDB = teamDBFactory.GetTeamDB("other-team")
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when a token has been created", func() {
+		var token db.APIToken
+
+		BeforeEach(func() {
+			var err error
+			token, err = teamDB.CreateAPIToken("some-bot", atc.RoleOperator, "some-hash")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the token", func() {
+			Expect(token.TeamID).To(Equal(team.ID))
+			Expect(token.TeamName).To(Equal("some-team"))
+			Expect(token.Name).To(Equal("some-bot"))
+			Expect(token.Role).To(Equal(atc.RoleOperator))
+			Expect(token.CreatedAt).To(BeTemporally("~", time.Now(), time.Minute))
+			Expect(token.LastUsedAt.IsZero()).To(BeTrue())
+		})
+
+		It("lists it for the team only", func() {
+			tokens, err := teamDB.GetAPITokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(HaveLen(1))
+			Expect(tokens[0].ID).To(Equal(token.ID))
+			Expect(tokens[0].Name).To(Equal("some-bot"))
+
+			tokens, err = otherTeamDB.GetAPITokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(BeEmpty())
+		})
+
+		It("does not allow another token with the same name in the team", func() {
+			_, err := teamDB.CreateAPIToken("some-bot", atc.RoleViewer, "other-hash")
+			Expect(err).To(Equal(db.ErrAPITokenAlreadyExists))
+
+			_, err = otherTeamDB.CreateAPIToken("some-bot", atc.RoleViewer, "other-hash")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("finds it by its hash, recording that it was used", func() {
+			used, found, err := database.UseAPIToken("some-hash")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(used.ID).To(Equal(token.ID))
+			Expect(used.TeamName).To(Equal("some-team"))
+			Expect(used.IsAdmin).To(BeFalse())
+			Expect(used.Role).To(Equal(atc.RoleOperator))
+			Expect(used.LastUsedAt).To(BeTemporally("~", time.Now(), time.Minute))
+
+			tokens, err := teamDB.GetAPITokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens[0].LastUsedAt).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+
+		It("does not find a token by any other hash", func() {
+			_, found, err := database.UseAPIToken("bogus-hash")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when the token is deleted", func() {
+			It("is no longer found", func() {
+				deleted, err := otherTeamDB.DeleteAPIToken("some-bot")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deleted).To(BeFalse())
+
+				deleted, err = teamDB.DeleteAPIToken("some-bot")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deleted).To(BeTrue())
+
+				_, found, err := database.UseAPIToken("some-hash")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				tokens, err := teamDB.GetAPITokens()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tokens).To(BeEmpty())
+			})
+		})
+	})
+})
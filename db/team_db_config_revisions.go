@@ -0,0 +1,112 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/concourse/atc"
+)
+
+const configRevisionColumns = "r.id, r.version, r.config, r.config_template, r.config_vars, r.saved_by, r.saved_at"
+
+// GetConfigRevisions returns every revision of the pipeline's config, newest
+// first.
+func (db *teamDB) GetConfigRevisions(pipelineName string) ([]ConfigRevision, bool, error) {
+	pipeline, found, err := db.GetPipelineByName(pipelineName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	rows, err := db.conn.Query(`
+		SELECT `+configRevisionColumns+`
+		FROM pipeline_config_revisions r
+		WHERE r.pipeline_id = $1
+		ORDER BY r.id DESC
+	`, pipeline.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer rows.Close()
+
+	revisions := []ConfigRevision{}
+
+	for rows.Next() {
+		revision, err := scanConfigRevision(rows)
+		if err != nil {
+			return nil, false, err
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, true, nil
+}
+
+func (db *teamDB) GetConfigRevision(pipelineName string, revisionID int) (ConfigRevision, bool, error) {
+	revision, err := scanConfigRevision(db.conn.QueryRow(`
+		SELECT `+configRevisionColumns+`
+		FROM pipeline_config_revisions r
+		INNER JOIN pipelines p ON p.id = r.pipeline_id
+		INNER JOIN teams t ON t.id = p.team_id
+		WHERE r.id = $1
+		AND p.name = $2
+		AND LOWER(t.name) = LOWER($3)
+	`, revisionID, pipelineName, db.teamName))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ConfigRevision{}, false, nil
+		}
+
+		return ConfigRevision{}, false, err
+	}
+
+	return revision, true, nil
+}
+
+func scanConfigRevision(row scannable) (ConfigRevision, error) {
+	var revision ConfigRevision
+	var version int
+	var configBlob []byte
+	var template, vars sql.NullString
+
+	err := row.Scan(
+		&revision.ID,
+		&version,
+		&configBlob,
+		&template,
+		&vars,
+		&revision.SavedBy,
+		&revision.SavedAt,
+	)
+	if err != nil {
+		return ConfigRevision{}, err
+	}
+
+	revision.Version = ConfigVersion(version)
+	revision.RawConfig = atc.RawConfig(configBlob)
+
+	err = json.Unmarshal(configBlob, &revision.Config)
+	if err != nil {
+		return ConfigRevision{}, atc.MalformedConfigError{err}
+	}
+
+	if template.Valid {
+		revision.Template = &ConfigTemplate{
+			Template: atc.RawConfig(template.String),
+		}
+
+		if vars.Valid {
+			err = json.Unmarshal([]byte(vars.String), &revision.Template.Vars)
+			if err != nil {
+				return ConfigRevision{}, err
+			}
+		}
+	}
+
+	return revision, nil
+}
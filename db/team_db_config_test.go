@@ -40,14 +40,14 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
 		bus := db.NewNotificationsBus(listener, dbConn)
 
-		database = db.NewSQL(dbConn, bus)
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus)
+		database = db.NewSQL(dbConn, bus, nil)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, nil)
 
 		var err error
 		team, err = database.CreateTeam(db.Team{Name: "some-team"})
 		Expect(err).NotTo(HaveOccurred())
 
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, nil)
 		teamDB = teamDBFactory.GetTeamDB("some-team")
 
 		config = atc.Config{
@@ -159,13 +159,13 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 
 		It("returns true for created", func() {
-			_, created, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+			_, created, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 			Expect(created).To(BeTrue())
 		})
 
 		It("caches the team id", func() {
-			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			pipeline, found, err := teamDB.GetPipelineByName(pipelineName)
@@ -175,7 +175,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 
 		It("can be saved as paused", func() {
-			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelinePaused)
+			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelinePaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			pipeline, found, err := teamDB.GetPipelineByName(pipelineName)
@@ -186,7 +186,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 
 		It("can be saved as unpaused", func() {
-			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused)
+			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			pipeline, found, err := teamDB.GetPipelineByName(pipelineName)
@@ -197,7 +197,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 
 		It("defaults to paused", func() {
-			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			pipeline, found, err := teamDB.GetPipelineByName(pipelineName)
@@ -208,7 +208,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 
 		It("creates all of the resources from the pipeline in the database", func() {
-			savedPipeline, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+			savedPipeline, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			pipelineDB := pipelineDBFactory.Build(savedPipeline)
@@ -218,7 +218,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 
 		It("creates all of the resource types from the pipeline in the database", func() {
-			savedPipeline, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+			savedPipeline, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			pipelineDB := pipelineDBFactory.Build(savedPipeline)
@@ -229,7 +229,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 
 		It("creates all of the jobs from the pipeline in the database", func() {
-			savedPipeline, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+			savedPipeline, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			pipelineDB := pipelineDBFactory.Build(savedPipeline)
@@ -239,7 +239,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 
 		It("creates all of the serial groups from the jobs in the database", func() {
-			savedPipeline, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+			savedPipeline, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			serialGroups := []SerialGroup{}
@@ -279,19 +279,19 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 
 		It("it returns created as false", func() {
-			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			_, _, configVersion, err := teamDB.GetConfig(pipelineName)
 			Expect(err).NotTo(HaveOccurred())
 
-			_, created, err := teamDB.SaveConfig(pipelineName, config, configVersion, db.PipelineNoChange)
+			_, created, err := teamDB.SaveConfig(pipelineName, config, configVersion, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 			Expect(created).To(BeFalse())
 		})
 
 		It("updating from paused to unpaused", func() {
-			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelinePaused)
+			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelinePaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			pipeline, found, err := teamDB.GetPipelineByName(pipelineName)
@@ -302,7 +302,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 			_, _, configVersion, err := teamDB.GetConfig(pipelineName)
 			Expect(err).NotTo(HaveOccurred())
 
-			_, _, err = teamDB.SaveConfig(pipelineName, config, configVersion, db.PipelineUnpaused)
+			_, _, err = teamDB.SaveConfig(pipelineName, config, configVersion, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			pipeline, found, err = teamDB.GetPipelineByName(pipelineName)
@@ -312,7 +312,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 
 		It("updating from unpaused to paused", func() {
-			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused)
+			_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			pipeline, found, err := teamDB.GetPipelineByName(pipelineName)
@@ -323,7 +323,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 			_, _, configVersion, err := teamDB.GetConfig(pipelineName)
 			Expect(err).NotTo(HaveOccurred())
 
-			_, _, err = teamDB.SaveConfig(pipelineName, config, configVersion, db.PipelinePaused)
+			_, _, err = teamDB.SaveConfig(pipelineName, config, configVersion, db.PipelinePaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			pipeline, found, err = teamDB.GetPipelineByName(pipelineName)
@@ -334,7 +334,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 
 		Context("updating with no change", func() {
 			It("maintains paused if the pipeline is paused", func() {
-				_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelinePaused)
+				_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelinePaused, "")
 				Expect(err).NotTo(HaveOccurred())
 
 				pipeline, found, err := teamDB.GetPipelineByName(pipelineName)
@@ -345,7 +345,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 				_, _, configVersion, err := teamDB.GetConfig(pipelineName)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, _, err = teamDB.SaveConfig(pipelineName, config, configVersion, db.PipelineNoChange)
+				_, _, err = teamDB.SaveConfig(pipelineName, config, configVersion, db.PipelineNoChange, "")
 				Expect(err).NotTo(HaveOccurred())
 
 				pipeline, found, err = teamDB.GetPipelineByName(pipelineName)
@@ -355,7 +355,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 			})
 
 			It("maintains unpaused if the pipeline is unpaused", func() {
-				_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused)
+				_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused, "")
 				Expect(err).NotTo(HaveOccurred())
 
 				pipeline, found, err := teamDB.GetPipelineByName(pipelineName)
@@ -366,7 +366,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 				_, _, configVersion, err := teamDB.GetConfig(pipelineName)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, _, err = teamDB.SaveConfig(pipelineName, config, configVersion, db.PipelineNoChange)
+				_, _, err = teamDB.SaveConfig(pipelineName, config, configVersion, db.PipelineNoChange, "")
 				Expect(err).NotTo(HaveOccurred())
 
 				pipeline, found, err = teamDB.GetPipelineByName(pipelineName)
@@ -381,9 +381,9 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		pipelineName := "a-pipeline-name"
 		otherPipelineName := "an-other-pipeline-name"
 
-		_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused)
+		_, _, err := teamDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
-		_, _, err = teamDB.SaveConfig(otherPipelineName, otherConfig, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(otherPipelineName, otherConfig, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		pipeline, found, err := teamDB.GetPipelineByName(pipelineName)
@@ -402,22 +402,22 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 	})
 
 	It("can order pipelines", func() {
-		_, _, err := teamDB.SaveConfig("some-pipeline", atc.Config{}, db.ConfigVersion(1), db.PipelineUnpaused)
+		_, _, err := teamDB.SaveConfig("some-pipeline", atc.Config{}, db.ConfigVersion(1), db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		_, _, err = teamDB.SaveConfig("pipeline-1", config, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig("pipeline-1", config, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		_, _, err = teamDB.SaveConfig("pipeline-2", config, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig("pipeline-2", config, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		_, _, err = teamDB.SaveConfig("pipeline-3", config, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig("pipeline-3", config, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		_, _, err = teamDB.SaveConfig("pipeline-4", config, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig("pipeline-4", config, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		_, _, err = teamDB.SaveConfig("pipeline-5", config, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig("pipeline-5", config, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		err = teamDB.OrderPipelines([]string{
@@ -430,7 +430,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 		Expect(err).NotTo(HaveOccurred())
 
-		_, _, err = teamDB.SaveConfig("pipeline-6", config, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig("pipeline-6", config, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		pipelines, err := teamDB.GetPipelines()
@@ -515,13 +515,13 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		pipelineName := "a-pipeline-name"
 		otherPipelineName := "an-other-pipeline-name"
 
-		_, _, err := teamDB.SaveConfig("some-pipeline", atc.Config{}, db.ConfigVersion(1), db.PipelineUnpaused)
+		_, _, err := teamDB.SaveConfig("some-pipeline", atc.Config{}, db.ConfigVersion(1), db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		_, _, err = teamDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		_, _, err = teamDB.SaveConfig(otherPipelineName, otherConfig, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(otherPipelineName, otherConfig, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		err = teamDB.OrderPipelines([]string{
@@ -568,7 +568,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 	})
 
 	It("can lookup configs by build id", func() {
-		savedPipeline, _, err := teamDB.SaveConfig("my-pipeline", config, 0, db.PipelineUnpaused)
+		savedPipeline, _, err := teamDB.SaveConfig("my-pipeline", config, 0, db.PipelineUnpaused, "")
 
 		myPipelineDB := pipelineDBFactory.Build(savedPipeline)
 
@@ -592,10 +592,10 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		Expect(initialOtherConfig).To(BeZero())
 
 		By("being able to save the config")
-		_, _, err = teamDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		_, _, err = teamDB.SaveConfig(otherPipelineName, otherConfig, 0, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(otherPipelineName, otherConfig, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		By("returning the saved config to later gets")
@@ -648,22 +648,22 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 
 		By("not allowing non-sequential updates")
-		_, _, err = teamDB.SaveConfig(pipelineName, updatedConfig, configVersion-1, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(pipelineName, updatedConfig, configVersion-1, db.PipelineUnpaused, "")
 		Expect(err).To(Equal(db.ErrConfigComparisonFailed))
 
-		_, _, err = teamDB.SaveConfig(pipelineName, updatedConfig, configVersion+10, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(pipelineName, updatedConfig, configVersion+10, db.PipelineUnpaused, "")
 		Expect(err).To(Equal(db.ErrConfigComparisonFailed))
 
-		_, _, err = teamDB.SaveConfig(otherPipelineName, updatedConfig, otherConfigVersion-1, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(otherPipelineName, updatedConfig, otherConfigVersion-1, db.PipelineUnpaused, "")
 		Expect(err).To(Equal(db.ErrConfigComparisonFailed))
 
-		_, _, err = teamDB.SaveConfig(otherPipelineName, updatedConfig, otherConfigVersion+10, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(otherPipelineName, updatedConfig, otherConfigVersion+10, db.PipelineUnpaused, "")
 		Expect(err).To(Equal(db.ErrConfigComparisonFailed))
 
 		By("being able to update the config with a valid con")
-		_, _, err = teamDB.SaveConfig(pipelineName, updatedConfig, configVersion, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(pipelineName, updatedConfig, configVersion, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
-		_, _, err = teamDB.SaveConfig(otherPipelineName, updatedConfig, otherConfigVersion, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(otherPipelineName, updatedConfig, otherConfigVersion, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		By("returning the updated config")
@@ -683,7 +683,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 
 		By("being able to retrieve invalid config")
 		invalidPipelineName := "invalid-config"
-		_, _, err = teamDB.SaveConfig(invalidPipelineName, config, 1, db.PipelineUnpaused)
+		_, _, err = teamDB.SaveConfig(invalidPipelineName, config, 1, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		dbConn.Exec(`
@@ -698,6 +698,134 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		Expect(invalidConfigVersion).NotTo(Equal(db.ConfigVersion(1)))
 	})
 
+	Context("when saving a config rendered from a template", func() {
+		var template db.ConfigTemplate
+
+		BeforeEach(func() {
+			template = db.ConfigTemplate{
+				Template: atc.RawConfig("resources: [{name: ((resource-name))}]"),
+				Vars: atc.Vars{
+					"resource-name": "some-resource",
+					"nested":        map[string]interface{}{"key": "value"},
+				},
+			}
+
+			_, _, err := teamDB.SaveConfigFromTemplate("a-pipeline-name", config, template, 0, db.PipelineUnpaused, "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the rendered config", func() {
+			actualConfig, _, _, err := teamDB.GetConfig("a-pipeline-name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualConfig).To(Equal(config))
+		})
+
+		It("returns the template and vars", func() {
+			actualTemplate, found, err := teamDB.GetConfigTemplate("a-pipeline-name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(actualTemplate).To(Equal(template))
+		})
+
+		Context("when the pipeline is later saved without a template", func() {
+			BeforeEach(func() {
+				_, _, version, err := teamDB.GetConfig("a-pipeline-name")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, err = teamDB.SaveConfig("a-pipeline-name", otherConfig, version, db.PipelineNoChange, "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("forgets the template", func() {
+				_, found, err := teamDB.GetConfigTemplate("a-pipeline-name")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	It("does not return a template for pipelines saved without one", func() {
+		_, _, err := teamDB.SaveConfig("a-pipeline-name", config, 0, db.PipelineUnpaused, "")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, found, err := teamDB.GetConfigTemplate("a-pipeline-name")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	Context("when the config has been saved more than once", func() {
+		var template db.ConfigTemplate
+
+		BeforeEach(func() {
+			_, _, err := teamDB.SaveConfig("a-pipeline-name", config, 0, db.PipelineUnpaused, "some-team/some-user")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, version, err := teamDB.GetConfig("a-pipeline-name")
+			Expect(err).NotTo(HaveOccurred())
+
+			template = db.ConfigTemplate{
+				Template: atc.RawConfig("jobs: ((jobs))"),
+				Vars:     atc.Vars{"jobs": "some-jobs"},
+			}
+
+			_, _, err = teamDB.SaveConfigFromTemplate("a-pipeline-name", otherConfig, template, version, db.PipelineNoChange, "main")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("keeps every revision, newest first", func() {
+			_, _, version, err := teamDB.GetConfig("a-pipeline-name")
+			Expect(err).NotTo(HaveOccurred())
+
+			revisions, found, err := teamDB.GetConfigRevisions("a-pipeline-name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(revisions).To(HaveLen(2))
+
+			Expect(revisions[0].Version).To(Equal(version))
+			Expect(revisions[0].Config).To(Equal(otherConfig))
+			Expect(revisions[0].Template).To(Equal(&template))
+			Expect(revisions[0].SavedBy).To(Equal("main"))
+			Expect(revisions[0].SavedAt).To(BeTemporally("~", time.Now(), time.Minute))
+
+			Expect(revisions[1].Version).To(BeNumerically("<", version))
+			Expect(revisions[1].Config).To(Equal(config))
+			Expect(revisions[1].Template).To(BeNil())
+			Expect(revisions[1].SavedBy).To(Equal("some-team/some-user"))
+
+			var rawConfig atc.Config
+			err = json.Unmarshal([]byte(revisions[1].RawConfig), &rawConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rawConfig).To(Equal(config))
+		})
+
+		It("can look up a single revision", func() {
+			revisions, _, err := teamDB.GetConfigRevisions("a-pipeline-name")
+			Expect(err).NotTo(HaveOccurred())
+
+			revision, found, err := teamDB.GetConfigRevision("a-pipeline-name", revisions[1].ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(revision.ID).To(Equal(revisions[1].ID))
+			Expect(revision.Version).To(Equal(revisions[1].Version))
+			Expect(revision.Config).To(Equal(config))
+			Expect(revision.SavedBy).To(Equal("some-team/some-user"))
+
+			_, found, err = teamDB.GetConfigRevision("other-pipeline-name", revisions[1].ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			_, found, err = teamDB.GetConfigRevision("a-pipeline-name", revisions[0].ID+1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not find revisions of a pipeline that does not exist", func() {
+			_, found, err := teamDB.GetConfigRevisions("bogus-pipeline")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
 	Context("when there are multiple teams", func() {
 		var otherTeam db.SavedTeam
 		var otherTeamDB db.TeamDB
@@ -710,11 +838,11 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		})
 
 		It("can allow pipelines with the same name across teams", func() {
-			_, _, err := teamDB.SaveConfig("steve", config, 0, db.PipelineUnpaused)
+			_, _, err := teamDB.SaveConfig("steve", config, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			By("allowing you to save a pipeline with the same name in another team")
-			_, _, err = otherTeamDB.SaveConfig("steve", otherConfig, 0, db.PipelineUnpaused)
+			_, _, err = otherTeamDB.SaveConfig("steve", otherConfig, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			By("getting the config for the correct team's pipeline")
@@ -725,10 +853,10 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 			Expect(actualOtherConfig).To(Equal(otherConfig))
 
 			By("updating the pipeline config for the correct team's pipeline")
-			_, _, err = teamDB.SaveConfig("steve", otherConfig, teamPipelineVersion, db.PipelineNoChange)
+			_, _, err = teamDB.SaveConfig("steve", otherConfig, teamPipelineVersion, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			_, _, err = otherTeamDB.SaveConfig("steve", config, otherTeamPipelineVersion, db.PipelineNoChange)
+			_, _, err = otherTeamDB.SaveConfig("steve", config, otherTeamPipelineVersion, db.PipelineNoChange, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			actualOtherConfig, _, teamPipelineVersion, err = teamDB.GetConfig("steve")
@@ -738,7 +866,7 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 			Expect(actualConfig).To(Equal(config))
 
 			By("pausing the correct team's pipeline")
-			_, _, err = teamDB.SaveConfig("steve", otherConfig, teamPipelineVersion, db.PipelinePaused)
+			_, _, err = teamDB.SaveConfig("steve", otherConfig, teamPipelineVersion, db.PipelinePaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			pausedPipeline, found, err := teamDB.GetPipelineByName("steve")
@@ -753,10 +881,10 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 			Expect(unpausedPipeline.Paused).To(BeFalse())
 
 			By("cannot cross update configs")
-			_, _, err = teamDB.SaveConfig("steve", otherConfig, otherTeamPipelineVersion, db.PipelineNoChange)
+			_, _, err = teamDB.SaveConfig("steve", otherConfig, otherTeamPipelineVersion, db.PipelineNoChange, "")
 			Expect(err).To(HaveOccurred())
 
-			_, _, err = teamDB.SaveConfig("steve", otherConfig, otherTeamPipelineVersion, db.PipelinePaused)
+			_, _, err = teamDB.SaveConfig("steve", otherConfig, otherTeamPipelineVersion, db.PipelinePaused, "")
 			Expect(err).To(HaveOccurred())
 		})
 	})
@@ -38,8 +38,8 @@ var _ = Describe("TeamDbContainers", func() {
 		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
 		bus := db.NewNotificationsBus(listener, dbConn)
 
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus)
-		database = db.NewSQL(dbConn, bus)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, nil)
+		database = db.NewSQL(dbConn, bus, nil)
 
 		team := db.Team{Name: "team-name"}
 		savedTeam, err := database.CreateTeam(team)
@@ -53,7 +53,7 @@ var _ = Describe("TeamDbContainers", func() {
 
 		teamDB = teamDBFactory.GetTeamDB("team-name")
 
-		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus)
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, nil)
 
 		config := atc.Config{
 			Jobs: atc.JobConfigs{
@@ -85,10 +85,10 @@ var _ = Describe("TeamDbContainers", func() {
 			},
 		}
 
-		savedPipeline, _, err = teamDB.SaveConfig("some-pipeline", config, 0, db.PipelineUnpaused)
+		savedPipeline, _, err = teamDB.SaveConfig("some-pipeline", config, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
-		savedOtherPipeline, _, err = teamDB.SaveConfig("some-other-pipeline", config, 0, db.PipelineUnpaused)
+		savedOtherPipeline, _, err = teamDB.SaveConfig("some-other-pipeline", config, 0, db.PipelineUnpaused, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		pipelineDB = pipelineDBFactory.Build(savedPipeline)
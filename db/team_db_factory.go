@@ -7,14 +7,16 @@ type TeamDBFactory interface {
 }
 
 type teamDBFactory struct {
-	conn Conn
-	bus  *notificationsBus
+	conn            Conn
+	bus             *notificationsBus
+	buildLogArchive BuildLogArchive
 }
 
-func NewTeamDBFactory(conn Conn, bus *notificationsBus) TeamDBFactory {
+func NewTeamDBFactory(conn Conn, bus *notificationsBus, buildLogArchive BuildLogArchive) TeamDBFactory {
 	return &teamDBFactory{
-		conn: conn,
-		bus:  bus,
+		conn:            conn,
+		bus:             bus,
+		buildLogArchive: buildLogArchive,
 	}
 }
 
@@ -22,6 +24,6 @@ func (f *teamDBFactory) GetTeamDB(teamName string) TeamDB {
 	return &teamDB{
 		teamName:     teamName,
 		conn:         f.conn,
-		buildFactory: newBuildFactory(f.conn, f.bus),
+		buildFactory: newBuildFactory(f.conn, f.bus, f.buildLogArchive),
 	}
 }
@@ -38,8 +38,8 @@ var _ = Describe("TeamDB", func() {
 		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
 		bus := db.NewNotificationsBus(listener, dbConn)
 
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus)
-		database = db.NewSQL(dbConn, bus)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, nil)
+		database = db.NewSQL(dbConn, bus, nil)
 
 		team := db.Team{Name: "TEAM-name"}
 		var err error
@@ -49,7 +49,7 @@ var _ = Describe("TeamDB", func() {
 		teamDB = teamDBFactory.GetTeamDB("team-NAME")
 		nonExistentTeamDB = teamDBFactory.GetTeamDB("non-existent-name")
 
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, nil)
 
 		team = db.Team{Name: "other-team-name"}
 		otherSavedTeam, err = database.CreateTeam(team)
@@ -69,10 +69,10 @@ var _ = Describe("TeamDB", func() {
 		var savedPipeline db.SavedPipeline
 		BeforeEach(func() {
 			var err error
-			savedPipeline, _, err = teamDB.SaveConfig("pipeline-name", atc.Config{}, 0, db.PipelineUnpaused)
+			savedPipeline, _, err = teamDB.SaveConfig("pipeline-name", atc.Config{}, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			_, _, err = otherTeamDB.SaveConfig("pipeline-name", atc.Config{}, 0, db.PipelineUnpaused)
+			_, _, err = otherTeamDB.SaveConfig("pipeline-name", atc.Config{}, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -90,16 +90,16 @@ var _ = Describe("TeamDB", func() {
 
 		BeforeEach(func() {
 			var err error
-			savedPipeline1, _, err = teamDB.SaveConfig("pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused)
+			savedPipeline1, _, err = teamDB.SaveConfig("pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			savedPipeline2, _, err = teamDB.SaveConfig("pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused)
+			savedPipeline2, _, err = teamDB.SaveConfig("pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			otherSavedPublicPipeline, _, err := otherTeamDB.SaveConfig("other-team-pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused)
+			otherSavedPublicPipeline, _, err := otherTeamDB.SaveConfig("other-team-pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			_, _, err = otherTeamDB.SaveConfig("other-team-pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused)
+			_, _, err = otherTeamDB.SaveConfig("other-team-pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			pipelineDB := pipelineDBFactory.Build(otherSavedPublicPipeline)
@@ -121,10 +121,10 @@ var _ = Describe("TeamDB", func() {
 
 		BeforeEach(func() {
 			var err error
-			privatePipeline, _, err = teamDB.SaveConfig("private-pipeline", atc.Config{}, 0, db.PipelineUnpaused)
+			privatePipeline, _, err = teamDB.SaveConfig("private-pipeline", atc.Config{}, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			publicPipeline, _, err = teamDB.SaveConfig("public-pipeline", atc.Config{}, 0, db.PipelineUnpaused)
+			publicPipeline, _, err = teamDB.SaveConfig("public-pipeline", atc.Config{}, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			pipelineDB := pipelineDBFactory.Build(publicPipeline)
@@ -152,22 +152,22 @@ var _ = Describe("TeamDB", func() {
 		var otherSavedPublicPipeline3 db.SavedPipeline
 		BeforeEach(func() {
 			var err error
-			savedPipeline1, _, err = teamDB.SaveConfig("pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused)
+			savedPipeline1, _, err = teamDB.SaveConfig("pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			savedPipeline2, _, err = teamDB.SaveConfig("pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused)
+			savedPipeline2, _, err = teamDB.SaveConfig("pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			savedPipeline3, _, err = teamDB.SaveConfig("pipeline-name-c", atc.Config{}, 0, db.PipelineUnpaused)
+			savedPipeline3, _, err = teamDB.SaveConfig("pipeline-name-c", atc.Config{}, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			otherSavedPublicPipeline1, _, err = otherTeamDB.SaveConfig("other-team-pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused)
+			otherSavedPublicPipeline1, _, err = otherTeamDB.SaveConfig("other-team-pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			otherSavedPublicPipeline2, _, err = otherTeamDB.SaveConfig("other-team-pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused)
+			otherSavedPublicPipeline2, _, err = otherTeamDB.SaveConfig("other-team-pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			otherSavedPublicPipeline3, _, err = otherTeamDB.SaveConfig("other-team-pipeline-name-c", atc.Config{}, 0, db.PipelineUnpaused)
+			otherSavedPublicPipeline3, _, err = otherTeamDB.SaveConfig("other-team-pipeline-name-c", atc.Config{}, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
 			pipelineDB1 := pipelineDBFactory.Build(savedPipeline1)
@@ -231,14 +231,14 @@ var _ = Describe("TeamDB", func() {
 
 		BeforeEach(func() {
 			var err error
-			savedPipeline1, _, err = teamDB.SaveConfig("pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused)
+			savedPipeline1, _, err = teamDB.SaveConfig("pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
-			savedPipeline2, _, err = teamDB.SaveConfig("pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused)
+			savedPipeline2, _, err = teamDB.SaveConfig("pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 
-			otherTeamSavedPipeline1, _, err = otherTeamDB.SaveConfig("pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused)
+			otherTeamSavedPipeline1, _, err = otherTeamDB.SaveConfig("pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
-			otherTeamSavedPipeline2, _, err = otherTeamDB.SaveConfig("pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused)
+			otherTeamSavedPipeline2, _, err = otherTeamDB.SaveConfig("pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused, "")
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -441,6 +441,147 @@ var _ = Describe("TeamDB", func() {
 				Expect(savedTeam.GenericOAuth).To(Equal(genericOAuth))
 			})
 		})
+
+		Describe("UpdateOIDCAuth", func() {
+			var oidcAuth *db.OIDCAuth
+
+			BeforeEach(func() {
+				oidcAuth = &db.OIDCAuth{
+					DisplayName:  "Corp SSO",
+					Issuer:       "https://sso.example.com",
+					ClientID:     "some-client-id",
+					ClientSecret: "some-client-secret",
+					Scopes:       []string{"groups"},
+					Groups:       []string{"ci-admins"},
+					GroupsClaim:  "groups",
+					Users:        []string{"someone@example.com"},
+					UserClaim:    "email",
+					Claims:       map[string]string{"department": "engineering"},
+				}
+			})
+
+			It("saves oidc auth info to the existing team", func() {
+				savedTeam, err := teamDB.UpdateOIDCAuth(oidcAuth)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.OIDCAuth).To(Equal(oidcAuth))
+
+				actualTeam, found, err := teamDB.GetTeam()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(actualTeam.OIDCAuth).To(Equal(oidcAuth))
+			})
+
+			It("saves oidc auth info without overwriting the Generic OAuth info", func() {
+				_, err := teamDB.UpdateGenericOAuth(genericOAuth)
+				Expect(err).NotTo(HaveOccurred())
+
+				savedTeam, err := teamDB.UpdateOIDCAuth(oidcAuth)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(savedTeam.GenericOAuth).To(Equal(genericOAuth))
+			})
+
+			It("nulls oidc auth when given nil", func() {
+				_, err := teamDB.UpdateOIDCAuth(oidcAuth)
+				Expect(err).NotTo(HaveOccurred())
+
+				savedTeam, err := teamDB.UpdateOIDCAuth(nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.OIDCAuth).To(BeNil())
+			})
+		})
+
+		Describe("UpdateLDAPAuth", func() {
+			var ldapAuth *db.LDAPAuth
+
+			BeforeEach(func() {
+				ldapAuth = &db.LDAPAuth{
+					DisplayName:  "Corp Directory",
+					Host:         "ldap.example.com:636",
+					BindDN:       "cn=concourse,dc=example,dc=com",
+					BindPassword: "some-password",
+					UserSearch: db.LDAPUserSearch{
+						BaseDN:            "ou=people,dc=example,dc=com",
+						Filter:            "(objectClass=person)",
+						UsernameAttribute: "uid",
+					},
+					GroupSearch: db.LDAPGroupSearch{
+						BaseDN:         "ou=groups,dc=example,dc=com",
+						GroupAttribute: "member",
+						NameAttribute:  "cn",
+					},
+					Groups: []string{"ci-admins"},
+				}
+			})
+
+			It("saves ldap auth info to the existing team", func() {
+				savedTeam, err := teamDB.UpdateLDAPAuth(ldapAuth)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.LDAPAuth).To(Equal(ldapAuth))
+
+				actualTeam, found, err := teamDB.GetTeam()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(actualTeam.LDAPAuth).To(Equal(ldapAuth))
+			})
+
+			It("nulls ldap auth when given nil", func() {
+				_, err := teamDB.UpdateLDAPAuth(ldapAuth)
+				Expect(err).NotTo(HaveOccurred())
+
+				savedTeam, err := teamDB.UpdateLDAPAuth(nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.LDAPAuth).To(BeNil())
+			})
+		})
+
+		Describe("UpdateNotificationHooks", func() {
+			var notificationHooks []atc.NotificationHook
+
+			BeforeEach(func() {
+				notificationHooks = []atc.NotificationHook{
+					{Name: "chat", URL: "https://chat.example.com/hook", Secret: "shh"},
+				}
+			})
+
+			It("saves the notification hooks to the existing team", func() {
+				savedTeam, err := teamDB.UpdateNotificationHooks(notificationHooks)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.NotificationHooks).To(Equal(notificationHooks))
+
+				actualTeam, found, err := teamDB.GetTeam()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(actualTeam.NotificationHooks).To(Equal(notificationHooks))
+			})
+
+			It("saves notification hooks without overwriting the Generic OAuth info", func() {
+				_, err := teamDB.UpdateGenericOAuth(genericOAuth)
+				Expect(err).NotTo(HaveOccurred())
+
+				savedTeam, err := teamDB.UpdateNotificationHooks(notificationHooks)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.GenericOAuth).To(Equal(genericOAuth))
+			})
+		})
+
+		Describe("UpdateRoles", func() {
+			It("saves the role mappings to the existing team", func() {
+				roles := []atc.TeamRoleMapping{
+					{Role: atc.RoleOperator, BasicAuthUsers: []string{"some-user"}},
+					{Role: atc.RoleMember, GitHubTeams: []atc.GitHubTeam{{OrganizationName: "some-org", TeamName: "some-team"}}},
+				}
+
+				savedTeam, err := teamDB.UpdateRoles(roles)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.Roles).To(Equal(roles))
+
+				actualTeam, found, err := teamDB.GetTeam()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(actualTeam.Roles).To(Equal(roles))
+			})
+		})
 	})
 
 	Describe("GetTeam", func() {
@@ -610,7 +751,7 @@ var _ = Describe("TeamDB", func() {
 						},
 					},
 				}
-				pipeline, _, err := teamDB.SaveConfig("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+				pipeline, _, err := teamDB.SaveConfig("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused, "")
 				Expect(err).NotTo(HaveOccurred())
 
 				pipelineDB = pipelineDBFactory.Build(pipeline)
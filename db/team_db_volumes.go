@@ -31,6 +31,9 @@ func (db *teamDB) GetVolumes() ([]SavedVolume, error) {
 			v.replicated_from,
 			v.path,
 			v.host_path_version,
+			v.task_cache_job_id,
+			v.task_cache_step_name,
+			v.task_cache_path,
 			v.size_in_bytes,
 			c.ttl,
 			v.team_id
@@ -0,0 +1,43 @@
+package db_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Team", func() {
+	var team db.Team
+
+	BeforeEach(func() {
+		team = db.Team{Name: "some-team"}
+	})
+
+	Describe("BasicAuthUserRole", func() {
+		Context("when the team has no role mappings", func() {
+			It("grants the owner role", func() {
+				Expect(team.BasicAuthUserRole("some-user")).To(Equal(atc.RoleOwner))
+			})
+		})
+
+		Context("when the team has role mappings", func() {
+			BeforeEach(func() {
+				team.Roles = []atc.TeamRoleMapping{
+					{Role: atc.RoleOperator, BasicAuthUsers: []string{"some-user"}},
+					{Role: atc.RoleMember, BasicAuthUsers: []string{"some-user"}},
+					{Role: atc.RoleOwner, GitHubUsers: []string{"some-user"}},
+				}
+			})
+
+			It("grants the highest basic auth role mapped to the user", func() {
+				Expect(team.BasicAuthUserRole("some-user")).To(Equal(atc.RoleMember))
+			})
+
+			It("grants the viewer role to users without a mapping", func() {
+				Expect(team.BasicAuthUserRole("some-other-user")).To(Equal(atc.RoleViewer))
+			})
+		})
+	})
+})
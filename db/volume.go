@@ -26,6 +26,7 @@ type VolumeIdentifier struct {
 	Output        *OutputIdentifier
 	Import        *ImportIdentifier
 	Replication   *ReplicationIdentifier
+	TaskCache     *TaskCacheIdentifier
 }
 
 func (i VolumeIdentifier) Type() string {
@@ -40,6 +41,8 @@ func (i VolumeIdentifier) Type() string {
 		return "import"
 	case i.Replication != nil:
 		return "replication"
+	case i.TaskCache != nil:
+		return "task-cache"
 	default:
 		return ""
 	}
@@ -57,6 +60,8 @@ func (i VolumeIdentifier) String() string {
 		return i.Import.String()
 	case i.Replication != nil:
 		return i.Replication.String()
+	case i.TaskCache != nil:
+		return i.TaskCache.String()
 	default:
 		return ""
 	}
@@ -102,6 +107,22 @@ func (i ReplicationIdentifier) String() string {
 	return i.ReplicatedVolumeHandle
 }
 
+// TaskCacheIdentifier identifies a cached directory of a task step. Caches
+// belong to the job of the build that created them; BuildID is only used to
+// find that job when creating or looking up a cache, and JobID is only set on
+// volumes read back from the database.
+type TaskCacheIdentifier struct {
+	BuildID    int
+	JobID      int
+	StepName   string
+	Path       string
+	WorkerName string
+}
+
+func (i TaskCacheIdentifier) String() string {
+	return fmt.Sprintf("%s:%s", i.StepName, i.Path)
+}
+
 type ImportIdentifier struct {
 	WorkerName string
 	Path       string
@@ -1,10 +1,12 @@
 package engine
 
 import (
+	"fmt"
+	"strings"
+
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
-	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
 )
 
@@ -22,6 +24,66 @@ func (build *execBuild) buildAggregateStep(logger lager.Logger, plan atc.Plan) e
 	return step
 }
 
+func (build *execBuild) buildInParallelStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("in-parallel")
+
+	step := exec.InParallel{
+		Limit:    plan.InParallel.Limit,
+		FailFast: plan.InParallel.FailFast,
+	}
+
+	for _, innerPlan := range plan.InParallel.Steps {
+		innerPlan.Attempts = plan.Attempts
+		stepFactory := build.buildStepFactory(logger, innerPlan)
+		step.Steps = append(step.Steps, stepFactory)
+	}
+
+	return step
+}
+
+func (build *execBuild) buildAcrossStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("across")
+
+	step := exec.Aggregate{}
+
+	for _, acrossStep := range plan.Across.Steps {
+		innerPlan := acrossStep.Step
+		innerPlan.Attempts = plan.Attempts
+
+		build.nameOrigins(innerPlan, acrossStepName(plan.Across.Vars, acrossStep.Values))
+
+		stepFactory := build.buildStepFactory(logger, innerPlan)
+		step = append(step, stepFactory)
+	}
+
+	return step
+}
+
+// nameOrigins names the origin of every step within the plan, so that the
+// events of each across combination can be told apart. Nested combinations
+// are named after all of their values.
+func (build *execBuild) nameOrigins(plan atc.Plan, name string) {
+	atc.NewPlanTraversal(func(plan *atc.Plan) error {
+		if outer, found := build.originNames[plan.ID]; found {
+			build.originNames[plan.ID] = outer + ", " + name
+		} else {
+			build.originNames[plan.ID] = name
+		}
+
+		return nil
+	}).Traverse(&plan)
+}
+
+func acrossStepName(vars []string, values []interface{}) string {
+	pairs := make([]string, len(vars))
+
+	for i, name := range vars {
+		pairs[i] = fmt.Sprintf("%s: %v", name, values[i])
+	}
+
+	return strings.Join(pairs, ", ")
+}
+
 func (build *execBuild) buildDoStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	logger = logger.Session("do")
 
@@ -112,10 +174,11 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 		exec.SourceName(plan.Task.Name),
 		workerID,
 		workerMetadata,
-		build.delegate.ExecutionDelegate(logger, *plan.Task, event.OriginID(plan.ID)),
+		build.delegate.ExecutionDelegate(logger, *plan.Task, build.origin(plan.ID)),
 		exec.Privileged(plan.Task.Privileged),
 		plan.Task.Tags,
 		build.teamID,
+		build.variables,
 		configSource,
 		plan.Task.ResourceTypes,
 		plan.Task.InputMapping,
@@ -147,7 +210,7 @@ func (build *execBuild) buildGetStep(logger lager.Logger, plan atc.Plan) exec.St
 		exec.SourceName(plan.Get.Name),
 		workerID,
 		workerMetadata,
-		build.delegate.InputDelegate(logger, *plan.Get, event.OriginID(plan.ID)),
+		build.delegate.InputDelegate(logger, *plan.Get, build.origin(plan.ID)),
 		atc.ResourceConfig{
 			Name:   plan.Get.Resource,
 			Type:   plan.Get.Type,
@@ -155,6 +218,7 @@ func (build *execBuild) buildGetStep(logger lager.Logger, plan atc.Plan) exec.St
 		},
 		plan.Get.Tags,
 		build.teamID,
+		build.variables,
 		plan.Get.Params,
 		plan.Get.Version,
 		plan.Get.ResourceTypes,
@@ -182,7 +246,7 @@ func (build *execBuild) buildPutStep(logger lager.Logger, plan atc.Plan) exec.St
 		build.stepMetadata,
 		workerID,
 		workerMetadata,
-		build.delegate.OutputDelegate(logger, *plan.Put, event.OriginID(plan.ID)),
+		build.delegate.OutputDelegate(logger, *plan.Put, build.origin(plan.ID)),
 		atc.ResourceConfig{
 			Name:   plan.Put.Resource,
 			Type:   plan.Put.Type,
@@ -190,6 +254,7 @@ func (build *execBuild) buildPutStep(logger lager.Logger, plan atc.Plan) exec.St
 		},
 		plan.Put.Tags,
 		build.teamID,
+		build.variables,
 		plan.Put.Params,
 		plan.Put.ResourceTypes,
 		build.containerSuccessTTL,
@@ -218,7 +283,7 @@ func (build *execBuild) buildDependentGetStep(logger lager.Logger, plan atc.Plan
 		exec.SourceName(getPlan.Name),
 		workerID,
 		workerMetadata,
-		build.delegate.InputDelegate(logger, getPlan, event.OriginID(plan.ID)),
+		build.delegate.InputDelegate(logger, getPlan, build.origin(plan.ID)),
 		atc.ResourceConfig{
 			Name:   getPlan.Resource,
 			Type:   getPlan.Type,
@@ -226,6 +291,7 @@ func (build *execBuild) buildDependentGetStep(logger lager.Logger, plan atc.Plan
 		},
 		getPlan.Tags,
 		build.teamID,
+		build.variables,
 		getPlan.Params,
 		getPlan.ResourceTypes,
 		build.containerSuccessTTL,
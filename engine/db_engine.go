@@ -10,15 +10,18 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/notifications"
 )
 
 var ErrBuildNotActive = errors.New("build not yet active")
 
 const trackLeaseDuration = time.Minute
 
-func NewDBEngine(engines Engines) Engine {
+func NewDBEngine(engines Engines, notifier notifications.Notifier, externalURL string) Engine {
 	return &dbEngine{
-		engines: engines,
+		engines:     engines,
+		notifier:    notifier,
+		externalURL: externalURL,
 	}
 }
 
@@ -31,7 +34,9 @@ func (err UnknownEngineError) Error() string {
 }
 
 type dbEngine struct {
-	engines Engines
+	engines     Engines
+	notifier    notifications.Notifier
+	externalURL string
 }
 
 func (*dbEngine) Name() string {
@@ -56,21 +61,27 @@ func (engine *dbEngine) CreateBuild(logger lager.Logger, build db.Build, plan at
 	}
 
 	return &dbBuild{
-		engines: engine.engines,
-		build:   build,
+		engines:     engine.engines,
+		notifier:    engine.notifier,
+		externalURL: engine.externalURL,
+		build:       build,
 	}, nil
 }
 
 func (engine *dbEngine) LookupBuild(logger lager.Logger, build db.Build) (Build, error) {
 	return &dbBuild{
-		engines: engine.engines,
-		build:   build,
+		engines:     engine.engines,
+		notifier:    engine.notifier,
+		externalURL: engine.externalURL,
+		build:       build,
 	}, nil
 }
 
 type dbBuild struct {
-	engines Engines
-	build   db.Build
+	engines     Engines
+	notifier    notifications.Notifier
+	externalURL string
+	build       db.Build
 }
 
 func (build *dbBuild) Metadata() string {
@@ -142,7 +153,20 @@ func (build *dbBuild) Abort(logger lager.Logger) error {
 		// finish the build so that the aborted event is put into the event stream
 		// even if the build has not started yet
 		logger.Info("finishing-build-with-no-engine")
-		return build.build.Finish(db.StatusAborted)
+		err := build.build.Finish(db.StatusAborted)
+		if err != nil {
+			return err
+		}
+
+		_, err = build.build.Reload()
+		if err != nil {
+			logger.Error("failed-to-load-build-from-db", err)
+			return err
+		}
+
+		build.notifyFinished(logger)
+
+		return nil
 	}
 
 	buildEngine, found := build.engines.Lookup(buildEngineName)
@@ -273,11 +297,33 @@ func (build *dbBuild) Resume(logger lager.Logger) {
 		BuildStatus:   build.build.Status(),
 		BuildDuration: build.build.EndTime().Sub(build.build.StartTime()),
 	}.Emit(logger)
+
+	if !build.build.IsRunning() {
+		build.notifyFinished(logger)
+	}
+}
+
+func (build *dbBuild) notifyFinished(logger lager.Logger) {
+	buildURL := buildMetadata(build.build, build.externalURL).URL()
+
+	err := build.notifier.BuildFinished(logger.Session("notify"), build.build, buildURL)
+	if err != nil {
+		logger.Error("failed-to-notify-build-finished", err)
+	}
 }
 
 func (build *dbBuild) finishWithError(logger lager.Logger) {
 	err := build.build.Finish(db.StatusErrored)
 	if err != nil {
 		logger.Error("failed-to-mark-build-as-errored", err)
+		return
+	}
+
+	_, err = build.build.Reload()
+	if err != nil {
+		logger.Error("failed-to-load-build-from-db", err)
+		return
 	}
+
+	build.notifyFinished(logger)
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/concourse/atc/db/dbfakes"
 	. "github.com/concourse/atc/engine"
 	"github.com/concourse/atc/engine/enginefakes"
+	"github.com/concourse/atc/notifications/notificationsfakes"
 )
 
 var _ = Describe("DBEngine", func() {
@@ -25,6 +26,8 @@ var _ = Describe("DBEngine", func() {
 		fakeEngineB *enginefakes.FakeEngine
 		dbBuild     *dbfakes.FakeBuild
 
+		fakeNotifier *notificationsfakes.FakeNotifier
+
 		dbEngine Engine
 	)
 
@@ -39,8 +42,14 @@ var _ = Describe("DBEngine", func() {
 
 		dbBuild = new(dbfakes.FakeBuild)
 		dbBuild.IDReturns(128)
+		dbBuild.NameReturns("42")
+		dbBuild.TeamNameReturns("some-team")
+		dbBuild.PipelineNameReturns("some-pipeline")
+		dbBuild.JobNameReturns("some-job")
+
+		fakeNotifier = new(notificationsfakes.FakeNotifier)
 
-		dbEngine = NewDBEngine(Engines{fakeEngineA, fakeEngineB})
+		dbEngine = NewDBEngine(Engines{fakeEngineA, fakeEngineB}, fakeNotifier, "http://example.com")
 	})
 
 	Describe("CreateBuild", func() {
@@ -364,6 +373,14 @@ var _ = Describe("DBEngine", func() {
 						Expect(status).To(Equal(db.StatusAborted))
 					})
 
+					It("notifies the team that the build finished", func() {
+						Expect(fakeNotifier.BuildFinishedCallCount()).To(Equal(1))
+
+						_, notifiedBuild, buildURL := fakeNotifier.BuildFinishedArgsForCall(0)
+						Expect(notifiedBuild).To(Equal(dbBuild))
+						Expect(buildURL).To(Equal("http://example.com/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/42"))
+					})
+
 					It("breaks the lease", func() {
 						Expect(fakeLease.BreakCallCount()).To(Equal(1))
 					})
@@ -521,6 +538,26 @@ var _ = Describe("DBEngine", func() {
 								Expect(notifier.CloseCallCount()).To(Equal(1))
 							})
 
+							It("does not notify the team while the build is still running", func() {
+								Expect(fakeNotifier.BuildFinishedCallCount()).To(BeZero())
+							})
+
+							Context("when the build finishes", func() {
+								BeforeEach(func() {
+									dbBuild.IsRunningStub = func() bool {
+										return realBuild.ResumeCallCount() == 0
+									}
+								})
+
+								It("notifies the team that the build finished", func() {
+									Expect(fakeNotifier.BuildFinishedCallCount()).To(Equal(1))
+
+									_, notifiedBuild, buildURL := fakeNotifier.BuildFinishedArgsForCall(0)
+									Expect(notifiedBuild).To(Equal(dbBuild))
+									Expect(buildURL).To(Equal("http://example.com/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/42"))
+								})
+							})
+
 							Context("when the build is aborted", func() {
 								var errAborted = errors.New("aborted")
 
@@ -602,6 +639,10 @@ var _ = Describe("DBEngine", func() {
 						buildStatus := dbBuild.FinishArgsForCall(0)
 						Expect(buildStatus).To(Equal(db.StatusErrored))
 					})
+
+					It("notifies the team that the build finished", func() {
+						Expect(fakeNotifier.BuildFinishedCallCount()).To(Equal(1))
+					})
 				})
 
 				Context("when the build is not yet active", func() {
@@ -12,32 +12,32 @@ import (
 )
 
 type FakeBuildDelegate struct {
-	InputDelegateStub        func(lager.Logger, atc.GetPlan, event.OriginID) exec.GetDelegate
+	InputDelegateStub        func(lager.Logger, atc.GetPlan, event.Origin) exec.GetDelegate
 	inputDelegateMutex       sync.RWMutex
 	inputDelegateArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 atc.GetPlan
-		arg3 event.OriginID
+		arg3 event.Origin
 	}
 	inputDelegateReturns struct {
 		result1 exec.GetDelegate
 	}
-	ExecutionDelegateStub        func(lager.Logger, atc.TaskPlan, event.OriginID) exec.TaskDelegate
+	ExecutionDelegateStub        func(lager.Logger, atc.TaskPlan, event.Origin) exec.TaskDelegate
 	executionDelegateMutex       sync.RWMutex
 	executionDelegateArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 atc.TaskPlan
-		arg3 event.OriginID
+		arg3 event.Origin
 	}
 	executionDelegateReturns struct {
 		result1 exec.TaskDelegate
 	}
-	OutputDelegateStub        func(lager.Logger, atc.PutPlan, event.OriginID) exec.PutDelegate
+	OutputDelegateStub        func(lager.Logger, atc.PutPlan, event.Origin) exec.PutDelegate
 	outputDelegateMutex       sync.RWMutex
 	outputDelegateArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 atc.PutPlan
-		arg3 event.OriginID
+		arg3 event.Origin
 	}
 	outputDelegateReturns struct {
 		result1 exec.PutDelegate
@@ -54,12 +54,12 @@ type FakeBuildDelegate struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeBuildDelegate) InputDelegate(arg1 lager.Logger, arg2 atc.GetPlan, arg3 event.OriginID) exec.GetDelegate {
+func (fake *FakeBuildDelegate) InputDelegate(arg1 lager.Logger, arg2 atc.GetPlan, arg3 event.Origin) exec.GetDelegate {
 	fake.inputDelegateMutex.Lock()
 	fake.inputDelegateArgsForCall = append(fake.inputDelegateArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 atc.GetPlan
-		arg3 event.OriginID
+		arg3 event.Origin
 	}{arg1, arg2, arg3})
 	fake.recordInvocation("InputDelegate", []interface{}{arg1, arg2, arg3})
 	fake.inputDelegateMutex.Unlock()
@@ -76,7 +76,7 @@ func (fake *FakeBuildDelegate) InputDelegateCallCount() int {
 	return len(fake.inputDelegateArgsForCall)
 }
 
-func (fake *FakeBuildDelegate) InputDelegateArgsForCall(i int) (lager.Logger, atc.GetPlan, event.OriginID) {
+func (fake *FakeBuildDelegate) InputDelegateArgsForCall(i int) (lager.Logger, atc.GetPlan, event.Origin) {
 	fake.inputDelegateMutex.RLock()
 	defer fake.inputDelegateMutex.RUnlock()
 	return fake.inputDelegateArgsForCall[i].arg1, fake.inputDelegateArgsForCall[i].arg2, fake.inputDelegateArgsForCall[i].arg3
@@ -89,12 +89,12 @@ func (fake *FakeBuildDelegate) InputDelegateReturns(result1 exec.GetDelegate) {
 	}{result1}
 }
 
-func (fake *FakeBuildDelegate) ExecutionDelegate(arg1 lager.Logger, arg2 atc.TaskPlan, arg3 event.OriginID) exec.TaskDelegate {
+func (fake *FakeBuildDelegate) ExecutionDelegate(arg1 lager.Logger, arg2 atc.TaskPlan, arg3 event.Origin) exec.TaskDelegate {
 	fake.executionDelegateMutex.Lock()
 	fake.executionDelegateArgsForCall = append(fake.executionDelegateArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 atc.TaskPlan
-		arg3 event.OriginID
+		arg3 event.Origin
 	}{arg1, arg2, arg3})
 	fake.recordInvocation("ExecutionDelegate", []interface{}{arg1, arg2, arg3})
 	fake.executionDelegateMutex.Unlock()
@@ -111,7 +111,7 @@ func (fake *FakeBuildDelegate) ExecutionDelegateCallCount() int {
 	return len(fake.executionDelegateArgsForCall)
 }
 
-func (fake *FakeBuildDelegate) ExecutionDelegateArgsForCall(i int) (lager.Logger, atc.TaskPlan, event.OriginID) {
+func (fake *FakeBuildDelegate) ExecutionDelegateArgsForCall(i int) (lager.Logger, atc.TaskPlan, event.Origin) {
 	fake.executionDelegateMutex.RLock()
 	defer fake.executionDelegateMutex.RUnlock()
 	return fake.executionDelegateArgsForCall[i].arg1, fake.executionDelegateArgsForCall[i].arg2, fake.executionDelegateArgsForCall[i].arg3
@@ -124,12 +124,12 @@ func (fake *FakeBuildDelegate) ExecutionDelegateReturns(result1 exec.TaskDelegat
 	}{result1}
 }
 
-func (fake *FakeBuildDelegate) OutputDelegate(arg1 lager.Logger, arg2 atc.PutPlan, arg3 event.OriginID) exec.PutDelegate {
+func (fake *FakeBuildDelegate) OutputDelegate(arg1 lager.Logger, arg2 atc.PutPlan, arg3 event.Origin) exec.PutDelegate {
 	fake.outputDelegateMutex.Lock()
 	fake.outputDelegateArgsForCall = append(fake.outputDelegateArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 atc.PutPlan
-		arg3 event.OriginID
+		arg3 event.Origin
 	}{arg1, arg2, arg3})
 	fake.recordInvocation("OutputDelegate", []interface{}{arg1, arg2, arg3})
 	fake.outputDelegateMutex.Unlock()
@@ -146,7 +146,7 @@ func (fake *FakeBuildDelegate) OutputDelegateCallCount() int {
 	return len(fake.outputDelegateArgsForCall)
 }
 
-func (fake *FakeBuildDelegate) OutputDelegateArgsForCall(i int) (lager.Logger, atc.PutPlan, event.OriginID) {
+func (fake *FakeBuildDelegate) OutputDelegateArgsForCall(i int) (lager.Logger, atc.PutPlan, event.Origin) {
 	fake.outputDelegateMutex.RLock()
 	defer fake.outputDelegateMutex.RUnlock()
 	return fake.outputDelegateArgsForCall[i].arg1, fake.outputDelegateArgsForCall[i].arg2, fake.outputDelegateArgsForCall[i].arg3
@@ -10,7 +10,9 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/worker"
 	"github.com/tedsuo/ifrit"
@@ -25,23 +27,26 @@ const successTTL = 5 * time.Minute
 const failureTTL = 5 * time.Minute
 
 type execEngine struct {
-	factory         exec.Factory
-	delegateFactory BuildDelegateFactory
-	teamDBFactory   db.TeamDBFactory
-	externalURL     string
+	factory          exec.Factory
+	delegateFactory  BuildDelegateFactory
+	teamDBFactory    db.TeamDBFactory
+	variablesFactory creds.VariablesFactory
+	externalURL      string
 }
 
 func NewExecEngine(
 	factory exec.Factory,
 	delegateFactory BuildDelegateFactory,
 	teamDBFactory db.TeamDBFactory,
+	variablesFactory creds.VariablesFactory,
 	externalURL string,
 ) Engine {
 	return &execEngine{
-		factory:         factory,
-		delegateFactory: delegateFactory,
-		teamDBFactory:   teamDBFactory,
-		externalURL:     externalURL,
+		factory:          factory,
+		delegateFactory:  delegateFactory,
+		teamDBFactory:    teamDBFactory,
+		variablesFactory: variablesFactory,
+		externalURL:      externalURL,
 	}
 }
 
@@ -55,6 +60,7 @@ func (engine *execEngine) CreateBuild(logger lager.Logger, build db.Build, plan
 		teamName:     build.TeamName(),
 		teamID:       build.TeamID(),
 		stepMetadata: buildMetadata(build, engine.externalURL),
+		variables:    engine.variablesFactory.NewVariables(build.TeamName(), build.PipelineName()),
 
 		factory:  engine.factory,
 		delegate: engine.delegateFactory.Delegate(build),
@@ -62,7 +68,8 @@ func (engine *execEngine) CreateBuild(logger lager.Logger, build db.Build, plan
 			Plan: plan,
 		},
 
-		signals: make(chan os.Signal, 1),
+		signals:     make(chan os.Signal, 1),
+		originNames: map[atc.PlanID]string{},
 
 		containerSuccessTTL: successTTL,
 		containerFailureTTL: failureTTL,
@@ -87,12 +94,14 @@ func (engine *execEngine) LookupBuild(logger lager.Logger, build db.Build) (Buil
 		teamName:     build.TeamName(),
 		teamID:       build.TeamID(),
 		stepMetadata: buildMetadata(build, engine.externalURL),
+		variables:    engine.variablesFactory.NewVariables(build.TeamName(), build.PipelineName()),
 
 		factory:  engine.factory,
 		delegate: engine.delegateFactory.Delegate(build),
 		metadata: metadata,
 
-		signals: make(chan os.Signal, 1),
+		signals:     make(chan os.Signal, 1),
+		originNames: map[atc.PlanID]string{},
 
 		containerSuccessTTL: successTTL,
 		containerFailureTTL: failureTTL,
@@ -154,6 +163,7 @@ func buildMetadata(build db.Build, externalURL string) StepMetadata {
 		BuildName:    build.Name(),
 		JobName:      build.JobName(),
 		PipelineName: build.PipelineName(),
+		TeamName:     build.TeamName(),
 		ExternalURL:  externalURL,
 	}
 }
@@ -163,6 +173,7 @@ type execBuild struct {
 	stepMetadata StepMetadata
 	teamName     string
 	teamID       int
+	variables    creds.Variables
 
 	factory  exec.Factory
 	delegate BuildDelegate
@@ -171,6 +182,9 @@ type execBuild struct {
 
 	metadata execMetadata
 
+	// names of the across combinations that steps belong to, by plan ID
+	originNames map[atc.PlanID]string
+
 	containerSuccessTTL time.Duration
 	containerFailureTTL time.Duration
 }
@@ -237,6 +251,14 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return build.buildAggregateStep(logger, plan)
 	}
 
+	if plan.InParallel != nil {
+		return build.buildInParallelStep(logger, plan)
+	}
+
+	if plan.Across != nil {
+		return build.buildAcrossStep(logger, plan)
+	}
+
 	if plan.Do != nil {
 		return build.buildDoStep(logger, plan)
 	}
@@ -284,6 +306,13 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 	return exec.Identity{}
 }
 
+func (build *execBuild) origin(id atc.PlanID) event.Origin {
+	return event.Origin{
+		ID:   event.OriginID(id),
+		Name: build.originNames[id],
+	}
+}
+
 func (build *execBuild) stepIdentifier(
 	logger lager.Logger,
 	stepName string,
@@ -22,9 +22,9 @@ type implicitOutput struct {
 //go:generate counterfeiter . BuildDelegate
 
 type BuildDelegate interface {
-	InputDelegate(lager.Logger, atc.GetPlan, event.OriginID) exec.GetDelegate
-	ExecutionDelegate(lager.Logger, atc.TaskPlan, event.OriginID) exec.TaskDelegate
-	OutputDelegate(lager.Logger, atc.PutPlan, event.OriginID) exec.PutDelegate
+	InputDelegate(lager.Logger, atc.GetPlan, event.Origin) exec.GetDelegate
+	ExecutionDelegate(lager.Logger, atc.TaskPlan, event.Origin) exec.TaskDelegate
+	OutputDelegate(lager.Logger, atc.PutPlan, event.Origin) exec.PutDelegate
 
 	Finish(lager.Logger, error, exec.Success, bool)
 }
@@ -61,31 +61,31 @@ func newBuildDelegate(build db.Build) BuildDelegate {
 	}
 }
 
-func (delegate *delegate) InputDelegate(logger lager.Logger, plan atc.GetPlan, id event.OriginID) exec.GetDelegate {
+func (delegate *delegate) InputDelegate(logger lager.Logger, plan atc.GetPlan, origin event.Origin) exec.GetDelegate {
 	return &inputDelegate{
 		logger: logger,
 
-		id:       id,
+		origin:   origin,
 		plan:     plan,
 		delegate: delegate,
 	}
 }
 
-func (delegate *delegate) OutputDelegate(logger lager.Logger, plan atc.PutPlan, id event.OriginID) exec.PutDelegate {
+func (delegate *delegate) OutputDelegate(logger lager.Logger, plan atc.PutPlan, origin event.Origin) exec.PutDelegate {
 	return &outputDelegate{
 		logger: logger,
 
-		id:       id,
+		origin:   origin,
 		plan:     plan,
 		delegate: delegate,
 	}
 }
 
-func (delegate *delegate) ExecutionDelegate(logger lager.Logger, plan atc.TaskPlan, id event.OriginID) exec.TaskDelegate {
+func (delegate *delegate) ExecutionDelegate(logger lager.Logger, plan atc.TaskPlan, origin event.Origin) exec.TaskDelegate {
 	return &executionDelegate{
 		logger: logger,
 
-		id:       id,
+		origin:   origin,
 		plan:     plan,
 		delegate: delegate,
 	}
@@ -195,6 +195,26 @@ func (delegate *delegate) saveErr(logger lager.Logger, errVal error, origin even
 	}
 }
 
+func (delegate *delegate) saveResourceUsage(logger lager.Logger, stepName string, stepType string, usage atc.ResourceUsage, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.ResourceUsage{
+		Usage:  usage,
+		Origin: origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-resource-usage-event", err)
+	}
+
+	err = delegate.build.SaveResourceUsage(atc.StepResourceUsage{
+		PlanID:   atc.PlanID(origin.ID),
+		StepName: stepName,
+		StepType: stepType,
+		Usage:    usage,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-resource-usage", err)
+	}
+}
+
 func (delegate *delegate) saveInput(logger lager.Logger, status exec.ExitStatus, plan atc.GetPlan, info *exec.VersionInfo, origin event.Origin) {
 	var version atc.Version
 	var metadata []atc.MetadataField
@@ -304,18 +324,16 @@ type inputDelegate struct {
 	logger lager.Logger
 
 	plan     atc.GetPlan
-	id       event.OriginID
+	origin   event.Origin
 	delegate *delegate
 }
 
 func (input *inputDelegate) Initializing() {
-	input.delegate.saveInitializeGet(input.logger, event.Origin{ID: input.id})
+	input.delegate.saveInitializeGet(input.logger, input.origin)
 }
 
 func (input *inputDelegate) Completed(status exec.ExitStatus, info *exec.VersionInfo) {
-	input.delegate.saveInput(input.logger, status, input.plan, info, event.Origin{
-		ID: input.id,
-	})
+	input.delegate.saveInput(input.logger, status, input.plan, info, input.origin)
 
 	if info != nil {
 		input.delegate.registerImplicitOutput(input.plan.Resource, implicitOutput{input.plan, *info})
@@ -325,85 +343,87 @@ func (input *inputDelegate) Completed(status exec.ExitStatus, info *exec.Version
 }
 
 func (input *inputDelegate) Failed(err error) {
-	input.delegate.saveErr(input.logger, err, event.Origin{
-		ID: input.id,
-	})
+	input.delegate.saveErr(input.logger, err, input.origin)
 
 	input.logger.Info("errored", lager.Data{"error": err.Error()})
 }
 
+func (input *inputDelegate) ResourceUsage(usage atc.ResourceUsage) {
+	input.delegate.saveResourceUsage(input.logger, input.plan.Name, "get", usage, input.origin)
+}
+
 func (input *inputDelegate) ImageVersionDetermined(identifier worker.VolumeIdentifier) error {
-	return input.delegate.build.SaveImageResourceVersion(atc.PlanID(input.id), *identifier.ResourceCache)
+	return input.delegate.build.SaveImageResourceVersion(atc.PlanID(input.origin.ID), *identifier.ResourceCache)
 }
 
 func (input *inputDelegate) Stdout() io.Writer {
-	return input.delegate.eventWriter(event.Origin{
-		Source: event.OriginSourceStdout,
-		ID:     input.id,
-	})
+	origin := input.origin
+	origin.Source = event.OriginSourceStdout
+
+	return input.delegate.eventWriter(origin)
 }
 
 func (input *inputDelegate) Stderr() io.Writer {
-	return input.delegate.eventWriter(event.Origin{
-		Source: event.OriginSourceStderr,
-		ID:     input.id,
-	})
+	origin := input.origin
+	origin.Source = event.OriginSourceStderr
+
+	return input.delegate.eventWriter(origin)
 }
 
 type outputDelegate struct {
 	logger lager.Logger
 
-	plan atc.PutPlan
-	id   event.OriginID
+	plan   atc.PutPlan
+	origin event.Origin
 
 	delegate *delegate
 	hook     string
 }
 
 func (output *outputDelegate) Initializing() {
-	output.delegate.saveInitializePut(output.logger, event.Origin{ID: output.id})
+	output.delegate.saveInitializePut(output.logger, output.origin)
 }
 
 func (output *outputDelegate) Completed(status exec.ExitStatus, info *exec.VersionInfo) {
 	output.delegate.unregisterImplicitOutput(output.plan.Resource)
-	output.delegate.saveOutput(output.logger, status, output.plan, info, event.Origin{
-		ID: output.id,
-	})
+	output.delegate.saveOutput(output.logger, status, output.plan, info, output.origin)
 
 	output.logger.Info("finished", lager.Data{"version-info": info})
 }
 
 func (output *outputDelegate) Failed(err error) {
-	output.delegate.saveErr(output.logger, err, event.Origin{
-		ID: output.id,
-	})
+	output.delegate.saveErr(output.logger, err, output.origin)
 
 	output.logger.Info("errored", lager.Data{"error": err.Error()})
 }
 
+func (output *outputDelegate) ResourceUsage(usage atc.ResourceUsage) {
+	output.delegate.saveResourceUsage(output.logger, output.plan.Name, "put", usage, output.origin)
+}
+
 func (output *outputDelegate) ImageVersionDetermined(identifier worker.VolumeIdentifier) error {
-	return output.delegate.build.SaveImageResourceVersion(atc.PlanID(output.id), *identifier.ResourceCache)
+	return output.delegate.build.SaveImageResourceVersion(atc.PlanID(output.origin.ID), *identifier.ResourceCache)
 }
 
 func (output *outputDelegate) Stdout() io.Writer {
-	return output.delegate.eventWriter(event.Origin{
-		Source: event.OriginSourceStdout,
-		ID:     output.id,
-	})
+	origin := output.origin
+	origin.Source = event.OriginSourceStdout
+
+	return output.delegate.eventWriter(origin)
 }
 
 func (output *outputDelegate) Stderr() io.Writer {
-	return output.delegate.eventWriter(event.Origin{
-		Source: event.OriginSourceStderr,
-		ID:     output.id,
-	})
+	origin := output.origin
+	origin.Source = event.OriginSourceStderr
+
+	return output.delegate.eventWriter(origin)
 }
 
 type executionDelegate struct {
 	logger lager.Logger
 
-	plan atc.TaskPlan
-	id   event.OriginID
+	plan   atc.TaskPlan
+	origin event.Origin
 
 	delegate *delegate
 
@@ -411,52 +431,48 @@ type executionDelegate struct {
 }
 
 func (execution *executionDelegate) Initializing(config atc.TaskConfig) {
-	execution.delegate.saveInitializeTask(execution.logger, config, event.Origin{
-		ID: execution.id,
-	})
+	execution.delegate.saveInitializeTask(execution.logger, config, execution.origin)
 
 	execution.logger.Info("initializing")
 }
 
 func (execution *executionDelegate) Started() {
-	execution.delegate.saveStart(execution.logger, event.Origin{
-		ID: execution.id,
-	})
+	execution.delegate.saveStart(execution.logger, execution.origin)
 
 	execution.logger.Info("started")
 }
 
 func (execution *executionDelegate) Finished(status exec.ExitStatus) {
-	execution.delegate.saveFinish(execution.logger, status, event.Origin{
-		ID: execution.id,
-	})
+	execution.delegate.saveFinish(execution.logger, status, execution.origin)
 
 	execution.logger.Info("finished", lager.Data{"exit-status": status})
 }
 
 func (execution *executionDelegate) Failed(err error) {
-	execution.delegate.saveErr(execution.logger, err, event.Origin{
-		ID: execution.id,
-	})
+	execution.delegate.saveErr(execution.logger, err, execution.origin)
 	execution.logger.Info("errored", lager.Data{"error": err.Error()})
 }
 
+func (execution *executionDelegate) ResourceUsage(usage atc.ResourceUsage) {
+	execution.delegate.saveResourceUsage(execution.logger, execution.plan.Name, "task", usage, execution.origin)
+}
+
 func (execution *executionDelegate) ImageVersionDetermined(identifier worker.VolumeIdentifier) error {
-	return execution.delegate.build.SaveImageResourceVersion(atc.PlanID(execution.id), *identifier.ResourceCache)
+	return execution.delegate.build.SaveImageResourceVersion(atc.PlanID(execution.origin.ID), *identifier.ResourceCache)
 }
 
 func (execution *executionDelegate) Stdout() io.Writer {
-	return execution.delegate.eventWriter(event.Origin{
-		Source: event.OriginSourceStdout,
-		ID:     execution.id,
-	})
+	origin := execution.origin
+	origin.Source = event.OriginSourceStdout
+
+	return execution.delegate.eventWriter(origin)
 }
 
 func (execution *executionDelegate) Stderr() io.Writer {
-	return execution.delegate.eventWriter(event.Origin{
-		Source: event.OriginSourceStderr,
-		ID:     execution.id,
-	})
+	origin := execution.origin
+	origin.Source = event.OriginSourceStderr
+
+	return execution.delegate.eventWriter(origin)
 }
 
 type dbEventWriter struct {
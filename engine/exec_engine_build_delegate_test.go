@@ -60,7 +60,7 @@ var _ = Describe("BuildDelegate", func() {
 				Params:     atc.Params{"some": "params"},
 			}
 
-			inputDelegate = delegate.InputDelegate(logger, getPlan, originID)
+			inputDelegate = delegate.InputDelegate(logger, getPlan, event.Origin{ID: originID})
 		})
 
 		Describe("Initializing", func() {
@@ -152,7 +152,7 @@ var _ = Describe("BuildDelegate", func() {
 				BeforeEach(func() {
 					getPlan.PipelineID = 0
 
-					inputDelegate = delegate.InputDelegate(logger, getPlan, originID)
+					inputDelegate = delegate.InputDelegate(logger, getPlan, event.Origin{ID: originID})
 				})
 
 				JustBeforeEach(func() {
@@ -347,7 +347,7 @@ var _ = Describe("BuildDelegate", func() {
 								Params:     atc.Params{"some": "output-params"},
 							}
 
-							outputDelegate = delegate.OutputDelegate(logger, putPlan, originID)
+							outputDelegate = delegate.OutputDelegate(logger, putPlan, event.Origin{ID: originID})
 						})
 
 						JustBeforeEach(func() {
@@ -389,7 +389,7 @@ var _ = Describe("BuildDelegate", func() {
 								BeforeEach(func() {
 									putPlan.PipelineID = 0
 
-									outputDelegate = delegate.OutputDelegate(logger, putPlan, originID)
+									outputDelegate = delegate.OutputDelegate(logger, putPlan, event.Origin{ID: originID})
 								})
 
 								It("does not save it as an output", func() {
@@ -426,6 +426,21 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("ResourceUsage", func() {
+			It("saves the usage of the get step on the build", func() {
+				usage := atc.ResourceUsage{PeakMemory: 1024, Samples: 1}
+				inputDelegate.ResourceUsage(usage)
+
+				Expect(fakeBuild.SaveResourceUsageCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveResourceUsageArgsForCall(0)).To(Equal(atc.StepResourceUsage{
+					PlanID:   atc.PlanID(originID),
+					StepName: "some-input",
+					StepType: "get",
+					Usage:    usage,
+				}))
+			})
+		})
+
 		Describe("ImageVersionDetermined", func() {
 			var identifier worker.VolumeIdentifier
 
@@ -550,7 +565,7 @@ var _ = Describe("BuildDelegate", func() {
 				ConfigPath: "/etc/concourse/config.yml",
 			}
 
-			executionDelegate = delegate.ExecutionDelegate(logger, taskPlan, originID)
+			executionDelegate = delegate.ExecutionDelegate(logger, taskPlan, event.Origin{ID: originID})
 		})
 
 		Describe("Initializing", func() {
@@ -717,6 +732,56 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("ResourceUsage", func() {
+			var usage atc.ResourceUsage
+
+			BeforeEach(func() {
+				usage = atc.ResourceUsage{
+					PeakCPU:       1.5,
+					AverageCPU:    0.5,
+					PeakMemory:    2048,
+					AverageMemory: 1024,
+					PeakDisk:      4096,
+					AverageDisk:   512,
+					Samples:       3,
+				}
+			})
+
+			JustBeforeEach(func() {
+				executionDelegate.ResourceUsage(usage)
+			})
+
+			It("saves a resource-usage event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.ResourceUsage{
+					Usage: usage,
+					Origin: event.Origin{
+						ID: originID,
+					},
+				}))
+			})
+
+			It("saves the usage of the step on the build", func() {
+				Expect(fakeBuild.SaveResourceUsageCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveResourceUsageArgsForCall(0)).To(Equal(atc.StepResourceUsage{
+					PlanID:   atc.PlanID(originID),
+					StepName: "some-task",
+					StepType: "task",
+					Usage:    usage,
+				}))
+			})
+
+			Context("when saving the event fails", func() {
+				BeforeEach(func() {
+					fakeBuild.SaveEventReturns(errors.New("nope"))
+				})
+
+				It("still saves the usage of the step", func() {
+					Expect(fakeBuild.SaveResourceUsageCallCount()).To(Equal(1))
+				})
+			})
+		})
+
 		Describe("ImageVersionDetermined", func() {
 			var identifier worker.VolumeIdentifier
 
@@ -821,7 +886,7 @@ var _ = Describe("BuildDelegate", func() {
 				Params:     atc.Params{"some": "params"},
 			}
 
-			outputDelegate = delegate.OutputDelegate(logger, putPlan, originID)
+			outputDelegate = delegate.OutputDelegate(logger, putPlan, event.Origin{ID: originID})
 		})
 
 		Describe("Initializing", func() {
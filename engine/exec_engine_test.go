@@ -10,6 +10,8 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine"
@@ -28,6 +30,7 @@ var _ = Describe("ExecEngine", func() {
 		fakeFactory         *execfakes.FakeFactory
 		fakeTeamDB          *dbfakes.FakeTeamDB
 		fakeDelegateFactory *enginefakes.FakeBuildDelegateFactory
+		fakeVariables       *credsfakes.FakeVariables
 		logger              *lagertest.TestLogger
 
 		execEngine engine.Engine
@@ -42,10 +45,22 @@ var _ = Describe("ExecEngine", func() {
 		fakeTeamDBFactory := new(dbfakes.FakeTeamDBFactory)
 		fakeTeamDB = new(dbfakes.FakeTeamDB)
 		fakeTeamDBFactory.GetTeamDBReturns(fakeTeamDB)
+
+		fakeVariables = new(credsfakes.FakeVariables)
+		fakeVariablesFactory := new(credsfakes.FakeVariablesFactory)
+		fakeVariablesFactory.NewVariablesStub = func(teamName string, pipelineName string) creds.Variables {
+			if teamName == "some-team" && pipelineName == "some-pipeline" {
+				return fakeVariables
+			}
+
+			return nil
+		}
+
 		execEngine = engine.NewExecEngine(
 			fakeFactory,
 			fakeDelegateFactory,
 			fakeTeamDBFactory,
+			fakeVariablesFactory,
 			"http://example.com",
 		)
 	})
@@ -95,6 +110,7 @@ var _ = Describe("ExecEngine", func() {
 				BuildName:    "21",
 				JobName:      "some-job",
 				PipelineName: "some-pipeline",
+				TeamName:     "some-team",
 				ExternalURL:  "http://example.com",
 			}
 
@@ -203,7 +219,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.PutCallCount()).To(Equal(2))
 
-					_, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.PutArgsForCall(0)
+					_, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.PutArgsForCall(0)
 					Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 					Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 				})
@@ -216,7 +232,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.PutCallCount()).To(Equal(2))
 
-					_, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.PutArgsForCall(0)
+					_, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.PutArgsForCall(0)
 					Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 					Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 				})
@@ -235,7 +251,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.PutCallCount()).To(Equal(2))
 
-					_, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.PutArgsForCall(0)
+					_, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.PutArgsForCall(0)
 					Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 					Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 				})
@@ -248,7 +264,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.DependentGetCallCount()).To(Equal(2))
 
-					_, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.DependentGetArgsForCall(0)
+					_, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.DependentGetArgsForCall(0)
 					Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 					Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 				})
@@ -263,7 +279,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.PutCallCount()).To(Equal(2))
 
-					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ := fakeFactory.PutArgsForCall(0)
+					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, _, params, _, _, _ := fakeFactory.PutArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -286,7 +302,7 @@ var _ = Describe("ExecEngine", func() {
 					Expect(resourceConfig.Source).To(Equal(atc.Source{"some": "source"}))
 					Expect(params).To(Equal(atc.Params{"some": "params"}))
 
-					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ = fakeFactory.PutArgsForCall(1)
+					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, _, params, _, _, _ = fakeFactory.PutArgsForCall(1)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -318,7 +334,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.DependentGetCallCount()).To(Equal(2))
 
-					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ := fakeFactory.DependentGetArgsForCall(0)
+					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, _, params, _, _, _ := fakeFactory.DependentGetArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -346,7 +362,7 @@ var _ = Describe("ExecEngine", func() {
 					Expect(resourceConfig.Source).To(Equal(atc.Source{"some": "source"}))
 					Expect(params).To(Equal(atc.Params{"another": "params"}))
 
-					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ = fakeFactory.DependentGetArgsForCall(1)
+					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, _, params, _, _, _ = fakeFactory.DependentGetArgsForCall(1)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -443,7 +459,7 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs the first get correctly", func() {
-				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _, _ := fakeFactory.GetArgsForCall(0)
+				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, variables, params, _, _, _, _ := fakeFactory.GetArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(metadata).To(Equal(expectedMetadata))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -461,6 +477,7 @@ var _ = Describe("ExecEngine", func() {
 
 				Expect(tags).To(BeEmpty())
 				Expect(actualTeamID).To(Equal(teamID))
+				Expect(variables).To(Equal(fakeVariables))
 				Expect(delegate).To(Equal(fakeInputDelegate))
 
 				Expect(sourceName).To(Equal(exec.SourceName("some-get")))
@@ -471,7 +488,7 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs the second get correctly", func() {
-				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _, _ := fakeFactory.GetArgsForCall(1)
+				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, _, params, _, _, _, _ := fakeFactory.GetArgsForCall(1)
 				Expect(logger).NotTo(BeNil())
 				Expect(metadata).To(Equal(expectedMetadata))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -503,7 +520,7 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested steps correctly", func() {
-				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, variables, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(sourceName).To(Equal(exec.SourceName("some-task")))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -523,9 +540,10 @@ var _ = Describe("ExecEngine", func() {
 				Expect(privileged).To(Equal(exec.Privileged(false)))
 				Expect(tags).To(Equal(atc.Tags{"some", "task", "tags"}))
 				Expect(actualTeamID).To(Equal(teamID))
+				Expect(variables).To(Equal(fakeVariables))
 				Expect(configSource).To(Equal(exec.ValidatingConfigSource{exec.FileConfigSource{"some-config-path"}}))
 
-				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
+				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, _, configSource, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(logger).NotTo(BeNil())
 				Expect(sourceName).To(Equal(exec.SourceName("some-task")))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -593,17 +611,70 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested steps correctly", func() {
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
+				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(2)
+				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(2)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(3)
+				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(3)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
 			})
 		})
 
+		Context("with an across plan", func() {
+			var (
+				acrossPlan atc.Plan
+				err        error
+			)
+
+			BeforeEach(func() {
+				acrossPlan = planFactory.NewPlan(atc.AcrossPlan{
+					Vars: []string{"go", "db"},
+					Steps: []atc.AcrossStep{
+						{
+							Values: []interface{}{"1.7", "postgres"},
+							Step: planFactory.NewPlan(atc.TaskPlan{
+								Name:       "some-task",
+								ConfigPath: "some-config-path",
+							}),
+						},
+						{
+							Values: []interface{}{"1.8", "mysql"},
+							Step: planFactory.NewPlan(atc.TaskPlan{
+								Name:       "some-task",
+								ConfigPath: "some-config-path",
+							}),
+						},
+					},
+				})
+
+				build, err = execEngine.CreateBuild(logger, dbBuild, acrossPlan)
+				Expect(err).NotTo(HaveOccurred())
+				build.Resume(logger)
+			})
+
+			It("constructs a step for each combination", func() {
+				Expect(fakeFactory.TaskCallCount()).To(Equal(2))
+			})
+
+			It("names each combination's origin after its values", func() {
+				Expect(fakeDelegate.ExecutionDelegateCallCount()).To(Equal(2))
+
+				_, _, origin := fakeDelegate.ExecutionDelegateArgsForCall(0)
+				Expect(origin).To(Equal(event.Origin{
+					ID:   event.OriginID(acrossPlan.Across.Steps[0].Step.ID),
+					Name: "go: 1.7, db: postgres",
+				}))
+
+				_, _, origin = fakeDelegate.ExecutionDelegateArgsForCall(1)
+				Expect(origin).To(Equal(event.Origin{
+					ID:   event.OriginID(acrossPlan.Across.Steps[1].Step.ID),
+					Name: "go: 1.8, db: mysql",
+				}))
+			})
+		})
+
 		Context("with a basic plan", func() {
 			var plan atc.Plan
 			Context("that contains inputs", func() {
@@ -635,7 +706,7 @@ var _ = Describe("ExecEngine", func() {
 						build.Resume(logger)
 						Expect(fakeFactory.GetCallCount()).To(Equal(1))
 
-						_, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.GetArgsForCall(0)
+						_, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.GetArgsForCall(0)
 						Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 						Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 					})
@@ -654,7 +725,7 @@ var _ = Describe("ExecEngine", func() {
 						build.Resume(logger)
 						Expect(fakeFactory.GetCallCount()).To(Equal(1))
 
-						_, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.GetArgsForCall(0)
+						_, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.GetArgsForCall(0)
 						Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 						Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 					})
@@ -668,7 +739,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.GetCallCount()).To(Equal(1))
 
-					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, version, _, _, _ := fakeFactory.GetArgsForCall(0)
+					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, _, params, version, _, _, _ := fakeFactory.GetArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -694,8 +765,8 @@ var _ = Describe("ExecEngine", func() {
 
 					Expect(delegate).To(Equal(fakeInputDelegate))
 
-					_, _, planID := fakeDelegate.InputDelegateArgsForCall(0)
-					Expect(planID).To(Equal(event.OriginID(plan.ID)))
+					_, _, origin := fakeDelegate.InputDelegateArgsForCall(0)
+					Expect(origin).To(Equal(event.Origin{ID: event.OriginID(plan.ID)}))
 				})
 
 				It("releases inputs correctly", func() {
@@ -750,7 +821,7 @@ var _ = Describe("ExecEngine", func() {
 						build.Resume(logger)
 						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-						_, _, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.TaskArgsForCall(0)
+						_, _, _, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.TaskArgsForCall(0)
 						Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 						Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 					})
@@ -769,7 +840,7 @@ var _ = Describe("ExecEngine", func() {
 						build.Resume(logger)
 						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-						_, _, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.TaskArgsForCall(0)
+						_, _, _, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.TaskArgsForCall(0)
 						Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 						Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 					})
@@ -782,7 +853,7 @@ var _ = Describe("ExecEngine", func() {
 						build.Resume(logger)
 						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-						logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, actualInputMapping, actualOutputMapping, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+						logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, _, configSource, _, actualInputMapping, actualOutputMapping, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 						Expect(logger).NotTo(BeNil())
 						Expect(sourceName).To(Equal(exec.SourceName("some-task")))
 						Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -804,8 +875,8 @@ var _ = Describe("ExecEngine", func() {
 
 						Expect(delegate).To(Equal(fakeExecutionDelegate))
 
-						_, _, planID := fakeDelegate.ExecutionDelegateArgsForCall(0)
-						Expect(planID).To(Equal(event.OriginID(plan.ID)))
+						_, _, origin := fakeDelegate.ExecutionDelegateArgsForCall(0)
+						Expect(origin).To(Equal(event.Origin{ID: event.OriginID(plan.ID)}))
 
 						Expect(actualInputMapping).To(Equal(inputMapping))
 						Expect(actualOutputMapping).To(Equal(outputMapping))
@@ -824,7 +895,7 @@ var _ = Describe("ExecEngine", func() {
 							build.Resume(logger)
 							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-							_, _, _, _, _, _, _, _, _, _, _, _, actualImageArtifactName, _, _, _ := fakeFactory.TaskArgsForCall(0)
+							_, _, _, _, _, _, _, _, _, _, _, _, _, actualImageArtifactName, _, _, _ := fakeFactory.TaskArgsForCall(0)
 							Expect(actualImageArtifactName).To(Equal("some-image-artifact-name"))
 						})
 					})
@@ -844,7 +915,7 @@ var _ = Describe("ExecEngine", func() {
 							build.Resume(logger)
 							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-							_, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+							_, _, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 							vcs, ok := configSource.(exec.ValidatingConfigSource)
 							Expect(ok).To(BeTrue())
 							_, ok = vcs.ConfigSource.(exec.MergedConfigSource)
@@ -869,7 +940,7 @@ var _ = Describe("ExecEngine", func() {
 							build.Resume(logger)
 							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-							_, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+							_, _, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 							vcs, ok := configSource.(exec.ValidatingConfigSource)
 							Expect(ok).To(BeTrue())
 							_, ok = vcs.ConfigSource.(exec.MergedConfigSource)
@@ -934,7 +1005,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.PutCallCount()).To(Equal(1))
 
-					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ := fakeFactory.PutArgsForCall(0)
+					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, variables, params, _, _, _ := fakeFactory.PutArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -954,12 +1025,13 @@ var _ = Describe("ExecEngine", func() {
 					Expect(resourceConfig.Source).To(Equal(atc.Source{"some": "source"}))
 					Expect(tags).To(ConsistOf("some", "putget", "tags"))
 					Expect(actualTeamID).To(Equal(teamID))
+					Expect(variables).To(Equal(fakeVariables))
 					Expect(params).To(Equal(atc.Params{"some": "params"}))
 
 					Expect(delegate).To(Equal(fakeOutputDelegate))
 
-					_, _, planID := fakeDelegate.OutputDelegateArgsForCall(0)
-					Expect(planID).To(Equal(event.OriginID(putPlan.ID)))
+					_, _, origin := fakeDelegate.OutputDelegateArgsForCall(0)
+					Expect(origin).To(Equal(event.Origin{ID: event.OriginID(putPlan.ID)}))
 				})
 
 				It("constructs the dependent get correctly", func() {
@@ -970,7 +1042,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.DependentGetCallCount()).To(Equal(1))
 
-					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ := fakeFactory.DependentGetArgsForCall(0)
+					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, _, params, _, _, _ := fakeFactory.DependentGetArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -995,8 +1067,8 @@ var _ = Describe("ExecEngine", func() {
 
 					Expect(delegate).To(Equal(fakeInputDelegate))
 
-					_, _, planID := fakeDelegate.InputDelegateArgsForCall(0)
-					Expect(planID).To(Equal(event.OriginID(dependentGetPlan.ID)))
+					_, _, origin := fakeDelegate.InputDelegateArgsForCall(0)
+					Expect(origin).To(Equal(event.Origin{ID: event.OriginID(dependentGetPlan.ID)}))
 				})
 
 				It("releases all sources", func() {
@@ -1119,13 +1191,14 @@ var _ = Describe("ExecEngine", func() {
 
 				foundBuild.Resume(logger)
 				Expect(fakeFactory.GetCallCount()).To(Equal(1))
-				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _, _ := fakeFactory.GetArgsForCall(0)
+				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, _, params, _, _, _, _ := fakeFactory.GetArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(metadata).To(Equal(engine.StepMetadata{
 					BuildID:      42,
 					BuildName:    "21",
 					JobName:      "some-job",
 					PipelineName: "some-pipeline",
+					TeamName:     "some-team",
 					ExternalURL:  "http://example.com",
 				}))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -1164,7 +1237,7 @@ var _ = Describe("ExecEngine", func() {
 					foundBuild.Resume(logger)
 					Expect(fakeFactory.GetCallCount()).To(Equal(1))
 
-					_, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.GetArgsForCall(0)
+					_, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.GetArgsForCall(0)
 					Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 					Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 				})
@@ -1182,7 +1255,7 @@ var _ = Describe("ExecEngine", func() {
 					foundBuild.Resume(logger)
 					Expect(fakeFactory.GetCallCount()).To(Equal(1))
 
-					_, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.GetArgsForCall(0)
+					_, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.GetArgsForCall(0)
 					Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 					Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 				})
@@ -5,6 +5,7 @@ import "fmt"
 type StepMetadata struct {
 	BuildID int
 
+	TeamName     string
 	PipelineName string
 	JobName      string
 	BuildName    string
@@ -32,3 +33,19 @@ func (metadata StepMetadata) Env() []string {
 
 	return env
 }
+
+// URL returns the location of the build in the web UI.
+func (metadata StepMetadata) URL() string {
+	if metadata.JobName == "" {
+		return fmt.Sprintf("%s/builds/%d", metadata.ExternalURL, metadata.BuildID)
+	}
+
+	return fmt.Sprintf(
+		"%s/teams/%s/pipelines/%s/jobs/%s/builds/%s",
+		metadata.ExternalURL,
+		metadata.TeamName,
+		metadata.PipelineName,
+		metadata.JobName,
+		metadata.BuildName,
+	)
+}
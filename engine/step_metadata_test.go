@@ -33,4 +33,26 @@ var _ = Describe("StepMetadata", func() {
 			}))
 		})
 	})
+
+	Describe("URL", func() {
+		It("links to the job build", func() {
+			Expect(StepMetadata{
+				BuildID:      1,
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline-name",
+				JobName:      "some-job-name",
+				BuildName:    "42",
+				ExternalURL:  "http://www.example.com",
+			}.URL()).To(Equal("http://www.example.com/teams/some-team/pipelines/some-pipeline-name/jobs/some-job-name/builds/42"))
+		})
+
+		It("links to one-off builds by ID", func() {
+			Expect(StepMetadata{
+				BuildID:     1,
+				TeamName:    "some-team",
+				BuildName:   "1",
+				ExternalURL: "http://www.example.com",
+			}.URL()).To(Equal("http://www.example.com/builds/1"))
+		})
+	})
 })
@@ -10,6 +10,14 @@ type Error struct {
 func (Error) EventType() atc.EventType  { return EventTypeError }
 func (Error) Version() atc.EventVersion { return "4.0" }
 
+type ResourceUsage struct {
+	Usage  atc.ResourceUsage `json:"usage"`
+	Origin Origin            `json:"origin"`
+}
+
+func (ResourceUsage) EventType() atc.EventType  { return EventTypeResourceUsage }
+func (ResourceUsage) Version() atc.EventVersion { return "1.0" }
+
 type FinishTask struct {
 	Time       int64  `json:"time"`
 	ExitStatus int    `json:"exit_status"`
@@ -95,6 +103,7 @@ func (Log) Version() atc.EventVersion { return "5.0" }
 
 type Origin struct {
 	ID     OriginID     `json:"id,omitempty"`
+	Name   string       `json:"name,omitempty"`
 	Source OriginSource `json:"source,omitempty"`
 }
 
@@ -43,6 +43,7 @@ func init() {
 	registerEvent(Status{})
 	registerEvent(Log{})
 	registerEvent(Error{})
+	registerEvent(ResourceUsage{})
 
 	// deprecated:
 	registerEvent(FinishV10{})
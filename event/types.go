@@ -34,4 +34,7 @@ const (
 
 	// error occurred
 	EventTypeError atc.EventType = "error"
+
+	// resources consumed by a step's container
+	EventTypeResourceUsage atc.EventType = "resource-usage"
 )
@@ -3,8 +3,10 @@ package exec
 import (
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/resource"
 )
 
@@ -20,8 +22,10 @@ type DependentGetStep struct {
 	session             resource.Session
 	tags                atc.Tags
 	teamID              int
+	variables           creds.Variables
 	delegate            ResourceDelegate
 	resourceFetcher     resource.Fetcher
+	clock               clock.Clock
 	resourceTypes       atc.ResourceTypes
 	containerSuccessTTL time.Duration
 	containerFailureTTL time.Duration
@@ -36,8 +40,10 @@ func newDependentGetStep(
 	session resource.Session,
 	tags atc.Tags,
 	teamID int,
+	variables creds.Variables,
 	delegate ResourceDelegate,
 	resourceFetcher resource.Fetcher,
+	clock clock.Clock,
 	resourceTypes atc.ResourceTypes,
 	containerSuccessTTL time.Duration,
 	containerFailureTTL time.Duration,
@@ -51,8 +57,10 @@ func newDependentGetStep(
 		session:             session,
 		tags:                tags,
 		teamID:              teamID,
+		variables:           variables,
 		delegate:            delegate,
 		resourceFetcher:     resourceFetcher,
+		clock:               clock,
 		resourceTypes:       resourceTypes,
 		containerSuccessTTL: containerSuccessTTL,
 		containerFailureTTL: containerFailureTTL,
@@ -81,8 +89,10 @@ func (step DependentGetStep) Using(prev Step, repo *SourceRepository) Step {
 		step.session,
 		step.tags,
 		step.teamID,
+		step.variables,
 		step.delegate,
 		step.resourceFetcher,
+		step.clock,
 		step.resourceTypes,
 		step.containerSuccessTTL,
 		step.containerFailureTTL,
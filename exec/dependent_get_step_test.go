@@ -10,9 +10,11 @@ import (
 	"os"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
@@ -33,6 +35,7 @@ var _ = Describe("DependentGet", func() {
 		fakeVersionedSource *rfakes.FakeVersionedSource
 		fakeFetchSource     *rfakes.FakeFetchSource
 		fakeCache           *rfakes.FakeCache
+		fakeVariables       *credsfakes.FakeVariables
 		getDelegate         *execfakes.FakeGetDelegate
 		resourceConfig      atc.ResourceConfig
 		params              atc.Params
@@ -73,9 +76,12 @@ var _ = Describe("DependentGet", func() {
 	BeforeEach(func() {
 		fakeWorkerClient = new(wfakes.FakeClient)
 		fakeResourceFetcher = new(rfakes.FakeFetcher)
+
+		fakeVariables = new(credsfakes.FakeVariables)
+		fakeVariables.GetReturns("super-secret", true, nil)
 		fakeTracker := new(rfakes.FakeTracker)
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, fakeResourceFetcher)
+		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, fakeResourceFetcher, atc.ContainerLimits{}, atc.ContainerLimits{}, clock.NewClock())
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -140,6 +146,7 @@ var _ = Describe("DependentGet", func() {
 			resourceConfig,
 			tags,
 			teamID,
+			fakeVariables,
 			params,
 			resourceTypes,
 			successTTL,
@@ -199,6 +206,17 @@ var _ = Describe("DependentGet", func() {
 			fakeVersionedSource.MetadataReturns([]atc.MetadataField{{"some", "metadata"}})
 		})
 
+		Context("when the source contains ((var)) placeholders", func() {
+			BeforeEach(func() {
+				resourceConfig.Source = atc.Source{"some": "((some-secret))"}
+			})
+
+			It("fetches with the resolved source", func() {
+				_, _, _, _, _, _, _, _, resourceOptions, _, _ := fakeResourceFetcher.FetchArgsForCall(0)
+				Expect(resourceOptions.Source()).To(Equal(atc.Source{"some": "super-secret"}))
+			})
+		})
+
 		It("initializes the resource with the correct type and session id, making sure that it is not ephemeral", func() {
 			Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
 			_, sid, tags, actualTeamID, actualResourceTypes, cacheID, sm, delegate, resourceOptions, _, _ := fakeResourceFetcher.FetchArgsForCall(0)
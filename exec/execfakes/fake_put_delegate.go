@@ -5,6 +5,7 @@ import (
 	"io"
 	"sync"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/worker"
 )
@@ -24,6 +25,11 @@ type FakePutDelegate struct {
 	failedArgsForCall []struct {
 		arg1 error
 	}
+	ResourceUsageStub        func(atc.ResourceUsage)
+	resourceUsageMutex       sync.RWMutex
+	resourceUsageArgsForCall []struct {
+		arg1 atc.ResourceUsage
+	}
 	ImageVersionDeterminedStub        func(worker.VolumeIdentifier) error
 	imageVersionDeterminedMutex       sync.RWMutex
 	imageVersionDeterminedArgsForCall []struct {
@@ -113,6 +119,30 @@ func (fake *FakePutDelegate) FailedArgsForCall(i int) error {
 	return fake.failedArgsForCall[i].arg1
 }
 
+func (fake *FakePutDelegate) ResourceUsage(arg1 atc.ResourceUsage) {
+	fake.resourceUsageMutex.Lock()
+	fake.resourceUsageArgsForCall = append(fake.resourceUsageArgsForCall, struct {
+		arg1 atc.ResourceUsage
+	}{arg1})
+	fake.recordInvocation("ResourceUsage", []interface{}{arg1})
+	fake.resourceUsageMutex.Unlock()
+	if fake.ResourceUsageStub != nil {
+		fake.ResourceUsageStub(arg1)
+	}
+}
+
+func (fake *FakePutDelegate) ResourceUsageCallCount() int {
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
+	return len(fake.resourceUsageArgsForCall)
+}
+
+func (fake *FakePutDelegate) ResourceUsageArgsForCall(i int) atc.ResourceUsage {
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
+	return fake.resourceUsageArgsForCall[i].arg1
+}
+
 func (fake *FakePutDelegate) ImageVersionDetermined(arg1 worker.VolumeIdentifier) error {
 	fake.imageVersionDeterminedMutex.Lock()
 	fake.imageVersionDeterminedArgsForCall = append(fake.imageVersionDeterminedArgsForCall, struct {
@@ -205,6 +235,8 @@ func (fake *FakePutDelegate) Invocations() map[string][][]interface{} {
 	defer fake.completedMutex.RUnlock()
 	fake.failedMutex.RLock()
 	defer fake.failedMutex.RUnlock()
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
 	fake.imageVersionDeterminedMutex.RLock()
 	defer fake.imageVersionDeterminedMutex.RUnlock()
 	fake.stdoutMutex.RLock()
@@ -7,6 +7,7 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/worker"
 )
 
@@ -25,6 +26,7 @@ type Factory interface {
 		atc.ResourceConfig,
 		atc.Tags,
 		int,
+		creds.Variables,
 		atc.Params,
 		atc.Version,
 		atc.ResourceTypes,
@@ -42,6 +44,7 @@ type Factory interface {
 		atc.ResourceConfig,
 		atc.Tags,
 		int,
+		creds.Variables,
 		atc.Params,
 		atc.ResourceTypes,
 		time.Duration,
@@ -60,6 +63,7 @@ type Factory interface {
 		atc.ResourceConfig,
 		atc.Tags,
 		int,
+		creds.Variables,
 		atc.Params,
 		atc.ResourceTypes,
 		time.Duration,
@@ -76,6 +80,7 @@ type Factory interface {
 		Privileged,
 		atc.Tags,
 		int,
+		creds.Variables,
 		TaskConfigSource,
 		atc.ResourceTypes,
 		map[string]string,
@@ -104,6 +109,8 @@ type TaskDelegate interface {
 	Finished(ExitStatus)
 	Failed(error)
 
+	ResourceUsage(atc.ResourceUsage)
+
 	ImageVersionDetermined(worker.VolumeIdentifier) error
 
 	Stdout() io.Writer
@@ -118,6 +125,8 @@ type ResourceDelegate interface {
 	Completed(ExitStatus, *VersionInfo)
 	Failed(error)
 
+	ResourceUsage(atc.ResourceUsage)
+
 	ImageVersionDetermined(worker.VolumeIdentifier) error
 
 	Stdout() io.Writer
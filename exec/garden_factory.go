@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
 )
@@ -18,6 +19,11 @@ type gardenFactory struct {
 	workerClient    worker.Client
 	tracker         resource.Tracker
 	resourceFetcher resource.Fetcher
+
+	defaultLimits atc.ContainerLimits
+	maxLimits     atc.ContainerLimits
+
+	clock clock.Clock
 }
 
 //go:generate counterfeiter . TrackerFactory
@@ -30,11 +36,19 @@ func NewGardenFactory(
 	workerClient worker.Client,
 	tracker resource.Tracker,
 	resourceFetcher resource.Fetcher,
+	defaultLimits atc.ContainerLimits,
+	maxLimits atc.ContainerLimits,
+	clock clock.Clock,
 ) Factory {
 	return &gardenFactory{
 		workerClient:    workerClient,
 		tracker:         tracker,
 		resourceFetcher: resourceFetcher,
+
+		defaultLimits: defaultLimits,
+		maxLimits:     maxLimits,
+
+		clock: clock,
 	}
 }
 
@@ -48,6 +62,7 @@ func (factory *gardenFactory) DependentGet(
 	resourceConfig atc.ResourceConfig,
 	tags atc.Tags,
 	teamID int,
+	variables creds.Variables,
 	params atc.Params,
 	resourceTypes atc.ResourceTypes,
 	containerSuccessTTL time.Duration,
@@ -66,8 +81,10 @@ func (factory *gardenFactory) DependentGet(
 		},
 		tags,
 		teamID,
+		variables,
 		delegate,
 		factory.resourceFetcher,
+		factory.clock,
 		resourceTypes,
 		containerSuccessTTL,
 		containerFailureTTL,
@@ -84,6 +101,7 @@ func (factory *gardenFactory) Get(
 	resourceConfig atc.ResourceConfig,
 	tags atc.Tags,
 	teamID int,
+	variables creds.Variables,
 	params atc.Params,
 	version atc.Version,
 	resourceTypes atc.ResourceTypes,
@@ -111,8 +129,10 @@ func (factory *gardenFactory) Get(
 		},
 		tags,
 		teamID,
+		variables,
 		delegate,
 		factory.resourceFetcher,
+		factory.clock,
 		resourceTypes,
 
 		containerSuccessTTL,
@@ -129,6 +149,7 @@ func (factory *gardenFactory) Put(
 	resourceConfig atc.ResourceConfig,
 	tags atc.Tags,
 	teamID int,
+	variables creds.Variables,
 	params atc.Params,
 	resourceTypes atc.ResourceTypes,
 	containerSuccessTTL time.Duration,
@@ -147,8 +168,10 @@ func (factory *gardenFactory) Put(
 		},
 		tags,
 		teamID,
+		variables,
 		delegate,
 		factory.tracker,
+		factory.clock,
 		resourceTypes,
 		containerSuccessTTL,
 		containerFailureTTL,
@@ -164,6 +187,7 @@ func (factory *gardenFactory) Task(
 	privileged Privileged,
 	tags atc.Tags,
 	teamID int,
+	variables creds.Variables,
 	configSource TaskConfigSource,
 	resourceTypes atc.ResourceTypes,
 	inputMapping map[string]string,
@@ -181,6 +205,7 @@ func (factory *gardenFactory) Task(
 		workerMetadata,
 		tags,
 		teamID,
+		variables,
 		delegate,
 		privileged,
 		configSource,
@@ -190,6 +215,8 @@ func (factory *gardenFactory) Task(
 		inputMapping,
 		outputMapping,
 		imageArtifactName,
+		factory.defaultLimits,
+		factory.maxLimits,
 		clock,
 		containerSuccessTTL,
 		containerFailureTTL,
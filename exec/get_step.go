@@ -9,8 +9,10 @@ import (
 	"os"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -29,8 +31,10 @@ type GetStep struct {
 	session         resource.Session
 	tags            atc.Tags
 	teamID          int
+	variables       creds.Variables
 	delegate        GetDelegate
 	resourceFetcher resource.Fetcher
+	clock           clock.Clock
 	resourceTypes   atc.ResourceTypes
 
 	repository *SourceRepository
@@ -54,8 +58,10 @@ func newGetStep(
 	session resource.Session,
 	tags atc.Tags,
 	teamID int,
+	variables creds.Variables,
 	delegate GetDelegate,
 	resourceFetcher resource.Fetcher,
+	clock clock.Clock,
 	resourceTypes atc.ResourceTypes,
 	containerSuccessTTL time.Duration,
 	containerFailureTTL time.Duration,
@@ -71,8 +77,10 @@ func newGetStep(
 		session:             session,
 		tags:                tags,
 		teamID:              teamID,
+		variables:           variables,
 		delegate:            delegate,
 		resourceFetcher:     resourceFetcher,
+		clock:               clock,
 		resourceTypes:       resourceTypes,
 		containerSuccessTTL: containerSuccessTTL,
 		containerFailureTTL: containerFailureTTL,
@@ -116,24 +124,43 @@ func (step GetStep) Using(prev Step, repo *SourceRepository) Step {
 func (step *GetStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 	step.delegate.Initializing()
 
+	source, err := creds.NewSource(step.variables, step.resourceConfig.Source).Evaluate()
+	if err != nil {
+		return err
+	}
+
+	params, err := creds.NewParams(step.variables, step.params).Evaluate()
+	if err != nil {
+		return err
+	}
+
+	resourceTypes, err := creds.NewResourceTypes(step.variables, step.resourceTypes).Evaluate()
+	if err != nil {
+		return err
+	}
+
 	runSession := step.session
 	runSession.ID.Stage = db.ContainerStageRun
 
 	resourceDefinition := &getStepResource{
-		source:       step.resourceConfig.Source,
+		source:       source,
 		resourceType: resource.ResourceType(step.resourceConfig.Type),
 		delegate:     step.delegate,
-		params:       step.params,
+		params:       params,
 		version:      step.version,
+		usage: &resource.UsageReporter{
+			Logger: step.logger.Session("sample-usage"),
+			Clock:  step.clock,
+			Report: step.delegate.ResourceUsage,
+		},
 	}
 
-	var err error
 	step.fetchSource, err = step.resourceFetcher.Fetch(
 		step.logger,
 		runSession,
 		step.tags,
 		step.teamID,
-		step.resourceTypes,
+		resourceTypes,
 		step.cacheIdentifier,
 		step.stepMetadata,
 		step.delegate,
@@ -249,12 +276,14 @@ type getStepResource struct {
 	source       atc.Source
 	params       atc.Params
 	version      atc.Version
+	usage        *resource.UsageReporter
 }
 
 func (d *getStepResource) IOConfig() resource.IOConfig {
 	return resource.IOConfig{
 		Stdout: d.delegate.Stdout(),
 		Stderr: d.delegate.Stderr(),
+		Usage:  d.usage,
 	}
 }
 
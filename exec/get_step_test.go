@@ -10,9 +10,12 @@ import (
 	"os"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
@@ -30,6 +33,7 @@ var _ = Describe("Get", func() {
 	var (
 		fakeWorkerClient    *wfakes.FakeClient
 		fakeResourceFetcher *rfakes.FakeFetcher
+		fakeVariables       *credsfakes.FakeVariables
 
 		fakeCache           *rfakes.FakeCache
 		fakeVolume          *wfakes.FakeVolume
@@ -82,6 +86,15 @@ var _ = Describe("Get", func() {
 		fakeVolume = new(wfakes.FakeVolume)
 		fakeCache.VolumeReturns(fakeVolume)
 
+		fakeVariables = new(credsfakes.FakeVariables)
+		fakeVariables.GetStub = func(name string) (interface{}, bool, error) {
+			if name == "some-secret" {
+				return "super-secret", true, nil
+			}
+
+			return nil, false, nil
+		}
+
 		getDelegate = new(execfakes.FakeGetDelegate)
 
 		stdoutBuf = gbytes.NewBuffer()
@@ -120,7 +133,7 @@ var _ = Describe("Get", func() {
 		fakeVersionedSource = new(rfakes.FakeVersionedSource)
 		fakeFetchSource.VersionedSourceReturns(fakeVersionedSource)
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, fakeResourceFetcher)
+		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, fakeResourceFetcher, atc.ContainerLimits{}, atc.ContainerLimits{}, clock.NewClock())
 	})
 
 	JustBeforeEach(func() {
@@ -134,6 +147,7 @@ var _ = Describe("Get", func() {
 			resourceConfig,
 			tags,
 			teamID,
+			fakeVariables,
 			params,
 			version,
 			resourceTypes,
@@ -226,6 +240,53 @@ var _ = Describe("Get", func() {
 		Expect(resourceOptions.LeaseName("fake-worker")).To(Equal(expectedLeaseName))
 	})
 
+	Context("when the source, params, and resource types contain ((var)) placeholders", func() {
+		BeforeEach(func() {
+			resourceConfig.Source = atc.Source{"some": "((some-secret))"}
+			params = atc.Params{"some-param": "((some-secret))"}
+			resourceTypes[0].Source = atc.Source{"some-custom": "((some-secret))"}
+		})
+
+		It("fetches with the resolved values", func() {
+			Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
+			_, _, _, _, actualResourceTypes, _, _, _, resourceOptions, _, _ := fakeResourceFetcher.FetchArgsForCall(0)
+			Expect(resourceOptions.Source()).To(Equal(atc.Source{"some": "super-secret"}))
+			Expect(resourceOptions.Params()).To(Equal(atc.Params{"some-param": "super-secret"}))
+			Expect(actualResourceTypes[0].Source).To(Equal(atc.Source{"some-custom": "super-secret"}))
+		})
+
+		It("keeps the placeholders in the cache identifier", func() {
+			_, _, _, _, _, cacheID, _, _, _, _, _ := fakeResourceFetcher.FetchArgsForCall(0)
+			Expect(cacheID).To(Equal(resource.ResourceCacheIdentifier{
+				Type:    "some-resource-type",
+				Source:  atc.Source{"some": "((some-secret))"},
+				Params:  atc.Params{"some-param": "((some-secret))"},
+				Version: version,
+			}))
+		})
+	})
+
+	Context("when a ((var)) placeholder is undefined", func() {
+		BeforeEach(func() {
+			resourceConfig.Source = atc.Source{"some": "((missing))"}
+		})
+
+		It("does not fetch the resource", func() {
+			Expect(fakeResourceFetcher.FetchCallCount()).To(BeZero())
+		})
+
+		It("reports the error to the delegate", func() {
+			Eventually(process.Wait()).Should(Receive(Equal(creds.UndefinedVariablesError{
+				Names: []string{"missing"},
+			})))
+
+			Expect(getDelegate.FailedCallCount()).To(Equal(1))
+			Expect(getDelegate.FailedArgsForCall(0)).To(Equal(creds.UndefinedVariablesError{
+				Names: []string{"missing"},
+			}))
+		})
+	})
+
 	Context("when fetching resource succeeds", func() {
 		var (
 			fakeContainer *wfakes.FakeContainer
@@ -0,0 +1,175 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tedsuo/ifrit"
+)
+
+// InParallel constructs a Step that will run its steps in parallel, at most
+// Limit at a time.
+type InParallel struct {
+	Steps    []StepFactory
+	Limit    int
+	FailFast bool
+}
+
+// Using delegates to each StepFactory and returns an *InParallelStep.
+func (stepFactory InParallel) Using(prev Step, repo *SourceRepository) Step {
+	inParallel := &InParallelStep{
+		limit:    stepFactory.Limit,
+		failFast: stepFactory.FailFast,
+	}
+
+	for _, subStepFactory := range stepFactory.Steps {
+		inParallel.steps = append(inParallel.steps, subStepFactory.Using(prev, repo))
+	}
+
+	return inParallel
+}
+
+// InParallelStep is a step of steps to run in parallel, at most limit at a
+// time.
+type InParallelStep struct {
+	steps    []Step
+	limit    int
+	failFast bool
+}
+
+type inParallelExit struct {
+	index int
+	err   error
+}
+
+// Run starts up to limit steps at once, starting the next step whenever a
+// running one exits. A limit of 0 runs every step at once. It will indicate
+// that it's ready when the first batch of steps is ready, and propagate any
+// signal received to all running steps.
+//
+// If failFast is set, the first step to fail or error causes the remaining
+// running steps to be interrupted and no further steps to be started. Errors
+// returned by steps interrupted this way are not reported; the failing step
+// determines the result.
+//
+// Otherwise it will wait for all steps to exit, even if one step fails or
+// errors. After all steps finish, their errors (if any) will be aggregated and
+// returned as a single error.
+func (step *InParallelStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	limit := step.limit
+	if limit <= 0 || limit > len(step.steps) {
+		limit = len(step.steps)
+	}
+
+	exits := make(chan inParallelExit, len(step.steps))
+	running := map[int]ifrit.Process{}
+	started := 0
+
+	start := func() {
+		index := started
+		started++
+
+		process := ifrit.Background(step.steps[index])
+		running[index] = process
+
+		go func() {
+			exits <- inParallelExit{index: index, err: <-process.Wait()}
+		}()
+	}
+
+	for started < limit {
+		start()
+	}
+
+	for _, mp := range running {
+		select {
+		case <-mp.Ready():
+		case <-mp.Wait():
+		}
+	}
+
+	close(ready)
+
+	var errorMessages []string
+	interrupted := false
+
+	for len(running) > 0 {
+		select {
+		case sig := <-signals:
+			for _, mp := range running {
+				mp.Signal(sig)
+			}
+
+			for _, mp := range running {
+				<-mp.Wait()
+			}
+
+			return ErrInterrupted
+
+		case exit := <-exits:
+			delete(running, exit.index)
+
+			if interrupted {
+				if exit.err != nil && exit.err != ErrInterrupted {
+					errorMessages = append(errorMessages, exit.err.Error())
+				}
+
+				continue
+			}
+
+			if exit.err != nil {
+				errorMessages = append(errorMessages, exit.err.Error())
+			}
+
+			if step.failFast && !stepSucceeded(step.steps[exit.index], exit.err) {
+				interrupted = true
+
+				for _, mp := range running {
+					mp.Signal(os.Interrupt)
+				}
+
+				continue
+			}
+
+			if started < len(step.steps) {
+				start()
+			}
+		}
+	}
+
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("steps failed:\n%s", strings.Join(errorMessages, "\n"))
+	}
+
+	return nil
+}
+
+func stepSucceeded(step Step, err error) bool {
+	if err != nil {
+		return false
+	}
+
+	var success Success
+	if !step.Result(&success) {
+		return true
+	}
+
+	return bool(success)
+}
+
+// Release iterates over the steps and Releases them individually.
+func (step *InParallelStep) Release() {
+	for _, src := range step.steps {
+		src.Release()
+	}
+}
+
+// Result indicates Success as true if all of the steps that ran indicate
+// Success as true, or if there were no steps at all. If none of the steps can
+// indicate Success, it will return false and not indicate success itself.
+//
+// All other result types are ignored, and Result will return false.
+func (step *InParallelStep) Result(x interface{}) bool {
+	return AggregateStep(step.steps).Result(x)
+}
@@ -0,0 +1,265 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+
+	. "github.com/concourse/atc/exec"
+
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("InParallel", func() {
+	var (
+		fakeStepA *execfakes.FakeStepFactory
+		fakeStepB *execfakes.FakeStepFactory
+		fakeStepC *execfakes.FakeStepFactory
+
+		limit    int
+		failFast bool
+
+		inStep *execfakes.FakeStep
+		repo   *SourceRepository
+
+		outStepA *execfakes.FakeStep
+		outStepB *execfakes.FakeStep
+		outStepC *execfakes.FakeStep
+
+		step    Step
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		fakeStepA = new(execfakes.FakeStepFactory)
+		fakeStepB = new(execfakes.FakeStepFactory)
+		fakeStepC = new(execfakes.FakeStepFactory)
+
+		limit = 0
+		failFast = false
+
+		inStep = new(execfakes.FakeStep)
+		repo = NewSourceRepository()
+
+		outStepA = new(execfakes.FakeStep)
+		fakeStepA.UsingReturns(outStepA)
+
+		outStepB = new(execfakes.FakeStep)
+		fakeStepB.UsingReturns(outStepB)
+
+		outStepC = new(execfakes.FakeStep)
+		fakeStepC.UsingReturns(outStepC)
+	})
+
+	JustBeforeEach(func() {
+		step = InParallel{
+			Steps:    []StepFactory{fakeStepA, fakeStepB, fakeStepC},
+			Limit:    limit,
+			FailFast: failFast,
+		}.Using(inStep, repo)
+
+		process = ifrit.Invoke(step)
+	})
+
+	It("uses the input source for all steps", func() {
+		for _, fakeStep := range []*execfakes.FakeStepFactory{fakeStepA, fakeStepB, fakeStepC} {
+			Expect(fakeStep.UsingCallCount()).To(Equal(1))
+			step, repo := fakeStep.UsingArgsForCall(0)
+			Expect(step).To(Equal(inStep))
+			Expect(repo).To(Equal(repo))
+		}
+	})
+
+	It("runs every step and exits successfully", func() {
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+
+		Expect(outStepA.RunCallCount()).To(Equal(1))
+		Expect(outStepB.RunCallCount()).To(Equal(1))
+		Expect(outStepC.RunCallCount()).To(Equal(1))
+	})
+
+	Context("with a limit", func() {
+		var finishA chan struct{}
+		var finishB chan struct{}
+
+		BeforeEach(func() {
+			limit = 2
+
+			finishA = make(chan struct{})
+			finishB = make(chan struct{})
+
+			outStepA.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+				<-finishA
+				return nil
+			}
+
+			outStepB.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+				<-finishB
+				return nil
+			}
+		})
+
+		AfterEach(func() {
+			close(finishB)
+		})
+
+		It("only runs up to the limit at once", func() {
+			Eventually(outStepA.RunCallCount).Should(Equal(1))
+			Eventually(outStepB.RunCallCount).Should(Equal(1))
+			Consistently(outStepC.RunCallCount).Should(BeZero())
+
+			close(finishA)
+
+			Eventually(outStepC.RunCallCount).Should(Equal(1))
+			Consistently(process.Wait()).ShouldNot(Receive())
+		})
+	})
+
+	Describe("signalling", func() {
+		var receivedSignals chan os.Signal
+		var actuallyExit chan struct{}
+
+		BeforeEach(func() {
+			receivedSignals = make(chan os.Signal, 3)
+			actuallyExit = make(chan struct{})
+
+			run := func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+				receivedSignals <- <-signals
+				<-actuallyExit
+				return ErrInterrupted
+			}
+
+			outStepA.RunStub = run
+			outStepB.RunStub = run
+			outStepC.RunStub = run
+		})
+
+		It("returns ErrInterrupted", func() {
+			process.Signal(os.Interrupt)
+
+			Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+			Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+			Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+			Consistently(process.Wait()).ShouldNot(Receive())
+			close(actuallyExit)
+			Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+		})
+	})
+
+	Context("when steps error", func() {
+		BeforeEach(func() {
+			outStepA.RunReturns(errors.New("nope A"))
+			outStepB.RunReturns(errors.New("nope B"))
+		})
+
+		It("runs the remaining steps and exits with an error including the original messages", func() {
+			var err error
+			Eventually(process.Wait()).Should(Receive(&err))
+
+			Expect(err.Error()).To(ContainSubstring("nope A"))
+			Expect(err.Error()).To(ContainSubstring("nope B"))
+
+			Expect(outStepC.RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when failing fast", func() {
+		var receivedSignals chan os.Signal
+
+		BeforeEach(func() {
+			limit = 2
+			failFast = true
+
+			receivedSignals = make(chan os.Signal, 1)
+
+			outStepB.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+				receivedSignals <- <-signals
+				return ErrInterrupted
+			}
+		})
+
+		Context("and a step fails", func() {
+			BeforeEach(func() {
+				outStepA.ResultStub = successResult(false)
+			})
+
+			It("interrupts the running steps and does not start any more", func() {
+				Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(outStepC.RunCallCount()).To(BeZero())
+			})
+
+			It("does not indicate success", func() {
+				Eventually(process.Wait()).Should(Receive())
+
+				var success Success
+				Expect(step.Result(&success)).To(BeTrue())
+				Expect(success).To(Equal(Success(false)))
+			})
+		})
+
+		Context("and a step errors", func() {
+			BeforeEach(func() {
+				outStepA.RunReturns(errors.New("nope A"))
+			})
+
+			It("interrupts the running steps and exits with only the original error", func() {
+				Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+
+				var err error
+				Eventually(process.Wait()).Should(Receive(&err))
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("nope A"))
+				Expect(err.Error()).NotTo(ContainSubstring(ErrInterrupted.Error()))
+
+				Expect(outStepC.RunCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("releasing", func() {
+		It("releases all steps", func() {
+			step.Release()
+
+			Expect(outStepA.ReleaseCallCount()).To(Equal(1))
+			Expect(outStepB.ReleaseCallCount()).To(Equal(1))
+			Expect(outStepC.ReleaseCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("getting a Success result", func() {
+		var result Success
+
+		Context("and all steps are successful", func() {
+			BeforeEach(func() {
+				outStepA.ResultStub = successResult(true)
+				outStepB.ResultStub = successResult(true)
+				outStepC.ResultStub = successResult(true)
+			})
+
+			It("yields true", func() {
+				Expect(step.Result(&result)).To(BeTrue())
+				Expect(result).To(Equal(Success(true)))
+			})
+		})
+
+		Context("and some steps are not successful", func() {
+			BeforeEach(func() {
+				outStepA.ResultStub = successResult(true)
+				outStepB.ResultStub = successResult(false)
+			})
+
+			It("yields false", func() {
+				Expect(step.Result(&result)).To(BeTrue())
+				Expect(result).To(Equal(Success(false)))
+			})
+		})
+	})
+})
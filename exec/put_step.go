@@ -6,8 +6,10 @@ import (
 	"os"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -23,8 +25,10 @@ type PutStep struct {
 	session        resource.Session
 	tags           atc.Tags
 	teamID         int
+	variables      creds.Variables
 	delegate       PutDelegate
 	tracker        resource.Tracker
+	clock          clock.Clock
 	resourceTypes  atc.ResourceTypes
 
 	repository *SourceRepository
@@ -47,8 +51,10 @@ func newPutStep(
 	session resource.Session,
 	tags atc.Tags,
 	teamID int,
+	variables creds.Variables,
 	delegate PutDelegate,
 	tracker resource.Tracker,
+	clock clock.Clock,
 	resourceTypes atc.ResourceTypes,
 	containerSuccessTTL time.Duration,
 	containerFailureTTL time.Duration,
@@ -61,8 +67,10 @@ func newPutStep(
 		session:             session,
 		tags:                tags,
 		teamID:              teamID,
+		variables:           variables,
 		delegate:            delegate,
 		tracker:             tracker,
+		clock:               clock,
 		resourceTypes:       resourceTypes,
 		containerSuccessTTL: containerSuccessTTL,
 		containerFailureTTL: containerFailureTTL,
@@ -91,6 +99,21 @@ func (step PutStep) Using(prev Step, repo *SourceRepository) Step {
 func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 	step.delegate.Initializing()
 
+	evaluatedSource, err := creds.NewSource(step.variables, step.resourceConfig.Source).Evaluate()
+	if err != nil {
+		return err
+	}
+
+	params, err := creds.NewParams(step.variables, step.params).Evaluate()
+	if err != nil {
+		return err
+	}
+
+	resourceTypes, err := creds.NewResourceTypes(step.variables, step.resourceTypes).Evaluate()
+	if err != nil {
+		return err
+	}
+
 	sources := step.repository.AsMap()
 
 	resourceSources := make(map[string]resource.ArtifactSource)
@@ -109,7 +132,7 @@ func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		step.tags,
 		step.teamID,
 		resourceSources,
-		step.resourceTypes,
+		resourceTypes,
 		step.delegate,
 	)
 
@@ -140,9 +163,14 @@ func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		resource.IOConfig{
 			Stdout: step.delegate.Stdout(),
 			Stderr: step.delegate.Stderr(),
+			Usage: &resource.UsageReporter{
+				Logger: step.logger.Session("sample-usage"),
+				Clock:  step.clock,
+				Report: step.delegate.ResourceUsage,
+			},
 		},
-		step.resourceConfig.Source,
-		step.params,
+		evaluatedSource,
+		params,
 		artifactSource,
 		signals,
 		ready,
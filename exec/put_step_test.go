@@ -8,9 +8,12 @@ import (
 	"os"
 	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
@@ -29,6 +32,8 @@ var _ = Describe("GardenFactory", func() {
 		fakeWorkerClient   *wfakes.FakeClient
 		fakeTracker        *rfakes.FakeTracker
 		fakeTrackerFactory *execfakes.FakeTrackerFactory
+		fakeVariables      *credsfakes.FakeVariables
+		fakeClock          *fakeclock.FakeClock
 
 		factory Factory
 
@@ -54,7 +59,18 @@ var _ = Describe("GardenFactory", func() {
 		fakeTrackerFactory = new(execfakes.FakeTrackerFactory)
 		fakeResourceFetcher := new(rfakes.FakeFetcher)
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, fakeResourceFetcher)
+		fakeVariables = new(credsfakes.FakeVariables)
+		fakeVariables.GetStub = func(name string) (interface{}, bool, error) {
+			if name == "some-secret" {
+				return "super-secret", true, nil
+			}
+
+			return nil, false, nil
+		}
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(0, 123))
+
+		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, fakeResourceFetcher, atc.ContainerLimits{}, atc.ContainerLimits{}, fakeClock)
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -117,6 +133,7 @@ var _ = Describe("GardenFactory", func() {
 				resourceConfig,
 				tags,
 				teamID,
+				fakeVariables,
 				params,
 				resourceTypes,
 				successTTL,
@@ -244,6 +261,18 @@ var _ = Describe("GardenFactory", func() {
 					Expect(ioConfig.Stderr).To(Equal(stderrBuf))
 				})
 
+				It("samples the resource's usage with the step's clock and reports it to the delegate", func() {
+					Expect(fakeResource.PutCallCount()).To(Equal(1))
+
+					ioConfig, _, _, _, _, _ := fakeResource.PutArgsForCall(0)
+					Expect(ioConfig.Usage).NotTo(BeNil())
+					Expect(ioConfig.Usage.Clock).To(Equal(fakeClock))
+
+					ioConfig.Usage.Report(atc.ResourceUsage{PeakMemory: 1024})
+					Expect(putDelegate.ResourceUsageCallCount()).To(Equal(1))
+					Expect(putDelegate.ResourceUsageArgsForCall(0)).To(Equal(atc.ResourceUsage{PeakMemory: 1024}))
+				})
+
 				It("runs the get resource action", func() {
 					Expect(fakeResource.PutCallCount()).To(Equal(1))
 				})
@@ -284,6 +313,44 @@ var _ = Describe("GardenFactory", func() {
 					}))
 				})
 
+				Context("when the source, params, and resource types contain ((var)) placeholders", func() {
+					BeforeEach(func() {
+						resourceConfig.Source = atc.Source{"some": "((some-secret))"}
+						params = atc.Params{"some-param": "((some-secret))"}
+						resourceTypes[0].Source = atc.Source{"some-custom": "((some-secret))"}
+					})
+
+					It("initializes the resource with the resolved resource types", func() {
+						_, _, _, _, _, _, _, actualResourceTypes, _ := fakeTracker.InitWithSourcesArgsForCall(0)
+						Expect(actualResourceTypes[0].Source).To(Equal(atc.Source{"some-custom": "super-secret"}))
+					})
+
+					It("puts the resource with the resolved source and params", func() {
+						_, putSource, putParams, _, _, _ := fakeResource.PutArgsForCall(0)
+						Expect(putSource).To(Equal(atc.Source{"some": "super-secret"}))
+						Expect(putParams).To(Equal(atc.Params{"some-param": "super-secret"}))
+					})
+				})
+
+				Context("when a ((var)) placeholder is undefined", func() {
+					BeforeEach(func() {
+						params = atc.Params{"some-param": "((missing))"}
+					})
+
+					It("exits with the error without initializing the resource", func() {
+						Eventually(process.Wait()).Should(Receive(Equal(creds.UndefinedVariablesError{
+							Names: []string{"missing"},
+						})))
+
+						Expect(fakeTracker.InitWithSourcesCallCount()).To(BeZero())
+					})
+
+					It("invokes the delegate's Failed callback", func() {
+						Eventually(process.Wait()).Should(Receive(HaveOccurred()))
+						Expect(putDelegate.FailedCallCount()).To(Equal(1))
+					})
+				})
+
 				Context("before initializing the resource", func() {
 					var callCountDuringInit chan int
 
@@ -16,6 +16,7 @@ import (
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/image"
@@ -43,6 +44,7 @@ type TaskStep struct {
 	metadata          worker.Metadata
 	tags              atc.Tags
 	teamID            int
+	variables         creds.Variables
 	delegate          TaskDelegate
 	privileged        Privileged
 	configSource      TaskConfigSource
@@ -52,6 +54,8 @@ type TaskStep struct {
 	inputMapping      map[string]string
 	outputMapping     map[string]string
 	imageArtifactName string
+	defaultLimits     atc.ContainerLimits
+	maxLimits         atc.ContainerLimits
 	clock             clock.Clock
 	repo              *SourceRepository
 
@@ -70,6 +74,7 @@ func newTaskStep(
 	metadata worker.Metadata,
 	tags atc.Tags,
 	teamID int,
+	variables creds.Variables,
 	delegate TaskDelegate,
 	privileged Privileged,
 	configSource TaskConfigSource,
@@ -79,6 +84,8 @@ func newTaskStep(
 	inputMapping map[string]string,
 	outputMapping map[string]string,
 	imageArtifactName string,
+	defaultLimits atc.ContainerLimits,
+	maxLimits atc.ContainerLimits,
 	clock clock.Clock,
 	containerSuccessTTL time.Duration,
 	containerFailureTTL time.Duration,
@@ -89,6 +96,7 @@ func newTaskStep(
 		metadata:            metadata,
 		tags:                tags,
 		teamID:              teamID,
+		variables:           variables,
 		delegate:            delegate,
 		privileged:          privileged,
 		configSource:        configSource,
@@ -98,6 +106,8 @@ func newTaskStep(
 		inputMapping:        inputMapping,
 		outputMapping:       outputMapping,
 		imageArtifactName:   imageArtifactName,
+		defaultLimits:       defaultLimits,
+		maxLimits:           maxLimits,
 		clock:               clock,
 		containerSuccessTTL: containerSuccessTTL,
 		containerFailureTTL: containerFailureTTL,
@@ -196,23 +206,29 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 		step.delegate.Initializing(config)
 
+		runConfig, resourceTypes, err := step.evaluateCredentials(config)
+		if err != nil {
+			return err
+		}
+
 		workerSpec := worker.WorkerSpec{
 			Platform: config.Platform,
 			Tags:     step.tags,
 			TeamID:   step.teamID,
+			Inputs:   step.volumeLocators(config),
 		}
 
 		if config.ImageResource != nil {
 			workerSpec.ResourceType = config.ImageResource.Type
 		}
 
-		compatibleWorkers, err := step.workerPool.AllSatisfying(workerSpec, step.resourceTypes)
+		chosenWorker, err := step.workerPool.Satisfying(workerSpec, resourceTypes)
 		if err != nil {
 			return err
 		}
 
 		var inputsToStream []inputPair
-		step.container, inputsToStream, err = step.createContainer(compatibleWorkers, config, signals)
+		step.container, inputsToStream, err = step.createContainer(chosenWorker, runConfig, resourceTypes, signals)
 
 		if err != nil {
 			return err
@@ -238,7 +254,7 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		step.process, err = step.container.Run(garden.ProcessSpec{
 			Path: config.Run.Path,
 			Args: config.Run.Args,
-			Env:  step.envForParams(config.Params),
+			Env:  step.envForParams(runConfig.Params),
 
 			Dir: path.Join(step.artifactsRoot, config.Run.Dir),
 			TTY: &garden.TTYSpec{},
@@ -255,6 +271,13 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 	close(ready)
 
+	sampler := worker.SampleUsage(
+		step.logger.Session("sample-usage"),
+		step.clock,
+		worker.UsageSamplingInterval,
+		step.container,
+	)
+
 	exited := make(chan struct{})
 	var processStatus int
 	var processErr error
@@ -275,9 +298,13 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 		<-exited
 
+		step.delegate.ResourceUsage(sampler.Stop())
+
 		return ErrInterrupted
 
 	case <-exited:
+		step.delegate.ResourceUsage(sampler.Stop())
+
 		if processErr != nil {
 			return processErr
 		}
@@ -297,13 +324,57 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 	}
 }
 
-func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config atc.TaskConfig, signals <-chan os.Signal) (worker.Container, []inputPair, error) {
-	chosenWorker, inputMounts, inputsToStream, err := step.chooseWorkerWithMostVolumes(compatibleWorkers, config.Inputs)
+// evaluateCredentials resolves any ((var)) placeholders in the task's params,
+// image resource, and resource types. The original config is left untouched
+// so that the resolved values never make it into build events or container
+// metadata.
+func (step *TaskStep) evaluateCredentials(config atc.TaskConfig) (atc.TaskConfig, atc.ResourceTypes, error) {
+	params, err := creds.NewTaskParams(step.variables, config.Params).Evaluate()
+	if err != nil {
+		return atc.TaskConfig{}, nil, err
+	}
+
+	config.Params = params
+
+	if config.ImageResource != nil {
+		source, err := creds.NewSource(step.variables, config.ImageResource.Source).Evaluate()
+		if err != nil {
+			return atc.TaskConfig{}, nil, err
+		}
+
+		config.ImageResource = &atc.ImageResource{
+			Type:   config.ImageResource.Type,
+			Source: source,
+		}
+	}
+
+	resourceTypes, err := creds.NewResourceTypes(step.variables, step.resourceTypes).Evaluate()
+	if err != nil {
+		return atc.TaskConfig{}, nil, err
+	}
+
+	return config, resourceTypes, nil
+}
+
+func (step *TaskStep) createContainer(chosenWorker worker.Worker, config atc.TaskConfig, resourceTypes atc.ResourceTypes, signals <-chan os.Signal) (worker.Container, []inputPair, error) {
+	inputMounts, inputsToStream, err := step.inputsOn(config.Inputs, chosenWorker)
+	if err != nil {
+		return nil, []inputPair{}, err
+	}
+
+	cacheMounts, err := step.cachesOn(config.Caches, chosenWorker)
 	if err != nil {
+		releaseMounts(inputMounts)
 		return nil, []inputPair{}, err
 	}
 
-	outputMounts := []worker.VolumeMount{}
+	cacheMounts, err = step.createMissingCaches(chosenWorker, config.Caches, cacheMounts)
+	if err != nil {
+		releaseMounts(inputMounts)
+		return nil, []inputPair{}, err
+	}
+
+	outputMounts := cacheMounts
 	for _, output := range config.Outputs {
 		path := artifactsPath(output, step.artifactsRoot)
 		outVolume, err := chosenWorker.CreateVolume(
@@ -421,6 +492,7 @@ func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config
 		Outputs:   outputMounts,
 		ImageSpec: imageSpec,
 		User:      config.Run.User,
+		Limits:    step.containerLimits(config),
 	}
 
 	runContainerID := step.containerID
@@ -434,7 +506,7 @@ func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config
 		runContainerID,
 		step.metadata,
 		containerSpec,
-		step.resourceTypes,
+		resourceTypes,
 	)
 
 	for _, mount := range inputMounts {
@@ -509,33 +581,149 @@ func (step *TaskStep) Release() {
 	}
 }
 
-func (step *TaskStep) chooseWorkerWithMostVolumes(compatibleWorkers []worker.Worker, inputs []atc.TaskInputConfig) (worker.Worker, []worker.VolumeMount, []inputPair, error) {
-	inputMounts := []worker.VolumeMount{}
-	inputsToStream := []inputPair{}
+// volumeLocators returns the task's inputs and caches, so that the placement
+// strategy can choose a worker which already has them.
+func (step *TaskStep) volumeLocators(config atc.TaskConfig) []worker.VolumeLocator {
+	locators := []worker.VolumeLocator{}
+
+	for _, input := range config.Inputs {
+		inputName := input.Name
+		if sourceName, ok := step.inputMapping[inputName]; ok {
+			inputName = sourceName
+		}
+
+		source, found := step.repo.SourceFor(SourceName(inputName))
+		if found {
+			locators = append(locators, source)
+		}
+	}
+
+	if step.cachingEnabled() {
+		for _, cache := range config.Caches {
+			locators = append(locators, taskCacheLocator{step: step, cache: cache})
+		}
+	}
+
+	return locators
+}
+
+// taskCacheLocator finds a task's cache on a worker.
+type taskCacheLocator struct {
+	step  *TaskStep
+	cache atc.CacheConfig
+}
+
+func (locator taskCacheLocator) VolumeOn(w worker.Worker) (worker.Volume, bool, error) {
+	volume, found, err := w.FindVolume(locator.step.logger, worker.VolumeSpec{
+		Strategy: locator.step.cacheStrategy(locator.cache, w),
+	})
+	if err == worker.ErrNoVolumeManager {
+		return nil, false, nil
+	}
+
+	return volume, found, err
+}
+
+func releaseMounts(mounts []worker.VolumeMount) {
+	for _, mount := range mounts {
+		mount.Volume.Release(nil)
+	}
+}
+
+// Task caches belong to a job, so they are only kept for builds of pipelines.
+func (step *TaskStep) cachingEnabled() bool {
+	return step.metadata.PipelineID != 0
+}
+
+func (step *TaskStep) cacheStrategy(cache atc.CacheConfig, w worker.Worker) worker.TaskCacheStrategy {
+	return worker.TaskCacheStrategy{
+		BuildID:    step.containerID.BuildID,
+		StepName:   step.metadata.StepName,
+		Path:       filepath.Clean(cache.Path),
+		WorkerName: w.Name(),
+	}
+}
+
+func (step *TaskStep) cacheDestination(cache atc.CacheConfig) string {
+	return filepath.Join(step.artifactsRoot, cache.Path)
+}
+
+func (step *TaskStep) cachesOn(caches []atc.CacheConfig, w worker.Worker) ([]worker.VolumeMount, error) {
+	mounts := []worker.VolumeMount{}
+
+	if !step.cachingEnabled() {
+		return mounts, nil
+	}
+
+	for _, cache := range caches {
+		volume, found, err := w.FindVolume(step.logger, worker.VolumeSpec{
+			Strategy: step.cacheStrategy(cache, w),
+		})
+		if err == worker.ErrNoVolumeManager {
+			break
+		}
 
-	var chosenWorker worker.Worker
-	for _, w := range compatibleWorkers {
-		mounts, toStream, err := step.inputsOn(inputs, w)
 		if err != nil {
-			return nil, nil, nil, err
+			releaseMounts(mounts)
+			return nil, err
 		}
 
-		if len(mounts) >= len(inputMounts) {
-			for _, mount := range inputMounts {
-				mount.Volume.Release(nil)
-			}
+		if found {
+			mounts = append(mounts, worker.VolumeMount{
+				Volume:    volume,
+				MountPath: step.cacheDestination(cache),
+			})
+		}
+	}
 
-			inputMounts = mounts
-			inputsToStream = toStream
-			chosenWorker = w
-		} else {
-			for _, mount := range mounts {
-				mount.Volume.Release(nil)
-			}
+	return mounts, nil
+}
+
+func (step *TaskStep) createMissingCaches(chosenWorker worker.Worker, caches []atc.CacheConfig, existing []worker.VolumeMount) ([]worker.VolumeMount, error) {
+	mounts := existing
+
+	if !step.cachingEnabled() {
+		return mounts, nil
+	}
+
+	existingPaths := map[string]bool{}
+	for _, mount := range existing {
+		existingPaths[mount.MountPath] = true
+	}
+
+	for _, cache := range caches {
+		path := step.cacheDestination(cache)
+		if existingPaths[path] {
+			continue
+		}
+
+		cacheVolume, err := chosenWorker.CreateVolume(
+			step.logger,
+			worker.VolumeSpec{
+				Strategy:   step.cacheStrategy(cache, chosenWorker),
+				Privileged: bool(step.privileged),
+				TTL:        worker.TaskCacheTTL,
+			},
+			step.teamID,
+		)
+		if err == worker.ErrNoVolumeManager {
+			break
 		}
+
+		if err != nil {
+			releaseMounts(mounts)
+			return nil, err
+		}
+
+		mounts = append(mounts, worker.VolumeMount{
+			Volume:    cacheVolume,
+			MountPath: path,
+		})
+
+		step.logger.Debug("created-task-cache-volume", lager.Data{"volume-handle": cacheVolume.Handle(), "path": cache.Path})
 	}
 
-	return chosenWorker, inputMounts, inputsToStream, nil
+	return mounts, nil
 }
 
 type inputPair struct {
@@ -787,3 +975,12 @@ type workerArtifactDestination struct {
 func (wad *workerArtifactDestination) StreamIn(path string, tarStream io.Reader) error {
 	return wad.destination.StreamIn(path, tarStream)
 }
+
+func (step *TaskStep) containerLimits(config atc.TaskConfig) atc.ContainerLimits {
+	var limits atc.ContainerLimits
+	if config.Limits != nil {
+		limits = *config.Limits
+	}
+
+	return limits.WithDefaults(step.defaultLimits).CappedAt(step.maxLimits)
+}
@@ -11,12 +11,15 @@ import (
 	"strings"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/garden"
 	gfakes "code.cloudfoundry.org/garden/gardenfakes"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
@@ -34,6 +37,7 @@ var _ = Describe("GardenFactory", func() {
 	var (
 		fakeWorkerClient *wfakes.FakeClient
 		fakeTracker      *rfakes.FakeTracker
+		fakeVariables    *credsfakes.FakeVariables
 
 		factory Factory
 
@@ -52,7 +56,16 @@ var _ = Describe("GardenFactory", func() {
 		fakeTracker = new(rfakes.FakeTracker)
 		fakeResourceFetcher := new(rfakes.FakeFetcher)
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, fakeResourceFetcher)
+		fakeVariables = new(credsfakes.FakeVariables)
+		fakeVariables.GetStub = func(name string) (interface{}, bool, error) {
+			if name == "some-secret" {
+				return "super-secret", true, nil
+			}
+
+			return nil, false, nil
+		}
+
+		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, fakeResourceFetcher, atc.ContainerLimits{}, atc.ContainerLimits{}, clock.NewClock())
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -130,6 +143,7 @@ var _ = Describe("GardenFactory", func() {
 				privileged,
 				tags,
 				teamID,
+				fakeVariables,
 				configSource,
 				resourceTypes,
 				inputMapping,
@@ -173,7 +187,7 @@ var _ = Describe("GardenFactory", func() {
 					disaster := errors.New("nope")
 
 					BeforeEach(func() {
-						fakeWorkerClient.AllSatisfyingReturns(nil, disaster)
+						fakeWorkerClient.SatisfyingReturns(nil, disaster)
 					})
 
 					It("exits with the error", func() {
@@ -186,7 +200,7 @@ var _ = Describe("GardenFactory", func() {
 
 					BeforeEach(func() {
 						fakeWorker = new(wfakes.FakeWorker)
-						fakeWorkerClient.AllSatisfyingReturns([]worker.Worker{fakeWorker}, nil)
+						fakeWorkerClient.SatisfyingReturns(fakeWorker, nil)
 					})
 
 					Context("when creating the task's container works", func() {
@@ -222,8 +236,8 @@ var _ = Describe("GardenFactory", func() {
 						})
 
 						It("found the worker with the right spec", func() {
-							Expect(fakeWorkerClient.AllSatisfyingCallCount()).To(Equal(1))
-							spec, actualResourceTypes := fakeWorkerClient.AllSatisfyingArgsForCall(0)
+							Expect(fakeWorkerClient.SatisfyingCallCount()).To(Equal(1))
+							spec, actualResourceTypes := fakeWorkerClient.SatisfyingArgsForCall(0)
 							Expect(spec.Platform).To(Equal("some-platform"))
 							Expect(spec.TeamID).To(Equal(teamID))
 							Expect(actualResourceTypes).To(Equal(atc.ResourceTypes{
@@ -285,6 +299,153 @@ var _ = Describe("GardenFactory", func() {
 									Source: atc.Source{"some-custom": "source"},
 								},
 							}))
+
+							Expect(spec.Limits).To(BeZero())
+						})
+
+						Context("when the config specifies caches", func() {
+							var fakeCacheVolume *wfakes.FakeVolume
+
+							BeforeEach(func() {
+								workerMetadata.PipelineID = 57
+								fakeWorker.NameReturns("some-worker")
+
+								fetchedConfig.Caches = []atc.CacheConfig{{Path: "some/cache"}}
+								configSource.FetchConfigReturns(fetchedConfig, nil)
+
+								fakeCacheVolume = new(wfakes.FakeVolume)
+								fakeCacheVolume.HandleReturns("some-cache-handle")
+							})
+
+							Context("when the worker already has the cache", func() {
+								BeforeEach(func() {
+									fakeWorker.FindVolumeReturns(fakeCacheVolume, true, nil)
+								})
+
+								It("looks up the cache of the job's step on the worker", func() {
+									Expect(fakeWorker.FindVolumeCallCount()).To(Equal(1))
+									_, spec := fakeWorker.FindVolumeArgsForCall(0)
+									Expect(spec.Strategy).To(Equal(worker.TaskCacheStrategy{
+										BuildID:    1234,
+										StepName:   "some-step",
+										Path:       "some/cache",
+										WorkerName: "some-worker",
+									}))
+								})
+
+								It("mounts the existing cache volume into the container", func() {
+									_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+									Expect(spec.Outputs).To(ContainElement(worker.VolumeMount{
+										Volume:    fakeCacheVolume,
+										MountPath: "/tmp/build/a1f5c0c1/some/cache",
+									}))
+
+									Expect(fakeWorker.CreateVolumeCallCount()).To(BeZero())
+								})
+
+								It("releases the cache volume once the container has been created", func() {
+									Expect(fakeCacheVolume.ReleaseCallCount()).To(Equal(1))
+								})
+							})
+
+							Context("when the worker does not have the cache yet", func() {
+								BeforeEach(func() {
+									fakeWorker.FindVolumeReturns(nil, false, nil)
+									fakeWorker.CreateVolumeReturns(fakeCacheVolume, nil)
+								})
+
+								It("creates a long-lived cache volume and mounts it into the container", func() {
+									Expect(fakeWorker.CreateVolumeCallCount()).To(Equal(1))
+									_, spec, actualTeamID := fakeWorker.CreateVolumeArgsForCall(0)
+									Expect(spec.Strategy).To(Equal(worker.TaskCacheStrategy{
+										BuildID:    1234,
+										StepName:   "some-step",
+										Path:       "some/cache",
+										WorkerName: "some-worker",
+									}))
+									Expect(spec.TTL).To(Equal(worker.TaskCacheTTL))
+									Expect(actualTeamID).To(Equal(teamID))
+
+									_, _, _, _, _, containerSpec, _ := fakeWorker.CreateContainerArgsForCall(0)
+									Expect(containerSpec.Outputs).To(ContainElement(worker.VolumeMount{
+										Volume:    fakeCacheVolume,
+										MountPath: "/tmp/build/a1f5c0c1/some/cache",
+									}))
+								})
+							})
+
+							Context("when another compatible worker already has the cache", func() {
+								var fakeCacheWorker *wfakes.FakeWorker
+
+								BeforeEach(func() {
+									fakeCacheWorker = new(wfakes.FakeWorker)
+									fakeCacheWorker.NameReturns("some-cache-worker")
+									fakeCacheWorker.FindVolumeReturns(fakeCacheVolume, true, nil)
+									fakeCacheWorker.CreateContainerReturns(fakeContainer, nil)
+
+									fakeWorkerClient.SatisfyingStub = func(spec worker.WorkerSpec, resourceTypes atc.ResourceTypes) (worker.Worker, error) {
+										return worker.NewVolumeLocalityPlacementStrategy().Choose([]worker.Worker{fakeCacheWorker, fakeWorker}, spec)
+									}
+								})
+
+								It("runs the task on the worker with the cache", func() {
+									Expect(fakeCacheWorker.CreateContainerCallCount()).To(Equal(1))
+									Expect(fakeWorker.CreateContainerCallCount()).To(BeZero())
+								})
+							})
+
+							Context("when the build is not part of a pipeline", func() {
+								BeforeEach(func() {
+									workerMetadata.PipelineID = 0
+								})
+
+								It("does not look up or create any caches", func() {
+									Expect(fakeWorker.FindVolumeCallCount()).To(BeZero())
+									Expect(fakeWorker.CreateVolumeCallCount()).To(BeZero())
+								})
+							})
+						})
+
+						Context("when default and maximum container limits are configured", func() {
+							BeforeEach(func() {
+								factory = NewGardenFactory(
+									fakeWorkerClient,
+									fakeTracker,
+									new(rfakes.FakeFetcher),
+									atc.ContainerLimits{Memory: 512 * 1024 * 1024, Disk: 1024 * 1024 * 1024},
+									atc.ContainerLimits{Memory: 1024 * 1024 * 1024, CPU: 1024},
+									clock.NewClock(),
+								)
+							})
+
+							It("creates the container with the default limits, capped at the maximums", func() {
+								_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+								Expect(spec.Limits).To(Equal(atc.ContainerLimits{
+									Memory: 512 * 1024 * 1024,
+									CPU:    1024,
+									Disk:   1024 * 1024 * 1024,
+								}))
+							})
+
+							Context("when the config specifies container limits", func() {
+								BeforeEach(func() {
+									fetchedConfig.Limits = &atc.ContainerLimits{
+										Memory: 2 * 1024 * 1024 * 1024,
+										CPU:    256,
+									}
+
+									configSource.FetchConfigReturns(fetchedConfig, nil)
+								})
+
+								It("creates the container with the config's limits, capped at the maximums", func() {
+									_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+									Expect(spec.Limits).To(Equal(atc.ContainerLimits{
+										Memory: 1024 * 1024 * 1024,
+										CPU:    256,
+										Disk:   1024 * 1024 * 1024,
+									}))
+								})
+							})
 						})
 
 						It("ensures artifacts root exists by streaming in an empty payload", func() {
@@ -332,6 +493,69 @@ var _ = Describe("GardenFactory", func() {
 							Expect(taskDelegate.StartedCallCount()).To(Equal(1))
 						})
 
+						Context("when the config and resource types contain ((var)) placeholders", func() {
+							BeforeEach(func() {
+								fetchedConfig.Params = map[string]string{"SECRET": "((some-secret))"}
+								fetchedConfig.ImageResource = &atc.ImageResource{
+									Type:   "docker",
+									Source: atc.Source{"password": "((some-secret))"},
+								}
+
+								configSource.FetchConfigReturns(fetchedConfig, nil)
+
+								resourceTypes[0].Source = atc.Source{"some-custom": "((some-secret))"}
+							})
+
+							It("invokes the delegate's Initializing callback with the placeholders intact", func() {
+								Expect(taskDelegate.InitializingArgsForCall(0)).To(Equal(fetchedConfig))
+							})
+
+							It("does not store the resolved params in the container's metadata", func() {
+								_, _, _, _, createdMetadata, _, _ := fakeWorker.CreateContainerArgsForCall(0)
+								Expect(createdMetadata.EnvironmentVariables).To(Equal([]string{"SECRET=((some-secret))"}))
+							})
+
+							It("creates the container with the resolved image resource and resource types", func() {
+								_, actualResourceTypes := fakeWorkerClient.SatisfyingArgsForCall(0)
+								Expect(actualResourceTypes[0].Source).To(Equal(atc.Source{"some-custom": "super-secret"}))
+
+								_, _, _, _, _, spec, actualResourceTypes := fakeWorker.CreateContainerArgsForCall(0)
+								Expect(spec.ImageSpec.ImageResource).To(Equal(&atc.ImageResource{
+									Type:   "docker",
+									Source: atc.Source{"password": "super-secret"},
+								}))
+								Expect(actualResourceTypes[0].Source).To(Equal(atc.Source{"some-custom": "super-secret"}))
+							})
+
+							It("runs the process with the resolved params", func() {
+								spec, _ := fakeContainer.RunArgsForCall(0)
+								Expect(spec.Env).To(Equal([]string{"SECRET=super-secret"}))
+							})
+						})
+
+						Context("when a ((var)) placeholder is undefined", func() {
+							BeforeEach(func() {
+								fetchedConfig.Params = map[string]string{"SECRET": "((missing))"}
+								configSource.FetchConfigReturns(fetchedConfig, nil)
+							})
+
+							It("exits with the error", func() {
+								Expect(<-process.Wait()).To(Equal(creds.UndefinedVariablesError{
+									Names: []string{"missing"},
+								}))
+							})
+
+							It("does not create the container", func() {
+								<-process.Wait()
+								Expect(fakeWorker.CreateContainerCallCount()).To(BeZero())
+							})
+
+							It("invokes the delegate's Failed callback", func() {
+								<-process.Wait()
+								Expect(taskDelegate.FailedCallCount()).To(Equal(1))
+							})
+						})
+
 						Context("when privileged", func() {
 							BeforeEach(func() {
 								privileged = true
@@ -484,6 +708,41 @@ var _ = Describe("GardenFactory", func() {
 										Expect(inputSource.StreamToCallCount()).To(Equal(0))
 										Expect(otherInputSource.StreamToCallCount()).To(Equal(0))
 									})
+
+									Context("when creating a task cache fails", func() {
+										disaster := errors.New("nope")
+
+										BeforeEach(func() {
+											workerMetadata.PipelineID = 57
+
+											configSource.FetchConfigReturns(atc.TaskConfig{
+												Platform: "some-platform",
+												Image:    "some-image",
+												Run: atc.TaskRunConfig{
+													Path: "ls",
+												},
+												Inputs: []atc.TaskInputConfig{
+													{Name: "some-input", Path: "some-input-configured-path"},
+													{Name: "some-other-input"},
+												},
+												Caches: []atc.CacheConfig{{Path: "some/cache"}},
+											}, nil)
+
+											fakeWorker.FindVolumeReturns(nil, false, nil)
+											fakeWorker.CreateVolumeReturns(nil, disaster)
+										})
+
+										It("exits with the error", func() {
+											Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+										})
+
+										It("releases the input volumes", func() {
+											Eventually(process.Wait()).Should(Receive())
+											Expect(fakeWorker.CreateContainerCallCount()).To(BeZero())
+											Expect(inputVolume.ReleaseCallCount()).To(Equal(1))
+											Expect(otherInputVolume.ReleaseCallCount()).To(Equal(1))
+										})
+									})
 								})
 
 								Context("when streaming the bits in to the container fails", func() {
@@ -1049,6 +1308,14 @@ var _ = Describe("GardenFactory", func() {
 										Expect(taskDelegate.FinishedCallCount()).To(Equal(1))
 										Expect(taskDelegate.FinishedArgsForCall(0)).To(Equal(ExitStatus(0)))
 									})
+
+									It("reports the container's resource usage to the delegate", func() {
+										Eventually(process.Wait()).Should(Receive(BeNil()))
+
+										Expect(fakeContainer.MetricsCallCount()).To(BeNumerically(">=", 2))
+										Expect(taskDelegate.ResourceUsageCallCount()).To(Equal(1))
+										Expect(taskDelegate.ResourceUsageArgsForCall(0).Samples).To(BeNumerically(">=", 2))
+									})
 								})
 
 								Context("when saving the exit status fails", func() {
@@ -1958,7 +2225,9 @@ var _ = Describe("GardenFactory", func() {
 						fakeWorker2 = new(wfakes.FakeWorker)
 						fakeWorker3 = new(wfakes.FakeWorker)
 
-						fakeWorkerClient.AllSatisfyingReturns([]worker.Worker{fakeWorker, fakeWorker2, fakeWorker3}, nil)
+						fakeWorkerClient.SatisfyingStub = func(spec worker.WorkerSpec, resourceTypes atc.ResourceTypes) (worker.Worker, error) {
+							return worker.NewVolumeLocalityPlacementStrategy().Choose([]worker.Worker{fakeWorker, fakeWorker2, fakeWorker3}, spec)
+						}
 					})
 
 					Context("when the configuration has inputs", func() {
@@ -2043,6 +2312,11 @@ var _ = Describe("GardenFactory", func() {
 									fakeWorker2.CreateContainerReturns(nil, errors.New("fall out of method here"))
 								})
 
+								It("gives the inputs to the placement strategy", func() {
+									spec, _ := fakeWorkerClient.SatisfyingArgsForCall(0)
+									Expect(spec.Inputs).To(ConsistOf(inputSource, otherInputSource))
+								})
+
 								It("picks the worker that has the most", func() {
 									Expect(fakeWorker.CreateContainerCallCount()).To(Equal(0))
 									Expect(fakeWorker2.CreateContainerCallCount()).To(Equal(1))
@@ -2053,8 +2327,10 @@ var _ = Describe("GardenFactory", func() {
 									Expect(inputVolume.ReleaseCallCount()).To(Equal(1))
 									Expect(inputVolume3.ReleaseCallCount()).To(Equal(1))
 
-									Expect(inputVolume2.ReleaseCallCount()).To(Equal(1))
-									Expect(otherInputVolume.ReleaseCallCount()).To(Equal(1))
+									// once by the placement strategy, and once
+									// the container has picked them up
+									Expect(inputVolume2.ReleaseCallCount()).To(Equal(2))
+									Expect(otherInputVolume.ReleaseCallCount()).To(Equal(2))
 								})
 							})
 						})
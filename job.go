@@ -36,3 +36,7 @@ type BuildInput struct {
 	Version  Version  `json:"version"`
 	Tags     []string `json:"tags,omitempty"`
 }
+
+type ClearJobCachesResponse struct {
+	CachesRemoved int `json:"caches_removed"`
+}
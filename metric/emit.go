@@ -1,49 +1,85 @@
 package metric
 
 import (
-	"errors"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/lager"
-	"github.com/bigdatadev/goryman"
 )
 
-type eventEmission struct {
-	event  goryman.Event
-	logger lager.Logger
+type EventState string
+
+const (
+	EventStateOK       EventState = "ok"
+	EventStateWarning  EventState = "warning"
+	EventStateCritical EventState = "critical"
+)
+
+// Names of the events emitted by the ATC. Emitters which treat events
+// differently by kind, rather than passing them through, should match on these.
+const (
+	EventNameSchedulingFullDuration         = "scheduling: full duration (ms)"
+	EventNameSchedulingLoadVersionsDuration = "scheduling: loading versions duration (ms)"
+	EventNameSchedulingJobDuration          = "scheduling: job duration (ms)"
+	EventNameWorkerContainers               = "worker containers"
+	EventNameBuildStarted                   = "build started"
+	EventNameBuildFinished                  = "build finished"
+	EventNameHTTPResponseTime               = "http response time"
+	EventNameTrackedContainers              = "tracked containers"
+	EventNameTrackedVolumes                 = "tracked volumes"
+	EventNameDatabaseQueries                = "database queries"
+	EventNameDatabaseConnections            = "database connections"
+	EventNameGCPauseTotalDuration           = "gc pause total duration"
+	EventNameMallocs                        = "mallocs"
+	EventNameFrees                          = "frees"
+	EventNameGoroutines                     = "goroutines"
+)
+
+type Event struct {
+	Name       string
+	Value      interface{}
+	State      EventState
+	Attributes map[string]string
+	Host       string
+	Time       int64
 }
 
-var riemannClient *goryman.GorymanClient
-var eventHost string
-var eventTags []string
-var eventAttributes map[string]string
+//go:generate counterfeiter . Emitter
 
-var clientConnected bool
-var emissions = make(chan eventEmission, 1000)
+// Emitter sends events to a metrics backend. Emit is called inline with the
+// code being measured, so implementations must not block.
+type Emitter interface {
+	Emit(lager.Logger, Event)
+}
 
-var errQueueFull = errors.New("event queue is full")
+var emittersLock sync.RWMutex
+var emitters []Emitter
+var eventHost string
+var eventAttributes map[string]string
 
-func Initialize(logger lager.Logger, riemannAddr string, host string, tags []string, attributes map[string]string) {
-	client := goryman.NewGorymanClient(riemannAddr)
+func Initialize(logger lager.Logger, host string, attributes map[string]string, configuredEmitters ...Emitter) {
+	emittersLock.Lock()
+	defer emittersLock.Unlock()
 
-	riemannClient = client
+	emitters = configuredEmitters
 	eventHost = host
-	eventTags = tags
 	eventAttributes = attributes
 
-	go emitLoop()
+	logger.Info("initialized", lager.Data{"emitters": len(configuredEmitters)})
 }
 
-func emit(logger lager.Logger, event goryman.Event) {
+func emit(logger lager.Logger, event Event) {
 	logger.Debug("emit")
 
-	if riemannClient == nil {
+	emittersLock.RLock()
+	defer emittersLock.RUnlock()
+
+	if len(emitters) == 0 {
 		return
 	}
 
 	event.Host = eventHost
 	event.Time = time.Now().Unix()
-	event.Tags = append(event.Tags, eventTags...)
 
 	mergedAttributes := map[string]string{}
 	for k, v := range eventAttributes {
@@ -58,34 +94,7 @@ func emit(logger lager.Logger, event goryman.Event) {
 
 	event.Attributes = mergedAttributes
 
-	select {
-	case emissions <- eventEmission{logger: logger, event: event}:
-	default:
-		logger.Error("queue-full", nil)
-	}
-}
-
-func emitLoop() {
-	for emission := range emissions {
-		if !clientConnected {
-			err := riemannClient.Connect()
-			if err != nil {
-				emission.logger.Error("connection-failed", err)
-				continue
-			}
-
-			clientConnected = true
-		}
-
-		err := riemannClient.SendEvent(&emission.event)
-		if err != nil {
-			emission.logger.Error("failed-to-emit", err)
-
-			if err := riemannClient.Close(); err != nil {
-				emission.logger.Error("failed-to-close", err)
-			}
-
-			clientConnected = false
-		}
+	for _, emitter := range emitters {
+		emitter.Emit(logger, event)
 	}
 }
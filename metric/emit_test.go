@@ -0,0 +1,75 @@
+package metric_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/concourse/atc/metric"
+	"github.com/concourse/atc/metric/metricfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Emitting events", func() {
+	var (
+		logger                *lagertest.TestLogger
+		fakeRiemannEmitter    *metricfakes.FakeEmitter
+		fakePrometheusEmitter *metricfakes.FakeEmitter
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeRiemannEmitter = new(metricfakes.FakeEmitter)
+		fakePrometheusEmitter = new(metricfakes.FakeEmitter)
+
+		Initialize(logger, "some-host", map[string]string{
+			"deployment": "some-deployment",
+			"pipeline":   "overridden",
+		}, fakeRiemannEmitter, fakePrometheusEmitter)
+	})
+
+	AfterEach(func() {
+		Initialize(logger, "", nil)
+	})
+
+	It("sends the event to every emitter", func() {
+		SchedulingJobDuration{
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+			Duration:     2 * time.Second,
+		}.Emit(logger)
+
+		for _, emitter := range []*metricfakes.FakeEmitter{fakeRiemannEmitter, fakePrometheusEmitter} {
+			Expect(emitter.EmitCallCount()).To(Equal(1))
+
+			_, event := emitter.EmitArgsForCall(0)
+			Expect(event.Name).To(Equal("scheduling: job duration (ms)"))
+			Expect(event.Value).To(Equal(2000.0))
+			Expect(event.State).To(Equal(EventStateWarning))
+			Expect(event.Host).To(Equal("some-host"))
+			Expect(event.Time).NotTo(BeZero())
+			Expect(event.Attributes).To(Equal(map[string]string{
+				"deployment": "some-deployment",
+				"pipeline":   "some-pipeline",
+				"job":        "some-job",
+			}))
+		}
+	})
+
+	Context("when no emitters are configured", func() {
+		BeforeEach(func() {
+			Initialize(logger, "some-host", nil)
+		})
+
+		It("does not emit anything", func() {
+			SchedulingFullDuration{
+				PipelineName: "some-pipeline",
+				Duration:     time.Second,
+			}.Emit(logger)
+
+			Expect(fakeRiemannEmitter.EmitCallCount()).To(BeZero())
+			Expect(fakePrometheusEmitter.EmitCallCount()).To(BeZero())
+		})
+	})
+})
@@ -5,15 +5,10 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
-	"github.com/bigdatadev/goryman"
 
 	"github.com/concourse/atc/db"
 )
 
-type Event interface {
-	Emit(lager.Logger)
-}
-
 var TrackedContainers = &Gauge{}
 var TrackedVolumes = &Gauge{}
 var DatabaseQueries = Meter(0)
@@ -25,14 +20,14 @@ type SchedulingFullDuration struct {
 }
 
 func (event SchedulingFullDuration) Emit(logger lager.Logger) {
-	state := "ok"
+	state := EventStateOK
 
 	if event.Duration > time.Second {
-		state = "warning"
+		state = EventStateWarning
 	}
 
 	if event.Duration > 5*time.Second {
-		state = "critical"
+		state = EventStateCritical
 	}
 
 	emit(
@@ -41,10 +36,10 @@ func (event SchedulingFullDuration) Emit(logger lager.Logger) {
 			"duration": event.Duration.String(),
 		}),
 
-		goryman.Event{
-			Service: "scheduling: full duration (ms)",
-			Metric:  ms(event.Duration),
-			State:   state,
+		Event{
+			Name:  EventNameSchedulingFullDuration,
+			Value: ms(event.Duration),
+			State: state,
 			Attributes: map[string]string{
 				"pipeline": event.PipelineName,
 			},
@@ -58,14 +53,14 @@ type SchedulingLoadVersionsDuration struct {
 }
 
 func (event SchedulingLoadVersionsDuration) Emit(logger lager.Logger) {
-	state := "ok"
+	state := EventStateOK
 
 	if event.Duration > time.Second {
-		state = "warning"
+		state = EventStateWarning
 	}
 
 	if event.Duration > 5*time.Second {
-		state = "critical"
+		state = EventStateCritical
 	}
 
 	emit(
@@ -73,10 +68,10 @@ func (event SchedulingLoadVersionsDuration) Emit(logger lager.Logger) {
 			"pipeline": event.PipelineName,
 			"duration": event.Duration.String(),
 		}),
-		goryman.Event{
-			Service: "scheduling: loading versions duration (ms)",
-			Metric:  ms(event.Duration),
-			State:   state,
+		Event{
+			Name:  EventNameSchedulingLoadVersionsDuration,
+			Value: ms(event.Duration),
+			State: state,
 			Attributes: map[string]string{
 				"pipeline": event.PipelineName,
 			},
@@ -91,14 +86,14 @@ type SchedulingJobDuration struct {
 }
 
 func (event SchedulingJobDuration) Emit(logger lager.Logger) {
-	state := "ok"
+	state := EventStateOK
 
 	if event.Duration > time.Second {
-		state = "warning"
+		state = EventStateWarning
 	}
 
 	if event.Duration > 5*time.Second {
-		state = "critical"
+		state = EventStateCritical
 	}
 
 	emit(
@@ -107,10 +102,10 @@ func (event SchedulingJobDuration) Emit(logger lager.Logger) {
 			"job":      event.JobName,
 			"duration": event.Duration.String(),
 		}),
-		goryman.Event{
-			Service: "scheduling: job duration (ms)",
-			Metric:  ms(event.Duration),
-			State:   state,
+		Event{
+			Name:  EventNameSchedulingJobDuration,
+			Value: ms(event.Duration),
+			State: state,
 			Attributes: map[string]string{
 				"pipeline": event.PipelineName,
 				"job":      event.JobName,
@@ -130,10 +125,10 @@ func (event WorkerContainers) Emit(logger lager.Logger) {
 			"worker":     event.WorkerName,
 			"containers": event.Containers,
 		}),
-		goryman.Event{
-			Service: "worker containers",
-			Metric:  event.Containers,
-			State:   "ok",
+		Event{
+			Name:  EventNameWorkerContainers,
+			Value: event.Containers,
+			State: EventStateOK,
 			Attributes: map[string]string{
 				"worker": event.WorkerName,
 			},
@@ -156,10 +151,10 @@ func (event BuildStarted) Emit(logger lager.Logger) {
 			"build-name": event.BuildName,
 			"build-id":   event.BuildID,
 		}),
-		goryman.Event{
-			Service: "build started",
-			Metric:  event.BuildID,
-			State:   "ok",
+		Event{
+			Name:  EventNameBuildStarted,
+			Value: event.BuildID,
+			State: EventStateOK,
 			Attributes: map[string]string{
 				"pipeline":   event.PipelineName,
 				"job":        event.JobName,
@@ -188,10 +183,10 @@ func (event BuildFinished) Emit(logger lager.Logger) {
 			"build-id":     event.BuildID,
 			"build-status": event.BuildStatus,
 		}),
-		goryman.Event{
-			Service: "build finished",
-			Metric:  ms(event.BuildDuration),
-			State:   "ok",
+		Event{
+			Name:  EventNameBuildFinished,
+			Value: ms(event.BuildDuration),
+			State: EventStateOK,
 			Attributes: map[string]string{
 				"pipeline":     event.PipelineName,
 				"job":          event.JobName,
@@ -214,14 +209,14 @@ type HTTPReponseTime struct {
 }
 
 func (event HTTPReponseTime) Emit(logger lager.Logger) {
-	state := "ok"
+	state := EventStateOK
 
 	if event.Duration > 100*time.Millisecond {
-		state = "warning"
+		state = EventStateWarning
 	}
 
 	if event.Duration > 1*time.Second {
-		state = "critical"
+		state = EventStateCritical
 	}
 
 	emit(
@@ -230,10 +225,10 @@ func (event HTTPReponseTime) Emit(logger lager.Logger) {
 			"path":     event.Path,
 			"duration": event.Duration.String(),
 		}),
-		goryman.Event{
-			Service: "http response time",
-			Metric:  ms(event.Duration),
-			State:   state,
+		Event{
+			Name:  EventNameHTTPResponseTime,
+			Value: ms(event.Duration),
+			State: state,
 			Attributes: map[string]string{
 				"route": event.Route,
 				"path":  event.Path,
@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
-	"github.com/bigdatadev/goryman"
 )
 
 func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
@@ -24,10 +23,10 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 			tLog.Session("tracked-containers", lager.Data{
 				"count": trackedContainers,
 			}),
-			goryman.Event{
-				Service: "tracked containers",
-				Metric:  trackedContainers,
-				State:   "ok",
+			Event{
+				Name:  EventNameTrackedContainers,
+				Value: trackedContainers,
+				State: EventStateOK,
 			},
 		)
 
@@ -35,10 +34,10 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 			tLog.Session("tracked-volumes", lager.Data{
 				"count": trackedVolumes,
 			}),
-			goryman.Event{
-				Service: "tracked volumes",
-				Metric:  trackedVolumes,
-				State:   "ok",
+			Event{
+				Name:  EventNameTrackedVolumes,
+				Value: trackedVolumes,
+				State: EventStateOK,
 			},
 		)
 
@@ -46,10 +45,10 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 			tLog.Session("database-queries", lager.Data{
 				"count": databaseQueries,
 			}),
-			goryman.Event{
-				Service: "database queries",
-				Metric:  databaseQueries,
-				State:   "ok",
+			Event{
+				Name:  EventNameDatabaseQueries,
+				Value: databaseQueries,
+				State: EventStateOK,
 			},
 		)
 
@@ -57,10 +56,10 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 			tLog.Session("database-connections", lager.Data{
 				"count": databaseConnections,
 			}),
-			goryman.Event{
-				Service: "database connections",
-				Metric:  databaseConnections,
-				State:   "ok",
+			Event{
+				Name:  EventNameDatabaseConnections,
+				Value: databaseConnections,
+				State: EventStateOK,
 			},
 		)
 
@@ -71,10 +70,10 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 			tLog.Session("gc-pause-total-duration", lager.Data{
 				"ns": memStats.PauseTotalNs,
 			}),
-			goryman.Event{
-				Service: "gc pause total duration",
-				Metric:  int(memStats.PauseTotalNs),
-				State:   "ok",
+			Event{
+				Name:  EventNameGCPauseTotalDuration,
+				Value: int(memStats.PauseTotalNs),
+				State: EventStateOK,
 			},
 		)
 
@@ -82,10 +81,10 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 			tLog.Session("mallocs", lager.Data{
 				"count": memStats.Mallocs,
 			}),
-			goryman.Event{
-				Service: "mallocs",
-				Metric:  int(memStats.Mallocs),
-				State:   "ok",
+			Event{
+				Name:  EventNameMallocs,
+				Value: int(memStats.Mallocs),
+				State: EventStateOK,
 			},
 		)
 
@@ -93,10 +92,10 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 			tLog.Session("frees", lager.Data{
 				"count": memStats.Frees,
 			}),
-			goryman.Event{
-				Service: "frees",
-				Metric:  int(memStats.Frees),
-				State:   "ok",
+			Event{
+				Name:  EventNameFrees,
+				Value: int(memStats.Frees),
+				State: EventStateOK,
 			},
 		)
 
@@ -104,10 +103,10 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 			tLog.Session("goroutines", lager.Data{
 				"count": runtime.NumGoroutine(),
 			}),
-			goryman.Event{
-				Service: "goroutines",
-				Metric:  int(runtime.NumGoroutine()),
-				State:   "ok",
+			Event{
+				Name:  EventNameGoroutines,
+				Value: int(runtime.NumGoroutine()),
+				State: EventStateOK,
 			},
 		)
 	}
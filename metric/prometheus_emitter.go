@@ -0,0 +1,201 @@
+package metric
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type PrometheusEmitter struct {
+	registry *prometheus.Registry
+
+	schedulingFullDuration         *prometheus.HistogramVec
+	schedulingLoadVersionsDuration *prometheus.HistogramVec
+	schedulingJobDuration          *prometheus.HistogramVec
+
+	buildsStarted  *prometheus.CounterVec
+	buildsFinished *prometheus.CounterVec
+	buildDuration  *prometheus.HistogramVec
+
+	httpResponseDuration *prometheus.HistogramVec
+
+	trackedContainers   prometheus.Gauge
+	trackedVolumes      prometheus.Gauge
+	workerContainers    *prometheus.GaugeVec
+	databaseQueries     prometheus.Counter
+	databaseConnections prometheus.Gauge
+	goroutines          prometheus.Gauge
+}
+
+func NewPrometheusEmitter() *PrometheusEmitter {
+	emitter := &PrometheusEmitter{
+		registry: prometheus.NewRegistry(),
+
+		schedulingFullDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "scheduling",
+			Name:      "full_duration_seconds",
+			Help:      "Time taken to schedule an entire pipeline.",
+		}, []string{"pipeline"}),
+
+		schedulingLoadVersionsDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "scheduling",
+			Name:      "load_versions_duration_seconds",
+			Help:      "Time taken to load the version history of a pipeline for scheduling.",
+		}, []string{"pipeline"}),
+
+		schedulingJobDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "scheduling",
+			Name:      "job_duration_seconds",
+			Help:      "Time taken to schedule a single job.",
+		}, []string{"pipeline", "job"}),
+
+		buildsStarted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "builds",
+			Name:      "started_total",
+			Help:      "Number of builds started.",
+		}, []string{"pipeline", "job"}),
+
+		buildsFinished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "builds",
+			Name:      "finished_total",
+			Help:      "Number of builds finished, by status.",
+		}, []string{"pipeline", "job", "status"}),
+
+		buildDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "builds",
+			Name:      "duration_seconds",
+			Help:      "Time taken for builds to finish, by status.",
+			Buckets:   []float64{1, 10, 30, 60, 120, 300, 600, 1800, 3600, 7200},
+		}, []string{"pipeline", "job", "status"}),
+
+		httpResponseDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "http",
+			Name:      "response_duration_seconds",
+			Help:      "Time taken to respond to API requests, by route.",
+		}, []string{"route"}),
+
+		trackedContainers: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "containers",
+			Name:      "tracked",
+			Help:      "Maximum number of containers tracked by this ATC over the last interval.",
+		}),
+
+		trackedVolumes: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "volumes",
+			Name:      "tracked",
+			Help:      "Maximum number of volumes tracked by this ATC over the last interval.",
+		}),
+
+		workerContainers: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "workers",
+			Name:      "containers",
+			Help:      "Number of containers reported by each worker.",
+		}, []string{"worker"}),
+
+		databaseQueries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "db",
+			Name:      "queries_total",
+			Help:      "Number of database queries made by this ATC.",
+		}),
+
+		databaseConnections: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "db",
+			Name:      "connections",
+			Help:      "Maximum number of open database connections over the last interval.",
+		}),
+
+		goroutines: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Name:      "goroutines",
+			Help:      "Number of goroutines running in this ATC.",
+		}),
+	}
+
+	emitter.registry.MustRegister(
+		emitter.schedulingFullDuration,
+		emitter.schedulingLoadVersionsDuration,
+		emitter.schedulingJobDuration,
+		emitter.buildsStarted,
+		emitter.buildsFinished,
+		emitter.buildDuration,
+		emitter.httpResponseDuration,
+		emitter.trackedContainers,
+		emitter.trackedVolumes,
+		emitter.workerContainers,
+		emitter.databaseQueries,
+		emitter.databaseConnections,
+		emitter.goroutines,
+	)
+
+	return emitter
+}
+
+// Handler serves the collected metrics in the Prometheus exposition format.
+func (emitter *PrometheusEmitter) Handler() http.Handler {
+	return promhttp.HandlerFor(emitter.registry, promhttp.HandlerOpts{})
+}
+
+func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event Event) {
+	value, ok := toFloat(event.Value)
+	if !ok {
+		logger.Info("unknown-metric-value-type", lager.Data{"name": event.Name})
+		return
+	}
+
+	attrs := event.Attributes
+
+	switch event.Name {
+	case EventNameSchedulingFullDuration:
+		emitter.schedulingFullDuration.WithLabelValues(attrs["pipeline"]).Observe(value / 1000)
+	case EventNameSchedulingLoadVersionsDuration:
+		emitter.schedulingLoadVersionsDuration.WithLabelValues(attrs["pipeline"]).Observe(value / 1000)
+	case EventNameSchedulingJobDuration:
+		emitter.schedulingJobDuration.WithLabelValues(attrs["pipeline"], attrs["job"]).Observe(value / 1000)
+	case EventNameBuildStarted:
+		emitter.buildsStarted.WithLabelValues(attrs["pipeline"], attrs["job"]).Inc()
+	case EventNameBuildFinished:
+		emitter.buildsFinished.WithLabelValues(attrs["pipeline"], attrs["job"], attrs["build_status"]).Inc()
+		emitter.buildDuration.WithLabelValues(attrs["pipeline"], attrs["job"], attrs["build_status"]).Observe(value / 1000)
+	case EventNameHTTPResponseTime:
+		emitter.httpResponseDuration.WithLabelValues(attrs["route"]).Observe(value / 1000)
+	case EventNameTrackedContainers:
+		emitter.trackedContainers.Set(value)
+	case EventNameTrackedVolumes:
+		emitter.trackedVolumes.Set(value)
+	case EventNameWorkerContainers:
+		emitter.workerContainers.WithLabelValues(attrs["worker"]).Set(value)
+	case EventNameDatabaseQueries:
+		emitter.databaseQueries.Add(value)
+	case EventNameDatabaseConnections:
+		emitter.databaseConnections.Set(value)
+	case EventNameGoroutines:
+		emitter.goroutines.Set(value)
+	}
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
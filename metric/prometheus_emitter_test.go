@@ -0,0 +1,95 @@
+package metric_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/concourse/atc/metric"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrometheusEmitter", func() {
+	var (
+		logger  *lagertest.TestLogger
+		emitter *PrometheusEmitter
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		emitter = NewPrometheusEmitter()
+	})
+
+	scrape := func() string {
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest("GET", "/metrics", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		emitter.Handler().ServeHTTP(recorder, request)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		body, err := ioutil.ReadAll(recorder.Body)
+		Expect(err).NotTo(HaveOccurred())
+
+		return string(body)
+	}
+
+	It("exposes scheduling durations in seconds", func() {
+		emitter.Emit(logger, Event{
+			Name:  EventNameSchedulingJobDuration,
+			Value: 1500.0,
+			Attributes: map[string]string{
+				"pipeline": "some-pipeline",
+				"job":      "some-job",
+			},
+		})
+
+		Expect(scrape()).To(ContainSubstring(`concourse_scheduling_job_duration_seconds_sum{job="some-job",pipeline="some-pipeline"} 1.5`))
+	})
+
+	It("exposes build durations and counts by status", func() {
+		emitter.Emit(logger, Event{
+			Name:  EventNameBuildFinished,
+			Value: 60000.0,
+			Attributes: map[string]string{
+				"pipeline":     "some-pipeline",
+				"job":          "some-job",
+				"build_status": "succeeded",
+			},
+		})
+
+		metrics := scrape()
+		Expect(metrics).To(ContainSubstring(`concourse_builds_finished_total{job="some-job",pipeline="some-pipeline",status="succeeded"} 1`))
+		Expect(metrics).To(ContainSubstring(`concourse_builds_duration_seconds_sum{job="some-job",pipeline="some-pipeline",status="succeeded"} 60`))
+	})
+
+	It("exposes http response times by route", func() {
+		emitter.Emit(logger, Event{
+			Name:  EventNameHTTPResponseTime,
+			Value: 250.0,
+			Attributes: map[string]string{
+				"route": "GetBuild",
+				"path":  "/api/v1/builds/1",
+			},
+		})
+
+		Expect(scrape()).To(ContainSubstring(`concourse_http_response_duration_seconds_sum{route="GetBuild"} 0.25`))
+	})
+
+	It("accumulates database queries across emissions", func() {
+		emitter.Emit(logger, Event{Name: EventNameDatabaseQueries, Value: 3})
+		emitter.Emit(logger, Event{Name: EventNameDatabaseQueries, Value: 4})
+
+		Expect(scrape()).To(ContainSubstring("concourse_db_queries_total 7"))
+	})
+
+	It("reports the latest tracked container count", func() {
+		emitter.Emit(logger, Event{Name: EventNameTrackedContainers, Value: 10})
+		emitter.Emit(logger, Event{Name: EventNameTrackedContainers, Value: 4})
+
+		Expect(scrape()).To(ContainSubstring("concourse_containers_tracked 4"))
+	})
+})
@@ -0,0 +1,74 @@
+package metric
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/bigdatadev/goryman"
+)
+
+type riemannEmission struct {
+	event  goryman.Event
+	logger lager.Logger
+}
+
+type RiemannEmitter struct {
+	client *goryman.GorymanClient
+	tags   []string
+
+	clientConnected bool
+	emissions       chan riemannEmission
+}
+
+func NewRiemannEmitter(riemannAddr string, tags []string) *RiemannEmitter {
+	emitter := &RiemannEmitter{
+		client:    goryman.NewGorymanClient(riemannAddr),
+		tags:      tags,
+		emissions: make(chan riemannEmission, 1000),
+	}
+
+	go emitter.emitLoop()
+
+	return emitter
+}
+
+func (emitter *RiemannEmitter) Emit(logger lager.Logger, event Event) {
+	riemannEvent := goryman.Event{
+		Service:    event.Name,
+		Metric:     event.Value,
+		State:      string(event.State),
+		Attributes: event.Attributes,
+		Host:       event.Host,
+		Time:       event.Time,
+		Tags:       emitter.tags,
+	}
+
+	select {
+	case emitter.emissions <- riemannEmission{logger: logger, event: riemannEvent}:
+	default:
+		logger.Error("queue-full", nil)
+	}
+}
+
+func (emitter *RiemannEmitter) emitLoop() {
+	for emission := range emitter.emissions {
+		if !emitter.clientConnected {
+			err := emitter.client.Connect()
+			if err != nil {
+				emission.logger.Error("connection-failed", err)
+				continue
+			}
+
+			emitter.clientConnected = true
+		}
+
+		err := emitter.client.SendEvent(&emission.event)
+		if err != nil {
+			emission.logger.Error("failed-to-emit", err)
+
+			if err := emitter.client.Close(); err != nil {
+				emission.logger.Error("failed-to-close", err)
+			}
+
+			emitter.clientConnected = false
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package notifications
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+const (
+	maxDeliveryAttempts = 5
+	initialRetryBackoff = 30 * time.Second
+)
+
+//go:generate counterfeiter . DelivererDB
+
+type DelivererDB interface {
+	GetPendingNotificationDeliveries() ([]db.SavedNotificationDelivery, error)
+	SaveNotificationDeliveryAttempt(deliveryID int, attempt db.NotificationDeliveryAttempt) error
+}
+
+type Deliverer interface {
+	Run() error
+}
+
+func NewDeliverer(
+	logger lager.Logger,
+	delivererDB DelivererDB,
+	httpClient *http.Client,
+) Deliverer {
+	return &deliverer{
+		logger:      logger,
+		delivererDB: delivererDB,
+		httpClient:  httpClient,
+	}
+}
+
+type deliverer struct {
+	logger      lager.Logger
+	delivererDB DelivererDB
+	httpClient  *http.Client
+}
+
+func (d *deliverer) Run() error {
+	deliveries, err := d.delivererDB.GetPendingNotificationDeliveries()
+	if err != nil {
+		d.logger.Error("failed-to-get-pending-deliveries", err)
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		logger := d.logger.Session("deliver", lager.Data{
+			"delivery": delivery.ID,
+			"build":    delivery.BuildID,
+			"hook":     delivery.HookName,
+		})
+
+		attempt := d.attempt(delivery)
+
+		if attempt.Status != db.NotificationDeliveryStatusDelivered {
+			logger.Info("delivery-attempt-failed", lager.Data{
+				"status":          attempt.Status,
+				"response-status": attempt.ResponseStatus,
+				"error":           attempt.Error,
+			})
+		}
+
+		err := d.delivererDB.SaveNotificationDeliveryAttempt(delivery.ID, attempt)
+		if err != nil {
+			logger.Error("failed-to-save-delivery-attempt", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *deliverer) attempt(delivery db.SavedNotificationDelivery) db.NotificationDeliveryAttempt {
+	request, err := http.NewRequest("POST", delivery.URL, strings.NewReader(delivery.Payload))
+	if err != nil {
+		// the hook's URL is invalid; retrying will not help
+		return db.NotificationDeliveryAttempt{
+			Error:  err.Error(),
+			Status: db.NotificationDeliveryStatusFailed,
+		}
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Concourse-Delivery", strconv.Itoa(delivery.ID))
+
+	if delivery.Signature != "" {
+		request.Header.Set(SignatureHeader, delivery.Signature)
+	}
+
+	var attempt db.NotificationDeliveryAttempt
+
+	response, err := d.httpClient.Do(request)
+	if err != nil {
+		attempt.Error = err.Error()
+	} else {
+		response.Body.Close()
+
+		attempt.ResponseStatus = response.StatusCode
+
+		if response.StatusCode >= 200 && response.StatusCode < 300 {
+			attempt.Status = db.NotificationDeliveryStatusDelivered
+			return attempt
+		}
+	}
+
+	if delivery.Attempts+1 >= maxDeliveryAttempts {
+		attempt.Status = db.NotificationDeliveryStatusFailed
+	} else {
+		attempt.Status = db.NotificationDeliveryStatusPending
+		attempt.RetryAfter = initialRetryBackoff << uint(delivery.Attempts)
+	}
+
+	return attempt
+}
@@ -0,0 +1,148 @@
+package notifications_test
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db"
+	. "github.com/concourse/atc/notifications"
+	"github.com/concourse/atc/notifications/notificationsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Deliverer", func() {
+	var (
+		fakeDelivererDB *notificationsfakes.FakeDelivererDB
+		server          *ghttp.Server
+
+		deliverer Deliverer
+		delivery  db.SavedNotificationDelivery
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeDelivererDB = new(notificationsfakes.FakeDelivererDB)
+		server = ghttp.NewServer()
+
+		delivery = db.SavedNotificationDelivery{
+			ID:       1,
+			Status:   db.NotificationDeliveryStatusPending,
+			Attempts: 0,
+			NotificationDelivery: db.NotificationDelivery{
+				BuildID:   42,
+				HookName:  "chat",
+				URL:       server.URL() + "/hook",
+				Payload:   `{"status":"failed"}`,
+				Signature: "sha256=abc",
+			},
+		}
+
+		fakeDelivererDB.GetPendingNotificationDeliveriesStub = func() ([]db.SavedNotificationDelivery, error) {
+			return []db.SavedNotificationDelivery{delivery}, nil
+		}
+
+		deliverer = NewDeliverer(lagertest.NewTestLogger("test"), fakeDelivererDB, http.DefaultClient)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		runErr = deliverer.Run()
+	})
+
+	Context("when the hook accepts the delivery", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/hook"),
+				ghttp.VerifyHeaderKV("Content-Type", "application/json"),
+				ghttp.VerifyHeaderKV(SignatureHeader, "sha256=abc"),
+				ghttp.VerifyHeaderKV("X-Concourse-Delivery", "1"),
+				ghttp.VerifyBody([]byte(`{"status":"failed"}`)),
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+		})
+
+		It("records a successful delivery", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+
+			Expect(fakeDelivererDB.SaveNotificationDeliveryAttemptCallCount()).To(Equal(1))
+			deliveryID, attempt := fakeDelivererDB.SaveNotificationDeliveryAttemptArgsForCall(0)
+			Expect(deliveryID).To(Equal(1))
+			Expect(attempt).To(Equal(db.NotificationDeliveryAttempt{
+				ResponseStatus: http.StatusNoContent,
+				Status:         db.NotificationDeliveryStatusDelivered,
+			}))
+		})
+	})
+
+	Context("when the hook rejects the delivery", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusBadGateway, nil))
+		})
+
+		It("schedules a retry with backoff", func() {
+			_, attempt := fakeDelivererDB.SaveNotificationDeliveryAttemptArgsForCall(0)
+			Expect(attempt).To(Equal(db.NotificationDeliveryAttempt{
+				ResponseStatus: http.StatusBadGateway,
+				Status:         db.NotificationDeliveryStatusPending,
+				RetryAfter:     30 * time.Second,
+			}))
+		})
+
+		Context("after previous failed attempts", func() {
+			BeforeEach(func() {
+				delivery.Attempts = 2
+			})
+
+			It("backs off exponentially", func() {
+				_, attempt := fakeDelivererDB.SaveNotificationDeliveryAttemptArgsForCall(0)
+				Expect(attempt.RetryAfter).To(Equal(2 * time.Minute))
+			})
+		})
+
+		Context("on the last attempt", func() {
+			BeforeEach(func() {
+				delivery.Attempts = 4
+			})
+
+			It("gives up", func() {
+				_, attempt := fakeDelivererDB.SaveNotificationDeliveryAttemptArgsForCall(0)
+				Expect(attempt.Status).To(Equal(db.NotificationDeliveryStatusFailed))
+			})
+		})
+	})
+
+	Context("when the hook cannot be reached", func() {
+		BeforeEach(func() {
+			server.Close()
+		})
+
+		It("records the error and schedules a retry", func() {
+			_, attempt := fakeDelivererDB.SaveNotificationDeliveryAttemptArgsForCall(0)
+			Expect(attempt.Error).NotTo(BeEmpty())
+			Expect(attempt.Status).To(Equal(db.NotificationDeliveryStatusPending))
+		})
+	})
+
+	Context("when getting pending deliveries fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDelivererDB.GetPendingNotificationDeliveriesStub = nil
+			fakeDelivererDB.GetPendingNotificationDeliveriesReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+})
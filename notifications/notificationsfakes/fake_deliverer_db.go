@@ -0,0 +1,114 @@
+// This file was generated by counterfeiter
+package notificationsfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/notifications"
+)
+
+type FakeDelivererDB struct {
+	GetPendingNotificationDeliveriesStub        func() ([]db.SavedNotificationDelivery, error)
+	getPendingNotificationDeliveriesMutex       sync.RWMutex
+	getPendingNotificationDeliveriesArgsForCall []struct{}
+	getPendingNotificationDeliveriesReturns     struct {
+		result1 []db.SavedNotificationDelivery
+		result2 error
+	}
+	SaveNotificationDeliveryAttemptStub        func(deliveryID int, attempt db.NotificationDeliveryAttempt) error
+	saveNotificationDeliveryAttemptMutex       sync.RWMutex
+	saveNotificationDeliveryAttemptArgsForCall []struct {
+		deliveryID int
+		attempt    db.NotificationDeliveryAttempt
+	}
+	saveNotificationDeliveryAttemptReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDelivererDB) GetPendingNotificationDeliveries() ([]db.SavedNotificationDelivery, error) {
+	fake.getPendingNotificationDeliveriesMutex.Lock()
+	fake.getPendingNotificationDeliveriesArgsForCall = append(fake.getPendingNotificationDeliveriesArgsForCall, struct{}{})
+	fake.recordInvocation("GetPendingNotificationDeliveries", []interface{}{})
+	fake.getPendingNotificationDeliveriesMutex.Unlock()
+	if fake.GetPendingNotificationDeliveriesStub != nil {
+		return fake.GetPendingNotificationDeliveriesStub()
+	} else {
+		return fake.getPendingNotificationDeliveriesReturns.result1, fake.getPendingNotificationDeliveriesReturns.result2
+	}
+}
+
+func (fake *FakeDelivererDB) GetPendingNotificationDeliveriesCallCount() int {
+	fake.getPendingNotificationDeliveriesMutex.RLock()
+	defer fake.getPendingNotificationDeliveriesMutex.RUnlock()
+	return len(fake.getPendingNotificationDeliveriesArgsForCall)
+}
+
+func (fake *FakeDelivererDB) GetPendingNotificationDeliveriesReturns(result1 []db.SavedNotificationDelivery, result2 error) {
+	fake.GetPendingNotificationDeliveriesStub = nil
+	fake.getPendingNotificationDeliveriesReturns = struct {
+		result1 []db.SavedNotificationDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDelivererDB) SaveNotificationDeliveryAttempt(deliveryID int, attempt db.NotificationDeliveryAttempt) error {
+	fake.saveNotificationDeliveryAttemptMutex.Lock()
+	fake.saveNotificationDeliveryAttemptArgsForCall = append(fake.saveNotificationDeliveryAttemptArgsForCall, struct {
+		deliveryID int
+		attempt    db.NotificationDeliveryAttempt
+	}{deliveryID, attempt})
+	fake.recordInvocation("SaveNotificationDeliveryAttempt", []interface{}{deliveryID, attempt})
+	fake.saveNotificationDeliveryAttemptMutex.Unlock()
+	if fake.SaveNotificationDeliveryAttemptStub != nil {
+		return fake.SaveNotificationDeliveryAttemptStub(deliveryID, attempt)
+	} else {
+		return fake.saveNotificationDeliveryAttemptReturns.result1
+	}
+}
+
+func (fake *FakeDelivererDB) SaveNotificationDeliveryAttemptCallCount() int {
+	fake.saveNotificationDeliveryAttemptMutex.RLock()
+	defer fake.saveNotificationDeliveryAttemptMutex.RUnlock()
+	return len(fake.saveNotificationDeliveryAttemptArgsForCall)
+}
+
+func (fake *FakeDelivererDB) SaveNotificationDeliveryAttemptArgsForCall(i int) (int, db.NotificationDeliveryAttempt) {
+	fake.saveNotificationDeliveryAttemptMutex.RLock()
+	defer fake.saveNotificationDeliveryAttemptMutex.RUnlock()
+	return fake.saveNotificationDeliveryAttemptArgsForCall[i].deliveryID, fake.saveNotificationDeliveryAttemptArgsForCall[i].attempt
+}
+
+func (fake *FakeDelivererDB) SaveNotificationDeliveryAttemptReturns(result1 error) {
+	fake.SaveNotificationDeliveryAttemptStub = nil
+	fake.saveNotificationDeliveryAttemptReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDelivererDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getPendingNotificationDeliveriesMutex.RLock()
+	defer fake.getPendingNotificationDeliveriesMutex.RUnlock()
+	fake.saveNotificationDeliveryAttemptMutex.RLock()
+	defer fake.saveNotificationDeliveryAttemptMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeDelivererDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ notifications.DelivererDB = new(FakeDelivererDB)
@@ -0,0 +1,82 @@
+// This file was generated by counterfeiter
+package notificationsfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/notifications"
+)
+
+type FakeNotifier struct {
+	BuildFinishedStub        func(logger lager.Logger, build db.Build, buildURL string) error
+	buildFinishedMutex       sync.RWMutex
+	buildFinishedArgsForCall []struct {
+		logger   lager.Logger
+		build    db.Build
+		buildURL string
+	}
+	buildFinishedReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeNotifier) BuildFinished(logger lager.Logger, build db.Build, buildURL string) error {
+	fake.buildFinishedMutex.Lock()
+	fake.buildFinishedArgsForCall = append(fake.buildFinishedArgsForCall, struct {
+		logger   lager.Logger
+		build    db.Build
+		buildURL string
+	}{logger, build, buildURL})
+	fake.recordInvocation("BuildFinished", []interface{}{logger, build, buildURL})
+	fake.buildFinishedMutex.Unlock()
+	if fake.BuildFinishedStub != nil {
+		return fake.BuildFinishedStub(logger, build, buildURL)
+	} else {
+		return fake.buildFinishedReturns.result1
+	}
+}
+
+func (fake *FakeNotifier) BuildFinishedCallCount() int {
+	fake.buildFinishedMutex.RLock()
+	defer fake.buildFinishedMutex.RUnlock()
+	return len(fake.buildFinishedArgsForCall)
+}
+
+func (fake *FakeNotifier) BuildFinishedArgsForCall(i int) (lager.Logger, db.Build, string) {
+	fake.buildFinishedMutex.RLock()
+	defer fake.buildFinishedMutex.RUnlock()
+	return fake.buildFinishedArgsForCall[i].logger, fake.buildFinishedArgsForCall[i].build, fake.buildFinishedArgsForCall[i].buildURL
+}
+
+func (fake *FakeNotifier) BuildFinishedReturns(result1 error) {
+	fake.BuildFinishedStub = nil
+	fake.buildFinishedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotifier) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.buildFinishedMutex.RLock()
+	defer fake.buildFinishedMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeNotifier) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ notifications.Notifier = new(FakeNotifier)
@@ -0,0 +1,77 @@
+// This file was generated by counterfeiter
+package notificationsfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/notifications"
+)
+
+type FakeNotifierDB struct {
+	CreateNotificationDeliveryStub        func(delivery db.NotificationDelivery) error
+	createNotificationDeliveryMutex       sync.RWMutex
+	createNotificationDeliveryArgsForCall []struct {
+		delivery db.NotificationDelivery
+	}
+	createNotificationDeliveryReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeNotifierDB) CreateNotificationDelivery(delivery db.NotificationDelivery) error {
+	fake.createNotificationDeliveryMutex.Lock()
+	fake.createNotificationDeliveryArgsForCall = append(fake.createNotificationDeliveryArgsForCall, struct {
+		delivery db.NotificationDelivery
+	}{delivery})
+	fake.recordInvocation("CreateNotificationDelivery", []interface{}{delivery})
+	fake.createNotificationDeliveryMutex.Unlock()
+	if fake.CreateNotificationDeliveryStub != nil {
+		return fake.CreateNotificationDeliveryStub(delivery)
+	} else {
+		return fake.createNotificationDeliveryReturns.result1
+	}
+}
+
+func (fake *FakeNotifierDB) CreateNotificationDeliveryCallCount() int {
+	fake.createNotificationDeliveryMutex.RLock()
+	defer fake.createNotificationDeliveryMutex.RUnlock()
+	return len(fake.createNotificationDeliveryArgsForCall)
+}
+
+func (fake *FakeNotifierDB) CreateNotificationDeliveryArgsForCall(i int) db.NotificationDelivery {
+	fake.createNotificationDeliveryMutex.RLock()
+	defer fake.createNotificationDeliveryMutex.RUnlock()
+	return fake.createNotificationDeliveryArgsForCall[i].delivery
+}
+
+func (fake *FakeNotifierDB) CreateNotificationDeliveryReturns(result1 error) {
+	fake.CreateNotificationDeliveryStub = nil
+	fake.createNotificationDeliveryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotifierDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createNotificationDeliveryMutex.RLock()
+	defer fake.createNotificationDeliveryMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeNotifierDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ notifications.NotifierDB = new(FakeNotifierDB)
@@ -0,0 +1,101 @@
+package notifications
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+//go:generate counterfeiter . NotifierDB
+
+type NotifierDB interface {
+	CreateNotificationDelivery(delivery db.NotificationDelivery) error
+}
+
+//go:generate counterfeiter . Notifier
+
+type Notifier interface {
+	BuildFinished(logger lager.Logger, build db.Build, buildURL string) error
+}
+
+func NewNotifier(notifierDB NotifierDB, teamDBFactory db.TeamDBFactory) Notifier {
+	return &notifier{
+		notifierDB:    notifierDB,
+		teamDBFactory: teamDBFactory,
+	}
+}
+
+type notifier struct {
+	notifierDB    NotifierDB
+	teamDBFactory db.TeamDBFactory
+}
+
+// BuildFinished queues a delivery of the build's final status to each of the
+// team's notification hooks. Deliveries are sent, and retried, by a Deliverer.
+func (n *notifier) BuildFinished(logger lager.Logger, build db.Build, buildURL string) error {
+	team, found, err := n.teamDBFactory.GetTeamDB(build.TeamName()).GetTeam()
+	if err != nil {
+		logger.Error("failed-to-get-team", err)
+		return err
+	}
+
+	if !found || len(team.NotificationHooks) == 0 {
+		return nil
+	}
+
+	buildInputs, _, err := build.GetResources()
+	if err != nil {
+		logger.Error("failed-to-get-build-resources", err)
+		return err
+	}
+
+	inputs := []Input{}
+	for _, input := range buildInputs {
+		inputs = append(inputs, Input{
+			Name:     input.Name,
+			Resource: input.Resource,
+			Type:     input.Type,
+			Version:  atc.Version(input.Version),
+		})
+	}
+
+	payload, err := json.Marshal(Payload{
+		Team:      build.TeamName(),
+		Pipeline:  build.PipelineName(),
+		Job:       build.JobName(),
+		BuildID:   build.ID(),
+		BuildName: build.Name(),
+		Status:    atc.BuildStatus(build.Status()),
+		URL:       buildURL,
+		StartTime: build.StartTime().Unix(),
+		EndTime:   build.EndTime().Unix(),
+		Inputs:    inputs,
+	})
+	if err != nil {
+		logger.Error("failed-to-marshal-payload", err)
+		return err
+	}
+
+	for _, hook := range team.NotificationHooks {
+		var signature string
+		if hook.Secret != "" {
+			signature = Sign(hook.Secret, payload)
+		}
+
+		err := n.notifierDB.CreateNotificationDelivery(db.NotificationDelivery{
+			BuildID:   build.ID(),
+			HookName:  hook.Name,
+			URL:       hook.URL,
+			Payload:   string(payload),
+			Signature: signature,
+		})
+		if err != nil {
+			logger.Error("failed-to-create-delivery", err, lager.Data{"hook": hook.Name})
+			return err
+		}
+	}
+
+	return nil
+}
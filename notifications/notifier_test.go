@@ -0,0 +1,177 @@
+package notifications_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/notifications"
+	"github.com/concourse/atc/notifications/notificationsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Notifier", func() {
+	var (
+		fakeNotifierDB    *notificationsfakes.FakeNotifierDB
+		fakeTeamDBFactory *dbfakes.FakeTeamDBFactory
+		fakeTeamDB        *dbfakes.FakeTeamDB
+		fakeBuild         *dbfakes.FakeBuild
+
+		notifier Notifier
+
+		notifyErr error
+	)
+
+	BeforeEach(func() {
+		fakeNotifierDB = new(notificationsfakes.FakeNotifierDB)
+		fakeTeamDBFactory = new(dbfakes.FakeTeamDBFactory)
+		fakeTeamDB = new(dbfakes.FakeTeamDB)
+		fakeTeamDBFactory.GetTeamDBReturns(fakeTeamDB)
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.NameReturns("7")
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.PipelineNameReturns("some-pipeline")
+		fakeBuild.JobNameReturns("some-job")
+		fakeBuild.StatusReturns(db.StatusFailed)
+		fakeBuild.StartTimeReturns(time.Unix(100, 0))
+		fakeBuild.EndTimeReturns(time.Unix(200, 0))
+		fakeBuild.GetResourcesReturns([]db.BuildInput{
+			{
+				Name: "some-input",
+				VersionedResource: db.VersionedResource{
+					Resource: "some-resource",
+					Type:     "git",
+					Version:  db.Version{"ref": "abc"},
+				},
+			},
+		}, nil, nil)
+
+		notifier = NewNotifier(fakeNotifierDB, fakeTeamDBFactory)
+	})
+
+	JustBeforeEach(func() {
+		notifyErr = notifier.BuildFinished(
+			lagertest.NewTestLogger("test"),
+			fakeBuild,
+			"https://ci.example.com/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/7",
+		)
+	})
+
+	It("looks up the build's team", func() {
+		Expect(fakeTeamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("some-team"))
+	})
+
+	Context("when the team has notification hooks", func() {
+		BeforeEach(func() {
+			fakeTeamDB.GetTeamReturns(db.SavedTeam{
+				Team: db.Team{
+					Name: "some-team",
+					NotificationHooks: []atc.NotificationHook{
+						{Name: "signed", URL: "https://chat.example.com/hook", Secret: "shh"},
+						{Name: "unsigned", URL: "https://other.example.com/hook"},
+					},
+				},
+			}, true, nil)
+		})
+
+		It("queues a delivery for each hook", func() {
+			Expect(notifyErr).NotTo(HaveOccurred())
+			Expect(fakeNotifierDB.CreateNotificationDeliveryCallCount()).To(Equal(2))
+
+			signed := fakeNotifierDB.CreateNotificationDeliveryArgsForCall(0)
+			Expect(signed.BuildID).To(Equal(42))
+			Expect(signed.HookName).To(Equal("signed"))
+			Expect(signed.URL).To(Equal("https://chat.example.com/hook"))
+			Expect(signed.Signature).To(Equal(Sign("shh", []byte(signed.Payload))))
+
+			unsigned := fakeNotifierDB.CreateNotificationDeliveryArgsForCall(1)
+			Expect(unsigned.HookName).To(Equal("unsigned"))
+			Expect(unsigned.Signature).To(BeEmpty())
+			Expect(unsigned.Payload).To(Equal(signed.Payload))
+		})
+
+		It("describes the build in the payload", func() {
+			delivery := fakeNotifierDB.CreateNotificationDeliveryArgsForCall(0)
+
+			var payload Payload
+			err := json.Unmarshal([]byte(delivery.Payload), &payload)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(payload).To(Equal(Payload{
+				Team:      "some-team",
+				Pipeline:  "some-pipeline",
+				Job:       "some-job",
+				BuildID:   42,
+				BuildName: "7",
+				Status:    atc.StatusFailed,
+				URL:       "https://ci.example.com/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/7",
+				StartTime: 100,
+				EndTime:   200,
+				Inputs: []Input{
+					{
+						Name:     "some-input",
+						Resource: "some-resource",
+						Type:     "git",
+						Version:  atc.Version{"ref": "abc"},
+					},
+				},
+			}))
+		})
+
+		Context("when getting the build's resources fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeBuild.GetResourcesReturns(nil, nil, disaster)
+			})
+
+			It("returns the error without queueing deliveries", func() {
+				Expect(notifyErr).To(Equal(disaster))
+				Expect(fakeNotifierDB.CreateNotificationDeliveryCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when queueing a delivery fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeNotifierDB.CreateNotificationDeliveryReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(notifyErr).To(Equal(disaster))
+			})
+		})
+	})
+
+	Context("when the team has no notification hooks", func() {
+		BeforeEach(func() {
+			fakeTeamDB.GetTeamReturns(db.SavedTeam{Team: db.Team{Name: "some-team"}}, true, nil)
+		})
+
+		It("does not queue any deliveries", func() {
+			Expect(notifyErr).NotTo(HaveOccurred())
+			Expect(fakeNotifierDB.CreateNotificationDeliveryCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when getting the team fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeTeamDB.GetTeamReturns(db.SavedTeam{}, false, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(notifyErr).To(Equal(disaster))
+		})
+	})
+})
@@ -0,0 +1,39 @@
+package notifications
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/concourse/atc"
+)
+
+const SignatureHeader = "X-Concourse-Signature"
+
+type Payload struct {
+	Team      string          `json:"team"`
+	Pipeline  string          `json:"pipeline,omitempty"`
+	Job       string          `json:"job,omitempty"`
+	BuildID   int             `json:"build_id"`
+	BuildName string          `json:"build_name"`
+	Status    atc.BuildStatus `json:"status"`
+	URL       string          `json:"url"`
+	StartTime int64           `json:"start_time"`
+	EndTime   int64           `json:"end_time"`
+	Inputs    []Input         `json:"inputs"`
+}
+
+type Input struct {
+	Name     string      `json:"name"`
+	Resource string      `json:"resource"`
+	Type     string      `json:"type"`
+	Version  atc.Version `json:"version"`
+}
+
+// Sign computes the value of the signature header for a payload, allowing
+// receivers to verify that it was sent by the ATC.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,55 @@
+package atc
+
+// PipelineArchiveVersion is the version of the archive format this ATC
+// exports and imports.
+const PipelineArchiveVersion = 1
+
+// PipelineArchive is a self-contained copy of a pipeline, for moving it to
+// another team or ATC. Nothing in it refers to database IDs; versions are
+// identified by their resource and content, and builds by their job and name.
+type PipelineArchive struct {
+	Version   int                `json:"version"`
+	Config    Config             `json:"config"`
+	Resources []ArchivedResource `json:"resources"`
+	Builds    []ArchivedBuild    `json:"builds,omitempty"`
+}
+
+// ArchivedResource is a resource's versions, in the order they were checked.
+type ArchivedResource struct {
+	Name     string            `json:"name"`
+	Versions []ArchivedVersion `json:"versions"`
+}
+
+type ArchivedVersion struct {
+	Type     string          `json:"type"`
+	Version  Version         `json:"version"`
+	Metadata []MetadataField `json:"metadata,omitempty"`
+	Enabled  bool            `json:"enabled"`
+}
+
+// ArchivedBuild is a finished build of one of the pipeline's jobs. Its logs
+// are not archived.
+type ArchivedBuild struct {
+	JobName   string                `json:"job_name"`
+	Name      string                `json:"name"`
+	Status    string                `json:"status"`
+	StartTime int64                 `json:"start_time,omitempty"`
+	EndTime   int64                 `json:"end_time,omitempty"`
+	RerunOf   string                `json:"rerun_of,omitempty"`
+	Inputs    []ArchivedBuildInput  `json:"inputs,omitempty"`
+	Outputs   []ArchivedBuildOutput `json:"outputs,omitempty"`
+}
+
+type ArchivedBuildInput struct {
+	Name     string  `json:"name"`
+	Resource string  `json:"resource"`
+	Type     string  `json:"type"`
+	Version  Version `json:"version"`
+}
+
+type ArchivedBuildOutput struct {
+	Resource string  `json:"resource"`
+	Type     string  `json:"type"`
+	Version  Version `json:"version"`
+	Explicit bool    `json:"explicit"`
+}
@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/clock"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/radar"
@@ -25,25 +26,31 @@ type RadarSchedulerFactory interface {
 }
 
 type radarSchedulerFactory struct {
-	tracker  resource.Tracker
-	interval time.Duration
-	engine   engine.Engine
+	tracker             resource.Tracker
+	interval            time.Duration
+	intervalWithWebhook time.Duration
+	engine              engine.Engine
+	variablesFactory    creds.VariablesFactory
 }
 
 func NewRadarSchedulerFactory(
 	tracker resource.Tracker,
 	interval time.Duration,
+	intervalWithWebhook time.Duration,
 	engine engine.Engine,
+	variablesFactory creds.VariablesFactory,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
-		tracker:  tracker,
-		interval: interval,
-		engine:   engine,
+		tracker:             tracker,
+		interval:            interval,
+		intervalWithWebhook: intervalWithWebhook,
+		engine:              engine,
+		variablesFactory:    variablesFactory,
 	}
 }
 
 func (rsf *radarSchedulerFactory) BuildScanRunnerFactory(pipelineDB db.PipelineDB, externalURL string) radar.ScanRunnerFactory {
-	return radar.NewScanRunnerFactory(rsf.tracker, rsf.interval, pipelineDB, clock.NewClock(), externalURL)
+	return radar.NewScanRunnerFactory(rsf.tracker, rsf.interval, rsf.intervalWithWebhook, pipelineDB, clock.NewClock(), externalURL, rsf.variables(pipelineDB))
 }
 
 func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, externalURL string) scheduler.BuildScheduler {
@@ -51,8 +58,10 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, exter
 		clock.NewClock(),
 		rsf.tracker,
 		rsf.interval,
+		rsf.intervalWithWebhook,
 		pipelineDB,
 		externalURL,
+		rsf.variables(pipelineDB),
 	)
 	return &scheduler.Scheduler{
 		DB: pipelineDB,
@@ -72,3 +81,7 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, exter
 		Scanner: scanner,
 	}
 }
+
+func (rsf *radarSchedulerFactory) variables(pipelineDB db.PipelineDB) creds.Variables {
+	return rsf.variablesFactory.NewVariables(pipelineDB.Pipeline().TeamName, pipelineDB.GetPipelineName())
+}
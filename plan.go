@@ -5,6 +5,8 @@ type Plan struct {
 	Attempts []int  `json:"attempts,omitempty"`
 
 	Aggregate    *AggregatePlan    `json:"aggregate,omitempty"`
+	InParallel   *InParallelPlan   `json:"in_parallel,omitempty"`
+	Across       *AcrossPlan       `json:"across,omitempty"`
 	Do           *DoPlan           `json:"do,omitempty"`
 	Get          *GetPlan          `json:"get,omitempty"`
 	Put          *PutPlan          `json:"put,omitempty"`
@@ -72,6 +74,24 @@ type TryPlan struct {
 
 type AggregatePlan []Plan
 
+type InParallelPlan struct {
+	Steps    []Plan `json:"steps"`
+	Limit    int    `json:"limit,omitempty"`
+	FailFast bool   `json:"fail_fast,omitempty"`
+}
+
+type AcrossPlan struct {
+	Vars  []string     `json:"vars"`
+	Steps []AcrossStep `json:"steps"`
+}
+
+// AcrossStep is the plan for one combination of an AcrossPlan's vars. Values
+// are in the same order as the vars.
+type AcrossStep struct {
+	Values []interface{} `json:"values"`
+	Step   Plan          `json:"step"`
+}
+
 type DoPlan []Plan
 
 type GetPlan struct {
@@ -7,6 +7,8 @@ func (plan Plan) Public() *json.RawMessage {
 		ID PlanID `json:"id"`
 
 		Aggregate    *json.RawMessage `json:"aggregate,omitempty"`
+		InParallel   *json.RawMessage `json:"in_parallel,omitempty"`
+		Across       *json.RawMessage `json:"across,omitempty"`
 		Do           *json.RawMessage `json:"do,omitempty"`
 		Get          *json.RawMessage `json:"get,omitempty"`
 		Put          *json.RawMessage `json:"put,omitempty"`
@@ -26,6 +28,14 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Aggregate = plan.Aggregate.Public()
 	}
 
+	if plan.InParallel != nil {
+		public.InParallel = plan.InParallel.Public()
+	}
+
+	if plan.Across != nil {
+		public.Across = plan.Across.Public()
+	}
+
 	if plan.Do != nil {
 		public.Do = plan.Do.Public()
 	}
@@ -83,6 +93,48 @@ func (plan AggregatePlan) Public() *json.RawMessage {
 	return enc(public)
 }
 
+func (plan InParallelPlan) Public() *json.RawMessage {
+	steps := make([]*json.RawMessage, len(plan.Steps))
+
+	for i := 0; i < len(plan.Steps); i++ {
+		steps[i] = plan.Steps[i].Public()
+	}
+
+	return enc(struct {
+		Steps    []*json.RawMessage `json:"steps"`
+		Limit    int                `json:"limit,omitempty"`
+		FailFast bool               `json:"fail_fast,omitempty"`
+	}{
+		Steps:    steps,
+		Limit:    plan.Limit,
+		FailFast: plan.FailFast,
+	})
+}
+
+func (plan AcrossPlan) Public() *json.RawMessage {
+	type publicAcrossStep struct {
+		Values []interface{}    `json:"values"`
+		Step   *json.RawMessage `json:"step"`
+	}
+
+	steps := make([]publicAcrossStep, len(plan.Steps))
+
+	for i := 0; i < len(plan.Steps); i++ {
+		steps[i] = publicAcrossStep{
+			Values: plan.Steps[i].Values,
+			Step:   plan.Steps[i].Step.Public(),
+		}
+	}
+
+	return enc(struct {
+		Vars  []string           `json:"vars"`
+		Steps []publicAcrossStep `json:"steps"`
+	}{
+		Vars:  plan.Vars,
+		Steps: steps,
+	})
+}
+
 func (plan DoPlan) Public() *json.RawMessage {
 	public := make([]*json.RawMessage, len(plan))
 
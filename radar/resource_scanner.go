@@ -8,32 +8,39 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
 )
 
 type resourceScanner struct {
-	clock           clock.Clock
-	tracker         resource.Tracker
-	defaultInterval time.Duration
-	db              RadarDB
-	externalURL     string
+	clock                      clock.Clock
+	tracker                    resource.Tracker
+	defaultInterval            time.Duration
+	defaultIntervalWithWebhook time.Duration
+	db                         RadarDB
+	externalURL                string
+	variables                  creds.Variables
 }
 
 func NewResourceScanner(
 	clock clock.Clock,
 	tracker resource.Tracker,
 	defaultInterval time.Duration,
+	defaultIntervalWithWebhook time.Duration,
 	db RadarDB,
 	externalURL string,
+	variables creds.Variables,
 ) Scanner {
 	return &resourceScanner{
-		clock:           clock,
-		tracker:         tracker,
-		defaultInterval: defaultInterval,
-		db:              db,
-		externalURL:     externalURL,
+		clock:                      clock,
+		tracker:                    tracker,
+		defaultInterval:            defaultInterval,
+		defaultIntervalWithWebhook: defaultIntervalWithWebhook,
+		db:                         db,
+		externalURL:                externalURL,
+		variables:                  variables,
 	}
 }
 
@@ -192,6 +199,20 @@ func (scanner *resourceScanner) scan(
 		return nil
 	}
 
+	source, err := creds.NewSource(scanner.variables, resourceConfig.Source).Evaluate()
+	if err != nil {
+		logger.Error("failed-to-evaluate-source", err)
+		scanner.setCheckError(logger, savedResource, err)
+		return err
+	}
+
+	evaluatedResourceTypes, err := creds.NewResourceTypes(scanner.variables, resourceTypes).Evaluate()
+	if err != nil {
+		logger.Error("failed-to-evaluate-resource-types", err)
+		scanner.setCheckError(logger, savedResource, err)
+		return err
+	}
+
 	pipelineID := scanner.db.GetPipelineID()
 
 	var resourceTypeVersion atc.Version
@@ -234,7 +255,7 @@ func (scanner *resourceScanner) scan(
 		resource.ResourceType(resourceConfig.Type),
 		[]string{},
 		scanner.db.TeamID(),
-		resourceTypes,
+		evaluatedResourceTypes,
 		worker.NoopImageFetchingDelegate{},
 	)
 	if err != nil {
@@ -248,12 +269,9 @@ func (scanner *resourceScanner) scan(
 		"from": fromVersion,
 	})
 
-	newVersions, err := res.Check(resourceConfig.Source, fromVersion)
+	newVersions, err := res.Check(source, fromVersion)
 
-	setErr := scanner.db.SetResourceCheckError(savedResource, err)
-	if setErr != nil {
-		logger.Error("failed-to-set-check-error", err)
-	}
+	scanner.setCheckError(logger, savedResource, err)
 
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
@@ -285,6 +303,13 @@ func (scanner *resourceScanner) scan(
 	return nil
 }
 
+func (scanner *resourceScanner) setCheckError(logger lager.Logger, savedResource db.SavedResource, err error) {
+	setErr := scanner.db.SetResourceCheckError(savedResource, err)
+	if setErr != nil {
+		logger.Error("failed-to-set-check-error", setErr)
+	}
+}
+
 func swallowErrResourceScriptFailed(err error) error {
 	if _, ok := err.(resource.ErrResourceScriptFailed); ok {
 		return nil
@@ -294,6 +319,13 @@ func swallowErrResourceScriptFailed(err error) error {
 
 func (scanner *resourceScanner) checkInterval(resourceConfig atc.ResourceConfig) (time.Duration, error) {
 	interval := scanner.defaultInterval
+
+	// resources with a webhook are checked as soon as the webhook fires, so
+	// periodic checking only needs to catch the occasional missed delivery
+	if resourceConfig.WebhookToken != "" {
+		interval = scanner.defaultIntervalWithWebhook
+	}
+
 	if resourceConfig.CheckEvery != "" {
 		configuredInterval, err := time.ParseDuration(resourceConfig.CheckEvery)
 		if err != nil {
@@ -8,6 +8,8 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/worker"
 
@@ -24,10 +26,12 @@ var _ = Describe("ResourceScanner", func() {
 	var (
 		epoch time.Time
 
-		fakeTracker *rfakes.FakeTracker
-		fakeRadarDB *radarfakes.FakeRadarDB
-		fakeClock   *fakeclock.FakeClock
-		interval    time.Duration
+		fakeTracker         *rfakes.FakeTracker
+		fakeRadarDB         *radarfakes.FakeRadarDB
+		fakeVariables       *credsfakes.FakeVariables
+		fakeClock           *fakeclock.FakeClock
+		interval            time.Duration
+		intervalWithWebhook time.Duration
 
 		scanner Scanner
 
@@ -44,14 +48,26 @@ var _ = Describe("ResourceScanner", func() {
 		fakeRadarDB = new(radarfakes.FakeRadarDB)
 		fakeClock = fakeclock.NewFakeClock(epoch)
 		interval = 1 * time.Minute
+		intervalWithWebhook = 1 * time.Hour
+
+		fakeVariables = new(credsfakes.FakeVariables)
+		fakeVariables.GetStub = func(name string) (interface{}, bool, error) {
+			if name == "some-secret" {
+				return "super-secret", true, nil
+			}
+
+			return nil, false, nil
+		}
 
 		fakeRadarDB.GetPipelineIDReturns(42)
 		scanner = NewResourceScanner(
 			fakeClock,
 			fakeTracker,
 			interval,
+			intervalWithWebhook,
 			fakeRadarDB,
 			"https://www.example.com",
+			fakeVariables,
 		)
 
 		resourceConfig = atc.ResourceConfig{
@@ -167,6 +183,112 @@ var _ = Describe("ResourceScanner", func() {
 				Expect(actualTeamID).To(Equal(teamID))
 			})
 
+			Context("when the source and resource types use credentials", func() {
+				BeforeEach(func() {
+					resourceConfig.Source = atc.Source{"uri": "http://example.com", "password": "((some-secret))"}
+
+					fakeRadarDB.GetConfigReturns(atc.Config{
+						Resources: atc.ResourceConfigs{
+							resourceConfig,
+						},
+						ResourceTypes: atc.ResourceTypes{
+							{
+								Name:   "some-custom-resource",
+								Type:   "docker-image",
+								Source: atc.Source{"custom": "((some-secret))"},
+							},
+						},
+					}, 1, true, nil)
+				})
+
+				It("checks with the credentials resolved", func() {
+					source, _ := fakeResource.CheckArgsForCall(0)
+					Expect(source).To(Equal(atc.Source{"uri": "http://example.com", "password": "super-secret"}))
+				})
+
+				It("constructs the resource with the resource types' credentials resolved", func() {
+					_, _, _, _, _, _, customTypes, _ := fakeTracker.InitArgsForCall(0)
+					Expect(customTypes).To(Equal(atc.ResourceTypes{
+						{
+							Name:   "some-custom-resource",
+							Type:   "docker-image",
+							Source: atc.Source{"custom": "super-secret"},
+						},
+					}))
+				})
+
+				It("does not put the credentials in the container's identifier", func() {
+					_, _, session, _, _, _, _, _ := fakeTracker.InitArgsForCall(0)
+					Expect(session.ID.CheckSource).To(Equal(atc.Source{"uri": "http://example.com", "password": "((some-secret))"}))
+				})
+
+				Context("when a credential is not defined", func() {
+					BeforeEach(func() {
+						resourceConfig.Source = atc.Source{"password": "((bogus-secret))"}
+
+						fakeRadarDB.GetConfigReturns(atc.Config{
+							Resources: atc.ResourceConfigs{
+								resourceConfig,
+							},
+						}, 1, true, nil)
+					})
+
+					It("does not check", func() {
+						Expect(fakeTracker.InitCallCount()).To(BeZero())
+						Expect(fakeResource.CheckCallCount()).To(BeZero())
+					})
+
+					It("returns and records the error", func() {
+						expectedErr := creds.UndefinedVariablesError{Names: []string{"bogus-secret"}}
+						Expect(runErr).To(Equal(expectedErr))
+
+						Expect(fakeRadarDB.SetResourceCheckErrorCallCount()).To(Equal(1))
+						resourceArg, err := fakeRadarDB.SetResourceCheckErrorArgsForCall(0)
+						Expect(resourceArg).To(Equal(savedResource))
+						Expect(err).To(Equal(expectedErr))
+					})
+				})
+			})
+
+			Context("when the resource config has a webhook token", func() {
+				BeforeEach(func() {
+					resourceConfig.WebhookToken = "some-token"
+
+					fakeRadarDB.GetConfigReturns(atc.Config{
+						Resources: atc.ResourceConfigs{
+							resourceConfig,
+						},
+					}, 1, true, nil)
+				})
+
+				It("leases for the webhook interval", func() {
+					Expect(fakeRadarDB.LeaseResourceCheckingCallCount()).To(Equal(1))
+
+					_, _, leaseInterval, _ := fakeRadarDB.LeaseResourceCheckingArgsForCall(0)
+					Expect(leaseInterval).To(Equal(intervalWithWebhook))
+				})
+
+				It("returns the webhook interval", func() {
+					Expect(actualInterval).To(Equal(intervalWithWebhook))
+				})
+
+				Context("when the resource config also has a specified check interval", func() {
+					BeforeEach(func() {
+						resourceConfig.CheckEvery = "10ms"
+
+						fakeRadarDB.GetConfigReturns(atc.Config{
+							Resources: atc.ResourceConfigs{
+								resourceConfig,
+							},
+						}, 1, true, nil)
+					})
+
+					It("returns the configured interval", func() {
+						Expect(actualInterval).To(Equal(10 * time.Millisecond))
+					})
+				})
+			})
+
 			Context("when the resource config has a specified check interval", func() {
 				BeforeEach(func() {
 					resourceConfig.CheckEvery = "10ms"
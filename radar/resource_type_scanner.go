@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -15,6 +16,7 @@ type resourceTypeScanner struct {
 	defaultInterval time.Duration
 	db              RadarDB
 	externalURL     string
+	variables       creds.Variables
 }
 
 func NewResourceTypeScanner(
@@ -22,12 +24,14 @@ func NewResourceTypeScanner(
 	defaultInterval time.Duration,
 	db RadarDB,
 	externalURL string,
+	variables creds.Variables,
 ) Scanner {
 	return &resourceTypeScanner{
 		tracker:         tracker,
 		defaultInterval: defaultInterval,
 		db:              db,
 		externalURL:     externalURL,
+		variables:       variables,
 	}
 }
 
@@ -97,6 +101,12 @@ func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, resour
 		from = vr.Version
 	}
 
+	source, err := creds.NewSource(scanner.variables, resourceType.Source).Evaluate()
+	if err != nil {
+		logger.Error("failed-to-evaluate-source", err)
+		return err
+	}
+
 	pipelineID := scanner.db.GetPipelineID()
 
 	session := resource.Session{
@@ -134,7 +144,7 @@ func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, resour
 
 	logger.Debug("checking")
 
-	newVersions, err := res.Check(resourceType.Source, atc.Version(from))
+	newVersions, err := res.Check(source, atc.Version(from))
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
 			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	. "github.com/concourse/atc/radar"
 	"github.com/concourse/atc/radar/radarfakes"
@@ -22,9 +23,10 @@ var _ = Describe("ResourceTypeScanner", func() {
 	var (
 		epoch time.Time
 
-		fakeTracker *rfakes.FakeTracker
-		fakeRadarDB *radarfakes.FakeRadarDB
-		interval    time.Duration
+		fakeTracker   *rfakes.FakeTracker
+		fakeRadarDB   *radarfakes.FakeRadarDB
+		fakeVariables *credsfakes.FakeVariables
+		interval      time.Duration
 
 		scanner Scanner
 
@@ -40,12 +42,22 @@ var _ = Describe("ResourceTypeScanner", func() {
 		fakeRadarDB = new(radarfakes.FakeRadarDB)
 		interval = 1 * time.Minute
 
+		fakeVariables = new(credsfakes.FakeVariables)
+		fakeVariables.GetStub = func(name string) (interface{}, bool, error) {
+			if name == "some-secret" {
+				return "super-secret", true, nil
+			}
+
+			return nil, false, nil
+		}
+
 		fakeRadarDB.GetPipelineIDReturns(42)
 		scanner = NewResourceTypeScanner(
 			fakeTracker,
 			interval,
 			fakeRadarDB,
 			"https://www.example.com",
+			fakeVariables,
 		)
 
 		fakeRadarDB.ScopedNameStub = func(thing string) string {
@@ -157,6 +169,30 @@ var _ = Describe("ResourceTypeScanner", func() {
 				Eventually(fakeResource.ReleaseCallCount).Should(Equal(1))
 			})
 
+			Context("when the source uses credentials", func() {
+				BeforeEach(func() {
+					fakeRadarDB.GetConfigReturns(atc.Config{
+						ResourceTypes: atc.ResourceTypes{
+							{
+								Name:   "some-resource-type",
+								Type:   "docker-image",
+								Source: atc.Source{"custom": "((some-secret))"},
+							},
+						},
+					}, 1, true, nil)
+				})
+
+				It("checks with the credentials resolved", func() {
+					source, _ := fakeResource.CheckArgsForCall(0)
+					Expect(source).To(Equal(atc.Source{"custom": "super-secret"}))
+				})
+
+				It("does not put the credentials in the container's identifier", func() {
+					_, _, session, _, _, _, _, _ := fakeTracker.InitArgsForCall(0)
+					Expect(session.ID.CheckSource).To(Equal(atc.Source{"custom": "((some-secret))"}))
+				})
+			})
+
 			Context("when there is no current version", func() {
 				It("checks from nil", func() {
 					_, version := fakeResource.CheckArgsForCall(0)
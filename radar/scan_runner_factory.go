@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/resource"
 	"github.com/tedsuo/ifrit"
 
@@ -42,22 +43,27 @@ type scanRunnerFactory struct {
 func NewScanRunnerFactory(
 	tracker resource.Tracker,
 	defaultInterval time.Duration,
+	defaultIntervalWithWebhook time.Duration,
 	db RadarDB,
 	clock clock.Clock,
 	externalURL string,
+	variables creds.Variables,
 ) ScanRunnerFactory {
 	resourceScanner := NewResourceScanner(
 		clock,
 		tracker,
 		defaultInterval,
+		defaultIntervalWithWebhook,
 		db,
 		externalURL,
+		variables,
 	)
 	resourceTypeScanner := NewResourceTypeScanner(
 		tracker,
 		defaultInterval,
 		db,
 		externalURL,
+		variables,
 	)
 
 	return &scanRunnerFactory{
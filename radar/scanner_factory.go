@@ -4,31 +4,40 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/clock"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 )
 
 type ScannerFactory interface {
-	NewResourceScanner(db RadarDB) Scanner
+	NewResourceScanner(pipelineDB db.PipelineDB) Scanner
 }
 
 type scannerFactory struct {
-	tracker         resource.Tracker
-	defaultInterval time.Duration
-	externalURL     string
+	tracker                    resource.Tracker
+	defaultInterval            time.Duration
+	defaultIntervalWithWebhook time.Duration
+	externalURL                string
+	variablesFactory           creds.VariablesFactory
 }
 
 func NewScannerFactory(
 	tracker resource.Tracker,
 	defaultInterval time.Duration,
+	defaultIntervalWithWebhook time.Duration,
 	externalURL string,
+	variablesFactory creds.VariablesFactory,
 ) ScannerFactory {
 	return &scannerFactory{
-		tracker:         tracker,
-		defaultInterval: defaultInterval,
-		externalURL:     externalURL,
+		tracker:                    tracker,
+		defaultInterval:            defaultInterval,
+		defaultIntervalWithWebhook: defaultIntervalWithWebhook,
+		externalURL:                externalURL,
+		variablesFactory:           variablesFactory,
 	}
 }
 
-func (f *scannerFactory) NewResourceScanner(db RadarDB) Scanner {
-	return NewResourceScanner(clock.NewClock(), f.tracker, f.defaultInterval, db, f.externalURL)
+func (f *scannerFactory) NewResourceScanner(pipelineDB db.PipelineDB) Scanner {
+	variables := f.variablesFactory.NewVariables(pipelineDB.Pipeline().TeamName, pipelineDB.GetPipelineName())
+	return NewResourceScanner(clock.NewClock(), f.tracker, f.defaultInterval, f.defaultIntervalWithWebhook, pipelineDB, f.externalURL, variables)
 }
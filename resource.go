@@ -8,6 +8,13 @@ type Resource struct {
 
 	Paused bool `json:"paused,omitempty"`
 
+	PinnedVersion Version `json:"pinned_version,omitempty"`
+	PinComment    string  `json:"pin_comment,omitempty"`
+
 	FailingToCheck bool   `json:"failing_to_check,omitempty"`
 	CheckError     string `json:"check_error,omitempty"`
 }
+
+type PinRequestBody struct {
+	Comment string `json:"comment"`
+}
@@ -100,6 +100,9 @@ func (f *fetchSourceProvider) Get() (FetchSource, error) {
 		ResourceType: string(f.resourceOptions.ResourceType()),
 		Tags:         f.tags,
 		TeamID:       f.teamID,
+		Inputs: []worker.VolumeLocator{
+			cacheLocator{logger: f.logger, cacheIdentifier: f.cacheIdentifier},
+		},
 	}
 
 	chosenWorker, err := f.workerClient.Satisfying(resourceSpec, f.resourceTypes)
@@ -132,3 +135,14 @@ func (f *fetchSourceProvider) Get() (FetchSource, error) {
 		f.resourceOptions,
 	), nil
 }
+
+// cacheLocator lets the placement strategy prefer a worker which already has
+// the resource cache.
+type cacheLocator struct {
+	logger          lager.Logger
+	cacheIdentifier CacheIdentifier
+}
+
+func (locator cacheLocator) VolumeOn(w worker.Worker) (worker.Volume, bool, error) {
+	return locator.cacheIdentifier.FindOn(locator.logger, w)
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/concourse/atc"
 	. "github.com/concourse/atc/resource"
 	"github.com/concourse/atc/resource/resourcefakes"
-	"github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/workerfakes"
 
 	. "github.com/onsi/ginkgo"
@@ -85,14 +84,32 @@ var _ = Describe("FetchSourceProvider", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(fakeWorkerClient.SatisfyingCallCount()).To(Equal(1))
 				resourceSpec, actualResourceTypes := fakeWorkerClient.SatisfyingArgsForCall(0)
-				Expect(resourceSpec).To(Equal(worker.WorkerSpec{
-					ResourceType: "some-resource-type",
-					Tags:         tags,
-					TeamID:       teamID,
-				}))
+				Expect(resourceSpec.ResourceType).To(Equal("some-resource-type"))
+				Expect(resourceSpec.Tags).To(Equal(tags))
+				Expect(resourceSpec.TeamID).To(Equal(teamID))
 				Expect(actualResourceTypes).To(Equal(resourceTypes))
 			})
 
+			It("lets the worker be chosen by where the cache already is", func() {
+				_, err := fetchSourceProvider.Get()
+				Expect(err).NotTo(HaveOccurred())
+
+				resourceSpec, _ := fakeWorkerClient.SatisfyingArgsForCall(0)
+				Expect(resourceSpec.Inputs).To(HaveLen(1))
+
+				fakeVolume := new(workerfakes.FakeVolume)
+				cacheID.FindOnReturns(fakeVolume, true, nil)
+
+				someWorker := new(workerfakes.FakeWorker)
+				volume, found, err := resourceSpec.Inputs[0].VolumeOn(someWorker)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(volume).To(Equal(fakeVolume))
+
+				_, actualWorker := cacheID.FindOnArgsForCall(cacheID.FindOnCallCount() - 1)
+				Expect(actualWorker).To(Equal(someWorker))
+			})
+
 			Context("when worker is found for resource types", func() {
 				var fakeWorker *workerfakes.FakeWorker
 
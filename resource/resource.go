@@ -7,6 +7,8 @@ import (
 	"sync"
 	"time"
 
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/worker"
 )
@@ -24,6 +26,18 @@ type Resource interface {
 type IOConfig struct {
 	Stdout io.Writer
 	Stderr io.Writer
+
+	// Usage, if set, samples the resources consumed by the script's container
+	// and reports them once the script exits.
+	Usage *UsageReporter
+}
+
+// UsageReporter samples a script's container with the logger and clock of
+// the step running it, and passes the result to Report.
+type UsageReporter struct {
+	Logger lager.Logger
+	Clock  clock.Clock
+	Report func(atc.ResourceUsage)
 }
 
 //go:generate counterfeiter . ArtifactSource
@@ -22,6 +22,7 @@ func (resource *resource) Check(source atc.Source, fromVersion atc.Version) ([]a
 		nil,
 		nil,
 		false,
+		nil,
 	))
 
 	err := <-checking.Wait()
@@ -33,6 +33,7 @@ func (resource *resource) Get(
 		nil,
 		nil,
 		true,
+		ioConfig.Usage,
 	)
 
 	err := runner.Run(signals, ready)
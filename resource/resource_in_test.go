@@ -13,6 +13,7 @@ import (
 
 	"code.cloudfoundry.org/garden"
 	gfakes "code.cloudfoundry.org/garden/gardenfakes"
+	"code.cloudfoundry.org/lager/lagertest"
 	wfakes "github.com/concourse/atc/worker/workerfakes"
 
 	"github.com/concourse/atc"
@@ -376,6 +377,32 @@ var _ = Describe("Resource In", func() {
 				})
 			})
 
+			Context("when usage reporting is requested", func() {
+				var reportedUsage []atc.ResourceUsage
+
+				BeforeEach(func() {
+					reportedUsage = nil
+					ioConfig.Usage = &UsageReporter{
+						Logger: lagertest.NewTestLogger("test"),
+						Clock:  fakeClock,
+						Report: func(usage atc.ResourceUsage) {
+							reportedUsage = append(reportedUsage, usage)
+						},
+					}
+
+					fakeContainer.MetricsReturns(garden.Metrics{
+						MemoryStat: garden.ContainerMemoryStat{TotalRss: 1024},
+						DiskStat:   garden.ContainerDiskStat{TotalBytesUsed: 2048},
+					}, nil)
+				})
+
+				It("reports the container's usage once the script exits", func() {
+					Expect(reportedUsage).To(HaveLen(1))
+					Expect(reportedUsage[0].PeakMemory).To(Equal(uint64(1024)))
+					Expect(reportedUsage[0].PeakDisk).To(Equal(uint64(2048)))
+				})
+			})
+
 			Context("when running /opt/resource/in fails", func() {
 				disaster := errors.New("oh no!")
 
@@ -38,6 +38,7 @@ func (resource *resource) Put(
 		artifactSource,
 		vs,
 		true,
+		ioConfig.Usage,
 	)
 
 	err := runner.Run(signals, ready)
@@ -2,6 +2,7 @@ package resource_test
 
 import (
 	"testing"
+	"time"
 
 	"code.cloudfoundry.org/clock/fakeclock"
 	wfakes "github.com/concourse/atc/worker/workerfakes"
@@ -24,6 +25,8 @@ var _ = BeforeEach(func() {
 
 	fakeContainer = new(wfakes.FakeContainer)
 
+	fakeClock = fakeclock.NewFakeClock(time.Unix(0, 123))
+
 	resource = NewResource(fakeContainer)
 })
 
@@ -9,6 +9,7 @@ import (
 	"os"
 
 	"code.cloudfoundry.org/garden"
+	"github.com/concourse/atc/worker"
 	"github.com/tedsuo/ifrit"
 )
 
@@ -49,6 +50,7 @@ func (resource *resource) runScript(
 	inputSource ArtifactSource,
 	inputDestination ArtifactDestination,
 	recoverable bool,
+	usage *UsageReporter,
 ) ifrit.Runner {
 	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
 		request, err := json.Marshal(input)
@@ -118,6 +120,19 @@ func (resource *resource) runScript(
 
 		close(ready)
 
+		if usage != nil {
+			sampler := worker.SampleUsage(
+				usage.Logger,
+				usage.Clock,
+				worker.UsageSamplingInterval,
+				resource.container,
+			)
+
+			defer func() {
+				usage.Report(sampler.Stop())
+			}()
+		}
+
 		processExited := make(chan struct{})
 
 		var processStatus int
@@ -106,48 +106,36 @@ func (tracker *tracker) InitWithSources(
 		Env:       metadata.Env(),
 	}
 
-	compatibleWorkers, err := tracker.workerClient.AllSatisfying(resourceSpec.WorkerSpec(), resourceTypes)
+	workerSpec := resourceSpec.WorkerSpec()
+	for _, source := range sources {
+		workerSpec.Inputs = append(workerSpec.Inputs, source)
+	}
+
+	chosenWorker, err := tracker.workerClient.Satisfying(workerSpec, resourceTypes)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// find the worker with the most volumes
 	mounts := []worker.VolumeMount{}
 	missingSources := []string{}
-	var chosenWorker worker.Worker
-
-	for _, w := range compatibleWorkers {
-		candidateMounts := []worker.VolumeMount{}
-		missing := []string{}
-
-		for name, source := range sources {
-			ourVolume, found, err := source.VolumeOn(w)
-			if err != nil {
-				return nil, nil, err
-			}
-
-			if found {
-				candidateMounts = append(candidateMounts, worker.VolumeMount{
-					Volume:    ourVolume,
-					MountPath: ResourcesDir("put/" + name),
-				})
-			} else {
-				missing = append(missing, name)
-			}
-		}
 
-		if len(candidateMounts) >= len(mounts) {
+	for name, source := range sources {
+		ourVolume, found, err := source.VolumeOn(chosenWorker)
+		if err != nil {
 			for _, mount := range mounts {
 				mount.Volume.Release(nil)
 			}
 
-			mounts = candidateMounts
-			missingSources = missing
-			chosenWorker = w
+			return nil, nil, err
+		}
+
+		if found {
+			mounts = append(mounts, worker.VolumeMount{
+				Volume:    ourVolume,
+				MountPath: ResourcesDir("put/" + name),
+			})
 		} else {
-			for _, mount := range candidateMounts {
-				mount.Volume.Release(nil)
-			}
+			missingSources = append(missingSources, name)
 		}
 	}
 
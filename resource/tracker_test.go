@@ -216,7 +216,7 @@ var _ = Describe("Tracker", func() {
 
 				BeforeEach(func() {
 					satisfyingWorker = new(wfakes.FakeWorker)
-					workerClient.AllSatisfyingReturns([]worker.Worker{satisfyingWorker}, nil)
+					workerClient.SatisfyingReturns(satisfyingWorker, nil)
 
 					satisfyingWorker.CreateContainerReturns(fakeContainer, nil)
 				})
@@ -242,8 +242,11 @@ var _ = Describe("Tracker", func() {
 					})
 
 					It("chose the worker satisfying the resource type and tags", func() {
-						Expect(workerClient.AllSatisfyingCallCount()).To(Equal(1))
-						actualSpec, actualCustomTypes := workerClient.AllSatisfyingArgsForCall(0)
+						Expect(workerClient.SatisfyingCallCount()).To(Equal(1))
+						actualSpec, actualCustomTypes := workerClient.SatisfyingArgsForCall(0)
+						Expect(actualSpec.Inputs).To(ConsistOf(inputSource1, inputSource2, inputSource3))
+
+						actualSpec.Inputs = nil
 						Expect(actualSpec).To(Equal(
 							worker.WorkerSpec{
 								ResourceType: "type1",
@@ -384,11 +387,13 @@ var _ = Describe("Tracker", func() {
 					satisfyingWorker2 = new(wfakes.FakeWorker)
 					satisfyingWorker3 = new(wfakes.FakeWorker)
 
-					workerClient.AllSatisfyingReturns([]worker.Worker{
-						satisfyingWorker1,
-						satisfyingWorker2,
-						satisfyingWorker3,
-					}, nil)
+					workerClient.SatisfyingStub = func(spec worker.WorkerSpec, resourceTypes atc.ResourceTypes) (worker.Worker, error) {
+						return worker.NewVolumeLocalityPlacementStrategy().Choose([]worker.Worker{
+							satisfyingWorker1,
+							satisfyingWorker2,
+							satisfyingWorker3,
+						}, spec)
+					}
 
 					satisfyingWorker1.CreateContainerReturns(fakeContainer, nil)
 					satisfyingWorker2.CreateContainerReturns(fakeContainer, nil)
@@ -454,11 +459,11 @@ var _ = Describe("Tracker", func() {
 						Expect(inputVolume.ReleaseCallCount()).To(Equal(1))
 						Expect(inputVolume3.ReleaseCallCount()).To(Equal(1))
 
-						// We don't expect these to be released because we are
-						// causing an error in the create container step, which
-						// happens before they are released.
-						Expect(inputVolume2.ReleaseCallCount()).To(Equal(0))
-						Expect(otherInputVolume.ReleaseCallCount()).To(Equal(0))
+						// These are only released by the placement strategy, as
+						// we are causing an error in the create container step,
+						// which happens before the mounts are released.
+						Expect(inputVolume2.ReleaseCallCount()).To(Equal(1))
+						Expect(otherInputVolume.ReleaseCallCount()).To(Equal(1))
 					})
 				})
 			})
@@ -467,7 +472,7 @@ var _ = Describe("Tracker", func() {
 				disaster := errors.New("nope")
 
 				BeforeEach(func() {
-					workerClient.AllSatisfyingReturns(nil, disaster)
+					workerClient.SatisfyingReturns(nil, disaster)
 				})
 
 				It("returns the error and no resource", func() {
@@ -0,0 +1,24 @@
+package atc
+
+// ResourceUsage summarizes the CPU, memory and disk consumed by a step's
+// container, sampled periodically while the step ran. CPU is measured in
+// cores.
+type ResourceUsage struct {
+	PeakCPU       float64 `json:"peak_cpu"`
+	AverageCPU    float64 `json:"average_cpu"`
+	PeakMemory    uint64  `json:"peak_memory"`
+	AverageMemory uint64  `json:"average_memory"`
+	PeakDisk      uint64  `json:"peak_disk"`
+	AverageDisk   uint64  `json:"average_disk"`
+
+	Samples int `json:"samples"`
+}
+
+// StepResourceUsage is the ResourceUsage of a single step of a build.
+type StepResourceUsage struct {
+	PlanID   PlanID `json:"plan_id"`
+	StepName string `json:"step_name"`
+	StepType string `json:"step_type"`
+
+	Usage ResourceUsage `json:"usage"`
+}
@@ -3,34 +3,45 @@ package atc
 import "github.com/tedsuo/rata"
 
 const (
-	SaveConfig = "SaveConfig"
-	GetConfig  = "GetConfig"
-
-	GetBuild            = "GetBuild"
-	GetBuildPlan        = "GetBuildPlan"
-	CreateBuild         = "CreateBuild"
-	ListBuilds          = "ListBuilds"
-	BuildEvents         = "BuildEvents"
-	BuildResources      = "BuildResources"
-	AbortBuild          = "AbortBuild"
-	GetBuildPreparation = "GetBuildPreparation"
-
-	GetJob         = "GetJob"
-	CreateJobBuild = "CreateJobBuild"
-	ListJobs       = "ListJobs"
-	ListJobBuilds  = "ListJobBuilds"
-	ListJobInputs  = "ListJobInputs"
-	GetJobBuild    = "GetJobBuild"
-	PauseJob       = "PauseJob"
-	UnpauseJob     = "UnpauseJob"
-	GetVersionsDB  = "GetVersionsDB"
-	JobBadge       = "JobBadge"
-
-	ListResources   = "ListResources"
-	GetResource     = "GetResource"
-	PauseResource   = "PauseResource"
-	UnpauseResource = "UnpauseResource"
-	CheckResource   = "CheckResource"
+	SaveConfig          = "SaveConfig"
+	GetConfig           = "GetConfig"
+	ListConfigRevisions = "ListConfigRevisions"
+	DiffConfigRevisions = "DiffConfigRevisions"
+	RollbackConfig      = "RollbackConfig"
+
+	GetBuild              = "GetBuild"
+	GetBuildPlan          = "GetBuildPlan"
+	CreateBuild           = "CreateBuild"
+	ListBuilds            = "ListBuilds"
+	BuildEvents           = "BuildEvents"
+	BuildResources        = "BuildResources"
+	AbortBuild            = "AbortBuild"
+	RerunBuild            = "RerunBuild"
+	GetBuildPreparation   = "GetBuildPreparation"
+	GetBuildResourceUsage = "GetBuildResourceUsage"
+
+	GetJob                      = "GetJob"
+	CreateJobBuild              = "CreateJobBuild"
+	ListJobs                    = "ListJobs"
+	ListJobBuilds               = "ListJobBuilds"
+	ListJobInputs               = "ListJobInputs"
+	GetJobSchedulingExplanation = "GetJobSchedulingExplanation"
+	SearchJobBuilds             = "SearchJobBuilds"
+	GetJobBuild                 = "GetJobBuild"
+	PauseJob                    = "PauseJob"
+	UnpauseJob                  = "UnpauseJob"
+	ClearJobCaches              = "ClearJobCaches"
+	GetVersionsDB               = "GetVersionsDB"
+	JobBadge                    = "JobBadge"
+
+	ListResources        = "ListResources"
+	GetResource          = "GetResource"
+	PauseResource        = "PauseResource"
+	UnpauseResource      = "UnpauseResource"
+	PinResource          = "PinResource"
+	UnpinResource        = "UnpinResource"
+	CheckResource        = "CheckResource"
+	CheckResourceWebHook = "CheckResourceWebHook"
 
 	ListResourceVersions          = "ListResourceVersions"
 	EnableResourceVersion         = "EnableResourceVersion"
@@ -48,6 +59,8 @@ const (
 	ExposePipeline   = "ExposePipeline"
 	HidePipeline     = "HidePipeline"
 	RenamePipeline   = "RenamePipeline"
+	ExportPipeline   = "ExportPipeline"
+	ImportPipeline   = "ImportPipeline"
 
 	CreatePipe = "CreatePipe"
 	WritePipe  = "WritePipe"
@@ -55,6 +68,9 @@ const (
 
 	RegisterWorker = "RegisterWorker"
 	ListWorkers    = "ListWorkers"
+	LandWorker     = "LandWorker"
+	RetireWorker   = "RetireWorker"
+	PruneWorker    = "PruneWorker"
 
 	SetLogLevel = "SetLogLevel"
 	GetLogLevel = "GetLogLevel"
@@ -74,11 +90,20 @@ const (
 
 	ListTeams = "ListTeams"
 	SetTeam   = "SetTeam"
+
+	CreateAPIToken = "CreateAPIToken"
+	ListAPITokens  = "ListAPITokens"
+	DeleteAPIToken = "DeleteAPIToken"
+
+	ListAuditEvents = "ListAuditEvents"
 )
 
 var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PUT", Name: SaveConfig},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "GET", Name: GetConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/revisions", Method: "GET", Name: ListConfigRevisions},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/revisions/:from_revision_id/diff/:to_revision_id", Method: "GET", Name: DiffConfigRevisions},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/revisions/:revision_id/rollback", Method: "PUT", Name: RollbackConfig},
 
 	{Path: "/api/v1/builds", Method: "POST", Name: CreateBuild},
 	{Path: "/api/v1/builds", Method: "GET", Name: ListBuilds},
@@ -87,16 +112,21 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id/events", Method: "GET", Name: BuildEvents},
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "POST", Name: AbortBuild},
+	{Path: "/api/v1/builds/:build_id/rerun", Method: "POST", Name: RerunBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
+	{Path: "/api/v1/builds/:build_id/usage", Method: "GET", Name: GetBuildResourceUsage},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name", Method: "GET", Name: GetJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "GET", Name: ListJobBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "POST", Name: CreateJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", Method: "GET", Name: ListJobInputs},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/scheduling-explanation", Method: "GET", Name: GetJobSchedulingExplanation},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/search", Method: "GET", Name: SearchJobBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/caches", Method: "DELETE", Name: ClearJobCaches},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: JobBadge},
 
 	{Path: "/api/v1/pipelines", Method: "GET", Name: ListAllPipelines},
@@ -110,12 +140,17 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/hide", Method: "PUT", Name: HidePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/versions-db", Method: "GET", Name: GetVersionsDB},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/rename", Method: "PUT", Name: RenamePipeline},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/export", Method: "GET", Name: ExportPipeline},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/import", Method: "PUT", Name: ImportPipeline},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources", Method: "GET", Name: ListResources},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name", Method: "GET", Name: GetResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pause", Method: "PUT", Name: PauseResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpause", Method: "PUT", Name: UnpauseResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", Method: "PUT", Name: PinResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", Method: "PUT", Name: UnpinResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebHook},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "GET", Name: ListResourceVersions},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
@@ -129,6 +164,9 @@ var Routes = rata.Routes([]rata.Route{
 
 	{Path: "/api/v1/workers", Method: "GET", Name: ListWorkers},
 	{Path: "/api/v1/workers", Method: "POST", Name: RegisterWorker},
+	{Path: "/api/v1/workers/:worker_name/land", Method: "PUT", Name: LandWorker},
+	{Path: "/api/v1/workers/:worker_name/retire", Method: "PUT", Name: RetireWorker},
+	{Path: "/api/v1/workers/:worker_name/prune", Method: "PUT", Name: PruneWorker},
 
 	{Path: "/api/v1/log-level", Method: "GET", Name: GetLogLevel},
 	{Path: "/api/v1/log-level", Method: "PUT", Name: SetLogLevel},
@@ -148,4 +186,109 @@ var Routes = rata.Routes([]rata.Route{
 
 	{Path: "/api/v1/teams", Method: "GET", Name: ListTeams},
 	{Path: "/api/v1/teams/:team_name", Method: "PUT", Name: SetTeam},
+
+	{Path: "/api/v1/teams/:team_name/tokens", Method: "POST", Name: CreateAPIToken},
+	{Path: "/api/v1/teams/:team_name/tokens", Method: "GET", Name: ListAPITokens},
+	{Path: "/api/v1/teams/:team_name/tokens/:token_name", Method: "DELETE", Name: DeleteAPIToken},
+
+	{Path: "/api/v1/audit", Method: "GET", Name: ListAuditEvents},
 })
+
+// RouteRoles declares the minimum role a user needs within their own team to
+// use each route. Whether the route is scoped to the user's team at all is
+// decided separately.
+var RouteRoles = map[string]TeamRole{
+	SaveConfig:          RoleMember,
+	GetConfig:           RoleViewer,
+	ListConfigRevisions: RoleViewer,
+	DiffConfigRevisions: RoleViewer,
+	RollbackConfig:      RoleMember,
+
+	CreateBuild:           RoleMember,
+	ListBuilds:            RoleViewer,
+	GetBuild:              RoleViewer,
+	GetBuildPlan:          RoleViewer,
+	BuildEvents:           RoleViewer,
+	BuildResources:        RoleViewer,
+	AbortBuild:            RoleOperator,
+	RerunBuild:            RoleOperator,
+	GetBuildPreparation:   RoleViewer,
+	GetBuildResourceUsage: RoleViewer,
+
+	ListJobs:                    RoleViewer,
+	GetJob:                      RoleViewer,
+	ListJobBuilds:               RoleViewer,
+	CreateJobBuild:              RoleOperator,
+	ListJobInputs:               RoleViewer,
+	GetJobSchedulingExplanation: RoleViewer,
+	SearchJobBuilds:             RoleViewer,
+	GetJobBuild:                 RoleViewer,
+	PauseJob:                    RoleOperator,
+	UnpauseJob:                  RoleOperator,
+	ClearJobCaches:              RoleOperator,
+	JobBadge:                    RoleViewer,
+
+	ListAllPipelines: RoleViewer,
+	ListPipelines:    RoleViewer,
+	GetPipeline:      RoleViewer,
+	DeletePipeline:   RoleMember,
+	OrderPipelines:   RoleMember,
+	PausePipeline:    RoleOperator,
+	UnpausePipeline:  RoleOperator,
+	ExposePipeline:   RoleMember,
+	HidePipeline:     RoleMember,
+	GetVersionsDB:    RoleViewer,
+	RenamePipeline:   RoleMember,
+	ExportPipeline:   RoleViewer,
+	ImportPipeline:   RoleMember,
+
+	ListResources:        RoleViewer,
+	GetResource:          RoleViewer,
+	PauseResource:        RoleOperator,
+	UnpauseResource:      RoleOperator,
+	PinResource:          RoleOperator,
+	UnpinResource:        RoleOperator,
+	CheckResource:        RoleOperator,
+	CheckResourceWebHook: RoleViewer,
+
+	ListResourceVersions:          RoleViewer,
+	EnableResourceVersion:         RoleOperator,
+	DisableResourceVersion:        RoleOperator,
+	ListBuildsWithVersionAsInput:  RoleViewer,
+	ListBuildsWithVersionAsOutput: RoleViewer,
+
+	CreatePipe: RoleMember,
+	WritePipe:  RoleMember,
+	ReadPipe:   RoleMember,
+
+	ListWorkers:    RoleViewer,
+	RegisterWorker: RoleOwner,
+	LandWorker:     RoleOwner,
+	RetireWorker:   RoleOwner,
+	PruneWorker:    RoleOwner,
+
+	GetLogLevel: RoleViewer,
+	SetLogLevel: RoleOwner,
+
+	DownloadCLI: RoleViewer,
+	GetInfo:     RoleViewer,
+
+	ListContainers:  RoleViewer,
+	GetContainer:    RoleViewer,
+	HijackContainer: RoleMember,
+
+	ListVolumes: RoleViewer,
+
+	ListAuthMethods: RoleViewer,
+	GetAuthToken:    RoleViewer,
+	GetUser:         RoleViewer,
+
+	ListTeams: RoleViewer,
+	SetTeam:   RoleOwner,
+
+	CreateAPIToken: RoleOwner,
+	ListAPITokens:  RoleViewer,
+	DeleteAPIToken: RoleOwner,
+
+	ListAuditEvents: RoleViewer,
+}
@@ -111,13 +111,25 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
-	buildInputs, found, err := s.db.GetNextBuildInputs(jobConfig.Name)
-	if err != nil {
-		logger.Error("failed-to-get-next-build-inputs", err)
-		return false, err
-	}
-	if !found {
-		return false, nil
+	isRerun := nextPendingBuild.RerunOf() != 0
+
+	var buildInputs []db.BuildInput
+	if isRerun {
+		// reruns were created with the inputs of the build they rerun
+		buildInputs, _, err = nextPendingBuild.GetResources()
+		if err != nil {
+			logger.Error("failed-to-get-rerun-build-inputs", err)
+			return false, err
+		}
+	} else {
+		buildInputs, found, err = s.db.GetNextBuildInputs(jobConfig.Name)
+		if err != nil {
+			logger.Error("failed-to-get-next-build-inputs", err)
+			return false, err
+		}
+		if !found {
+			return false, nil
+		}
 	}
 
 	pipelinePaused, err := s.db.IsPaused()
@@ -149,9 +161,11 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
-	err = s.db.UseInputsForBuild(nextPendingBuild.ID(), buildInputs)
-	if err != nil {
-		return false, err
+	if !isRerun {
+		err = s.db.UseInputsForBuild(nextPendingBuild.ID(), buildInputs)
+		if err != nil {
+			return false, err
+		}
 	}
 
 	plan, err := s.factory.Create(jobConfig, resourceConfigs, resourceTypes, buildInputs)
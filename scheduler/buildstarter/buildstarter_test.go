@@ -343,6 +343,58 @@ var _ = Describe("I'm a BuildStarter", func() {
 					itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
 					itUpdatedMaxInFlightForTheRightJob()
 				})
+
+				Context("when the pending build is a rerun", func() {
+					var engineBuild *enginefakes.FakeBuild
+
+					BeforeEach(func() {
+						pendingBuild.RerunOfReturns(42)
+						pendingBuild.GetResourcesReturns([]db.BuildInput{{Name: "some-rerun-input"}}, nil, nil)
+
+						fakeDB.GetNextPendingBuildStub = func(string) (db.Build, bool, error) {
+							if pendingBuild.GetResourcesCallCount() < pendingBuildCount {
+								return pendingBuild, true, nil
+							}
+							return nil, false, nil
+						}
+
+						fakeDB.UpdateBuildToScheduledReturns(true, nil)
+
+						engineBuild = new(enginefakes.FakeBuild)
+						fakeEngine.CreateBuildReturns(engineBuild, nil)
+					})
+
+					It("doesn't return an error", func() {
+						Expect(tryStartErr).NotTo(HaveOccurred())
+					})
+
+					It("doesn't use the job's next build inputs", func() {
+						Expect(fakeDB.GetNextBuildInputsCallCount()).To(BeZero())
+						Expect(fakeDB.UseInputsForBuildCallCount()).To(BeZero())
+					})
+
+					It("creates the build plan with the inputs the rerun was created with", func() {
+						Expect(fakeFactory.CreateCallCount()).To(Equal(1))
+						_, _, _, actualInputs := fakeFactory.CreateArgsForCall(0)
+						Expect(actualInputs).To(Equal([]db.BuildInput{{Name: "some-rerun-input"}}))
+					})
+
+					It("starts the engine build (asynchronously)", func() {
+						Eventually(engineBuild.ResumeCallCount).Should(Equal(1))
+					})
+
+					Context("when getting the rerun's inputs fails", func() {
+						BeforeEach(func() {
+							pendingBuild.GetResourcesReturns(nil, nil, disaster)
+						})
+
+						itReturnsTheError()
+
+						It("doesn't try to mark the build as scheduled", func() {
+							Expect(fakeDB.UpdateBuildToScheduledCallCount()).To(BeZero())
+						})
+					})
+				})
 			})
 		})
 	})
@@ -0,0 +1,211 @@
+package factory
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+)
+
+func (factory *buildFactory) across(
+	planConfig atc.PlanConfig,
+	resources atc.ResourceConfigs,
+	resourceTypes atc.ResourceTypes,
+	inputs []db.BuildInput,
+) (atc.Plan, error) {
+	vars := planConfig.Across
+
+	stepConfig := planConfig
+	stepConfig.Across = nil
+
+	across := atc.AcrossPlan{}
+	for _, v := range vars {
+		across.Vars = append(across.Vars, v.Var)
+	}
+
+	for _, values := range acrossCombinations(vars) {
+		variables := acrossVariables{}
+		for i, v := range vars {
+			variables[v.Var] = values[i]
+		}
+
+		combinationConfig, err := interpolateAcrossVars(variables, stepConfig)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		step, err := factory.constructPlanFromConfig(
+			combinationConfig,
+			resources,
+			resourceTypes,
+			inputs,
+		)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		across.Steps = append(across.Steps, atc.AcrossStep{
+			Values: values,
+			Step:   step,
+		})
+	}
+
+	return factory.planFactory.NewPlan(across), nil
+}
+
+// acrossCombinations returns every combination of the vars' values, varying
+// the last var fastest.
+func acrossCombinations(vars []atc.AcrossVarConfig) [][]interface{} {
+	combinations := [][]interface{}{{}}
+
+	for _, v := range vars {
+		var next [][]interface{}
+
+		for _, combination := range combinations {
+			for _, value := range v.Values {
+				extended := make([]interface{}, len(combination), len(combination)+1)
+				copy(extended, combination)
+
+				next = append(next, append(extended, value))
+			}
+		}
+
+		combinations = next
+	}
+
+	return combinations
+}
+
+// acrossVariables resolves the ((var)) placeholders of an across step. Any
+// other placeholders are left for the credential manager.
+type acrossVariables map[string]interface{}
+
+func (variables acrossVariables) Get(name string) (interface{}, bool, error) {
+	val, found := variables[name]
+	return val, found, nil
+}
+
+// interpolateAcrossVars substitutes the values of one across combination into
+// the params and task config of a step and of any steps nested within it.
+func interpolateAcrossVars(variables acrossVariables, planConfig atc.PlanConfig) (atc.PlanConfig, error) {
+	var err error
+
+	if planConfig.Params != nil {
+		var params atc.Params
+		_, err = creds.InterpolateJSON(variables, planConfig.Params, &params)
+		if err != nil {
+			return atc.PlanConfig{}, err
+		}
+
+		planConfig.Params = params
+	}
+
+	if planConfig.GetParams != nil {
+		var getParams atc.Params
+		_, err = creds.InterpolateJSON(variables, planConfig.GetParams, &getParams)
+		if err != nil {
+			return atc.PlanConfig{}, err
+		}
+
+		planConfig.GetParams = getParams
+	}
+
+	if planConfig.TaskConfig != nil {
+		taskConfig, err := interpolateTaskConfig(variables, *planConfig.TaskConfig)
+		if err != nil {
+			return atc.PlanConfig{}, err
+		}
+
+		planConfig.TaskConfig = &taskConfig
+	}
+
+	nested := []**atc.PlanConfig{
+		&planConfig.Success,
+		&planConfig.Failure,
+		&planConfig.Ensure,
+		&planConfig.Try,
+	}
+
+	for _, hook := range nested {
+		if *hook == nil {
+			continue
+		}
+
+		interpolated, err := interpolateAcrossVars(variables, **hook)
+		if err != nil {
+			return atc.PlanConfig{}, err
+		}
+
+		*hook = &interpolated
+	}
+
+	if planConfig.Do != nil {
+		do, err := interpolateAcrossSequence(variables, *planConfig.Do)
+		if err != nil {
+			return atc.PlanConfig{}, err
+		}
+
+		planConfig.Do = &do
+	}
+
+	if planConfig.Aggregate != nil {
+		aggregate, err := interpolateAcrossSequence(variables, *planConfig.Aggregate)
+		if err != nil {
+			return atc.PlanConfig{}, err
+		}
+
+		planConfig.Aggregate = &aggregate
+	}
+
+	if planConfig.InParallel != nil {
+		inParallel := *planConfig.InParallel
+
+		inParallel.Steps, err = interpolateAcrossSequence(variables, inParallel.Steps)
+		if err != nil {
+			return atc.PlanConfig{}, err
+		}
+
+		planConfig.InParallel = &inParallel
+	}
+
+	return planConfig, nil
+}
+
+func interpolateAcrossSequence(variables acrossVariables, sequence atc.PlanSequence) (atc.PlanSequence, error) {
+	interpolated := make(atc.PlanSequence, len(sequence))
+
+	for i, planConfig := range sequence {
+		var err error
+		interpolated[i], err = interpolateAcrossVars(variables, planConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return interpolated, nil
+}
+
+// interpolateTaskConfig substitutes vars into a task config. Task params are
+// strings, so any value which isn't one is substituted as JSON.
+func interpolateTaskConfig(variables acrossVariables, taskConfig atc.TaskConfig) (atc.TaskConfig, error) {
+	rawParams := taskConfig.Params
+	taskConfig.Params = nil
+
+	var interpolated atc.TaskConfig
+	_, err := creds.InterpolateJSON(variables, taskConfig, &interpolated)
+	if err != nil {
+		return atc.TaskConfig{}, err
+	}
+
+	if rawParams != nil {
+		interpolated.Params = map[string]string{}
+
+		for key, val := range rawParams {
+			interpolated.Params[key], _, err = creds.InterpolateString(variables, val)
+			if err != nil {
+				return atc.TaskConfig{}, err
+			}
+		}
+	}
+
+	return interpolated, nil
+}
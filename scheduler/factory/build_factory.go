@@ -81,6 +81,10 @@ func (factory *buildFactory) constructPlanFromConfig(
 	resourceTypes atc.ResourceTypes,
 	inputs []db.BuildInput,
 ) (atc.Plan, error) {
+	if len(planConfig.Across) > 0 {
+		return factory.across(planConfig, resources, resourceTypes, inputs)
+	}
+
 	var plan atc.Plan
 	var err error
 
@@ -269,6 +273,28 @@ func (factory *buildFactory) constructUnhookedPlan(
 		}
 
 		plan = factory.planFactory.NewPlan(aggregate)
+
+	case planConfig.InParallel != nil:
+		inParallel := atc.InParallelPlan{
+			Limit:    planConfig.InParallel.Limit,
+			FailFast: planConfig.InParallel.FailFast,
+		}
+
+		for _, planConfig := range planConfig.InParallel.Steps {
+			nextStep, err := factory.constructPlanFromConfig(
+				planConfig,
+				resources,
+				resourceTypes,
+				inputs,
+			)
+			if err != nil {
+				return atc.Plan{}, err
+			}
+
+			inParallel.Steps = append(inParallel.Steps, nextStep)
+		}
+
+		plan = factory.planFactory.NewPlan(inParallel)
 	}
 
 	if planConfig.Timeout != "" {
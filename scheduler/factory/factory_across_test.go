@@ -0,0 +1,142 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Across", func() {
+	var (
+		buildFactory factory.BuildFactory
+
+		resources           atc.ResourceConfigs
+		resourceTypes       atc.ResourceTypes
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		resources = atc.ResourceConfigs{
+			{
+				Name:   "some-resource",
+				Type:   "git",
+				Source: atc.Source{"uri": "git://some-resource"},
+			},
+		}
+
+		resourceTypes = atc.ResourceTypes{
+			{
+				Name:   "some-custom-resource",
+				Type:   "docker-image",
+				Source: atc.Source{"some": "custom-source"},
+			},
+		}
+	})
+
+	Context("when a task runs across several vars", func() {
+		var jobConfig atc.JobConfig
+
+		BeforeEach(func() {
+			jobConfig = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "unit",
+						Across: []atc.AcrossVarConfig{
+							{Var: "go", Values: []interface{}{"1.7", "1.8"}},
+							{Var: "db", Values: []interface{}{"postgres", "mysql"}},
+						},
+						Params: atc.Params{
+							"database": "((db))",
+							"secret":   "((some-credential))",
+						},
+						TaskConfig: &atc.TaskConfig{
+							Image: "golang:((go))",
+							Params: map[string]string{
+								"GO_VERSION": "((go))",
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("expands into a step for every combination with the values interpolated", func() {
+			actual, err := buildFactory.Create(jobConfig, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			combination := func(goVersion string, db string) atc.AcrossStep {
+				return atc.AcrossStep{
+					Values: []interface{}{goVersion, db},
+					Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:          "unit",
+						PipelineID:    42,
+						ResourceTypes: resourceTypes,
+						Params: atc.Params{
+							"database": db,
+							"secret":   "((some-credential))",
+						},
+						Config: &atc.TaskConfig{
+							Image: "golang:" + goVersion,
+							Params: map[string]string{
+								"GO_VERSION": goVersion,
+							},
+						},
+					}),
+				}
+			}
+
+			expected := expectedPlanFactory.NewPlan(atc.AcrossPlan{
+				Vars: []string{"go", "db"},
+				Steps: []atc.AcrossStep{
+					combination("1.7", "postgres"),
+					combination("1.7", "mysql"),
+					combination("1.8", "postgres"),
+					combination("1.8", "mysql"),
+				},
+			})
+			Expect(actual).To(Equal(expected))
+		})
+
+		It("does not modify the job config", func() {
+			_, err := buildFactory.Create(jobConfig, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(jobConfig.Plan[0].Params["database"]).To(Equal("((db))"))
+			Expect(jobConfig.Plan[0].TaskConfig.Image).To(Equal("golang:((go))"))
+			Expect(jobConfig.Plan[0].TaskConfig.Params["GO_VERSION"]).To(Equal("((go))"))
+		})
+	})
+
+	Context("when a value is not a string", func() {
+		It("substitutes it into task params as JSON", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "unit",
+						Across: []atc.AcrossVarConfig{
+							{Var: "shards", Values: []interface{}{4}},
+						},
+						TaskConfig: &atc.TaskConfig{
+							Params: map[string]string{
+								"SHARDS": "((shards))",
+							},
+						},
+					},
+				},
+			}, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(actual.Across.Steps).To(HaveLen(1))
+			Expect(actual.Across.Steps[0].Step.Task.Config.Params).To(Equal(map[string]string{
+				"SHARDS": "4",
+			}))
+		})
+	})
+})
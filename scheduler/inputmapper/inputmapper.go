@@ -24,6 +24,8 @@ type InputMapperDB interface {
 	SaveIndependentInputMapping(inputVersions algorithm.InputMapping, jobName string) error
 	SaveNextInputMapping(inputVersions algorithm.InputMapping, jobName string) error
 	DeleteNextInputMapping(jobName string) error
+	SaveSchedulingExplanation(explanation algorithm.Explanation, jobName string) error
+	GetSchedulingExplanation(jobName string) (algorithm.Explanation, error)
 }
 
 func NewInputMapper(db InputMapperDB, transformer inputconfig.Transformer) InputMapper {
@@ -69,9 +71,10 @@ func (i *inputMapper) SaveNextInputMapping(
 		err := i.db.DeleteNextInputMapping(job.Name)
 		if err != nil {
 			logger.Error("failed-to-delete-next-input-mapping-after-missing-pending", err)
+			return nil, err
 		}
 
-		return nil, err
+		return nil, i.saveExplanation(logger, explain(versions, inputConfigs, algorithmInputConfigs), job.Name)
 	}
 
 	resolvedMapping, ok := algorithmInputConfigs.Resolve(versions)
@@ -79,9 +82,10 @@ func (i *inputMapper) SaveNextInputMapping(
 		err := i.db.DeleteNextInputMapping(job.Name)
 		if err != nil {
 			logger.Error("failed-to-delete-next-input-mapping-after-failed-resolve", err)
+			return nil, err
 		}
 
-		return nil, err
+		return nil, i.saveExplanation(logger, explain(versions, inputConfigs, algorithmInputConfigs), job.Name)
 	}
 
 	err = i.db.SaveNextInputMapping(resolvedMapping, job.Name)
@@ -90,5 +94,58 @@ func (i *inputMapper) SaveNextInputMapping(
 		return nil, err
 	}
 
+	err = i.saveExplanation(logger, nil, job.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	return resolvedMapping, nil
 }
+
+// saveExplanation only writes the explanation when it differs from the stored
+// one, as it is saved for every job on every tick.
+func (i *inputMapper) saveExplanation(logger lager.Logger, explanation algorithm.Explanation, jobName string) error {
+	storedExplanation, err := i.db.GetSchedulingExplanation(jobName)
+	if err != nil {
+		logger.Error("failed-to-get-scheduling-explanation", err)
+		return err
+	}
+
+	if storedExplanation.Equal(explanation) {
+		return nil
+	}
+
+	err = i.db.SaveSchedulingExplanation(explanation, jobName)
+	if err != nil {
+		logger.Error("failed-to-save-scheduling-explanation", err)
+	}
+
+	return err
+}
+
+func explain(
+	versions *algorithm.VersionsDB,
+	inputConfigs []config.JobInput,
+	algorithmInputConfigs algorithm.InputConfigs,
+) algorithm.Explanation {
+	explanation := algorithmInputConfigs.Explain(versions)
+
+	// the transformer leaves out inputs whose pinned version does not exist
+	for _, inputConfig := range inputConfigs {
+		transformed := false
+		for _, algorithmInputConfig := range algorithmInputConfigs {
+			if algorithmInputConfig.Name == inputConfig.Name {
+				transformed = true
+				break
+			}
+		}
+
+		if !transformed {
+			explanation[inputConfig.Name] = algorithm.InputExplanation{
+				Reason: algorithm.ExplanationPinnedVersionUnavailable,
+			}
+		}
+	}
+
+	return explanation
+}
@@ -192,6 +192,49 @@ var _ = Describe("Inputmapper", func() {
 						It("didn't delete the mapping", func() {
 							Expect(fakeDB.DeleteNextInputMappingCallCount()).To(BeZero())
 						})
+
+						It("does not save the scheduling explanation when none is stored", func() {
+							Expect(fakeDB.GetSchedulingExplanationCallCount()).To(Equal(1))
+							Expect(fakeDB.GetSchedulingExplanationArgsForCall(0)).To(Equal("some-job"))
+							Expect(fakeDB.SaveSchedulingExplanationCallCount()).To(BeZero())
+						})
+
+						Context("when getting the stored scheduling explanation fails", func() {
+							BeforeEach(func() {
+								fakeDB.GetSchedulingExplanationReturns(nil, disaster)
+							})
+
+							It("returns the error", func() {
+								Expect(mappingErr).To(Equal(disaster))
+							})
+						})
+
+						Context("when a scheduling explanation is stored", func() {
+							BeforeEach(func() {
+								fakeDB.GetSchedulingExplanationReturns(algorithm.Explanation{
+									"a": algorithm.InputExplanation{
+										Reason: algorithm.ExplanationNoVersions,
+									},
+								}, nil)
+							})
+
+							It("clears the scheduling explanation", func() {
+								Expect(fakeDB.SaveSchedulingExplanationCallCount()).To(Equal(1))
+								actualExplanation, actualJobName := fakeDB.SaveSchedulingExplanationArgsForCall(0)
+								Expect(actualExplanation).To(BeEmpty())
+								Expect(actualJobName).To(Equal("some-job"))
+							})
+
+							Context("when clearing the scheduling explanation fails", func() {
+								BeforeEach(func() {
+									fakeDB.SaveSchedulingExplanationReturns(disaster)
+								})
+
+								It("returns the error", func() {
+									Expect(mappingErr).To(Equal(disaster))
+								})
+							})
+						})
 					})
 				})
 			})
@@ -258,6 +301,56 @@ var _ = Describe("Inputmapper", func() {
 					Expect(mappingErr).NotTo(HaveOccurred())
 					Expect(inputMapping).To(BeEmpty())
 				})
+
+				It("explains that the inputs' versions conflict", func() {
+					Expect(fakeDB.SaveSchedulingExplanationCallCount()).To(Equal(1))
+					actualExplanation, actualJobName := fakeDB.SaveSchedulingExplanationArgsForCall(0)
+					Expect(actualExplanation).To(Equal(algorithm.Explanation{
+						"a": algorithm.InputExplanation{
+							Reason:        algorithm.ExplanationConflictingVersions,
+							Passed:        []string{"upstream"},
+							ConflictsWith: []string{"b"},
+						},
+						"b": algorithm.InputExplanation{
+							Reason:        algorithm.ExplanationConflictingVersions,
+							Passed:        []string{"upstream"},
+							ConflictsWith: []string{"a"},
+						},
+					}))
+					Expect(actualJobName).To(Equal("some-job"))
+				})
+
+				Context("when saving the scheduling explanation fails", func() {
+					BeforeEach(func() {
+						fakeDB.SaveSchedulingExplanationReturns(disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(mappingErr).To(Equal(disaster))
+					})
+				})
+
+				Context("when the same scheduling explanation is already stored", func() {
+					BeforeEach(func() {
+						fakeDB.GetSchedulingExplanationReturns(algorithm.Explanation{
+							"a": algorithm.InputExplanation{
+								Reason:        algorithm.ExplanationConflictingVersions,
+								Passed:        []string{"upstream"},
+								ConflictsWith: []string{"b"},
+							},
+							"b": algorithm.InputExplanation{
+								Reason:        algorithm.ExplanationConflictingVersions,
+								Passed:        []string{"upstream"},
+								ConflictsWith: []string{"a"},
+							},
+						}, nil)
+					})
+
+					It("does not save it again", func() {
+						Expect(mappingErr).NotTo(HaveOccurred())
+						Expect(fakeDB.SaveSchedulingExplanationCallCount()).To(BeZero())
+					})
+				})
 			})
 		})
 
@@ -297,6 +390,15 @@ var _ = Describe("Inputmapper", func() {
 				Expect(actualJobName).To(Equal("some-job"))
 			})
 
+			It("explains that the input has no versions", func() {
+				actualExplanation, _ := fakeDB.SaveSchedulingExplanationArgsForCall(0)
+				Expect(actualExplanation).To(Equal(algorithm.Explanation{
+					"no-versions": algorithm.InputExplanation{
+						Reason: algorithm.ExplanationNoVersions,
+					},
+				}))
+			})
+
 			It("deleted the next input mapping", func() {
 				Expect(fakeDB.DeleteNextInputMappingCallCount()).To(Equal(1))
 				Expect(fakeDB.DeleteNextInputMappingArgsForCall(0)).To(Equal("some-job"))
@@ -339,6 +441,15 @@ var _ = Describe("Inputmapper", func() {
 				Expect(actualJobName).To(Equal("some-job"))
 			})
 
+			It("explains that the pinned version is unavailable", func() {
+				actualExplanation, _ := fakeDB.SaveSchedulingExplanationArgsForCall(0)
+				Expect(actualExplanation).To(Equal(algorithm.Explanation{
+					"a": algorithm.InputExplanation{
+						Reason: algorithm.ExplanationPinnedVersionUnavailable,
+					},
+				}))
+			})
+
 			It("deleted the next input mapping", func() {
 				Expect(fakeDB.DeleteNextInputMappingCallCount()).To(Equal(1))
 				Expect(fakeDB.DeleteNextInputMappingArgsForCall(0)).To(Equal("some-job"))
@@ -35,6 +35,24 @@ type FakeInputMapperDB struct {
 	deleteNextInputMappingReturns struct {
 		result1 error
 	}
+	SaveSchedulingExplanationStub        func(explanation algorithm.Explanation, jobName string) error
+	saveSchedulingExplanationMutex       sync.RWMutex
+	saveSchedulingExplanationArgsForCall []struct {
+		explanation algorithm.Explanation
+		jobName     string
+	}
+	saveSchedulingExplanationReturns struct {
+		result1 error
+	}
+	GetSchedulingExplanationStub        func(jobName string) (algorithm.Explanation, error)
+	getSchedulingExplanationMutex       sync.RWMutex
+	getSchedulingExplanationArgsForCall []struct {
+		jobName string
+	}
+	getSchedulingExplanationReturns struct {
+		result1 algorithm.Explanation
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -140,6 +158,74 @@ func (fake *FakeInputMapperDB) DeleteNextInputMappingReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeInputMapperDB) SaveSchedulingExplanation(explanation algorithm.Explanation, jobName string) error {
+	fake.saveSchedulingExplanationMutex.Lock()
+	fake.saveSchedulingExplanationArgsForCall = append(fake.saveSchedulingExplanationArgsForCall, struct {
+		explanation algorithm.Explanation
+		jobName     string
+	}{explanation, jobName})
+	fake.recordInvocation("SaveSchedulingExplanation", []interface{}{explanation, jobName})
+	fake.saveSchedulingExplanationMutex.Unlock()
+	if fake.SaveSchedulingExplanationStub != nil {
+		return fake.SaveSchedulingExplanationStub(explanation, jobName)
+	} else {
+		return fake.saveSchedulingExplanationReturns.result1
+	}
+}
+
+func (fake *FakeInputMapperDB) SaveSchedulingExplanationCallCount() int {
+	fake.saveSchedulingExplanationMutex.RLock()
+	defer fake.saveSchedulingExplanationMutex.RUnlock()
+	return len(fake.saveSchedulingExplanationArgsForCall)
+}
+
+func (fake *FakeInputMapperDB) SaveSchedulingExplanationArgsForCall(i int) (algorithm.Explanation, string) {
+	fake.saveSchedulingExplanationMutex.RLock()
+	defer fake.saveSchedulingExplanationMutex.RUnlock()
+	return fake.saveSchedulingExplanationArgsForCall[i].explanation, fake.saveSchedulingExplanationArgsForCall[i].jobName
+}
+
+func (fake *FakeInputMapperDB) SaveSchedulingExplanationReturns(result1 error) {
+	fake.SaveSchedulingExplanationStub = nil
+	fake.saveSchedulingExplanationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeInputMapperDB) GetSchedulingExplanation(jobName string) (algorithm.Explanation, error) {
+	fake.getSchedulingExplanationMutex.Lock()
+	fake.getSchedulingExplanationArgsForCall = append(fake.getSchedulingExplanationArgsForCall, struct {
+		jobName string
+	}{jobName})
+	fake.recordInvocation("GetSchedulingExplanation", []interface{}{jobName})
+	fake.getSchedulingExplanationMutex.Unlock()
+	if fake.GetSchedulingExplanationStub != nil {
+		return fake.GetSchedulingExplanationStub(jobName)
+	} else {
+		return fake.getSchedulingExplanationReturns.result1, fake.getSchedulingExplanationReturns.result2
+	}
+}
+
+func (fake *FakeInputMapperDB) GetSchedulingExplanationCallCount() int {
+	fake.getSchedulingExplanationMutex.RLock()
+	defer fake.getSchedulingExplanationMutex.RUnlock()
+	return len(fake.getSchedulingExplanationArgsForCall)
+}
+
+func (fake *FakeInputMapperDB) GetSchedulingExplanationArgsForCall(i int) string {
+	fake.getSchedulingExplanationMutex.RLock()
+	defer fake.getSchedulingExplanationMutex.RUnlock()
+	return fake.getSchedulingExplanationArgsForCall[i].jobName
+}
+
+func (fake *FakeInputMapperDB) GetSchedulingExplanationReturns(result1 algorithm.Explanation, result2 error) {
+	fake.GetSchedulingExplanationStub = nil
+	fake.getSchedulingExplanationReturns = struct {
+		result1 algorithm.Explanation
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeInputMapperDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -149,6 +235,10 @@ func (fake *FakeInputMapperDB) Invocations() map[string][][]interface{} {
 	defer fake.saveNextInputMappingMutex.RUnlock()
 	fake.deleteNextInputMappingMutex.RLock()
 	defer fake.deleteNextInputMappingMutex.RUnlock()
+	fake.saveSchedulingExplanationMutex.RLock()
+	defer fake.saveSchedulingExplanationMutex.RUnlock()
+	fake.getSchedulingExplanationMutex.RLock()
+	defer fake.getSchedulingExplanationMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -31,6 +31,13 @@ type TaskConfig struct {
 
 	// The set of (logical, name-only) outputs provided by the task.
 	Outputs []TaskOutputConfig `json:"outputs,omitempty" yaml:"outputs,omitempty" mapstructure:"outputs"`
+
+	// Optional limits on the resources the task's container may consume.
+	Limits *ContainerLimits `json:"container_limits,omitempty" yaml:"container_limits,omitempty" mapstructure:"container_limits"`
+
+	// Directories, relative to the working directory, whose contents are kept
+	// between builds of the job on the same worker.
+	Caches []CacheConfig `json:"caches,omitempty" yaml:"caches,omitempty" mapstructure:"caches"`
 }
 
 type ImageResource struct {
@@ -38,6 +45,72 @@ type ImageResource struct {
 	Source Source `yaml:"source" json:"source" mapstructure:"source"`
 }
 
+// ContainerLimits caps the resources available to a container. A zero value
+// for any field means no limit.
+type ContainerLimits struct {
+	// Memory limit in bytes.
+	Memory uint64 `json:"memory,omitempty" yaml:"memory,omitempty" mapstructure:"memory"`
+
+	// Relative CPU weight, in cgroup CPU shares.
+	CPU uint64 `json:"cpu,omitempty" yaml:"cpu,omitempty" mapstructure:"cpu"`
+
+	// Disk limit in bytes.
+	Disk uint64 `json:"disk,omitempty" yaml:"disk,omitempty" mapstructure:"disk"`
+}
+
+const (
+	MinContainerMemoryLimit = 4 * 1024 * 1024
+	MinContainerCPULimit    = 2
+)
+
+// WithDefaults fills in any unset limits from defaults.
+func (limits ContainerLimits) WithDefaults(defaults ContainerLimits) ContainerLimits {
+	if limits.Memory == 0 {
+		limits.Memory = defaults.Memory
+	}
+
+	if limits.CPU == 0 {
+		limits.CPU = defaults.CPU
+	}
+
+	if limits.Disk == 0 {
+		limits.Disk = defaults.Disk
+	}
+
+	return limits
+}
+
+// CappedAt lowers any limit that exceeds its maximum, including unset
+// (unlimited) ones, to that maximum. Unset maximums are ignored.
+func (limits ContainerLimits) CappedAt(max ContainerLimits) ContainerLimits {
+	limits.Memory = capLimit(limits.Memory, max.Memory)
+	limits.CPU = capLimit(limits.CPU, max.CPU)
+	limits.Disk = capLimit(limits.Disk, max.Disk)
+	return limits
+}
+
+func capLimit(limit uint64, max uint64) uint64 {
+	if max != 0 && (limit == 0 || limit > max) {
+		return max
+	}
+
+	return limit
+}
+
+func (limits ContainerLimits) Validate() []string {
+	messages := []string{}
+
+	if limits.Memory != 0 && limits.Memory < MinContainerMemoryLimit {
+		messages = append(messages, fmt.Sprintf("  container_limits.memory must be at least %d bytes", MinContainerMemoryLimit))
+	}
+
+	if limits.CPU != 0 && limits.CPU < MinContainerCPULimit {
+		messages = append(messages, fmt.Sprintf("  container_limits.cpu must be at least %d shares", MinContainerCPULimit))
+	}
+
+	return messages
+}
+
 func LoadTaskConfig(configBytes []byte) (TaskConfig, error) {
 	var untypedInput map[string]interface{}
 
@@ -110,6 +183,14 @@ func (config TaskConfig) Merge(other TaskConfig) TaskConfig {
 		config.Run = other.Run
 	}
 
+	if other.Limits != nil {
+		config.Limits = other.Limits
+	}
+
+	if len(other.Caches) != 0 {
+		config.Caches = other.Caches
+	}
+
 	return config
 }
 
@@ -126,6 +207,12 @@ func (config TaskConfig) Validate() error {
 
 	messages = append(messages, config.validateInputsAndOutputs()...)
 
+	if config.Limits != nil {
+		messages = append(messages, config.Limits.Validate()...)
+	}
+
+	messages = append(messages, config.validateCaches()...)
+
 	if len(messages) > 0 {
 		return fmt.Errorf("invalid task configuration:\n%s", strings.Join(messages, "\n"))
 	}
@@ -327,6 +414,24 @@ func (config TaskConfig) validateInputContainsNames() []string {
 	return messages
 }
 
+func (config TaskConfig) validateCaches() []string {
+	messages := []string{}
+
+	for i, cache := range config.Caches {
+		if cache.Path == "" {
+			messages = append(messages, fmt.Sprintf("  cache in position %d is missing a path", i))
+			continue
+		}
+
+		cleaned := filepath.Clean(cache.Path)
+		if filepath.IsAbs(cleaned) || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+			messages = append(messages, fmt.Sprintf("  cache path '%s' must be a directory inside the working directory", cache.Path))
+		}
+	}
+
+	return messages
+}
+
 type TaskRunConfig struct {
 	Path string   `json:"path" yaml:"path"`
 	Args []string `json:"args,omitempty" yaml:"args"`
@@ -360,6 +465,10 @@ func (output TaskOutputConfig) resolvePath() string {
 	return output.Name
 }
 
+type CacheConfig struct {
+	Path string `json:"path" yaml:"path" mapstructure:"path"`
+}
+
 type MetadataField struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
@@ -234,6 +234,74 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the task has container limits", func() {
+			BeforeEach(func() {
+				validConfig.Limits = &ContainerLimits{
+					Memory: 1024 * 1024 * 1024,
+					CPU:    512,
+					Disk:   10 * 1024 * 1024 * 1024,
+				}
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when the memory limit is too small to run anything", func() {
+				BeforeEach(func() {
+					invalidConfig.Limits = &ContainerLimits{Memory: 1024}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  container_limits.memory must be at least 4194304 bytes")))
+				})
+			})
+
+			Context("when the cpu limit is below the minimum number of shares", func() {
+				BeforeEach(func() {
+					invalidConfig.Limits = &ContainerLimits{CPU: 1}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  container_limits.cpu must be at least 2 shares")))
+				})
+			})
+		})
+
+		Context("when the task has caches", func() {
+			BeforeEach(func() {
+				validConfig.Caches = []CacheConfig{{Path: "gopath/pkg"}, {Path: ".m2"}}
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when a cache is missing a path", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = []CacheConfig{{Path: "some-path"}, {Path: ""}}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cache in position 1 is missing a path")))
+				})
+			})
+
+			Context("when a cache path is outside of the working directory", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = []CacheConfig{{Path: "/root/.m2"}, {Path: "some/../../path"}, {Path: "."}}
+				})
+
+				It("returns an error", func() {
+					err := invalidConfig.Validate()
+
+					Expect(err).To(MatchError(ContainSubstring("  cache path '/root/.m2' must be a directory inside the working directory")))
+					Expect(err).To(MatchError(ContainSubstring("  cache path 'some/../../path' must be a directory inside the working directory")))
+					Expect(err).To(MatchError(ContainSubstring("  cache path '.' must be a directory inside the working directory")))
+				})
+			})
+		})
+
 		Context("when run is missing", func() {
 			BeforeEach(func() {
 				invalidConfig.Run.Path = ""
@@ -723,5 +791,78 @@ run: {path: a/file}
 				}))
 
 		})
+
+		It("overrides the caches", func() {
+			Expect(TaskConfig{
+				Caches: []CacheConfig{{Path: "some-cache"}},
+			}.Merge(TaskConfig{
+				Caches: []CacheConfig{{Path: "another-cache"}},
+			})).To(
+
+				Equal(TaskConfig{
+					Caches: []CacheConfig{{Path: "another-cache"}},
+				}))
+
+		})
+
+		It("overrides the container limits", func() {
+			Expect(TaskConfig{
+				Limits: &ContainerLimits{Memory: 1024, CPU: 512},
+			}.Merge(TaskConfig{
+				Limits: &ContainerLimits{Disk: 2048},
+			})).To(
+
+				Equal(TaskConfig{
+					Limits: &ContainerLimits{Disk: 2048},
+				}))
+
+		})
+	})
+})
+
+var _ = Describe("ContainerLimits", func() {
+	Describe("WithDefaults", func() {
+		It("fills in only the limits that are not set", func() {
+			Expect(ContainerLimits{
+				Memory: 1024,
+			}.WithDefaults(ContainerLimits{
+				Memory: 2048,
+				CPU:    512,
+			})).To(Equal(ContainerLimits{
+				Memory: 1024,
+				CPU:    512,
+			}))
+		})
+	})
+
+	Describe("CappedAt", func() {
+		It("lowers limits that exceed the maximum", func() {
+			Expect(ContainerLimits{
+				Memory: 4096,
+				CPU:    256,
+			}.CappedAt(ContainerLimits{
+				Memory: 2048,
+				CPU:    512,
+			})).To(Equal(ContainerLimits{
+				Memory: 2048,
+				CPU:    256,
+			}))
+		})
+
+		It("applies the maximum to unset limits", func() {
+			Expect(ContainerLimits{}.CappedAt(ContainerLimits{
+				Disk: 8192,
+			})).To(Equal(ContainerLimits{
+				Disk: 8192,
+			}))
+		})
+
+		It("leaves limits alone when there is no maximum", func() {
+			Expect(ContainerLimits{
+				Memory: 4096,
+			}.CappedAt(ContainerLimits{})).To(Equal(ContainerLimits{
+				Memory: 4096,
+			}))
+		})
 	})
 })
@@ -12,6 +12,84 @@ type Team struct {
 	GitHubAuth   *GitHubAuth   `json:"github_auth,omitempty"`
 	UAAAuth      *UAAAuth      `json:"uaa_auth,omitempty"`
 	GenericOAuth *GenericOAuth `json:"genericoauth_auth,omitempty"`
+	OIDCAuth     *OIDCAuth     `json:"oidc_auth,omitempty"`
+	LDAPAuth     *LDAPAuth     `json:"ldap_auth,omitempty"`
+
+	NotificationHooks []NotificationHook `json:"notification_hooks,omitempty"`
+
+	Roles []TeamRoleMapping `json:"roles,omitempty"`
+}
+
+// TeamRole determines what a member of a team may do within it. Each role
+// includes the permissions of the roles below it.
+type TeamRole string
+
+const (
+	// RoleViewer can see the team's pipelines, builds and containers
+	RoleViewer TeamRole = "viewer"
+
+	// RoleOperator can also trigger and abort builds, pause things and check
+	// resources
+	RoleOperator TeamRole = "operator"
+
+	// RoleMember can also configure pipelines, run one-off builds and hijack
+	// containers
+	RoleMember TeamRole = "member"
+
+	// RoleOwner can also configure the team itself
+	RoleOwner TeamRole = "owner"
+)
+
+// TeamRoles lists every role, from most to least privileged.
+var TeamRoles = []TeamRole{RoleOwner, RoleMember, RoleOperator, RoleViewer}
+
+func (role TeamRole) rank() int {
+	for i, r := range TeamRoles {
+		if r == role {
+			return len(TeamRoles) - i
+		}
+	}
+
+	return 0
+}
+
+// IsValid returns whether the role is one of TeamRoles.
+func (role TeamRole) IsValid() bool {
+	return role.rank() != 0
+}
+
+// Includes returns whether the role grants everything the other role does.
+func (role TeamRole) Includes(other TeamRole) bool {
+	return role.IsValid() && role.rank() >= other.rank()
+}
+
+// TeamRoleMapping grants a role to users authenticated by the team's auth
+// providers. When a team has no mappings, every authenticated user is an
+// owner; otherwise users that no mapping applies to are viewers.
+type TeamRoleMapping struct {
+	Role TeamRole `json:"role"`
+
+	BasicAuthUsers []string `json:"basic_auth_users,omitempty"`
+
+	GitHubOrganizations []string     `json:"github_organizations,omitempty"`
+	GitHubTeams         []GitHubTeam `json:"github_teams,omitempty"`
+	GitHubUsers         []string     `json:"github_users,omitempty"`
+
+	CFSpaces []string `json:"cf_spaces,omitempty"`
+
+	OIDCGroups []string          `json:"oidc_groups,omitempty"`
+	OIDCUsers  []string          `json:"oidc_users,omitempty"`
+	OIDCClaims map[string]string `json:"oidc_claims,omitempty"`
+
+	LDAPGroups []string `json:"ldap_groups,omitempty"`
+}
+
+// NotificationHook is sent a signed JSON payload whenever one of the team's
+// builds finishes
+type NotificationHook struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Secret string `json:"secret,omitempty"`
 }
 
 type BasicAuth struct {
@@ -53,3 +131,66 @@ type GenericOAuth struct {
 	TokenURL      string            `json:"token_url,omitempty"`
 	AuthURLParams map[string]string `json:"auth_url_params,omitempty"`
 }
+
+// OIDCAuth lets users of an OpenID Connect provider into the team if their ID
+// token names one of the Users, lists one of the Groups, or has any of the
+// Claims.
+type OIDCAuth struct {
+	DisplayName  string            `json:"display_name,omitempty"`
+	Issuer       string            `json:"issuer,omitempty"`
+	ClientID     string            `json:"client_id,omitempty"`
+	ClientSecret string            `json:"client_secret,omitempty"`
+	Scopes       []string          `json:"scopes,omitempty"`
+	Groups       []string          `json:"groups,omitempty"`
+	GroupsClaim  string            `json:"groups_claim,omitempty"`
+	Users        []string          `json:"users,omitempty"`
+	UserClaim    string            `json:"user_claim,omitempty"`
+	Claims       map[string]string `json:"claims,omitempty"`
+	CACert       string            `json:"ca_cert,omitempty"`
+}
+
+// LDAPAuth lets users of an LDAP directory log in to the team with their
+// directory password if they are in one of the Groups.
+type LDAPAuth struct {
+	DisplayName string `json:"display_name,omitempty"`
+
+	// Host is the directory's address, as host:port.
+	Host string `json:"host,omitempty"`
+
+	// Connections use TLS unless InsecureNoSSL is set. StartTLS upgrades a
+	// plain connection instead of dialing with TLS.
+	InsecureNoSSL      bool   `json:"insecure_no_ssl,omitempty"`
+	StartTLS           bool   `json:"start_tls,omitempty"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"`
+	CACert             string `json:"ca_cert,omitempty"`
+
+	// BindDN and BindPassword are used to search the directory. Searches are
+	// anonymous if they are not set.
+	BindDN       string `json:"bind_dn,omitempty"`
+	BindPassword string `json:"bind_password,omitempty"`
+
+	UserSearch  LDAPUserSearch  `json:"user_search"`
+	GroupSearch LDAPGroupSearch `json:"group_search"`
+
+	Groups []string `json:"groups,omitempty"`
+}
+
+// LDAPUserSearch finds the entry of the user logging in: the entry under
+// BaseDN matching Filter whose UsernameAttribute is their username.
+type LDAPUserSearch struct {
+	BaseDN            string `json:"base_dn,omitempty"`
+	Filter            string `json:"filter,omitempty"`
+	UsernameAttribute string `json:"username_attribute,omitempty"`
+}
+
+// LDAPGroupSearch finds the groups of the user logging in: the entries under
+// BaseDN matching Filter whose GroupAttribute holds the user's UserAttribute
+// (or their DN, if UserAttribute is not set). Groups are named by their
+// NameAttribute.
+type LDAPGroupSearch struct {
+	BaseDN         string `json:"base_dn,omitempty"`
+	Filter         string `json:"filter,omitempty"`
+	UserAttribute  string `json:"user_attribute,omitempty"`
+	GroupAttribute string `json:"group_attribute,omitempty"`
+	NameAttribute  string `json:"name_attribute,omitempty"`
+}
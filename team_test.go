@@ -0,0 +1,27 @@
+package atc_test
+
+import (
+	"github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TeamRole", func() {
+	Describe("Includes", func() {
+		It("includes itself and every less privileged role", func() {
+			Expect(atc.RoleMember.Includes(atc.RoleMember)).To(BeTrue())
+			Expect(atc.RoleMember.Includes(atc.RoleOperator)).To(BeTrue())
+			Expect(atc.RoleMember.Includes(atc.RoleViewer)).To(BeTrue())
+		})
+
+		It("does not include more privileged roles", func() {
+			Expect(atc.RoleMember.Includes(atc.RoleOwner)).To(BeFalse())
+			Expect(atc.RoleViewer.Includes(atc.RoleOperator)).To(BeFalse())
+		})
+
+		It("includes nothing for an unknown role", func() {
+			Expect(atc.TeamRole("bogus").Includes(atc.RoleViewer)).To(BeFalse())
+		})
+	})
+})
@@ -7,6 +7,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/web"
+	"github.com/concourse/go-concourse/concourse"
 	"github.com/tedsuo/rata"
 )
 
@@ -50,9 +51,15 @@ func (h *processHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	team := client.Team(teamName)
 
 	token, err := team.AuthToken()
+	if err == concourse.ErrUnauthorized {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
 	if err != nil {
 		h.logger.Error("failed-to-get-token", err, lager.Data{})
 		w.WriteHeader(http.StatusInternalServerError)
+		return
 	}
 
 	http.SetCookie(w, &http.Cookie{
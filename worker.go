@@ -18,8 +18,21 @@ type Worker struct {
 	Team      string   `json:"team"`
 	Name      string   `json:"name"`
 	StartTime int64    `json:"start_time"`
+
+	State WorkerState `json:"state"`
 }
 
+type WorkerState string
+
+const (
+	WorkerStateRunning  WorkerState = "running"
+	WorkerStateLanding  WorkerState = "landing"
+	WorkerStateLanded   WorkerState = "landed"
+	WorkerStateRetiring WorkerState = "retiring"
+	WorkerStateRetired  WorkerState = "retired"
+	WorkerStateStalled  WorkerState = "stalled"
+)
+
 type WorkerResourceType struct {
 	Type    string `json:"type"`
 	Image   string `json:"image"`
@@ -111,6 +111,28 @@ func (strategy ImageArtifactReplicationStrategy) dbIdentifier() db.VolumeIdentif
 	}
 }
 
+type TaskCacheStrategy struct {
+	BuildID    int
+	StepName   string
+	Path       string
+	WorkerName string
+}
+
+func (TaskCacheStrategy) baggageclaimStrategy() baggageclaim.Strategy {
+	return baggageclaim.EmptyStrategy{}
+}
+
+func (strategy TaskCacheStrategy) dbIdentifier() db.VolumeIdentifier {
+	return db.VolumeIdentifier{
+		TaskCache: &db.TaskCacheIdentifier{
+			BuildID:    strategy.BuildID,
+			StepName:   strategy.StepName,
+			Path:       strategy.Path,
+			WorkerName: strategy.WorkerName,
+		},
+	}
+}
+
 type ContainerRootFSStrategy struct {
 	Parent Volume
 }
@@ -13,6 +13,10 @@ type WorkerSpec struct {
 	ResourceType string
 	Tags         []string
 	TeamID       int
+
+	// Artifacts the container will need. Only used to choose between
+	// satisfying workers; see PlacementStrategy.
+	Inputs []VolumeLocator
 }
 
 type ContainerSpec struct {
@@ -31,6 +35,9 @@ type ContainerSpec struct {
 
 	// Optional user to run processes as. Overwrites the one specified in the docker image.
 	User string
+
+	// Optional limits on the resources the container may consume.
+	Limits atc.ContainerLimits
 }
 
 type ImageSpec struct {
@@ -71,10 +71,14 @@ func (provider *dbProvider) Workers() ([]Worker, error) {
 
 	tikTok := clock.NewClock()
 
-	workers := make([]Worker, len(savedWorkers))
+	workers := []Worker{}
 
-	for i, savedWorker := range savedWorkers {
-		workers[i] = provider.newGardenWorker(tikTok, savedWorker)
+	for _, savedWorker := range savedWorkers {
+		if savedWorker.State != db.WorkerStateRunning {
+			continue
+		}
+
+		workers = append(workers, provider.newGardenWorker(tikTok, savedWorker))
 	}
 
 	return workers, nil
@@ -90,6 +94,12 @@ func (provider *dbProvider) GetWorker(name string) (Worker, bool, error) {
 		return nil, false, nil
 	}
 
+	// a stalled or retired worker has stopped heartbeating, so it cannot be
+	// reached
+	if savedWorker.State == db.WorkerStateStalled || savedWorker.State == db.WorkerStateRetired {
+		return nil, false, nil
+	}
+
 	tikTok := clock.NewClock()
 
 	worker := provider.newGardenWorker(tikTok, savedWorker)
@@ -117,6 +117,7 @@ var _ = Describe("DBProvider", func() {
 								{Type: "some-resource-a", Image: "some-image-a"},
 							},
 						},
+						State: db.WorkerStateRunning,
 					},
 					{
 						WorkerInfo: db.WorkerInfo{
@@ -127,6 +128,28 @@ var _ = Describe("DBProvider", func() {
 								{Type: "some-resource-b", Image: "some-image-b"},
 							},
 						},
+						State: db.WorkerStateRunning,
+					},
+					{
+						WorkerInfo: db.WorkerInfo{
+							Name:       "some-landing-worker",
+							GardenAddr: gardenAddr,
+						},
+						State: db.WorkerStateLanding,
+					},
+					{
+						WorkerInfo: db.WorkerInfo{
+							Name:       "some-retiring-worker",
+							GardenAddr: gardenAddr,
+						},
+						State: db.WorkerStateRetiring,
+					},
+					{
+						WorkerInfo: db.WorkerInfo{
+							Name:       "some-stalled-worker",
+							GardenAddr: gardenAddr,
+						},
+						State: db.WorkerStateStalled,
 					},
 				}, nil)
 			})
@@ -135,8 +158,10 @@ var _ = Describe("DBProvider", func() {
 				Expect(workersErr).NotTo(HaveOccurred())
 			})
 
-			It("returns a worker for each one", func() {
+			It("returns a worker for each running one", func() {
 				Expect(workers).To(HaveLen(2))
+				Expect(workers[0].Name()).To(Equal("some-worker"))
+				Expect(workers[1].Name()).To(Equal("some-other-worker"))
 			})
 
 			Context("creating the connection to garden", func() {
@@ -306,6 +331,38 @@ var _ = Describe("DBProvider", func() {
 			})
 		})
 
+		Context("when we find a stalled worker", func() {
+			It("returns found as false", func() {
+				fakeDB.GetWorkerReturns(db.SavedWorker{
+					WorkerInfo: db.WorkerInfo{
+						Name: "some-worker",
+					},
+					State: db.WorkerStateStalled,
+				}, true, nil)
+
+				worker, found, workersErr = provider.GetWorker("some-worker")
+				Expect(workersErr).NotTo(HaveOccurred())
+				Expect(worker).To(BeNil())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when we find a retired worker", func() {
+			It("returns found as false", func() {
+				fakeDB.GetWorkerReturns(db.SavedWorker{
+					WorkerInfo: db.WorkerInfo{
+						Name: "some-worker",
+					},
+					State: db.WorkerStateRetired,
+				}, true, nil)
+
+				worker, found, workersErr = provider.GetWorker("some-worker")
+				Expect(workersErr).NotTo(HaveOccurred())
+				Expect(worker).To(BeNil())
+				Expect(found).To(BeFalse())
+			})
+		})
+
 		Context("when we find worker", func() {
 			It("returns the found worker", func() {
 				fakeDB.GetWorkerReturns(db.SavedWorker{
@@ -0,0 +1,148 @@
+package worker
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+//go:generate counterfeiter . PlacementStrategy
+
+// PlacementStrategy chooses which of the workers satisfying a spec should run
+// a container. It is only ever given a non-empty list of workers.
+type PlacementStrategy interface {
+	Choose([]Worker, WorkerSpec) (Worker, error)
+}
+
+//go:generate counterfeiter . VolumeLocator
+
+// VolumeLocator finds an artifact's volume on a worker, if it is already
+// there. exec.ArtifactSource is one.
+type VolumeLocator interface {
+	VolumeOn(Worker) (Volume, bool, error)
+}
+
+const (
+	RandomPlacement           = "random"
+	FewestContainersPlacement = "fewest-containers"
+	VolumeLocalityPlacement   = "volume-locality"
+)
+
+// NewPlacementStrategy constructs the named strategy.
+func NewPlacementStrategy(name string) (PlacementStrategy, error) {
+	switch name {
+	case RandomPlacement:
+		return NewRandomPlacementStrategy(), nil
+	case FewestContainersPlacement:
+		return NewFewestContainersPlacementStrategy(), nil
+	case VolumeLocalityPlacement:
+		return NewVolumeLocalityPlacementStrategy(), nil
+	default:
+		return nil, fmt.Errorf("unknown placement strategy: %s", name)
+	}
+}
+
+// lockedRand is a rand.Rand which is safe to share between builds.
+type lockedRand struct {
+	rand *rand.Rand
+	lock sync.Mutex
+}
+
+func newLockedRand() *lockedRand {
+	return &lockedRand{
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (r *lockedRand) Intn(n int) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.rand.Intn(n)
+}
+
+type randomPlacementStrategy struct {
+	rand *lockedRand
+}
+
+// NewRandomPlacementStrategy chooses any of the workers at random.
+func NewRandomPlacementStrategy() PlacementStrategy {
+	return &randomPlacementStrategy{
+		rand: newLockedRand(),
+	}
+}
+
+func (strategy *randomPlacementStrategy) Choose(workers []Worker, spec WorkerSpec) (Worker, error) {
+	return workers[strategy.rand.Intn(len(workers))], nil
+}
+
+type fewestContainersPlacementStrategy struct {
+	rand *lockedRand
+}
+
+// NewFewestContainersPlacementStrategy chooses the worker with the fewest
+// active containers, picking at random between equally busy workers.
+func NewFewestContainersPlacementStrategy() PlacementStrategy {
+	return &fewestContainersPlacementStrategy{
+		rand: newLockedRand(),
+	}
+}
+
+func (strategy *fewestContainersPlacementStrategy) Choose(workers []Worker, spec WorkerSpec) (Worker, error) {
+	sorted := make([]Worker, len(workers))
+	copy(sorted, workers)
+
+	sort.Stable(byActiveContainers(sorted))
+
+	fewest := 1
+	for fewest < len(sorted) && sorted[fewest].ActiveContainers() == sorted[0].ActiveContainers() {
+		fewest++
+	}
+
+	return sorted[strategy.rand.Intn(fewest)], nil
+}
+
+type volumeLocalityPlacementStrategy struct {
+	rand *lockedRand
+}
+
+// NewVolumeLocalityPlacementStrategy chooses the worker which already has the
+// most of the spec's inputs, so that the fewest volumes need to be streamed to
+// it. It picks at random between equally good workers.
+func NewVolumeLocalityPlacementStrategy() PlacementStrategy {
+	return &volumeLocalityPlacementStrategy{
+		rand: newLockedRand(),
+	}
+}
+
+func (strategy *volumeLocalityPlacementStrategy) Choose(workers []Worker, spec WorkerSpec) (Worker, error) {
+	candidates := []Worker{}
+	mostVolumes := 0
+
+	for _, worker := range workers {
+		volumes := 0
+
+		for _, input := range spec.Inputs {
+			volume, found, err := input.VolumeOn(worker)
+			if err != nil {
+				return nil, err
+			}
+
+			if found {
+				volume.Release(nil)
+				volumes++
+			}
+		}
+
+		if volumes > mostVolumes {
+			candidates = []Worker{worker}
+			mostVolumes = volumes
+		} else if volumes == mostVolumes {
+			candidates = append(candidates, worker)
+		}
+	}
+
+	return candidates[strategy.rand.Intn(len(candidates))], nil
+}
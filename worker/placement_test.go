@@ -0,0 +1,206 @@
+package worker_test
+
+import (
+	"errors"
+
+	. "github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PlacementStrategy", func() {
+	var (
+		workerA *workerfakes.FakeWorker
+		workerB *workerfakes.FakeWorker
+		workerC *workerfakes.FakeWorker
+		workers []Worker
+	)
+
+	BeforeEach(func() {
+		workerA = new(workerfakes.FakeWorker)
+		workerA.NameReturns("worker-a")
+		workerB = new(workerfakes.FakeWorker)
+		workerB.NameReturns("worker-b")
+		workerC = new(workerfakes.FakeWorker)
+		workerC.NameReturns("worker-c")
+
+		workers = []Worker{workerA, workerB, workerC}
+	})
+
+	Describe("NewPlacementStrategy", func() {
+		It("constructs each of the strategies", func() {
+			for _, name := range []string{RandomPlacement, FewestContainersPlacement, VolumeLocalityPlacement} {
+				strategy, err := NewPlacementStrategy(name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(strategy).NotTo(BeNil())
+			}
+		})
+
+		It("errors for an unknown strategy", func() {
+			_, err := NewPlacementStrategy("bogus")
+			Expect(err).To(MatchError("unknown placement strategy: bogus"))
+		})
+	})
+
+	Describe("random", func() {
+		It("chooses between all of the workers", func() {
+			strategy := NewRandomPlacementStrategy()
+
+			Eventually(func() Worker {
+				chosen, err := strategy.Choose(workers, WorkerSpec{})
+				Expect(err).NotTo(HaveOccurred())
+				return chosen
+			}).Should(Equal(workerA))
+
+			Eventually(func() Worker {
+				chosen, err := strategy.Choose(workers, WorkerSpec{})
+				Expect(err).NotTo(HaveOccurred())
+				return chosen
+			}).Should(Equal(workerC))
+		})
+	})
+
+	Describe("fewest-containers", func() {
+		var strategy PlacementStrategy
+
+		BeforeEach(func() {
+			strategy = NewFewestContainersPlacementStrategy()
+
+			workerA.ActiveContainersReturns(5)
+			workerB.ActiveContainersReturns(1)
+			workerC.ActiveContainersReturns(3)
+		})
+
+		It("chooses the worker with the fewest active containers", func() {
+			for i := 0; i < 10; i++ {
+				chosen, err := strategy.Choose(workers, WorkerSpec{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(chosen).To(Equal(workerB))
+			}
+		})
+
+		It("does not reorder the given workers", func() {
+			_, err := strategy.Choose(workers, WorkerSpec{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workers).To(Equal([]Worker{workerA, workerB, workerC}))
+		})
+
+		Context("when workers are equally busy", func() {
+			BeforeEach(func() {
+				workerC.ActiveContainersReturns(1)
+			})
+
+			It("chooses between them", func() {
+				Consistently(func() Worker {
+					chosen, err := strategy.Choose(workers, WorkerSpec{})
+					Expect(err).NotTo(HaveOccurred())
+					return chosen
+				}).ShouldNot(Equal(workerA))
+
+				Eventually(func() Worker {
+					chosen, err := strategy.Choose(workers, WorkerSpec{})
+					Expect(err).NotTo(HaveOccurred())
+					return chosen
+				}).Should(Equal(workerC))
+			})
+		})
+	})
+
+	Describe("volume-locality", func() {
+		var (
+			strategy PlacementStrategy
+
+			inputA *workerfakes.FakeVolumeLocator
+			inputB *workerfakes.FakeVolumeLocator
+
+			volumeA *workerfakes.FakeVolume
+			volumeB *workerfakes.FakeVolume
+
+			spec WorkerSpec
+		)
+
+		BeforeEach(func() {
+			strategy = NewVolumeLocalityPlacementStrategy()
+
+			inputA = new(workerfakes.FakeVolumeLocator)
+			inputB = new(workerfakes.FakeVolumeLocator)
+
+			volumeA = new(workerfakes.FakeVolume)
+			volumeB = new(workerfakes.FakeVolume)
+
+			inputA.VolumeOnStub = func(w Worker) (Volume, bool, error) {
+				if w == workerB || w == workerC {
+					return volumeA, true, nil
+				}
+
+				return nil, false, nil
+			}
+
+			inputB.VolumeOnStub = func(w Worker) (Volume, bool, error) {
+				if w == workerC {
+					return volumeB, true, nil
+				}
+
+				return nil, false, nil
+			}
+
+			spec = WorkerSpec{
+				Inputs: []VolumeLocator{inputA, inputB},
+			}
+		})
+
+		It("chooses the worker with the most inputs", func() {
+			for i := 0; i < 10; i++ {
+				chosen, err := strategy.Choose(workers, spec)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(chosen).To(Equal(workerC))
+			}
+		})
+
+		It("releases the volumes it finds", func() {
+			_, err := strategy.Choose(workers, spec)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(volumeA.ReleaseCallCount()).To(Equal(2))
+			Expect(volumeB.ReleaseCallCount()).To(Equal(1))
+		})
+
+		Context("when no worker has any of the inputs", func() {
+			BeforeEach(func() {
+				spec = WorkerSpec{
+					Inputs: []VolumeLocator{new(workerfakes.FakeVolumeLocator)},
+				}
+			})
+
+			It("chooses between all of the workers", func() {
+				Eventually(func() Worker {
+					chosen, err := strategy.Choose(workers, spec)
+					Expect(err).NotTo(HaveOccurred())
+					return chosen
+				}).Should(Equal(workerA))
+
+				Eventually(func() Worker {
+					chosen, err := strategy.Choose(workers, spec)
+					Expect(err).NotTo(HaveOccurred())
+					return chosen
+				}).Should(Equal(workerC))
+			})
+		})
+
+		Context("when looking for an input fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				inputB.VolumeOnStub = nil
+				inputB.VolumeOnReturns(nil, false, disaster)
+			})
+
+			It("returns the error", func() {
+				_, err := strategy.Choose(workers, spec)
+				Expect(err).To(Equal(disaster))
+			})
+		})
+	})
+})
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
-	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
@@ -15,6 +14,7 @@ import (
 //go:generate counterfeiter . WorkerProvider
 
 type WorkerProvider interface {
+	// Workers returns the workers which new containers may be placed on.
 	Workers() ([]Worker, error)
 	GetWorker(string) (Worker, bool, error)
 
@@ -48,14 +48,13 @@ func (err NoCompatibleWorkersError) Error() string {
 
 type pool struct {
 	provider WorkerProvider
-
-	rand *rand.Rand
+	strategy PlacementStrategy
 }
 
-func NewPool(provider WorkerProvider) Client {
+func NewPool(provider WorkerProvider, strategy PlacementStrategy) Client {
 	return &pool{
 		provider: provider,
-		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		strategy: strategy,
 	}
 }
 
@@ -123,8 +122,8 @@ func (pool *pool) Satisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes) (
 	if err != nil {
 		return nil, err
 	}
-	randomWorker := compatibleWorkers[pool.rand.Intn(len(compatibleWorkers))]
-	return randomWorker, nil
+
+	return pool.strategy.Choose(compatibleWorkers, spec)
 }
 
 func (pool *pool) CreateContainer(logger lager.Logger, signals <-chan os.Signal, delegate ImageFetchingDelegate, id Identifier, metadata Metadata, spec ContainerSpec, resourceTypes atc.ResourceTypes) (Container, error) {
@@ -25,7 +25,7 @@ var _ = Describe("Pool", func() {
 		logger = lagertest.NewTestLogger("test")
 		fakeProvider = new(workerfakes.FakeWorkerProvider)
 
-		pool = NewPool(fakeProvider)
+		pool = NewPool(fakeProvider, NewRandomPlacementStrategy())
 	})
 
 	Describe("GetWorker", func() {
@@ -167,6 +167,50 @@ var _ = Describe("Pool", func() {
 			})
 		})
 
+		Context("with a placement strategy", func() {
+			var (
+				fakeStrategy *workerfakes.FakePlacementStrategy
+				workerA      *workerfakes.FakeWorker
+				workerB      *workerfakes.FakeWorker
+			)
+
+			BeforeEach(func() {
+				fakeStrategy = new(workerfakes.FakePlacementStrategy)
+				pool = NewPool(fakeProvider, fakeStrategy)
+
+				workerA = new(workerfakes.FakeWorker)
+				workerB = new(workerfakes.FakeWorker)
+
+				workerA.SatisfyingReturns(workerA, nil)
+				workerB.SatisfyingReturns(nil, errors.New("nope"))
+
+				fakeProvider.WorkersReturns([]Worker{workerA, workerB}, nil)
+				fakeStrategy.ChooseReturns(workerA, nil)
+			})
+
+			It("lets the strategy choose between the satisfying workers", func() {
+				Expect(satisfyingErr).NotTo(HaveOccurred())
+				Expect(satisfyingWorker).To(Equal(workerA))
+
+				Expect(fakeStrategy.ChooseCallCount()).To(Equal(1))
+				workers, actualSpec := fakeStrategy.ChooseArgsForCall(0)
+				Expect(workers).To(Equal([]Worker{workerA}))
+				Expect(actualSpec).To(Equal(spec))
+			})
+
+			Context("when the strategy fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeStrategy.ChooseReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(satisfyingErr).To(Equal(disaster))
+				})
+			})
+		})
+
 		Context("with no workers", func() {
 			BeforeEach(func() {
 				fakeProvider.WorkersReturns([]Worker{}, nil)
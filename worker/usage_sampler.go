@@ -0,0 +1,127 @@
+package worker
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+)
+
+// UsageSamplingInterval is how often a running step's container metrics are
+// sampled.
+const UsageSamplingInterval = 10 * time.Second
+
+// UsageSampler periodically samples the metrics of a container in the
+// background, keeping track of the peak and average usage.
+type UsageSampler struct {
+	logger    lager.Logger
+	clock     clock.Clock
+	container garden.Container
+
+	stop    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+
+	usage atc.ResourceUsage
+
+	cpuSamples   int
+	totalCPU     float64
+	totalMemory  uint64
+	totalDisk    uint64
+	lastCPUUsage uint64
+	lastSampled  time.Time
+}
+
+// SampleUsage begins sampling the container's metrics every interval until the
+// returned sampler is stopped.
+func SampleUsage(logger lager.Logger, clock clock.Clock, interval time.Duration, container garden.Container) *UsageSampler {
+	sampler := &UsageSampler{
+		logger:    logger,
+		clock:     clock,
+		container: container,
+
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	go sampler.run(interval)
+
+	return sampler
+}
+
+// Stop takes a final sample and returns the usage observed over the lifetime
+// of the sampler.
+func (sampler *UsageSampler) Stop() atc.ResourceUsage {
+	sampler.once.Do(func() {
+		close(sampler.stop)
+	})
+
+	<-sampler.stopped
+
+	return sampler.usage
+}
+
+func (sampler *UsageSampler) run(interval time.Duration) {
+	defer close(sampler.stopped)
+
+	ticker := sampler.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	sampler.sample()
+
+	for {
+		select {
+		case <-ticker.C():
+			sampler.sample()
+		case <-sampler.stop:
+			sampler.sample()
+			return
+		}
+	}
+}
+
+func (sampler *UsageSampler) sample() {
+	metrics, err := sampler.container.Metrics()
+	if err != nil {
+		sampler.logger.Info("failed-to-sample-metrics", lager.Data{"error": err.Error()})
+		return
+	}
+
+	now := sampler.clock.Now()
+
+	usage := &sampler.usage
+	usage.Samples++
+
+	memory := metrics.MemoryStat.TotalRss
+	sampler.totalMemory += memory
+	if memory > usage.PeakMemory {
+		usage.PeakMemory = memory
+	}
+	usage.AverageMemory = sampler.totalMemory / uint64(usage.Samples)
+
+	disk := metrics.DiskStat.TotalBytesUsed
+	sampler.totalDisk += disk
+	if disk > usage.PeakDisk {
+		usage.PeakDisk = disk
+	}
+	usage.AverageDisk = sampler.totalDisk / uint64(usage.Samples)
+
+	// CPU usage is cumulative, so utilization is only known between samples
+	cpuUsage := metrics.CPUStat.Usage
+	if !sampler.lastSampled.IsZero() && now.After(sampler.lastSampled) && cpuUsage >= sampler.lastCPUUsage {
+		cpu := float64(cpuUsage-sampler.lastCPUUsage) / float64(now.Sub(sampler.lastSampled).Nanoseconds())
+
+		sampler.cpuSamples++
+		sampler.totalCPU += cpu
+		if cpu > usage.PeakCPU {
+			usage.PeakCPU = cpu
+		}
+		usage.AverageCPU = sampler.totalCPU / float64(sampler.cpuSamples)
+	}
+
+	sampler.lastCPUUsage = cpuUsage
+	sampler.lastSampled = now
+}
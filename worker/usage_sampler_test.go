@@ -0,0 +1,80 @@
+package worker_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/garden"
+	gfakes "code.cloudfoundry.org/garden/gardenfakes"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/worker"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UsageSampler", func() {
+	var (
+		fakeClock     *fakeclock.FakeClock
+		fakeContainer *gfakes.FakeContainer
+
+		samples chan garden.Metrics
+		sampler *UsageSampler
+	)
+
+	metrics := func(cpu uint64, memory uint64, disk uint64) garden.Metrics {
+		return garden.Metrics{
+			CPUStat:    garden.ContainerCPUStat{Usage: cpu},
+			MemoryStat: garden.ContainerMemoryStat{TotalRss: memory},
+			DiskStat:   garden.ContainerDiskStat{TotalBytesUsed: disk},
+		}
+	}
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+		fakeContainer = new(gfakes.FakeContainer)
+
+		samples = make(chan garden.Metrics, 10)
+		fakeContainer.MetricsStub = func() (garden.Metrics, error) {
+			select {
+			case m := <-samples:
+				return m, nil
+			default:
+				return garden.Metrics{}, errors.New("no more samples")
+			}
+		}
+	})
+
+	It("tracks the peak and average usage across samples", func() {
+		samples <- metrics(0, 100, 1000)
+
+		sampler = SampleUsage(lagertest.NewTestLogger("test"), fakeClock, 10*time.Second, fakeContainer)
+		Eventually(fakeContainer.MetricsCallCount).Should(Equal(1))
+
+		samples <- metrics(uint64(20*time.Second), 300, 3000)
+		fakeClock.WaitForWatcherAndIncrement(10 * time.Second)
+		Eventually(fakeContainer.MetricsCallCount).Should(Equal(2))
+
+		samples <- metrics(uint64(20*time.Second), 200, 2000)
+		fakeClock.Increment(10 * time.Second)
+
+		Expect(sampler.Stop()).To(Equal(atc.ResourceUsage{
+			PeakCPU:       2,
+			AverageCPU:    1,
+			PeakMemory:    300,
+			AverageMemory: 200,
+			PeakDisk:      3000,
+			AverageDisk:   2000,
+			Samples:       3,
+		}))
+	})
+
+	It("ignores samples that fail", func() {
+		sampler = SampleUsage(lagertest.NewTestLogger("test"), fakeClock, 10*time.Second, fakeContainer)
+		Eventually(fakeContainer.MetricsCallCount).Should(Equal(1))
+
+		Expect(sampler.Stop()).To(Equal(atc.ResourceUsage{}))
+	})
+})
@@ -38,6 +38,10 @@ const ContainerTTL = 5 * time.Minute
 
 const VolumeTTL = 5 * time.Minute
 
+// TaskCacheTTL is how long a task cache volume is kept after the last build
+// that used it.
+const TaskCacheTTL = 7 * 24 * time.Hour
+
 const ephemeralPropertyName = "concourse:ephemeral"
 const volumePropertyName = "concourse:volumes"
 const volumeMountsPropertyName = "concourse:volume-mounts"
@@ -428,6 +432,11 @@ func (worker *gardenWorker) CreateContainer(
 		Properties: gardenProperties,
 		RootFSPath: imageURL,
 		Env:        env,
+		Limits: garden.Limits{
+			Memory: garden.MemoryLimits{LimitInBytes: spec.Limits.Memory},
+			CPU:    garden.CPULimits{LimitInShares: spec.Limits.CPU},
+			Disk:   garden.DiskLimits{ByteHard: spec.Limits.Disk},
+		},
 	}
 
 	gardenContainer, err := worker.gardenClient.Create(gardenSpec)
@@ -228,6 +228,27 @@ var _ = Describe("Worker", func() {
 			})
 		})
 
+		Context("when the spec specifies limits", func() {
+			BeforeEach(func() {
+				containerSpec.Limits = atc.ContainerLimits{
+					Memory: 1024 * 1024 * 1024,
+					CPU:    512,
+					Disk:   2 * 1024 * 1024 * 1024,
+				}
+			})
+
+			It("creates the container in garden with the limits", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+				actualGardenSpec := fakeGardenClient.CreateArgsForCall(0)
+				Expect(actualGardenSpec.Limits).To(Equal(garden.Limits{
+					Memory: garden.MemoryLimits{LimitInBytes: 1024 * 1024 * 1024},
+					CPU:    garden.CPULimits{LimitInShares: 512},
+					Disk:   garden.DiskLimits{ByteHard: 2 * 1024 * 1024 * 1024},
+				}))
+			})
+		})
+
 		Context("when creating the container succeeds", func() {
 			var fakeContainer *gfakes.FakeContainer
 			BeforeEach(func() {
@@ -0,0 +1,85 @@
+// This file was generated by counterfeiter
+package workerfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/worker"
+)
+
+type FakePlacementStrategy struct {
+	ChooseStub        func([]worker.Worker, worker.WorkerSpec) (worker.Worker, error)
+	chooseMutex       sync.RWMutex
+	chooseArgsForCall []struct {
+		arg1 []worker.Worker
+		arg2 worker.WorkerSpec
+	}
+	chooseReturns struct {
+		result1 worker.Worker
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakePlacementStrategy) Choose(arg1 []worker.Worker, arg2 worker.WorkerSpec) (worker.Worker, error) {
+	var arg1Copy []worker.Worker
+	if arg1 != nil {
+		arg1Copy = make([]worker.Worker, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.chooseMutex.Lock()
+	fake.chooseArgsForCall = append(fake.chooseArgsForCall, struct {
+		arg1 []worker.Worker
+		arg2 worker.WorkerSpec
+	}{arg1Copy, arg2})
+	fake.recordInvocation("Choose", []interface{}{arg1Copy, arg2})
+	fake.chooseMutex.Unlock()
+	if fake.ChooseStub != nil {
+		return fake.ChooseStub(arg1, arg2)
+	} else {
+		return fake.chooseReturns.result1, fake.chooseReturns.result2
+	}
+}
+
+func (fake *FakePlacementStrategy) ChooseCallCount() int {
+	fake.chooseMutex.RLock()
+	defer fake.chooseMutex.RUnlock()
+	return len(fake.chooseArgsForCall)
+}
+
+func (fake *FakePlacementStrategy) ChooseArgsForCall(i int) ([]worker.Worker, worker.WorkerSpec) {
+	fake.chooseMutex.RLock()
+	defer fake.chooseMutex.RUnlock()
+	return fake.chooseArgsForCall[i].arg1, fake.chooseArgsForCall[i].arg2
+}
+
+func (fake *FakePlacementStrategy) ChooseReturns(result1 worker.Worker, result2 error) {
+	fake.ChooseStub = nil
+	fake.chooseReturns = struct {
+		result1 worker.Worker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePlacementStrategy) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.chooseMutex.RLock()
+	defer fake.chooseMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakePlacementStrategy) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ worker.PlacementStrategy = new(FakePlacementStrategy)
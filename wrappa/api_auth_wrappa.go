@@ -39,6 +39,12 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 	rejector := auth.UnauthorizedRejector{}
 
 	for name, handler := range handlers {
+		role, found := atc.RouteRoles[name]
+		if !found {
+			panic("you missed a role for " + name)
+		}
+
+		handler = auth.CheckRoleHandler(handler, role, rejector)
 		newHandler := handler
 
 		switch name {
@@ -49,7 +55,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListTeams,
 			atc.ListAllPipelines,
 			atc.ListPipelines,
-			atc.ListBuilds:
+			atc.ListBuilds,
+			atc.CheckResourceWebHook:
 
 		// pipeline is public or authorized
 		case atc.GetBuild,
@@ -59,11 +66,13 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 
 		// pipeline and job are public or authorized
 		case atc.GetBuildPreparation,
+			atc.GetBuildResourceUsage,
 			atc.BuildEvents:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 		// resource belongs to authorized team
-		case atc.AbortBuild:
+		case atc.AbortBuild,
+			atc.RerunBuild:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// pipeline is public or authorized
@@ -90,31 +99,48 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListWorkers,
 			atc.ReadPipe,
 			atc.RegisterWorker,
+			atc.LandWorker,
+			atc.RetireWorker,
+			atc.PruneWorker,
 			atc.SetLogLevel,
 			atc.SetTeam,
 			atc.WritePipe,
 			atc.ListVolumes,
 			atc.GetLogLevel,
-			atc.GetUser:
+			atc.GetUser,
+			atc.ListAuditEvents:
 			newHandler = auth.CheckAuthenticationHandler(handler, rejector)
 
 		// authorized (requested team matches resource team)
 		case atc.CheckResource,
+			atc.ClearJobCaches,
 			atc.CreateJobBuild,
 			atc.DeletePipeline,
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
 			atc.GetConfig,
+			atc.ListConfigRevisions,
+			atc.DiffConfigRevisions,
+			atc.RollbackConfig,
+			atc.CreateAPIToken,
+			atc.ListAPITokens,
+			atc.DeleteAPIToken,
 			atc.GetVersionsDB,
+			atc.GetJobSchedulingExplanation,
+			atc.SearchJobBuilds,
 			atc.ListJobInputs,
 			atc.OrderPipelines,
 			atc.PauseJob,
 			atc.PausePipeline,
 			atc.PauseResource,
+			atc.PinResource,
 			atc.RenamePipeline,
+			atc.ExportPipeline,
+			atc.ImportPipeline,
 			atc.UnpauseJob,
 			atc.UnpausePipeline,
 			atc.UnpauseResource,
+			atc.UnpinResource,
 			atc.ExposePipeline,
 			atc.HidePipeline,
 			atc.SaveConfig:
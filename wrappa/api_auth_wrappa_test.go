@@ -129,8 +129,9 @@ var _ = Describe("APIAuthWrappa", func() {
 
 	Describe("Wrap", func() {
 		var (
-			inputHandlers    rata.Handlers
-			expectedHandlers rata.Handlers
+			inputHandlers       rata.Handlers
+			roleCheckedHandlers rata.Handlers
+			expectedHandlers    rata.Handlers
 
 			wrappedHandlers rata.Handlers
 		)
@@ -138,82 +139,109 @@ var _ = Describe("APIAuthWrappa", func() {
 		BeforeEach(func() {
 			inputHandlers = rata.Handlers{}
 
+			roleCheckedHandlers = rata.Handlers{}
+
 			for _, route := range atc.Routes {
 				inputHandlers[route.Name] = &stupidHandler{}
+				roleCheckedHandlers[route.Name] = auth.CheckRoleHandler(
+					inputHandlers[route.Name],
+					atc.RouteRoles[route.Name],
+					auth.UnauthorizedRejector{},
+				)
 			}
 
 			expectedHandlers = rata.Handlers{
 				// unauthenticated / delegating to handler
-				atc.GetInfo:          unauthenticated(inputHandlers[atc.GetInfo]),
-				atc.DownloadCLI:      unauthenticated(inputHandlers[atc.DownloadCLI]),
-				atc.ListAuthMethods:  unauthenticated(inputHandlers[atc.ListAuthMethods]),
-				atc.ListAllPipelines: unauthenticated(inputHandlers[atc.ListAllPipelines]),
-				atc.ListBuilds:       unauthenticated(inputHandlers[atc.ListBuilds]),
-				atc.ListPipelines:    unauthenticated(inputHandlers[atc.ListPipelines]),
-				atc.ListTeams:        unauthenticated(inputHandlers[atc.ListTeams]),
+				atc.GetInfo:              unauthenticated(roleCheckedHandlers[atc.GetInfo]),
+				atc.DownloadCLI:          unauthenticated(roleCheckedHandlers[atc.DownloadCLI]),
+				atc.ListAuthMethods:      unauthenticated(roleCheckedHandlers[atc.ListAuthMethods]),
+				atc.ListAllPipelines:     unauthenticated(roleCheckedHandlers[atc.ListAllPipelines]),
+				atc.ListBuilds:           unauthenticated(roleCheckedHandlers[atc.ListBuilds]),
+				atc.ListPipelines:        unauthenticated(roleCheckedHandlers[atc.ListPipelines]),
+				atc.ListTeams:            unauthenticated(roleCheckedHandlers[atc.ListTeams]),
+				atc.CheckResourceWebHook: unauthenticated(roleCheckedHandlers[atc.CheckResourceWebHook]),
 
 				// authorized or public pipeline
-				atc.GetBuild:       doesNotCheckIfPrivateJob(inputHandlers[atc.GetBuild]),
-				atc.BuildResources: doesNotCheckIfPrivateJob(inputHandlers[atc.BuildResources]),
-				atc.GetBuildPlan:   doesNotCheckIfPrivateJob(inputHandlers[atc.GetBuildPlan]),
+				atc.GetBuild:       doesNotCheckIfPrivateJob(roleCheckedHandlers[atc.GetBuild]),
+				atc.BuildResources: doesNotCheckIfPrivateJob(roleCheckedHandlers[atc.BuildResources]),
+				atc.GetBuildPlan:   doesNotCheckIfPrivateJob(roleCheckedHandlers[atc.GetBuildPlan]),
 
 				// authorized or public pipeline and public job
-				atc.BuildEvents:         checksIfPrivateJob(inputHandlers[atc.BuildEvents]),
-				atc.GetBuildPreparation: checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
+				atc.BuildEvents:           checksIfPrivateJob(roleCheckedHandlers[atc.BuildEvents]),
+				atc.GetBuildPreparation:   checksIfPrivateJob(roleCheckedHandlers[atc.GetBuildPreparation]),
+				atc.GetBuildResourceUsage: checksIfPrivateJob(roleCheckedHandlers[atc.GetBuildResourceUsage]),
 
 				// resource belongs to authorized team
-				atc.AbortBuild: checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.AbortBuild: checkWritePermissionForBuild(roleCheckedHandlers[atc.AbortBuild]),
+				atc.RerunBuild: checkWritePermissionForBuild(roleCheckedHandlers[atc.RerunBuild]),
 
 				// belongs to public pipeline or authorized
-				atc.GetPipeline:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetPipeline]),
-				atc.GetJobBuild:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJobBuild]),
-				atc.JobBadge:                      openForPublicPipelineOrAuthorized(inputHandlers[atc.JobBadge]),
-				atc.ListJobs:                      openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobs]),
-				atc.GetJob:                        openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJob]),
-				atc.ListJobBuilds:                 openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobBuilds]),
-				atc.GetResource:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetResource]),
-				atc.ListBuildsWithVersionAsInput:  openForPublicPipelineOrAuthorized(inputHandlers[atc.ListBuildsWithVersionAsInput]),
-				atc.ListBuildsWithVersionAsOutput: openForPublicPipelineOrAuthorized(inputHandlers[atc.ListBuildsWithVersionAsOutput]),
-				atc.ListResources:                 openForPublicPipelineOrAuthorized(inputHandlers[atc.ListResources]),
-				atc.ListResourceVersions:          openForPublicPipelineOrAuthorized(inputHandlers[atc.ListResourceVersions]),
+				atc.GetPipeline:                   openForPublicPipelineOrAuthorized(roleCheckedHandlers[atc.GetPipeline]),
+				atc.GetJobBuild:                   openForPublicPipelineOrAuthorized(roleCheckedHandlers[atc.GetJobBuild]),
+				atc.JobBadge:                      openForPublicPipelineOrAuthorized(roleCheckedHandlers[atc.JobBadge]),
+				atc.ListJobs:                      openForPublicPipelineOrAuthorized(roleCheckedHandlers[atc.ListJobs]),
+				atc.GetJob:                        openForPublicPipelineOrAuthorized(roleCheckedHandlers[atc.GetJob]),
+				atc.ListJobBuilds:                 openForPublicPipelineOrAuthorized(roleCheckedHandlers[atc.ListJobBuilds]),
+				atc.GetResource:                   openForPublicPipelineOrAuthorized(roleCheckedHandlers[atc.GetResource]),
+				atc.ListBuildsWithVersionAsInput:  openForPublicPipelineOrAuthorized(roleCheckedHandlers[atc.ListBuildsWithVersionAsInput]),
+				atc.ListBuildsWithVersionAsOutput: openForPublicPipelineOrAuthorized(roleCheckedHandlers[atc.ListBuildsWithVersionAsOutput]),
+				atc.ListResources:                 openForPublicPipelineOrAuthorized(roleCheckedHandlers[atc.ListResources]),
+				atc.ListResourceVersions:          openForPublicPipelineOrAuthorized(roleCheckedHandlers[atc.ListResourceVersions]),
 
 				// authenticated
-				atc.CreateBuild:     authenticated(inputHandlers[atc.CreateBuild]),
-				atc.CreatePipe:      authenticated(inputHandlers[atc.CreatePipe]),
-				atc.GetAuthToken:    authenticatedWithGetTokenValidator(inputHandlers[atc.GetAuthToken]),
-				atc.GetContainer:    authenticated(inputHandlers[atc.GetContainer]),
-				atc.GetLogLevel:     authenticated(inputHandlers[atc.GetLogLevel]),
-				atc.HijackContainer: authenticated(inputHandlers[atc.HijackContainer]),
-				atc.ListContainers:  authenticated(inputHandlers[atc.ListContainers]),
-				atc.ListVolumes:     authenticated(inputHandlers[atc.ListVolumes]),
-				atc.ListWorkers:     authenticated(inputHandlers[atc.ListWorkers]),
-				atc.ReadPipe:        authenticated(inputHandlers[atc.ReadPipe]),
-				atc.RegisterWorker:  authenticated(inputHandlers[atc.RegisterWorker]),
-				atc.SetLogLevel:     authenticated(inputHandlers[atc.SetLogLevel]),
-				atc.SetTeam:         authenticated(inputHandlers[atc.SetTeam]),
-				atc.WritePipe:       authenticated(inputHandlers[atc.WritePipe]),
-				atc.GetUser:         authenticated(inputHandlers[atc.GetUser]),
+				atc.CreateBuild:     authenticated(roleCheckedHandlers[atc.CreateBuild]),
+				atc.CreatePipe:      authenticated(roleCheckedHandlers[atc.CreatePipe]),
+				atc.GetAuthToken:    authenticatedWithGetTokenValidator(roleCheckedHandlers[atc.GetAuthToken]),
+				atc.GetContainer:    authenticated(roleCheckedHandlers[atc.GetContainer]),
+				atc.GetLogLevel:     authenticated(roleCheckedHandlers[atc.GetLogLevel]),
+				atc.HijackContainer: authenticated(roleCheckedHandlers[atc.HijackContainer]),
+				atc.ListContainers:  authenticated(roleCheckedHandlers[atc.ListContainers]),
+				atc.ListVolumes:     authenticated(roleCheckedHandlers[atc.ListVolumes]),
+				atc.ListWorkers:     authenticated(roleCheckedHandlers[atc.ListWorkers]),
+				atc.ReadPipe:        authenticated(roleCheckedHandlers[atc.ReadPipe]),
+				atc.RegisterWorker:  authenticated(roleCheckedHandlers[atc.RegisterWorker]),
+				atc.LandWorker:      authenticated(roleCheckedHandlers[atc.LandWorker]),
+				atc.RetireWorker:    authenticated(roleCheckedHandlers[atc.RetireWorker]),
+				atc.PruneWorker:     authenticated(roleCheckedHandlers[atc.PruneWorker]),
+				atc.SetLogLevel:     authenticated(roleCheckedHandlers[atc.SetLogLevel]),
+				atc.SetTeam:         authenticated(roleCheckedHandlers[atc.SetTeam]),
+				atc.WritePipe:       authenticated(roleCheckedHandlers[atc.WritePipe]),
+				atc.GetUser:         authenticated(roleCheckedHandlers[atc.GetUser]),
+				atc.ListAuditEvents: authenticated(roleCheckedHandlers[atc.ListAuditEvents]),
 
 				// authorized (requested team matches resource team)
-				atc.CheckResource:          authorized(inputHandlers[atc.CheckResource]),
-				atc.CreateJobBuild:         authorized(inputHandlers[atc.CreateJobBuild]),
-				atc.DeletePipeline:         authorized(inputHandlers[atc.DeletePipeline]),
-				atc.DisableResourceVersion: authorized(inputHandlers[atc.DisableResourceVersion]),
-				atc.EnableResourceVersion:  authorized(inputHandlers[atc.EnableResourceVersion]),
-				atc.GetConfig:              authorized(inputHandlers[atc.GetConfig]),
-				atc.GetVersionsDB:          authorized(inputHandlers[atc.GetVersionsDB]),
-				atc.ListJobInputs:          authorized(inputHandlers[atc.ListJobInputs]),
-				atc.OrderPipelines:         authorized(inputHandlers[atc.OrderPipelines]),
-				atc.PauseJob:               authorized(inputHandlers[atc.PauseJob]),
-				atc.PausePipeline:          authorized(inputHandlers[atc.PausePipeline]),
-				atc.PauseResource:          authorized(inputHandlers[atc.PauseResource]),
-				atc.RenamePipeline:         authorized(inputHandlers[atc.RenamePipeline]),
-				atc.SaveConfig:             authorized(inputHandlers[atc.SaveConfig]),
-				atc.UnpauseJob:             authorized(inputHandlers[atc.UnpauseJob]),
-				atc.UnpausePipeline:        authorized(inputHandlers[atc.UnpausePipeline]),
-				atc.UnpauseResource:        authorized(inputHandlers[atc.UnpauseResource]),
-				atc.ExposePipeline:         authorized(inputHandlers[atc.ExposePipeline]),
-				atc.HidePipeline:           authorized(inputHandlers[atc.HidePipeline]),
+				atc.CheckResource:               authorized(roleCheckedHandlers[atc.CheckResource]),
+				atc.CreateJobBuild:              authorized(roleCheckedHandlers[atc.CreateJobBuild]),
+				atc.ClearJobCaches:              authorized(roleCheckedHandlers[atc.ClearJobCaches]),
+				atc.DeletePipeline:              authorized(roleCheckedHandlers[atc.DeletePipeline]),
+				atc.DisableResourceVersion:      authorized(roleCheckedHandlers[atc.DisableResourceVersion]),
+				atc.EnableResourceVersion:       authorized(roleCheckedHandlers[atc.EnableResourceVersion]),
+				atc.GetConfig:                   authorized(roleCheckedHandlers[atc.GetConfig]),
+				atc.ListConfigRevisions:         authorized(roleCheckedHandlers[atc.ListConfigRevisions]),
+				atc.DiffConfigRevisions:         authorized(roleCheckedHandlers[atc.DiffConfigRevisions]),
+				atc.RollbackConfig:              authorized(roleCheckedHandlers[atc.RollbackConfig]),
+				atc.CreateAPIToken:              authorized(roleCheckedHandlers[atc.CreateAPIToken]),
+				atc.ListAPITokens:               authorized(roleCheckedHandlers[atc.ListAPITokens]),
+				atc.DeleteAPIToken:              authorized(roleCheckedHandlers[atc.DeleteAPIToken]),
+				atc.GetVersionsDB:               authorized(roleCheckedHandlers[atc.GetVersionsDB]),
+				atc.ListJobInputs:               authorized(roleCheckedHandlers[atc.ListJobInputs]),
+				atc.GetJobSchedulingExplanation: authorized(roleCheckedHandlers[atc.GetJobSchedulingExplanation]),
+				atc.SearchJobBuilds:             authorized(roleCheckedHandlers[atc.SearchJobBuilds]),
+				atc.OrderPipelines:              authorized(roleCheckedHandlers[atc.OrderPipelines]),
+				atc.PauseJob:                    authorized(roleCheckedHandlers[atc.PauseJob]),
+				atc.PausePipeline:               authorized(roleCheckedHandlers[atc.PausePipeline]),
+				atc.PauseResource:               authorized(roleCheckedHandlers[atc.PauseResource]),
+				atc.PinResource:                 authorized(roleCheckedHandlers[atc.PinResource]),
+				atc.RenamePipeline:              authorized(roleCheckedHandlers[atc.RenamePipeline]),
+				atc.ExportPipeline:              authorized(roleCheckedHandlers[atc.ExportPipeline]),
+				atc.ImportPipeline:              authorized(roleCheckedHandlers[atc.ImportPipeline]),
+				atc.SaveConfig:                  authorized(roleCheckedHandlers[atc.SaveConfig]),
+				atc.UnpauseJob:                  authorized(roleCheckedHandlers[atc.UnpauseJob]),
+				atc.UnpausePipeline:             authorized(roleCheckedHandlers[atc.UnpausePipeline]),
+				atc.UnpauseResource:             authorized(roleCheckedHandlers[atc.UnpauseResource]),
+				atc.UnpinResource:               authorized(roleCheckedHandlers[atc.UnpinResource]),
+				atc.ExposePipeline:              authorized(roleCheckedHandlers[atc.ExposePipeline]),
+				atc.HidePipeline:                authorized(roleCheckedHandlers[atc.HidePipeline]),
 			}
 		})
 
@@ -228,6 +256,12 @@ var _ = Describe("APIAuthWrappa", func() {
 			).Wrap(inputHandlers)
 		})
 
+		It("requires a role for every route", func() {
+			for name, _ := range inputHandlers {
+				Expect(atc.RouteRoles).To(HaveKey(name))
+			}
+		})
+
 		It("validates sensitive routes, and noop validates public routes", func() {
 			for name, _ := range inputHandlers {
 				Expect(wrappedHandlers[name]).To(BeIdenticalTo(expectedHandlers[name]))
@@ -0,0 +1,168 @@
+package wrappa
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+//go:generate counterfeiter . AuditDB
+
+type AuditDB interface {
+	SaveAuditEvent(event db.AuditEvent) error
+}
+
+type AuditWrappa struct {
+	logger            lager.Logger
+	auditDB           AuditDB
+	userContextReader auth.UserContextReader
+}
+
+func NewAuditWrappa(
+	logger lager.Logger,
+	auditDB AuditDB,
+	userContextReader auth.UserContextReader,
+) Wrappa {
+	return AuditWrappa{
+		logger:            logger,
+		auditDB:           auditDB,
+		userContextReader: userContextReader,
+	}
+}
+
+// Wrap records every request to a route which changes something. Hijacking a
+// container is a GET, but is recorded all the same.
+func (wrappa AuditWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
+	methods := map[string]string{}
+	for _, route := range atc.Routes {
+		methods[route.Name] = route.Method
+	}
+
+	wrapped := rata.Handlers{}
+
+	for name, handler := range handlers {
+		method := methods[name]
+
+		if method == "GET" && name != atc.HijackContainer {
+			wrapped[name] = handler
+			continue
+		}
+
+		wrapped[name] = auditHandler{
+			logger:            wrappa.logger.Session("audit"),
+			route:             name,
+			auditDB:           wrappa.auditDB,
+			userContextReader: wrappa.userContextReader,
+			handler:           handler,
+		}
+	}
+
+	return wrapped
+}
+
+type auditHandler struct {
+	logger            lager.Logger
+	route             string
+	auditDB           AuditDB
+	userContextReader auth.UserContextReader
+	handler           http.Handler
+}
+
+func (handler auditHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	event := db.AuditEvent{
+		Route:  handler.route,
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Params: auditParams(r),
+	}
+
+	event.TeamName, _, event.IsAdmin, _ = handler.userContextReader.GetTeam(r)
+	event.Username, _ = handler.userContextReader.GetUsername(r)
+
+	recorder := &statusRecorder{ResponseWriter: w}
+
+	handler.handler.ServeHTTP(recorder, r)
+
+	event.Status = recorder.status
+	if event.Status == 0 {
+		event.Status = http.StatusOK
+	}
+
+	err := handler.auditDB.SaveAuditEvent(event)
+	if err != nil {
+		handler.logger.Error("failed-to-save-audit-event", err, lager.Data{
+			"route": handler.route,
+			"path":  event.Path,
+		})
+	}
+}
+
+// auditParams collects the route's parameters and the query string. Anything
+// which looks like a credential is redacted.
+func auditParams(r *http.Request) map[string]string {
+	params := map[string]string{}
+
+	for key, values := range r.URL.Query() {
+		if len(values) == 0 {
+			continue
+		}
+
+		key = strings.TrimPrefix(key, ":")
+
+		if strings.Contains(key, "token") {
+			params[key] = "[redacted]"
+		} else {
+			params[key] = values[0]
+		}
+	}
+
+	return params
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+
+	status int
+}
+
+func (recorder *statusRecorder) WriteHeader(status int) {
+	if recorder.status == 0 {
+		recorder.status = status
+	}
+
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+func (recorder *statusRecorder) Write(p []byte) (int, error) {
+	if recorder.status == 0 {
+		recorder.status = http.StatusOK
+	}
+
+	return recorder.ResponseWriter.Write(p)
+}
+
+func (recorder *statusRecorder) Flush() {
+	if flusher, ok := recorder.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (recorder *statusRecorder) CloseNotify() <-chan bool {
+	return recorder.ResponseWriter.(http.CloseNotifier).CloseNotify()
+}
+
+func (recorder *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := recorder.ResponseWriter.(http.Hijacker).Hijack()
+	if err == nil && recorder.status == 0 {
+		recorder.status = http.StatusSwitchingProtocols
+	}
+
+	return conn, rw, err
+}
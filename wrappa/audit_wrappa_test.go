@@ -0,0 +1,131 @@
+package wrappa_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth/authfakes"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/wrappa"
+	"github.com/concourse/atc/wrappa/wrappafakes"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type statusHandler int
+
+func (status statusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(int(status))
+}
+
+var _ = Describe("AuditWrappa", func() {
+	var (
+		fakeAuditDB           *wrappafakes.FakeAuditDB
+		fakeUserContextReader *authfakes.FakeUserContextReader
+
+		inputHandlers   rata.Handlers
+		wrappedHandlers rata.Handlers
+	)
+
+	BeforeEach(func() {
+		fakeAuditDB = new(wrappafakes.FakeAuditDB)
+		fakeUserContextReader = new(authfakes.FakeUserContextReader)
+
+		inputHandlers = rata.Handlers{}
+		for _, route := range atc.Routes {
+			inputHandlers[route.Name] = statusHandler(http.StatusNoContent)
+		}
+	})
+
+	JustBeforeEach(func() {
+		wrappedHandlers = wrappa.NewAuditWrappa(
+			lagertest.NewTestLogger("test"),
+			fakeAuditDB,
+			fakeUserContextReader,
+		).Wrap(inputHandlers)
+	})
+
+	It("leaves read-only routes alone", func() {
+		for _, route := range atc.Routes {
+			if route.Method == "GET" && route.Name != atc.HijackContainer {
+				Expect(wrappedHandlers[route.Name]).To(Equal(inputHandlers[route.Name]))
+			} else {
+				Expect(wrappedHandlers[route.Name]).NotTo(Equal(inputHandlers[route.Name]))
+			}
+		}
+	})
+
+	Describe("serving a mutating route", func() {
+		var response *httptest.ResponseRecorder
+
+		BeforeEach(func() {
+			fakeUserContextReader.GetTeamReturns("some-team", 42, true, true)
+			fakeUserContextReader.GetUsernameReturns("some-user", true)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest(
+				"POST",
+				"/api/v1/teams/some-team/pipelines/some-pipeline/resources/some-resource/check/webhook?:team_name=some-team&:resource_name=some-resource&webhook_token=secret",
+				nil,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			response = httptest.NewRecorder()
+			wrappedHandlers[atc.CheckResourceWebHook].ServeHTTP(response, request)
+		})
+
+		It("calls the handler", func() {
+			Expect(response.Code).To(Equal(http.StatusNoContent))
+		})
+
+		It("records who made the request, what it was, and how it went", func() {
+			Expect(fakeAuditDB.SaveAuditEventCallCount()).To(Equal(1))
+			Expect(fakeAuditDB.SaveAuditEventArgsForCall(0)).To(Equal(db.AuditEvent{
+				TeamName: "some-team",
+				IsAdmin:  true,
+				Username: "some-user",
+				Route:    atc.CheckResourceWebHook,
+				Method:   "POST",
+				Path:     "/api/v1/teams/some-team/pipelines/some-pipeline/resources/some-resource/check/webhook",
+				Params: map[string]string{
+					"team_name":     "some-team",
+					"resource_name": "some-resource",
+					"webhook_token": "[redacted]",
+				},
+				Status: http.StatusNoContent,
+			}))
+		})
+
+		Context("when the request is not authenticated", func() {
+			BeforeEach(func() {
+				fakeUserContextReader.GetTeamReturns("", 0, false, false)
+				fakeUserContextReader.GetUsernameReturns("", false)
+			})
+
+			It("records it without an actor", func() {
+				Expect(fakeAuditDB.SaveAuditEventCallCount()).To(Equal(1))
+
+				event := fakeAuditDB.SaveAuditEventArgsForCall(0)
+				Expect(event.TeamName).To(BeEmpty())
+				Expect(event.IsAdmin).To(BeFalse())
+				Expect(event.Username).To(BeEmpty())
+			})
+		})
+
+		Context("when saving the event fails", func() {
+			BeforeEach(func() {
+				fakeAuditDB.SaveAuditEventReturns(errors.New("nope"))
+			})
+
+			It("still responds", func() {
+				Expect(response.Code).To(Equal(http.StatusNoContent))
+			})
+		})
+	})
+})